@@ -65,6 +65,10 @@ func TestWarmCategoriesCache_TableDriven(t *testing.T) {
 						description TEXT NOT NULL,
 						primary_category_id INTEGER NOT NULL,
 						FOREIGN KEY (primary_category_id) REFERENCES primary_categories (id) ON DELETE CASCADE
+					);
+					CREATE TABLE IF NOT EXISTS user_categories (
+						detailed_category_id INTEGER PRIMARY KEY,
+						user_id TEXT NOT NULL
 					);`
 				if _, err := db.Exec(detailedStmt); err != nil {
 					return err
@@ -86,6 +90,10 @@ func TestWarmCategoriesCache_TableDriven(t *testing.T) {
 						description TEXT NOT NULL,
 						primary_category_id INTEGER NOT NULL,
 						FOREIGN KEY (primary_category_id) REFERENCES primary_categories (id) ON DELETE CASCADE
+					);
+					CREATE TABLE IF NOT EXISTS user_categories (
+						detailed_category_id INTEGER PRIMARY KEY,
+						user_id TEXT NOT NULL
 					);`
 				if _, err := db.Exec(detailedStmt); err != nil {
 					return err
@@ -118,6 +126,10 @@ func TestWarmCategoriesCache_TableDriven(t *testing.T) {
 						description TEXT NOT NULL,
 						primary_category_id INTEGER NOT NULL,
 						FOREIGN KEY (primary_category_id) REFERENCES primary_categories (id) ON DELETE CASCADE
+					);
+					CREATE TABLE IF NOT EXISTS user_categories (
+						detailed_category_id INTEGER PRIMARY KEY,
+						user_id TEXT NOT NULL
 					);`
 				if _, err := db.Exec(detailedStmt); err != nil {
 					return err
@@ -150,6 +162,10 @@ func TestWarmCategoriesCache_TableDriven(t *testing.T) {
 						description TEXT NOT NULL,
 						primary_category_id INTEGER NOT NULL,
 						FOREIGN KEY (primary_category_id) REFERENCES primary_categories (id) ON DELETE CASCADE
+					);
+					CREATE TABLE IF NOT EXISTS user_categories (
+						detailed_category_id INTEGER PRIMARY KEY,
+						user_id TEXT NOT NULL
 					);`
 				if _, err := db.Exec(detailedStmt); err != nil {
 					return err
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+func (h *Handler) CreateAPIKey(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var input models.CreateAPIKeyInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	created, err := h.authSvc.CreateAPIKey(ctx.Request.Context(), userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidAPIKeyName):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid name",
+				},
+			})
+		case errors.Is(err, auth.ErrInvalidScope):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "unable to create api key",
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"data": created,
+	})
+}
+
+func (h *Handler) ListAPIKeys(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	keys, err := h.authSvc.ListAPIKeys(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "unable to get api keys",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"api_keys": keys,
+		},
+	})
+}
+
+func (h *Handler) RevokeAPIKey(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	keyID, ok := helpers.BindUUIDParam(ctx, "id")
+	if !ok {
+		// response is in the helper
+		return
+	}
+
+	if err := h.authSvc.RevokeAPIKey(ctx.Request.Context(), userID, keyID); err != nil {
+		if errors.Is(err, auth.ErrAPIKeyNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"data": gin.H{
+					"error": "not found",
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "error revoking api key",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"api_key_revoked": "success",
+		},
+	})
+}
@@ -2,9 +2,29 @@ package auth
 
 import (
 	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/seanhuebl/unity-wealth/internal/models"
 )
 
 type AuthService interface {
 	Login(ctx context.Context, input models.LoginInput) (models.LoginResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (models.LoginResponse, error)
+	Logout(ctx context.Context, claims *jwt.RegisteredClaims, refreshToken string) error
+	LogoutAll(ctx context.Context, claims *jwt.RegisteredClaims) error
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerificationEmail(ctx context.Context, email string) error
+	CompleteMFALogin(ctx context.Context, input models.MFALoginInput) (models.LoginResponse, error)
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (models.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (models.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error
+	JWKS() models.JWKSet
 }
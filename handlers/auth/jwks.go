@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS publishes the token verification keys. The body is a bare JWK Set, as RFC 7517
+// requires, rather than the usual data envelope.
+func (h *Handler) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.authSvc.JWKS())
+}
@@ -1,13 +1,17 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
 )
 
 func (h *Handler) Login(ctx *gin.Context) {
@@ -20,9 +24,28 @@ func (h *Handler) Login(ctx *gin.Context) {
 		})
 		return
 	}
+	input.TrustedDeviceToken, _ = ctx.Cookie(trustedDeviceCookie)
 
 	loginResp, err := h.authSvc.Login(ctx.Request.Context(), input)
 	if err != nil {
+		var retryErr *auth.RetryAfterError
+		if errors.As(err, &retryErr) {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+			ctx.JSON(http.StatusTooManyRequests, gin.H{
+				"data": gin.H{
+					"error": "too many login attempts",
+				},
+			})
+			return
+		}
+		if errors.Is(err, auth.ErrEmailNotVerified) {
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"data": gin.H{
+					"error": "email not verified",
+				},
+			})
+			return
+		}
 		fmt.Println("Login error:", err)
 		ctx.JSON(http.StatusUnauthorized, gin.H{
 			"data": gin.H{
@@ -32,18 +55,59 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	// Set the refresh token cookie (HTTP-specific).
-	SetRefreshTokenCookie(ctx, loginResp.RefreshToken)
+	// A second factor is owed; the client finishes at POST /login/mfa.
+	if loginResp.MFARequired {
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": gin.H{
+				"message":    "mfa_required",
+				"mfa_token":  loginResp.MFAToken,
+				"expires_in": int(auth.MFAChallengeTTL.Seconds()),
+			},
+		})
+		return
+	}
+
+	// Set the refresh token cookie (HTTP-specific). Restricted logins have none, and
+	// their access token is refused on /app routes until the email is verified.
+	if !loginResp.Restricted {
+		SetRefreshTokenCookie(ctx, loginResp.RefreshToken)
+	}
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"data": models.LoginResponseData{
-			Message: "login successful",
-			Token:   loginResp.JWTToken,
+			Message:    "login successful",
+			Token:      loginResp.JWTToken,
+			Restricted: loginResp.Restricted,
 		},
 	})
 }
 
 // Helpers
+
+// trustedDeviceCookie holds the token that lets a device skip the second factor. It is
+// only sent to the login routes.
+const trustedDeviceCookie = "mfa_trusted_device"
+
+func SetTrustedDeviceCookie(ctx *gin.Context, token string) {
+	cookieDomain := os.Getenv("COOKIE_DOMAIN")
+	if cookieDomain == "" {
+		cookieDomain = "localhost"
+	}
+
+	cookie := http.Cookie{
+		Name:     trustedDeviceCookie,
+		Value:    token,
+		Path:     "/login",
+		Domain:   cookieDomain,
+		Expires:  time.Now().Add(auth.MFATrustTTL),
+		HttpOnly: true,
+		Secure:   os.Getenv("ENV") == "prod",
+		SameSite: http.SameSiteStrictMode,
+	}
+
+	http.SetCookie(ctx.Writer, &cookie)
+}
+
 func SetRefreshTokenCookie(ctx *gin.Context, refreshToken string) {
 	isProduction := os.Getenv("ENV") == "prod"
 	cookieDomain := os.Getenv("COOKIE_DOMAIN")
@@ -64,3 +128,23 @@ func SetRefreshTokenCookie(ctx *gin.Context, refreshToken string) {
 
 	http.SetCookie(ctx.Writer, &cookie)
 }
+
+func ClearRefreshTokenCookie(ctx *gin.Context) {
+	cookieDomain := os.Getenv("COOKIE_DOMAIN")
+	if cookieDomain == "" {
+		cookieDomain = "localhost"
+	}
+
+	cookie := http.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		Path:     "/",
+		Domain:   cookieDomain,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   os.Getenv("ENV") == "prod",
+		SameSite: http.SameSiteStrictMode,
+	}
+
+	http.SetCookie(ctx.Writer, &cookie)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+)
+
+func (h *Handler) Logout(ctx *gin.Context) {
+	claims, err := helpers.ValidateClaims(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	// A missing cookie still logs out the access token.
+	refreshToken, _ := ctx.Cookie("refresh_token")
+
+	if err := h.authSvc.Logout(ctx.Request.Context(), claims, refreshToken); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "internal server error",
+			},
+		})
+		return
+	}
+
+	ClearRefreshTokenCookie(ctx)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "logout successful",
+		},
+	})
+}
+
+func (h *Handler) LogoutAll(ctx *gin.Context) {
+	claims, err := helpers.ValidateClaims(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	if err := h.authSvc.LogoutAll(ctx.Request.Context(), claims); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "internal server error",
+			},
+		})
+		return
+	}
+
+	ClearRefreshTokenCookie(ctx)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "logout successful",
+		},
+	})
+}
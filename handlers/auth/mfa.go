@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+func (h *Handler) LoginMFA(ctx *gin.Context) {
+	var input models.MFALoginInput
+	if err := ctx.ShouldBindJSON(&input); err != nil || (input.Code == "" && input.RecoveryCode == "") {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	loginResp, err := h.authSvc.CompleteMFALogin(ctx.Request.Context(), input)
+	if err != nil {
+		var retryErr *auth.RetryAfterError
+		switch {
+		case errors.Is(err, auth.ErrInvalidMFAChallenge):
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"data": gin.H{
+					"error": "invalid or expired mfa token",
+				},
+			})
+		case errors.As(err, &retryErr):
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+			ctx.JSON(http.StatusTooManyRequests, gin.H{
+				"data": gin.H{
+					"error": "too many attempts",
+				},
+			})
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"data": gin.H{
+					"error": "invalid code",
+				},
+			})
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			ctx.JSON(http.StatusForbidden, gin.H{
+				"data": gin.H{
+					"error": "email not verified",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "internal server error",
+				},
+			})
+		}
+		return
+	}
+
+	if !loginResp.Restricted {
+		SetRefreshTokenCookie(ctx, loginResp.RefreshToken)
+	}
+	if loginResp.TrustedDeviceToken != "" {
+		SetTrustedDeviceCookie(ctx, loginResp.TrustedDeviceToken)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": models.LoginResponseData{
+			Message:    "login successful",
+			Token:      loginResp.JWTToken,
+			Restricted: loginResp.Restricted,
+		},
+	})
+}
+
+func (h *Handler) EnrollTOTP(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	enrollment, err := h.authSvc.EnrollTOTP(ctx.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, auth.ErrMFAAlreadyEnabled) {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"data": gin.H{
+					"error": "mfa already enabled",
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "internal server error",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": enrollment,
+	})
+}
+
+func (h *Handler) ConfirmTOTP(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var input models.MFACodeInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	codes, err := h.authSvc.ConfirmTOTP(ctx.Request.Context(), userID, input.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrMFAAlreadyEnabled):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"data": gin.H{
+					"error": "mfa already enabled",
+				},
+			})
+		case errors.Is(err, auth.ErrMFANotEnabled):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "mfa enrollment not started",
+				},
+			})
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid code",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "internal server error",
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"recovery_codes": codes,
+		},
+	})
+}
+
+func (h *Handler) DisableTOTP(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var input models.MFACodeInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := h.authSvc.DisableTOTP(ctx.Request.Context(), userID, input.Code); err != nil {
+		var retryErr *auth.RetryAfterError
+		switch {
+		case errors.Is(err, auth.ErrMFANotEnabled):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "mfa not enabled",
+				},
+			})
+		case errors.As(err, &retryErr):
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+			ctx.JSON(http.StatusTooManyRequests, gin.H{
+				"data": gin.H{
+					"error": "too many attempts",
+				},
+			})
+		case errors.Is(err, auth.ErrInvalidMFACode):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid code",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "internal server error",
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "mfa disabled",
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+func (h *Handler) ForgotPassword(ctx *gin.Context) {
+	var input models.ForgotPasswordInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	// Only a malformed email and the rate limit are reported. Any other failure gets the
+	// same response as success, so the response never tells whether the account exists.
+	err := h.authSvc.RequestPasswordReset(ctx.Request.Context(), input.Email)
+	var retryErr *auth.RetryAfterError
+	switch {
+	case errors.Is(err, auth.ErrInvalidEmail):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid email",
+			},
+		})
+		return
+	case errors.As(err, &retryErr):
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+		ctx.JSON(http.StatusTooManyRequests, gin.H{
+			"data": gin.H{
+				"error": "too many requests",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"data": gin.H{
+			"message": "if an account exists for that email, a reset link has been sent",
+		},
+	})
+}
+
+func (h *Handler) ResetPassword(ctx *gin.Context) {
+	var input models.ResetPasswordInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := h.authSvc.ResetPassword(ctx.Request.Context(), input.Token, input.Password); err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidPassword):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid password",
+				},
+			})
+		case errors.Is(err, auth.ErrInvalidResetToken),
+			errors.Is(err, auth.ErrResetTokenExpired):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid or expired reset token",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "internal server error",
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "password reset successful",
+		},
+	})
+}
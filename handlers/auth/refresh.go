@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+func (h *Handler) RefreshToken(ctx *gin.Context) {
+	refreshToken, err := ctx.Cookie("refresh_token")
+	if err != nil || refreshToken == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "missing refresh token",
+			},
+		})
+		return
+	}
+
+	refreshResp, err := h.authSvc.Refresh(ctx.Request.Context(), refreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidRefreshToken),
+			errors.Is(err, auth.ErrRefreshTokenExpired),
+			errors.Is(err, auth.ErrRefreshTokenReused):
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"data": gin.H{
+					"error": "refresh failed",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "internal server error",
+				},
+			})
+		}
+		return
+	}
+
+	SetRefreshTokenCookie(ctx, refreshResp.RefreshToken)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": models.LoginResponseData{
+			Message: "token refreshed",
+			Token:   refreshResp.JWTToken,
+		},
+	})
+}
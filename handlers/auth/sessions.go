@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+func (h *Handler) ListSessions(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	sessions, err := h.authSvc.ListSessions(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "unable to get sessions",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"sessions": sessions,
+		},
+	})
+}
+
+func (h *Handler) RevokeSession(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	sessionID, ok := helpers.BindUUIDParam(ctx, "id")
+	if !ok {
+		// response is in the helper
+		return
+	}
+
+	if err := h.authSvc.RevokeSession(ctx.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"data": gin.H{
+					"error": "not found",
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "error revoking session",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"session_revoked": "success",
+		},
+	})
+}
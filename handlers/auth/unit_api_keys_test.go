@@ -0,0 +1,332 @@
+package auth_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	keyID := uuid.NewString()
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		body               string
+		expectsSvcCall     bool
+		created            models.CreatedAPIKey
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:           "success",
+			userID:         userID,
+			body:           `{"name":"budget script","scopes":["transactions:read"]}`,
+			expectsSvcCall: true,
+			created: models.CreatedAPIKey{
+				APIKey: models.APIKey{
+					ID:     keyID,
+					Name:   "budget script",
+					Prefix: "uw_abcdefgh",
+					Scopes: []string{auth.ScopeTransactionsRead},
+				},
+				Key: "uw_abcdefgh_secret",
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":           keyID,
+					"name":         "budget script",
+					"prefix":       "uw_abcdefgh",
+					"scopes":       []interface{}{"transactions:read"},
+					"last_used_at": nil,
+					"created_at":   "0001-01-01T00:00:00Z",
+					"key":          "uw_abcdefgh_secret",
+				},
+			},
+		},
+		{
+			name:               "unauthorized",
+			body:               `{"name":"budget script"}`,
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "missing name",
+			userID:             userID,
+			body:               `{"scopes":["transactions:read"]}`,
+			expErrSubstr:       "invalid request body",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid request body",
+				},
+			},
+		},
+		{
+			name:               "unknown scope",
+			userID:             userID,
+			body:               `{"name":"budget script","scopes":["admin"]}`,
+			expectsSvcCall:     true,
+			svcErr:             fmt.Errorf("%w: %q", auth.ErrInvalidScope, "admin"),
+			expErrSubstr:       "invalid scope",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": `invalid scope: "admin"`,
+				},
+			},
+		},
+		{
+			name:               "service error",
+			userID:             userID,
+			body:               `{"name":"budget script"}`,
+			expectsSvcCall:     true,
+			svcErr:             errors.New("failed to create api key"),
+			expErrSubstr:       "unable to create api key",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unable to create api key",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api-keys", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsSvcCall {
+				mockSvc.On("CreateAPIKey", mock.Anything, tc.userID, mock.AnythingOfType("models.CreateAPIKeyInput")).Return(tc.created, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.userID != uuid.Nil {
+					c.Set(string(constants.UserIDKey), tc.userID)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/api-keys", h.CreateAPIKey)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	keyID := uuid.NewString()
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		keys               []models.APIKey
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			keys: []models.APIKey{
+				{ID: keyID, Name: "budget script", Prefix: "uw_abcdefgh", Scopes: []string{auth.ScopeTransactionsRead}},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"api_keys": []interface{}{
+						map[string]interface{}{
+							"id":           keyID,
+							"name":         "budget script",
+							"prefix":       "uw_abcdefgh",
+							"scopes":       []interface{}{"transactions:read"},
+							"last_used_at": nil,
+							"created_at":   "0001-01-01T00:00:00Z",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:               "unauthorized",
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			userID:             userID,
+			svcErr:             errors.New("failed to list api keys"),
+			expErrSubstr:       "unable to get api keys",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unable to get api keys",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api-keys", nil)
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.userID != uuid.Nil {
+				mockSvc.On("ListAPIKeys", mock.Anything, tc.userID).Return(tc.keys, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.userID != uuid.Nil {
+					c.Set(string(constants.UserIDKey), tc.userID)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.GET("/api-keys", h.ListAPIKeys)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		keyID              string
+		svcErr             error
+		expectsSvcCall     bool
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			userID:             userID,
+			keyID:              keyID.String(),
+			expectsSvcCall:     true,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"api_key_revoked": "success",
+				},
+			},
+		},
+		{
+			name:               "unauthorized",
+			keyID:              keyID.String(),
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "invalid id",
+			userID:             userID,
+			keyID:              "not-a-uuid",
+			expErrSubstr:       "invalid id",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid id",
+				},
+			},
+		},
+		{
+			name:               "not found",
+			userID:             userID,
+			keyID:              keyID.String(),
+			svcErr:             auth.ErrAPIKeyNotFound,
+			expectsSvcCall:     true,
+			expErrSubstr:       "not found",
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "not found",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			userID:             userID,
+			keyID:              keyID.String(),
+			svcErr:             errors.New("failed to revoke api key"),
+			expectsSvcCall:     true,
+			expErrSubstr:       "error revoking api key",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "error revoking api key",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/api-keys/"+tc.keyID, nil)
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsSvcCall {
+				mockSvc.On("RevokeAPIKey", mock.Anything, tc.userID, keyID).Return(tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.userID != uuid.Nil {
+					c.Set(string(constants.UserIDKey), tc.userID)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.DELETE("/api-keys/:id", h.RevokeAPIKey)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
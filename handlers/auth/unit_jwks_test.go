@@ -0,0 +1,37 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	set := models.JWKSet{Keys: []models.JWK{
+		{Kty: "OKP", Kid: "2026-02", Alg: "EdDSA", Use: "sig", Crv: "Ed25519", X: "abc"},
+	}}
+	mockSvc := handlermocks.NewAuthService(t)
+	mockSvc.On("JWKS").Return(set).Once()
+
+	router := gin.New()
+	h := authhttp.NewHandler(mockSvc)
+	router.GET("/.well-known/jwks.json", h.JWKS)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	var got models.JWKSet
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Equal(t, set, got)
+}
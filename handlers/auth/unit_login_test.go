@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 	"github.com/seanhuebl/unity-wealth/internal/constants"
 	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
 	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -82,6 +84,7 @@ func TestLogin(t *testing.T) {
 		req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(`{"email": "user@example.com", "password": "Validpass1!"}`))
 		req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
 		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(&http.Cookie{Name: "mfa_trusted_device", Value: "trusted"})
 		req = req.WithContext(context.WithValue(req.Context(), constants.RequestKey, req))
 
 		router := gin.New()
@@ -91,8 +94,9 @@ func TestLogin(t *testing.T) {
 
 		mockSvc.
 			On("Login", mock.Anything, models.LoginInput{
-				Email:    "user@example.com",
-				Password: "Validpass1!",
+				Email:              "user@example.com",
+				Password:           "Validpass1!",
+				TrustedDeviceToken: "trusted",
 			}).
 			Return(models.LoginResponse{
 				UserID:       uuid.New(),
@@ -118,4 +122,97 @@ func TestLogin(t *testing.T) {
 		require.Equal(t, "refresh", refreshCookie.Value)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("email not verified", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(`{"email": "user@example.com", "password": "Validpass1!"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := gin.New()
+		mockSvc := handlermocks.NewAuthService(t)
+		h := authhttp.NewHandler(mockSvc)
+		router.POST("/login", h.Login)
+
+		mockSvc.On("Login", mock.Anything, mock.Anything).Return(models.LoginResponse{}, auth.ErrEmailNotVerified).Once()
+
+		router.ServeHTTP(w, req)
+		testhelpers.CheckHTTPResponse(t, w, "email not verified", http.StatusForbidden, map[string]interface{}{
+			"data": map[string]interface{}{
+				"error": "email not verified",
+			},
+		}, testhelpers.ProcessResponse(w, t))
+	})
+	t.Run("restricted login sets no refresh cookie", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(`{"email": "user@example.com", "password": "Validpass1!"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := gin.New()
+		mockSvc := handlermocks.NewAuthService(t)
+		h := authhttp.NewHandler(mockSvc)
+		router.POST("/login", h.Login)
+
+		mockSvc.On("Login", mock.Anything, mock.Anything).Return(models.LoginResponse{
+			UserID:     uuid.New(),
+			JWTToken:   "dummytoken",
+			Restricted: true,
+		}, nil).Once()
+
+		router.ServeHTTP(w, req)
+		testhelpers.CheckHTTPResponse(t, w, "", http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"message":    "login successful",
+				"token":      "dummytoken",
+				"restricted": true,
+			},
+		}, testhelpers.ProcessResponse(w, t))
+		require.Empty(t, w.Result().Cookies())
+	})
+	t.Run("throttled login returns retry after", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(`{"email": "user@example.com", "password": "Validpass1!"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := gin.New()
+		mockSvc := handlermocks.NewAuthService(t)
+		h := authhttp.NewHandler(mockSvc)
+		router.POST("/login", h.Login)
+
+		mockSvc.On("Login", mock.Anything, mock.Anything).
+			Return(models.LoginResponse{}, &auth.RetryAfterError{RetryAfter: 1500 * time.Millisecond}).Once()
+
+		router.ServeHTTP(w, req)
+		testhelpers.CheckHTTPResponse(t, w, "too many login attempts", http.StatusTooManyRequests, map[string]interface{}{
+			"data": map[string]interface{}{
+				"error": "too many login attempts",
+			},
+		}, testhelpers.ProcessResponse(w, t))
+		require.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+	t.Run("mfa required returns challenge without cookie", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(`{"email": "user@example.com", "password": "Validpass1!"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := gin.New()
+		mockSvc := handlermocks.NewAuthService(t)
+		h := authhttp.NewHandler(mockSvc)
+		router.POST("/login", h.Login)
+
+		mockSvc.On("Login", mock.Anything, mock.Anything).Return(models.LoginResponse{
+			UserID:      uuid.New(),
+			MFARequired: true,
+			MFAToken:    "mfatoken",
+		}, nil).Once()
+
+		router.ServeHTTP(w, req)
+		testhelpers.CheckHTTPResponse(t, w, "", http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"message":    "mfa_required",
+				"mfa_token":  "mfatoken",
+				"expires_in": float64(300),
+			},
+		}, testhelpers.ProcessResponse(w, t))
+		require.Empty(t, w.Result().Cookies())
+	})
 }
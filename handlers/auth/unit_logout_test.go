@@ -0,0 +1,162 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	claims := &jwt.RegisteredClaims{ID: uuid.NewString(), Subject: uuid.NewString()}
+
+	tests := []struct {
+		name               string
+		path               string
+		claims             *jwt.RegisteredClaims
+		cookie             string
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "logout successful",
+			path:               "/logout",
+			claims:             claims,
+			cookie:             "refresh",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "logout successful",
+				},
+			},
+		},
+		{
+			name:               "logout without cookie",
+			path:               "/logout",
+			claims:             claims,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "logout successful",
+				},
+			},
+		},
+		{
+			name:               "logout missing claims",
+			path:               "/logout",
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "logout service error",
+			path:               "/logout",
+			claims:             claims,
+			svcErr:             errors.New("failed to denylist access token"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+		{
+			name:               "logout all successful",
+			path:               "/logout/all",
+			claims:             claims,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "logout successful",
+				},
+			},
+		},
+		{
+			name:               "logout all missing claims",
+			path:               "/logout/all",
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "logout all service error",
+			path:               "/logout/all",
+			claims:             claims,
+			svcErr:             errors.New("failed to revoke tokens"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tc.path, nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tc.cookie})
+			}
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.claims != nil {
+				if tc.path == "/logout" {
+					mockSvc.On("Logout", mock.Anything, tc.claims, tc.cookie).Return(tc.svcErr).Once()
+				} else {
+					mockSvc.On("LogoutAll", mock.Anything, tc.claims).Return(tc.svcErr).Once()
+				}
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.claims != nil {
+					c.Set("claims", tc.claims)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/logout", h.Logout)
+			router.POST("/logout/all", h.LogoutAll)
+			router.ServeHTTP(w, req)
+
+			if tc.svcErr == nil && tc.claims != nil {
+				resp := w.Result()
+				defer resp.Body.Close()
+				var refreshCookie *http.Cookie
+				for _, cookie := range resp.Cookies() {
+					if cookie.Name == "refresh_token" {
+						refreshCookie = cookie
+					}
+				}
+				require.NotNil(t, refreshCookie)
+				require.Empty(t, refreshCookie.Value)
+				require.Negative(t, refreshCookie.MaxAge)
+			}
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package auth_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		reqBody            string
+		expectsCall        bool
+		trustedToken       string
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedCookies    []string
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			expectedStatusCode: http.StatusOK,
+			expectedCookies:    []string{"refresh_token"},
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "login successful",
+					"token":   "dummytoken",
+				},
+			},
+		},
+		{
+			name:               "trusted device",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			trustedToken:       "trusted",
+			expectedStatusCode: http.StatusOK,
+			expectedCookies:    []string{"refresh_token", "mfa_trusted_device"},
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "login successful",
+					"token":   "dummytoken",
+				},
+			},
+		},
+		{
+			name:               "email not verified",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrEmailNotVerified,
+			expErrSubstr:       "email not verified",
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "email not verified",
+				},
+			},
+		},
+		{
+			name:               "missing code and recovery code",
+			reqBody:            `{"mfa_token": "mfatoken"}`,
+			expErrSubstr:       "invalid request body",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid request body",
+				},
+			},
+		},
+		{
+			name:               "invalid challenge",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidMFAChallenge,
+			expErrSubstr:       "invalid or expired mfa token",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid or expired mfa token",
+				},
+			},
+		},
+		{
+			name:               "invalid code",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidMFACode,
+			expErrSubstr:       "invalid code",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid code",
+				},
+			},
+		},
+		{
+			name:               "locked out",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             &auth.RetryAfterError{RetryAfter: 15 * time.Minute},
+			expErrSubstr:       "too many attempts",
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "too many attempts",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			reqBody:            `{"mfa_token": "mfatoken", "code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             errors.New("failed to commit transaction"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/login/mfa", bytes.NewBufferString(tc.reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsCall {
+				mockSvc.On("CompleteMFALogin", mock.Anything, models.MFALoginInput{MFAToken: "mfatoken", Code: "123456"}).
+					Return(models.LoginResponse{JWTToken: "dummytoken", RefreshToken: "refresh", TrustedDeviceToken: tc.trustedToken}, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/login/mfa", h.LoginMFA)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+			var cookies []string
+			for _, c := range w.Result().Cookies() {
+				require.True(t, c.HttpOnly, c.Name)
+				cookies = append(cookies, c.Name)
+			}
+			require.Equal(t, tc.expectedCookies, cookies)
+		})
+	}
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			userID:             userID,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"secret":           "SECRET",
+					"provisioning_uri": "otpauth://totp/x",
+				},
+			},
+		},
+		{
+			name:               "unauthorized",
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "already enabled",
+			userID:             userID,
+			svcErr:             auth.ErrMFAAlreadyEnabled,
+			expErrSubstr:       "mfa already enabled",
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "mfa already enabled",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/mfa/totp/enroll", nil)
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.userID != uuid.Nil {
+				mockSvc.On("EnrollTOTP", mock.Anything, tc.userID).
+					Return(models.TOTPEnrollment{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.userID != uuid.Nil {
+					c.Set(string(constants.UserIDKey), tc.userID)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/mfa/totp/enroll", h.EnrollTOTP)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		reqBody            string
+		expectsCall        bool
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			reqBody:            `{"code": "123456"}`,
+			expectsCall:        true,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"recovery_codes": []interface{}{"aaaaa-bbbbb"},
+				},
+			},
+		},
+		{
+			name:               "missing code",
+			reqBody:            `{}`,
+			expErrSubstr:       "invalid request body",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid request body",
+				},
+			},
+		},
+		{
+			name:               "enrollment not started",
+			reqBody:            `{"code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrMFANotEnabled,
+			expErrSubstr:       "mfa enrollment not started",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "mfa enrollment not started",
+				},
+			},
+		},
+		{
+			name:               "invalid code",
+			reqBody:            `{"code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidMFACode,
+			expErrSubstr:       "invalid code",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid code",
+				},
+			},
+		},
+		{
+			name:               "already enabled",
+			reqBody:            `{"code": "123456"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrMFAAlreadyEnabled,
+			expErrSubstr:       "mfa already enabled",
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "mfa already enabled",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/mfa/totp/confirm", bytes.NewBufferString(tc.reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsCall {
+				mockSvc.On("ConfirmTOTP", mock.Anything, userID, "123456").Return([]string{"aaaaa-bbbbb"}, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set(string(constants.UserIDKey), userID)
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/mfa/totp/confirm", h.ConfirmTOTP)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "mfa disabled",
+				},
+			},
+		},
+		{
+			name:               "not enabled",
+			svcErr:             auth.ErrMFANotEnabled,
+			expErrSubstr:       "mfa not enabled",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "mfa not enabled",
+				},
+			},
+		},
+		{
+			name:               "invalid code",
+			svcErr:             auth.ErrInvalidMFACode,
+			expErrSubstr:       "invalid code",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid code",
+				},
+			},
+		},
+		{
+			name:               "locked out",
+			svcErr:             &auth.RetryAfterError{RetryAfter: 15 * time.Minute},
+			expErrSubstr:       "too many attempts",
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "too many attempts",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/mfa/totp/disable", bytes.NewBufferString(`{"code": "aaaaa-bbbbb"}`))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			mockSvc.On("DisableTOTP", mock.Anything, userID, "aaaaa-bbbbb").Return(tc.svcErr).Once()
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set(string(constants.UserIDKey), userID)
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/mfa/totp/disable", h.DisableTOTP)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
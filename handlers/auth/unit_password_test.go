@@ -0,0 +1,220 @@
+package auth_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestForgotPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		reqBody            string
+		expectsCall        bool
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "if an account exists for that email, a reset link has been sent",
+				},
+			},
+		},
+		{
+			name:               "missing email",
+			reqBody:            `{}`,
+			expErrSubstr:       "invalid request body",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid request body",
+				},
+			},
+		},
+		{
+			name:               "invalid email",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidEmail,
+			expErrSubstr:       "invalid email",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid email",
+				},
+			},
+		},
+		{
+			name:               "rate limited",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			svcErr:             &auth.RetryAfterError{RetryAfter: time.Hour},
+			expErrSubstr:       "too many requests",
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "too many requests",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			svcErr:             errors.New("failed to send reset email"),
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "if an account exists for that email, a reset link has been sent",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/password/forgot", strings.NewReader(tc.reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsCall {
+				mockSvc.On("RequestPasswordReset", mock.Anything, "user@example.com").Return(tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/password/forgot", h.ForgotPassword)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		reqBody            string
+		expectsCall        bool
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			reqBody:            `{"token":"resettoken","password":"Newpass1!"}`,
+			expectsCall:        true,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "password reset successful",
+				},
+			},
+		},
+		{
+			name:               "missing token",
+			reqBody:            `{"password":"Newpass1!"}`,
+			expErrSubstr:       "invalid request body",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid request body",
+				},
+			},
+		},
+		{
+			name:               "weak password",
+			reqBody:            `{"token":"resettoken","password":"Newpass1!"}`,
+			expectsCall:        true,
+			svcErr:             fmt.Errorf("%w, %v", auth.ErrInvalidPassword, errors.New("too short")),
+			expErrSubstr:       "invalid password",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid password",
+				},
+			},
+		},
+		{
+			name:               "invalid token",
+			reqBody:            `{"token":"resettoken","password":"Newpass1!"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidResetToken,
+			expErrSubstr:       "invalid or expired reset token",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid or expired reset token",
+				},
+			},
+		},
+		{
+			name:               "expired token",
+			reqBody:            `{"token":"resettoken","password":"Newpass1!"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrResetTokenExpired,
+			expErrSubstr:       "invalid or expired reset token",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid or expired reset token",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			reqBody:            `{"token":"resettoken","password":"Newpass1!"}`,
+			expectsCall:        true,
+			svcErr:             errors.New("failed to update password"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/password/reset", strings.NewReader(tc.reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsCall {
+				mockSvc.On("ResetPassword", mock.Anything, "resettoken", "Newpass1!").Return(tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/password/reset", h.ResetPassword)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name               string
+		cookie             string
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "missing cookie",
+			expErrSubstr:       "missing refresh token",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "missing refresh token",
+				},
+			},
+		},
+		{
+			name:               "invalid token",
+			cookie:             "bad",
+			svcErr:             auth.ErrInvalidRefreshToken,
+			expErrSubstr:       "refresh failed",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "refresh failed",
+				},
+			},
+		},
+		{
+			name:               "reused token",
+			cookie:             "reused",
+			svcErr:             auth.ErrRefreshTokenReused,
+			expErrSubstr:       "refresh failed",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "refresh failed",
+				},
+			},
+		},
+		{
+			name:               "internal error",
+			cookie:             "valid",
+			svcErr:             errors.New("failed to start transaction"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/refresh", nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tc.cookie})
+			}
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.svcErr != nil {
+				mockSvc.On("Refresh", mock.Anything, tc.cookie).Return(models.LoginResponse{}, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/refresh", h.RefreshToken)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+
+	t.Run("refresh successful", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "old"})
+
+		mockSvc := handlermocks.NewAuthService(t)
+		mockSvc.On("Refresh", mock.Anything, "old").Return(models.LoginResponse{
+			UserID:       uuid.New(),
+			RefreshToken: "rotated",
+			JWTToken:     "dummytoken",
+		}, nil).Once()
+
+		router := gin.New()
+		h := authhttp.NewHandler(mockSvc)
+		router.POST("/refresh", h.RefreshToken)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		var refreshCookie *http.Cookie
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == "refresh_token" {
+				refreshCookie = cookie
+				break
+			}
+		}
+		require.NotNil(t, refreshCookie)
+		require.Equal(t, "rotated", refreshCookie.Value)
+
+		testhelpers.CheckHTTPResponse(t, w, "", http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"message": "token refreshed",
+			},
+		}, testhelpers.ProcessResponse(w, t))
+	})
+}
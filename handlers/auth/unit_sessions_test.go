@@ -0,0 +1,211 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	sessionID := uuid.NewString()
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		sessions           []models.Session
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			sessions: []models.Session{
+				{ID: sessionID, DeviceType: "Mobile", Browser: "Chrome", Os: "Android", Active: true},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"sessions": []interface{}{
+						map[string]interface{}{
+							"id":              sessionID,
+							"device_type":     "Mobile",
+							"browser":         "Chrome",
+							"browser_version": "",
+							"os":              "Android",
+							"os_version":      "",
+							"created_at":      "0001-01-01T00:00:00Z",
+							"last_used_at":    "0001-01-01T00:00:00Z",
+							"active":          true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:               "unauthorized",
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			userID:             userID,
+			svcErr:             errors.New("failed to list sessions"),
+			expErrSubstr:       "unable to get sessions",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unable to get sessions",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/sessions", nil)
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.userID != uuid.Nil {
+				mockSvc.On("ListSessions", mock.Anything, tc.userID).Return(tc.sessions, tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.userID != uuid.Nil {
+					c.Set(string(constants.UserIDKey), tc.userID)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.GET("/sessions", h.ListSessions)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name               string
+		userID             uuid.UUID
+		sessionID          string
+		svcErr             error
+		expectsSvcCall     bool
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			userID:             userID,
+			sessionID:          sessionID.String(),
+			expectsSvcCall:     true,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"session_revoked": "success",
+				},
+			},
+		},
+		{
+			name:               "unauthorized",
+			sessionID:          sessionID.String(),
+			expErrSubstr:       "unauthorized",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "unauthorized",
+				},
+			},
+		},
+		{
+			name:               "invalid id",
+			userID:             userID,
+			sessionID:          "not-a-uuid",
+			expErrSubstr:       "invalid id",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid id",
+				},
+			},
+		},
+		{
+			name:               "not found",
+			userID:             userID,
+			sessionID:          sessionID.String(),
+			svcErr:             auth.ErrSessionNotFound,
+			expectsSvcCall:     true,
+			expErrSubstr:       "not found",
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "not found",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			userID:             userID,
+			sessionID:          sessionID.String(),
+			svcErr:             errors.New("failed to revoke token"),
+			expectsSvcCall:     true,
+			expErrSubstr:       "error revoking session",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "error revoking session",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/sessions/"+tc.sessionID, nil)
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsSvcCall {
+				mockSvc.On("RevokeSession", mock.Anything, tc.userID, sessionID).Return(tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tc.userID != uuid.Nil {
+					c.Set(string(constants.UserIDKey), tc.userID)
+				}
+				c.Next()
+			})
+			h := authhttp.NewHandler(mockSvc)
+			router.DELETE("/sessions/:id", h.RevokeSession)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	authhttp "github.com/seanhuebl/unity-wealth/handlers/auth"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		query              string
+		expectsCall        bool
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			query:              "?token=verifytoken",
+			expectsCall:        true,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "email verified",
+				},
+			},
+		},
+		{
+			name:               "missing token",
+			expErrSubstr:       "missing token",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "missing token",
+				},
+			},
+		},
+		{
+			name:               "invalid token",
+			query:              "?token=verifytoken",
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidVerifyToken,
+			expErrSubstr:       "invalid or expired verification token",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid or expired verification token",
+				},
+			},
+		},
+		{
+			name:               "expired token",
+			query:              "?token=verifytoken",
+			expectsCall:        true,
+			svcErr:             auth.ErrVerifyTokenExpired,
+			expErrSubstr:       "invalid or expired verification token",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid or expired verification token",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			query:              "?token=verifytoken",
+			expectsCall:        true,
+			svcErr:             errors.New("failed to mark email verified"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/verify-email"+tc.query, nil)
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsCall {
+				mockSvc.On("VerifyEmail", mock.Anything, "verifytoken").Return(tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			h := authhttp.NewHandler(mockSvc)
+			router.GET("/verify-email", h.VerifyEmail)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+		})
+	}
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		reqBody            string
+		expectsCall        bool
+		svcErr             error
+		expErrSubstr       string
+		expectedStatusCode int
+		expectedResponse   map[string]interface{}
+	}{
+		{
+			name:               "success",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"message": "if an unverified account exists for that email, a verification link has been sent",
+				},
+			},
+		},
+		{
+			name:               "missing email",
+			reqBody:            `{}`,
+			expErrSubstr:       "invalid request body",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid request body",
+				},
+			},
+		},
+		{
+			name:               "invalid email",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrInvalidEmail,
+			expErrSubstr:       "invalid email",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "invalid email",
+				},
+			},
+		},
+		{
+			name:               "throttled",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			svcErr:             auth.ErrVerifyEmailThrottled,
+			expErrSubstr:       "too many requests",
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "too many requests",
+				},
+			},
+		},
+		{
+			name:               "service error",
+			reqBody:            `{"email":"user@example.com"}`,
+			expectsCall:        true,
+			svcErr:             errors.New("failed to send verification email"),
+			expErrSubstr:       "internal server error",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": "internal server error",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/verify-email/resend", strings.NewReader(tc.reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			mockSvc := handlermocks.NewAuthService(t)
+			if tc.expectsCall {
+				mockSvc.On("ResendVerificationEmail", mock.Anything, "user@example.com").Return(tc.svcErr).Once()
+			}
+
+			router := gin.New()
+			h := authhttp.NewHandler(mockSvc)
+			router.POST("/verify-email/resend", h.ResendVerificationEmail)
+			router.ServeHTTP(w, req)
+
+			testhelpers.CheckHTTPResponse(t, w, tc.expErrSubstr, tc.expectedStatusCode, tc.expectedResponse, testhelpers.ProcessResponse(w, t))
+			if tc.svcErr == auth.ErrVerifyEmailThrottled {
+				require.Equal(t, "60", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
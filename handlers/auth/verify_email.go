@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+func (h *Handler) VerifyEmail(ctx *gin.Context) {
+	token := ctx.Query("token")
+	if token == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "missing token",
+			},
+		})
+		return
+	}
+
+	if err := h.authSvc.VerifyEmail(ctx.Request.Context(), token); err != nil {
+		if errors.Is(err, auth.ErrInvalidVerifyToken) || errors.Is(err, auth.ErrVerifyTokenExpired) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid or expired verification token",
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "internal server error",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "email verified",
+		},
+	})
+}
+
+func (h *Handler) ResendVerificationEmail(ctx *gin.Context) {
+	var input models.ResendVerificationInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	if err := h.authSvc.ResendVerificationEmail(ctx.Request.Context(), input.Email); err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidEmail):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid email",
+				},
+			})
+		case errors.Is(err, auth.ErrVerifyEmailThrottled):
+			ctx.Header("Retry-After", strconv.Itoa(int(auth.VerifyEmailResendCooldown.Seconds())))
+			ctx.JSON(http.StatusTooManyRequests, gin.H{
+				"data": gin.H{
+					"error": "too many requests",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "internal server error",
+				},
+			})
+		}
+		return
+	}
+
+	// Same response whether or not the account exists or is already verified.
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"data": gin.H{
+			"message": "if an unverified account exists for that email, a verification link has been sent",
+		},
+	})
+}
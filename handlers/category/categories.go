@@ -0,0 +1,119 @@
+package category
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+func (h *Handler) CreateCategory(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var req models.NewCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	created, err := h.categorySvc.CreateCategory(ctx.Request.Context(), userID.String(), req)
+	if err != nil {
+		categoryError(ctx, err, "failed to create category")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"data": created,
+	})
+}
+
+// UpdateCategory renames, hides or archives a category for the user.
+func (h *Handler) UpdateCategory(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	categoryID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || categoryID <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid category id",
+			},
+		})
+		return
+	}
+
+	var update models.CategoryUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	updated, err := h.categorySvc.UpdateCategory(ctx.Request.Context(), userID.String(), categoryID, update)
+	if err != nil {
+		categoryError(ctx, err, "failed to update category")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": updated,
+	})
+}
+
+// MergeCategories moves everything filed under the source categories to the target
+// and responds with the target.
+func (h *Handler) MergeCategories(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var merge models.CategoryMerge
+	if err := ctx.ShouldBindJSON(&merge); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	merged, err := h.categorySvc.MergeCategories(ctx.Request.Context(), userID.String(), merge)
+	if err != nil {
+		categoryError(ctx, err, "failed to merge categories")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": merged,
+	})
+}
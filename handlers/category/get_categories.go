@@ -6,9 +6,25 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/seanhuebl/unity-wealth/cache"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
 )
 
+// GetCategories responds with the categories as the signed in user sees them, their
+// own included and hidden ones left out unless include_hidden=true. Without a user
+// it responds with the global list from the cache.
 func (h *Handler) GetCategories(ctx *gin.Context) {
+	if userID, err := helpers.GetUserID(ctx); err == nil {
+		categories, err := h.categorySvc.ListCategories(ctx.Request.Context(), userID.String(), ctx.Query("include_hidden") == "true")
+		if err != nil {
+			categoryError(ctx, err, "failed to list categories")
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"data": categories,
+		})
+		return
+	}
+
 	primaryHash, err := cache.RedisClient.HGetAll(ctx.Request.Context(), "primary_categories").Result()
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -92,7 +92,7 @@ func TestGetCategories(t *testing.T) {
 			req, _ := http.NewRequest("GET", "/categories", nil)
 			c.Request = req
 
-			h := NewHandler()
+			h := NewHandler(nil)
 
 			h.GetCategories(c)
 
@@ -72,7 +72,7 @@ func TestGetDetailedCategoryByID_WithRedismock(t *testing.T) {
 			c.Request = httptest.NewRequest(http.MethodGet, "/detailed_categories/"+tc.id, nil)
 			c.Params = gin.Params{{Key: "id", Value: tc.id}}
 
-			h := NewHandler()
+			h := NewHandler(nil)
 
 			h.GetDetailedCategoryByID(c)
 
@@ -73,7 +73,7 @@ func TestGetPrimaryCategoryByID_WithRedismock(t *testing.T) {
 			c.Request = httptest.NewRequest(http.MethodGet, "/primary_categories/"+tc.id, nil)
 			c.Params = gin.Params{{Key: "id", Value: tc.id}}
 
-			h := NewHandler()
+			h := NewHandler(nil)
 
 			h.GetPrimaryCategoryByID(c)
 
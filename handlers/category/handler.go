@@ -1,7 +1,39 @@
 package category
 
-type Handler struct{}
+import (
+	"errors"
+	"net/http"
 
-func NewHandler() *Handler {
-	return &Handler{}
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/services/category"
+)
+
+type Handler struct {
+	categorySvc CategoryService
+}
+
+func NewHandler(categorySvc CategoryService) *Handler {
+	return &Handler{
+		categorySvc: categorySvc,
+	}
+}
+
+// categoryError responds with the status for a service error, using fallback as the
+// message for errors that are ours.
+func categoryError(ctx *gin.Context, err error, fallback string) {
+	status, msg := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, category.ErrInvalidCategory),
+		errors.Is(err, category.ErrInvalidMerge):
+		status, msg = http.StatusBadRequest, err.Error()
+	case errors.Is(err, category.ErrCategoryNotFound):
+		status, msg = http.StatusNotFound, err.Error()
+	case errors.Is(err, category.ErrCategoryExists):
+		status, msg = http.StatusConflict, err.Error()
+	}
+	ctx.JSON(status, gin.H{
+		"data": gin.H{
+			"error": msg,
+		},
+	})
 }
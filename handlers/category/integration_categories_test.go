@@ -0,0 +1,99 @@
+package category_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationCategories(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	w := serveCategories(t, env, userID, http.MethodPost, "/categories", `{"name": "Farmers markets", "primary_category": 7}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data models.Category `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	own := created.Data
+
+	txn, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-03-01", Merchant: "Ferry Plaza", Amount: 32, DetailedCategory: own.ID,
+	})
+	require.NoError(t, err)
+
+	w = serveCategories(t, env, userID, http.MethodPatch, "/categories/40", `{"name": "Food shopping"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveCategories(t, env, userID, http.MethodGet, "/categories", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, []string{"Food shopping", "Farmers markets"}, categoryNames(t, w))
+
+	w = serveCategories(t, env, userID, http.MethodPost, "/categories/merge", fmt.Sprintf(`{"target_id": 40, "source_ids": [%d]}`, own.ID))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	got, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txn.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), got.DetailedCategory)
+
+	// The merged category is archived, so it is listed only on request.
+	w = serveCategories(t, env, userID, http.MethodGet, "/categories", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, []string{"Food shopping"}, categoryNames(t, w))
+	w = serveCategories(t, env, userID, http.MethodGet, "/categories?include_hidden=true", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, []string{"Food shopping", "Farmers markets"}, categoryNames(t, w))
+
+	w = serveCategories(t, env, userID, http.MethodPatch, "/categories/999", `{"hidden": true}`)
+	require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+}
+
+func serveCategories(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	h := env.Handlers.CategoryHandler
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.GET("/categories", h.GetCategories)
+	router.POST("/categories", h.CreateCategory)
+	router.POST("/categories/merge", h.MergeCategories)
+	router.PATCH("/categories/:id", h.UpdateCategory)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func categoryNames(t *testing.T, w *httptest.ResponseRecorder) []string {
+	t.Helper()
+	var resp struct {
+		Data []models.Category `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	names := make([]string, len(resp.Data))
+	for i, c := range resp.Data {
+		names[i] = c.Name
+	}
+	return names
+}
@@ -0,0 +1,14 @@
+package category
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type CategoryService interface {
+	ListCategories(ctx context.Context, userID string, includeHidden bool) ([]models.Category, error)
+	CreateCategory(ctx context.Context, userID string, req models.NewCategoryRequest) (models.Category, error)
+	UpdateCategory(ctx context.Context, userID string, categoryID int64, update models.CategoryUpdate) (models.Category, error)
+	MergeCategories(ctx context.Context, userID string, merge models.CategoryMerge) (models.Category, error)
+}
@@ -0,0 +1,326 @@
+package category_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	hcategory "github.com/seanhuebl/unity-wealth/handlers/category"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/category"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	name := "Food shopping"
+	update := models.CategoryUpdate{Name: &name}
+	body := `{"name": "Food shopping"}`
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		path      string
+		body      string
+		expectSvc bool
+		svcErr    error
+	}{
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+			path:             "/categories/40",
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+			path:             "/categories/40",
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "bad id",
+				UserID:             userID,
+				ExpectedError:      "invalid category id",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid category id",
+					},
+				},
+			},
+			path: "/categories/groceries",
+			body: body,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "bad body",
+				UserID:             userID,
+				ExpectedError:      "invalid request body",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid request body",
+					},
+				},
+			},
+			path: "/categories/40",
+			body: `{"hidden": "yes"}`,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"id":                    float64(40),
+						"name":                  "Food shopping",
+						"description":           "Purchases for groceries",
+						"primary_category":      float64(7),
+						"primary_category_name": "FOOD_AND_DRINK",
+						"custom":                false,
+					},
+				},
+			},
+			path:      "/categories/40",
+			body:      body,
+			expectSvc: true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "name taken",
+				UserID:             userID,
+				ExpectedError:      "another category has this name",
+				ExpectedStatusCode: http.StatusConflict,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "another category has this name",
+					},
+				},
+			},
+			path:      "/categories/40",
+			body:      body,
+			expectSvc: true,
+			svcErr:    category.ErrCategoryExists,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "archive system category",
+				UserID:             userID,
+				ExpectedError:      "invalid category",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid category",
+					},
+				},
+			},
+			path:      "/categories/40",
+			body:      body,
+			expectSvc: true,
+			svcErr:    category.ErrInvalidCategory,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "not found",
+				UserID:             userID,
+				ExpectedError:      "category not found",
+				ExpectedStatusCode: http.StatusNotFound,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "category not found",
+					},
+				},
+			},
+			path:      "/categories/40",
+			body:      body,
+			expectSvc: true,
+			svcErr:    category.ErrCategoryNotFound,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "failed to update category",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "failed to update category",
+					},
+				},
+			},
+			path:      "/categories/40",
+			body:      body,
+			expectSvc: true,
+			svcErr:    errors.New("db down"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewCategoryService(t)
+			if tc.expectSvc {
+				updated := models.Category{
+					ID:                  40,
+					Name:                name,
+					Description:         "Purchases for groceries",
+					PrimaryCategory:     7,
+					PrimaryCategoryName: "FOOD_AND_DRINK",
+				}
+				if tc.svcErr != nil {
+					updated = models.Category{}
+				}
+				mockSvc.On("UpdateCategory", mock.Anything, userID.String(), int64(40), update).Return(updated, tc.svcErr).Once()
+			}
+			h := hcategory.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.PATCH("/categories/:id", h.UpdateCategory)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, tc.path, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+		})
+	}
+}
+
+func TestCategoryRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	own := models.Category{ID: 1001, Name: "Kids activities", PrimaryCategory: 9, PrimaryCategoryName: "ENTERTAINMENT", Custom: true}
+	req := models.NewCategoryRequest{Name: "Kids activities", PrimaryCategory: 9}
+	merge := models.CategoryMerge{TargetID: 1001, SourceIDs: []int64{40}}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setup          func(svc *handlermocks.CategoryService)
+		expectedStatus int
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			path:   "/categories",
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("ListCategories", mock.Anything, userID.String(), false).Return([]models.Category{own}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list hidden",
+			method: http.MethodGet,
+			path:   "/categories?include_hidden=true",
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("ListCategories", mock.Anything, userID.String(), true).Return([]models.Category{own}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list fails",
+			method: http.MethodGet,
+			path:   "/categories",
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("ListCategories", mock.Anything, userID.String(), false).Return(nil, errors.New("db down")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "create",
+			method: http.MethodPost,
+			path:   "/categories",
+			body:   `{"name": "Kids activities", "primary_category": 9}`,
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("CreateCategory", mock.Anything, userID.String(), req).Return(own, nil).Once()
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create without primary",
+			method:         http.MethodPost,
+			path:           "/categories",
+			body:           `{"name": "Kids activities"}`,
+			setup:          func(svc *handlermocks.CategoryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create taken",
+			method: http.MethodPost,
+			path:   "/categories",
+			body:   `{"name": "Kids activities", "primary_category": 9}`,
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("CreateCategory", mock.Anything, userID.String(), req).Return(models.Category{}, category.ErrCategoryExists).Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "merge",
+			method: http.MethodPost,
+			path:   "/categories/merge",
+			body:   `{"target_id": 1001, "source_ids": [40]}`,
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("MergeCategories", mock.Anything, userID.String(), merge).Return(own, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "merge without sources",
+			method:         http.MethodPost,
+			path:           "/categories/merge",
+			body:           `{"target_id": 1001, "source_ids": []}`,
+			setup:          func(svc *handlermocks.CategoryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "merge invalid",
+			method: http.MethodPost,
+			path:   "/categories/merge",
+			body:   `{"target_id": 1001, "source_ids": [40]}`,
+			setup: func(svc *handlermocks.CategoryService) {
+				svc.On("MergeCategories", mock.Anything, userID.String(), merge).Return(models.Category{}, category.ErrInvalidMerge).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewCategoryService(t)
+			tc.setup(mockSvc)
+			h := hcategory.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues("success", userID, c)
+				c.Next()
+			})
+			router.GET("/categories", h.GetCategories)
+			router.POST("/categories", h.CreateCategory)
+			router.POST("/categories/merge", h.MergeCategories)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
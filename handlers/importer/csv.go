@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// PreviewCSV parses an uploaded CSV file and returns the rows it would import, each
+// with its errors, without writing anything. The upload is multipart: the file in
+// "file", and optionally "bank" to use the mapping saved for that bank and "mapping"
+// as JSON to override it.
+func (h *Handler) PreviewCSV(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	bank, data, mapping, ok := readUpload(ctx)
+	if !ok {
+		return
+	}
+
+	preview, err := h.importSvc.PreviewCSV(ctx.Request.Context(), userID.String(), bank, data, mapping)
+	if err != nil {
+		importError(ctx, err, "failed to preview import")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": preview,
+	})
+}
+
+// ImportCSV stores the rows of an uploaded CSV file that parse. It takes the same
+// upload as PreviewCSV and reports the rows that were not imported.
+func (h *Handler) ImportCSV(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	bank, data, mapping, ok := readUpload(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.importSvc.ImportCSV(ctx.Request.Context(), userID.String(), bank, data, mapping)
+	if err != nil {
+		importError(ctx, err, "failed to import transactions")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+func (h *Handler) ListMappings(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	mappings, err := h.importSvc.ListMappings(ctx.Request.Context(), userID.String())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "failed to list mappings",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": mappings,
+	})
+}
+
+// SaveMapping confirms a column mapping for a bank. Later imports naming the bank use
+// it unless they send a mapping of their own.
+func (h *Handler) SaveMapping(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var mapping models.CSVMapping
+	if err := ctx.ShouldBindJSON(&mapping); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	saved, err := h.importSvc.SaveMapping(ctx.Request.Context(), userID.String(), ctx.Param("bank"), mapping)
+	if err != nil {
+		importError(ctx, err, "failed to save mapping")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": saved,
+	})
+}
+
+func (h *Handler) DeleteMapping(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	if err := h.importSvc.DeleteMapping(ctx.Request.Context(), userID.String(), ctx.Param("bank")); err != nil {
+		importError(ctx, err, "failed to delete mapping")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// readUpload reads the multipart CSV import form, responding with 400 when it is not
+// usable.
+func readUpload(ctx *gin.Context) (string, []byte, *models.CSVMapping, bool) {
+	data, ok := readFile(ctx)
+	if !ok {
+		return "", nil, nil, false
+	}
+	var mapping *models.CSVMapping
+	if raw := ctx.PostForm("mapping"); raw != "" {
+		mapping = &models.CSVMapping{}
+		if err := json.Unmarshal([]byte(raw), mapping); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "mapping must be a JSON object",
+				},
+			})
+			return "", nil, nil, false
+		}
+	}
+	return ctx.PostForm("bank"), data, mapping, true
+}
@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+)
+
+// MaxUploadBytes bounds the size of an uploaded import file.
+const MaxUploadBytes = 5 << 20
+
+type Handler struct {
+	importSvc ImportService
+}
+
+func NewHandler(importSvc ImportService) *Handler {
+	return &Handler{
+		importSvc: importSvc,
+	}
+}
+
+// readFile reads the uploaded file in the multipart form field "file", responding
+// with 400 when there is none or it is too large.
+func readFile(ctx *gin.Context) ([]byte, bool) {
+	badRequest := func(msg string) ([]byte, bool) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": msg,
+			},
+		})
+		return nil, false
+	}
+
+	// Leave room for the other form fields.
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MaxUploadBytes+1<<20)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return badRequest("a multipart upload with the file in \"file\" is required")
+	}
+	if header.Size > MaxUploadBytes {
+		return badRequest("file is larger than 5 MB")
+	}
+	f, err := header.Open()
+	if err != nil {
+		return badRequest("could not read file")
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, MaxUploadBytes+1))
+	if err != nil {
+		return badRequest("could not read file")
+	}
+	if len(data) > MaxUploadBytes {
+		return badRequest("file is larger than 5 MB")
+	}
+	return data, true
+}
+
+// importError responds with the status for a service error, using fallback as the
+// message for errors that are ours.
+func importError(ctx *gin.Context, err error, fallback string) {
+	status, msg := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, importer.ErrInvalidBank),
+		errors.Is(err, importer.ErrInvalidFile),
+		errors.Is(err, importer.ErrInvalidMapping),
+		errors.Is(err, importer.ErrTooManyRows),
+		errors.Is(err, importer.ErrUnknownCategory):
+		status, msg = http.StatusBadRequest, err.Error()
+	case errors.Is(err, importer.ErrMappingNotFound):
+		status, msg = http.StatusNotFound, err.Error()
+	}
+	ctx.JSON(status, gin.H{
+		"data": gin.H{
+			"error": msg,
+		},
+	})
+}
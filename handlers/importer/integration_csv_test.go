@@ -0,0 +1,149 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+// A bank export with day-first dates, a decimal comma, separate money out and money in
+// columns, and one bad row.
+const bankCSV = "\xef\xbb\xbfBooking Date;Payee;Money Out;Money In;Category\n" +
+	"05.03.2025;Costco;125,98;;Food Groceries\n" +
+	"31.03.2025;Refund;;10,00;FOOD_GROCERIES\n" +
+	"01.04.2025;Unknown;5,00;;Travel\n"
+
+func TestIntegrationImportCSV(t *testing.T) {
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	w := serveImport(t, env, userID, uploadRequest(t, "/imports/csv/preview", bankCSV, map[string]string{"bank": "n26"}))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var preview struct {
+		Data models.ImportPreview `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+	require.Equal(t, models.MappingSourceDetected, preview.Data.MappingSource)
+	require.Equal(t, "DD.MM.YYYY", preview.Data.Mapping.DateFormat)
+	require.Equal(t, 2, preview.Data.ValidRows)
+	require.Equal(t, []string{`unknown category "Travel"`}, preview.Data.Rows[2].Errors)
+	require.Equal(t, &models.NewTxRequest{Date: "2025-03-31", Merchant: "Refund", Amount: -10, DetailedCategory: 40}, preview.Data.Rows[1].Transaction)
+
+	// Confirm the detected mapping for the bank; later uploads use it.
+	body, err := json.Marshal(preview.Data.Mapping)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/imports/mappings/n26", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = serveImport(t, env, userID, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveImport(t, env, userID, uploadRequest(t, "/imports/csv", bankCSV, map[string]string{"bank": "N26"}))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result struct {
+		Data models.ImportResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, 2, result.Data.Imported)
+	require.Equal(t, 1, result.Data.Failed)
+	require.Equal(t, 4, result.Data.Errors[0].Line)
+
+	var count, total int
+	require.NoError(t, env.Db.QueryRow("SELECT COUNT(*), SUM(amount_cents) FROM transactions WHERE user_id = ?", userID.String()).Scan(&count, &total))
+	require.Equal(t, 2, count)
+	require.Equal(t, 11598, total)
+
+	w = serveImport(t, env, userID, httptest.NewRequest(http.MethodGet, "/imports/mappings", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var mappings struct {
+		Data []models.SavedCSVMapping `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &mappings))
+	require.Len(t, mappings.Data, 1)
+	require.Equal(t, preview.Data.Mapping, mappings.Data[0].Mapping)
+
+	w = serveImport(t, env, userID, httptest.NewRequest(http.MethodDelete, "/imports/mappings/n26", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = serveImport(t, env, userID, httptest.NewRequest(http.MethodDelete, "/imports/mappings/n26", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// TestIntegrationImportCSVUserCategories checks that a category name only resolves to
+// a system category or an active category of the importing user.
+func TestIntegrationImportCSVUserCategories(t *testing.T) {
+	ctx := context.Background()
+	userID, otherUserID := uuid.New(), uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	require.NoError(t, env.UserQ.CreateUser(ctx, database.CreateUserParams{
+		ID:             otherUserID.String(),
+		Email:          "other@example.com",
+		HashedPassword: "hashedpwd",
+	}))
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	data := []byte("Date,Merchant,Amount,Category\n2025-03-05,Ferry Plaza,12,Food Markets\n")
+	category := func() (int64, []string) {
+		t.Helper()
+		preview, err := env.Services.ImportService.PreviewCSV(ctx, userID.String(), "", data, nil)
+		require.NoError(t, err)
+		require.Len(t, preview.Rows, 1)
+		if preview.Rows[0].Transaction == nil {
+			return 0, preview.Rows[0].Errors
+		}
+		return preview.Rows[0].Transaction.DetailedCategory, preview.Rows[0].Errors
+	}
+
+	_, err := env.Services.CategoryService.CreateCategory(ctx, otherUserID.String(), models.NewCategoryRequest{Name: "Markets", PrimaryCategory: 7})
+	require.NoError(t, err)
+	_, errs := category()
+	require.Equal(t, []string{`unknown category "Food Markets"`}, errs)
+
+	own, err := env.Services.CategoryService.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "Markets", PrimaryCategory: 7})
+	require.NoError(t, err)
+	id, errs := category()
+	require.Empty(t, errs)
+	require.Equal(t, own.ID, id)
+
+	archived := true
+	_, err = env.Services.CategoryService.UpdateCategory(ctx, userID.String(), own.ID, models.CategoryUpdate{Archived: &archived})
+	require.NoError(t, err)
+	_, errs = category()
+	require.Equal(t, []string{`unknown category "Food Markets"`}, errs)
+}
+
+func serveImport(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	h := env.Handlers.ImportHandler
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.POST("/imports/csv", h.ImportCSV)
+	router.POST("/imports/csv/preview", h.PreviewCSV)
+	router.POST("/imports/ofx", h.ImportOFX)
+	router.POST("/imports/qif", h.ImportQIF)
+	router.GET("/imports/mappings", h.ListMappings)
+	router.PUT("/imports/mappings/:bank", h.SaveMapping)
+	router.DELETE("/imports/mappings/:bank", h.DeleteMapping)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
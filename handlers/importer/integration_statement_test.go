@@ -0,0 +1,88 @@
+package importer_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationImportStatements(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		file             string
+		expectedImported int
+		expectedFailed   int
+	}{
+		{name: "OFX 1.x", path: "/imports/ofx", file: "checking_v1.ofx", expectedImported: 3, expectedFailed: 1},
+		{name: "OFX 2.x", path: "/imports/ofx", file: "credit_card_v2.qfx", expectedImported: 2, expectedFailed: 1},
+		{name: "QIF", path: "/imports/qif", file: "checking.qif", expectedImported: 5, expectedFailed: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			env := testhelpers.SetupTestEnv(t)
+			defer env.Db.Close()
+			testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+			testhelpers.SeedTestCategories(t, env.Db)
+
+			data, err := testfixtures.ImportFiles.ReadFile("imports/" + tc.file)
+			require.NoError(t, err)
+
+			importFile := func() models.ImportResult {
+				w := serveImport(t, env, userID, uploadRequest(t, tc.path, string(data), map[string]string{"default_category": "FOOD_GROCERIES"}))
+				require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+				var resp struct {
+					Data models.ImportResult `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				return resp.Data
+			}
+
+			first := importFile()
+			require.Equal(t, tc.expectedImported, first.Imported)
+			require.Zero(t, first.Skipped)
+			require.Equal(t, tc.expectedFailed, first.Failed)
+
+			// Importing the same file again only skips.
+			second := importFile()
+			require.Zero(t, second.Imported)
+			require.Equal(t, tc.expectedImported, second.Skipped)
+			require.Equal(t, tc.expectedFailed, second.Failed)
+
+			var count int
+			require.NoError(t, env.Db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", userID.String()).Scan(&count))
+			require.Equal(t, tc.expectedImported, count)
+		})
+	}
+}
+
+func TestIntegrationImportOFXNeedsCategory(t *testing.T) {
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	data, err := testfixtures.ImportFiles.ReadFile("imports/credit_card_v2.qfx")
+	require.NoError(t, err)
+
+	w := serveImport(t, env, userID, uploadRequest(t, "/imports/ofx", string(data), map[string]string{"default_category": "TRAVEL_FLIGHTS"}))
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = serveImport(t, env, userID, uploadRequest(t, "/imports/ofx", string(data), nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data models.ImportResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 3, resp.Data.Failed)
+	require.Equal(t, []string{"no category and no default_category"}, resp.Data.Errors[0].Errors)
+}
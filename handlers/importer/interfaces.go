@@ -0,0 +1,17 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type ImportService interface {
+	PreviewCSV(ctx context.Context, userID, bank string, data []byte, mapping *models.CSVMapping) (models.ImportPreview, error)
+	ImportCSV(ctx context.Context, userID, bank string, data []byte, mapping *models.CSVMapping) (models.ImportResult, error)
+	ImportOFX(ctx context.Context, userID string, data []byte, defaultCategory string) (models.ImportResult, error)
+	ImportQIF(ctx context.Context, userID string, data []byte, defaultCategory string) (models.ImportResult, error)
+	ListMappings(ctx context.Context, userID string) ([]models.SavedCSVMapping, error)
+	SaveMapping(ctx context.Context, userID, bank string, mapping models.CSVMapping) (models.SavedCSVMapping, error)
+	DeleteMapping(ctx context.Context, userID, bank string) error
+}
@@ -0,0 +1,67 @@
+package importer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+)
+
+// ImportOFX stores the transactions of an uploaded OFX or QFX statement. The upload
+// is multipart: the file in "file" and a PRIMARY_DETAILED category name in
+// "default_category". Transactions imported before, going by FITID, are skipped.
+func (h *Handler) ImportOFX(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	data, ok := readFile(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.importSvc.ImportOFX(ctx.Request.Context(), userID.String(), data, ctx.PostForm("default_category"))
+	if err != nil {
+		importError(ctx, err, "failed to import transactions")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// ImportQIF stores the transactions of an uploaded QIF file. It takes the same upload
+// as ImportOFX; default_category is used for transactions without a known category.
+func (h *Handler) ImportQIF(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	data, ok := readFile(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.importSvc.ImportQIF(ctx.Request.Context(), userID.String(), data, ctx.PostForm("default_category"))
+	if err != nil {
+		importError(ctx, err, "failed to import transactions")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
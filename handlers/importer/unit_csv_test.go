@@ -0,0 +1,283 @@
+package importer_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	himport "github.com/seanhuebl/unity-wealth/handlers/importer"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testCSV = "Date,Merchant,Amount\n2025-03-05,Costco,125.98\n"
+
+func TestPreviewCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	mapping := &models.CSVMapping{Delimiter: ",", DateColumn: "Date", DateFormat: "YYYY-MM-DD", MerchantColumn: "Merchant", AmountColumn: "Amount"}
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		fields    map[string]string
+		noFile    bool
+		expectSvc bool
+		mapping   *models.CSVMapping
+		preview   models.ImportPreview
+		svcErr    error
+	}{
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "no file",
+				UserID:             userID,
+				ExpectedError:      `a multipart upload with the file in "file" is required`,
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": `a multipart upload with the file in "file" is required`,
+					},
+				},
+			},
+			noFile: true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "mapping is not JSON",
+				UserID:             userID,
+				ExpectedError:      "mapping must be a JSON object",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "mapping must be a JSON object",
+					},
+				},
+			},
+			fields: map[string]string{"mapping": "date=Date"},
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"bank": "chase",
+						"mapping": map[string]interface{}{
+							"delimiter":       ",",
+							"date_column":     "Date",
+							"date_format":     "YYYY-MM-DD",
+							"merchant_column": "Merchant",
+							"amount_column":   "Amount",
+						},
+						"mapping_source": "request",
+						"headers":        []interface{}{"Date", "Merchant", "Amount"},
+						"rows": []interface{}{
+							map[string]interface{}{"line": float64(2), "errors": []interface{}{"no category and no default_category"}},
+						},
+						"valid_rows": float64(0),
+						"error_rows": float64(1),
+					},
+				},
+			},
+			fields:    map[string]string{"bank": "chase", "mapping": `{"delimiter": ",", "date_column": "Date", "date_format": "YYYY-MM-DD", "merchant_column": "Merchant", "amount_column": "Amount"}`},
+			expectSvc: true,
+			mapping:   mapping,
+			preview: models.ImportPreview{
+				Bank:          "chase",
+				Mapping:       *mapping,
+				MappingSource: models.MappingSourceRequest,
+				Headers:       []string{"Date", "Merchant", "Amount"},
+				Rows:          []models.ImportRow{{Line: 2, Errors: []string{"no category and no default_category"}}},
+				ErrorRows:     1,
+			},
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid mapping",
+				UserID:             userID,
+				ExpectedError:      `invalid column mapping: column "Total" not found`,
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": `invalid column mapping: column "Total" not found`,
+					},
+				},
+			},
+			expectSvc: true,
+			svcErr:    fmt.Errorf("%w: column %q not found", importer.ErrInvalidMapping, "Total"),
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "failed to preview import",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "failed to preview import",
+					},
+				},
+			},
+			expectSvc: true,
+			svcErr:    errors.New("failed to look up category"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewImportService(t)
+			if tc.expectSvc {
+				mockSvc.On("PreviewCSV", mock.Anything, userID.String(), tc.fields["bank"], []byte(testCSV), tc.mapping).Return(tc.preview, tc.svcErr)
+			}
+			h := himport.NewHandler(mockSvc)
+
+			file := testCSV
+			if tc.noFile {
+				file = ""
+			}
+			w := httptest.NewRecorder()
+			req := uploadRequest(t, "/imports/csv/preview", file, tc.fields)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.POST("/imports/csv/preview", h.PreviewCSV)
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMappingRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	mapping := models.CSVMapping{Delimiter: ",", DateColumn: "Date", DateFormat: "YYYY-MM-DD", MerchantColumn: "Merchant", AmountColumn: "Amount"}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		setup          func(svc *handlermocks.ImportService)
+		expectedStatus int
+	}{
+		{
+			name:   "save",
+			method: http.MethodPut,
+			body:   `{"delimiter": ",", "date_column": "Date", "date_format": "YYYY-MM-DD", "merchant_column": "Merchant", "amount_column": "Amount"}`,
+			setup: func(svc *handlermocks.ImportService) {
+				svc.On("SaveMapping", mock.Anything, userID.String(), "chase", mapping).Return(models.SavedCSVMapping{Bank: "chase", Mapping: mapping}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "save with a bad body",
+			method:         http.MethodPut,
+			body:           `{"delimiter": 1}`,
+			setup:          func(svc *handlermocks.ImportService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "save an invalid mapping",
+			method: http.MethodPut,
+			body:   `{}`,
+			setup: func(svc *handlermocks.ImportService) {
+				svc.On("SaveMapping", mock.Anything, userID.String(), "chase", models.CSVMapping{}).Return(models.SavedCSVMapping{}, importer.ErrInvalidMapping).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "list",
+			method: http.MethodGet,
+			setup: func(svc *handlermocks.ImportService) {
+				svc.On("ListMappings", mock.Anything, userID.String()).Return([]models.SavedCSVMapping{{Bank: "chase", Mapping: mapping}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "delete",
+			method: http.MethodDelete,
+			setup: func(svc *handlermocks.ImportService) {
+				svc.On("DeleteMapping", mock.Anything, userID.String(), "chase").Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete a missing mapping",
+			method: http.MethodDelete,
+			setup: func(svc *handlermocks.ImportService) {
+				svc.On("DeleteMapping", mock.Anything, userID.String(), "chase").Return(importer.ErrMappingNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewImportService(t)
+			tc.setup(mockSvc)
+			h := himport.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.name, userID, c)
+				c.Next()
+			})
+			router.GET("/imports/mappings", h.ListMappings)
+			router.PUT("/imports/mappings/:bank", h.SaveMapping)
+			router.DELETE("/imports/mappings/:bank", h.DeleteMapping)
+
+			path := "/imports/mappings/chase"
+			if tc.method == http.MethodGet {
+				path = "/imports/mappings"
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, path, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func uploadRequest(t *testing.T, path, file string, fields map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if file != "" {
+		fw, err := mw.CreateFormFile("file", "export.csv")
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(file))
+		require.NoError(t, err)
+	}
+	for k, v := range fields {
+		require.NoError(t, mw.WriteField(k, v))
+	}
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
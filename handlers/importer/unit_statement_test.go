@@ -0,0 +1,64 @@
+package importer_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	himport "github.com/seanhuebl/unity-wealth/handlers/importer"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportStatementRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	const file = "<OFX></OFX>"
+
+	tests := []struct {
+		name           string
+		path           string
+		file           string
+		method         string
+		result         models.ImportResult
+		svcErr         error
+		expectedStatus int
+	}{
+		{name: "OFX", path: "/imports/ofx", file: file, method: "ImportOFX", result: models.ImportResult{Imported: 2, Skipped: 1}, expectedStatus: http.StatusOK},
+		{name: "QIF", path: "/imports/qif", file: file, method: "ImportQIF", result: models.ImportResult{Imported: 1}, expectedStatus: http.StatusOK},
+		{name: "no file", path: "/imports/ofx", expectedStatus: http.StatusBadRequest},
+		{name: "invalid file", path: "/imports/qif", file: file, method: "ImportQIF", svcErr: fmt.Errorf("%w: not a QIF file", importer.ErrInvalidFile), expectedStatus: http.StatusBadRequest},
+		{name: "unknown default category", path: "/imports/ofx", file: file, method: "ImportOFX", svcErr: fmt.Errorf("%w: %q", importer.ErrUnknownCategory, "NOPE"), expectedStatus: http.StatusBadRequest},
+		{name: "service error", path: "/imports/ofx", file: file, method: "ImportOFX", svcErr: errors.New("database is locked"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewImportService(t)
+			if tc.method != "" {
+				mockSvc.On(tc.method, mock.Anything, userID.String(), []byte(tc.file), "FOOD_GROCERIES").Return(tc.result, tc.svcErr).Once()
+			}
+			h := himport.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set(string(constants.UserIDKey), userID)
+				c.Next()
+			})
+			router.POST("/imports/ofx", h.ImportOFX)
+			router.POST("/imports/qif", h.ImportQIF)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, uploadRequest(t, tc.path, tc.file, map[string]string{"default_category": "FOOD_GROCERIES"}))
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package merchant
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/services/merchant"
+)
+
+type Handler struct {
+	merchantSvc MerchantService
+}
+
+func NewHandler(merchantSvc MerchantService) *Handler {
+	return &Handler{
+		merchantSvc: merchantSvc,
+	}
+}
+
+// merchantError responds with the status for a service error, using fallback as the
+// message for errors that are ours.
+func merchantError(ctx *gin.Context, err error, fallback string) {
+	status, msg := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, merchant.ErrInvalidMerchant),
+		errors.Is(err, merchant.ErrInvalidMerge):
+		status, msg = http.StatusBadRequest, err.Error()
+	case errors.Is(err, merchant.ErrMerchantNotFound):
+		status, msg = http.StatusNotFound, err.Error()
+	case errors.Is(err, merchant.ErrMerchantExists):
+		status, msg = http.StatusConflict, err.Error()
+	}
+	ctx.JSON(status, gin.H{
+		"data": gin.H{
+			"error": msg,
+		},
+	})
+}
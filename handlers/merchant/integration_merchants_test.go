@@ -0,0 +1,107 @@
+package merchant_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationMerchants(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	// One transaction predates the directory; the rest are linked as they are written.
+	testhelpers.SeedTestTransaction(t, env.TxQ, userID, uuid.New(), &models.NewTxRequest{Date: "2025-01-02", Merchant: "BLUE BOTTLE COFFEE 12", Amount: 4.5, DetailedCategory: 40})
+	_, err := env.Db.Exec("UPDATE transactions SET raw_merchant = merchant")
+	require.NoError(t, err)
+	for _, raw := range []string{"SQ *BLUE BOTTLE 0423 SAN FRANCISCO CA", "Blue Bottle"} {
+		_, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{Date: "2025-02-01", Merchant: raw, Amount: 5.25, DetailedCategory: 40})
+		require.NoError(t, err)
+	}
+
+	w := serveMerchants(t, env, userID, http.MethodGet, "/merchants", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	merchants := decodeMerchants(t, w)
+	require.Len(t, merchants, 2)
+	var target, source models.MerchantSummary
+	for _, m := range merchants {
+		switch m.Name {
+		case "Blue Bottle":
+			target = m
+		case "Blue Bottle Coffee":
+			source = m
+		}
+	}
+	require.Equal(t, int64(2), target.TransactionCount)
+	require.Equal(t, int64(1), source.TransactionCount)
+
+	w = serveMerchants(t, env, userID, http.MethodPost, "/merchants/merge", fmt.Sprintf(`{"target_id": %q, "source_ids": [%q]}`, target.ID, source.ID))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveMerchants(t, env, userID, http.MethodPatch, "/merchants/"+target.ID, `{"name": "Blue Bottle Coffee Co", "default_category": 40}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveMerchants(t, env, userID, http.MethodGet, "/merchants", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	category := int64(40)
+	require.Equal(t, []models.MerchantSummary{{
+		ID:               target.ID,
+		Name:             "Blue Bottle Coffee Co",
+		DefaultCategory:  &category,
+		Aliases:          []string{"blue bottle", "blue bottle coffee", "blue bottle coffee co"},
+		TransactionCount: 3,
+	}}, decodeMerchants(t, w))
+
+	// Every transaction takes the new name and keeps the string it was sent with.
+	var renamed, raw int
+	require.NoError(t, env.Db.QueryRow("SELECT COUNT(*), COUNT(DISTINCT raw_merchant) FROM transactions WHERE merchant = 'Blue Bottle Coffee Co'").Scan(&renamed, &raw))
+	require.Equal(t, 3, renamed)
+	require.Equal(t, 3, raw)
+
+	w = serveMerchants(t, env, userID, http.MethodPatch, "/merchants/"+source.ID, `{"name": "Anything"}`)
+	require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+}
+
+func serveMerchants(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	h := env.Handlers.MerchantHandler
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.GET("/merchants", h.ListMerchants)
+	router.POST("/merchants/merge", h.MergeMerchants)
+	router.PATCH("/merchants/:id", h.UpdateMerchant)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeMerchants(t *testing.T, w *httptest.ResponseRecorder) []models.MerchantSummary {
+	t.Helper()
+	var resp struct {
+		Data []models.MerchantSummary `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
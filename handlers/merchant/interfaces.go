@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type MerchantService interface {
+	ListMerchants(ctx context.Context, userID string) ([]models.MerchantSummary, error)
+	UpdateMerchant(ctx context.Context, userID, merchantID string, update models.MerchantUpdate) (models.MerchantSummary, error)
+	MergeMerchants(ctx context.Context, userID string, merge models.MerchantMerge) (models.MerchantSummary, error)
+}
@@ -0,0 +1,99 @@
+package merchant
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+func (h *Handler) ListMerchants(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	merchants, err := h.merchantSvc.ListMerchants(ctx.Request.Context(), userID.String())
+	if err != nil {
+		merchantError(ctx, err, "failed to list merchants")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": merchants,
+	})
+}
+
+// UpdateMerchant renames a merchant, along with its transactions, or sets its default
+// category.
+func (h *Handler) UpdateMerchant(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var update models.MerchantUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	updated, err := h.merchantSvc.UpdateMerchant(ctx.Request.Context(), userID.String(), ctx.Param("id"), update)
+	if err != nil {
+		merchantError(ctx, err, "failed to update merchant")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": updated,
+	})
+}
+
+// MergeMerchants folds the source merchants into the target and responds with the
+// target as it is afterwards.
+func (h *Handler) MergeMerchants(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var merge models.MerchantMerge
+	if err := ctx.ShouldBindJSON(&merge); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	merged, err := h.merchantSvc.MergeMerchants(ctx.Request.Context(), userID.String(), merge)
+	if err != nil {
+		merchantError(ctx, err, "failed to merge merchants")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": merged,
+	})
+}
@@ -0,0 +1,258 @@
+package merchant_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	hmerchant "github.com/seanhuebl/unity-wealth/handlers/merchant"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/merchant"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateMerchant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	name := "Blue Bottle"
+	category := int64(41)
+	update := models.MerchantUpdate{Name: &name, DefaultCategory: &category}
+	body := `{"name": "Blue Bottle", "default_category": 41}`
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		body      string
+		expectSvc bool
+		svcErr    error
+	}{
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "bad body",
+				UserID:             userID,
+				ExpectedError:      "invalid request body",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid request body",
+					},
+				},
+			},
+			body: `{"name": 1}`,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"id":                "merchant-1",
+						"name":              "Blue Bottle",
+						"default_category":  float64(41),
+						"aliases":           []interface{}{"blue bottle", "blue bottle coffee"},
+						"transaction_count": float64(3),
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "name taken",
+				UserID:             userID,
+				ExpectedError:      "merge them instead",
+				ExpectedStatusCode: http.StatusConflict,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "another merchant has this name; merge them instead",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    merchant.ErrMerchantExists,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "not found",
+				UserID:             userID,
+				ExpectedError:      "merchant not found",
+				ExpectedStatusCode: http.StatusNotFound,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "merchant not found",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    merchant.ErrMerchantNotFound,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "failed to update merchant",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "failed to update merchant",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    errors.New("db down"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewMerchantService(t)
+			if tc.expectSvc {
+				updated := models.MerchantSummary{
+					ID:               "merchant-1",
+					Name:             name,
+					DefaultCategory:  &category,
+					Aliases:          []string{"blue bottle", "blue bottle coffee"},
+					TransactionCount: 3,
+				}
+				if tc.svcErr != nil {
+					updated = models.MerchantSummary{}
+				}
+				mockSvc.On("UpdateMerchant", mock.Anything, userID.String(), "merchant-1", update).Return(updated, tc.svcErr).Once()
+			}
+			h := hmerchant.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.PATCH("/merchants/:id", h.UpdateMerchant)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPatch, "/merchants/merchant-1", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+		})
+	}
+}
+
+func TestMerchantRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	target := models.MerchantSummary{ID: "merchant-1", Name: "Blue Bottle", Aliases: []string{"blue bottle"}}
+	merge := models.MerchantMerge{TargetID: "merchant-1", SourceIDs: []string{"merchant-2"}}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setup          func(svc *handlermocks.MerchantService)
+		expectedStatus int
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			path:   "/merchants",
+			setup: func(svc *handlermocks.MerchantService) {
+				svc.On("ListMerchants", mock.Anything, userID.String()).Return([]models.MerchantSummary{target}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list fails",
+			method: http.MethodGet,
+			path:   "/merchants",
+			setup: func(svc *handlermocks.MerchantService) {
+				svc.On("ListMerchants", mock.Anything, userID.String()).Return(nil, errors.New("db down")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "merge",
+			method: http.MethodPost,
+			path:   "/merchants/merge",
+			body:   `{"target_id": "merchant-1", "source_ids": ["merchant-2"]}`,
+			setup: func(svc *handlermocks.MerchantService) {
+				svc.On("MergeMerchants", mock.Anything, userID.String(), merge).Return(target, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "merge without sources",
+			method:         http.MethodPost,
+			path:           "/merchants/merge",
+			body:           `{"target_id": "merchant-1", "source_ids": []}`,
+			setup:          func(svc *handlermocks.MerchantService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "merge into itself",
+			method: http.MethodPost,
+			path:   "/merchants/merge",
+			body:   `{"target_id": "merchant-1", "source_ids": ["merchant-1"]}`,
+			setup: func(svc *handlermocks.MerchantService) {
+				svc.On("MergeMerchants", mock.Anything, userID.String(), models.MerchantMerge{TargetID: "merchant-1", SourceIDs: []string{"merchant-1"}}).
+					Return(models.MerchantSummary{}, merchant.ErrInvalidMerge).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "merge a missing merchant",
+			method: http.MethodPost,
+			path:   "/merchants/merge",
+			body:   `{"target_id": "merchant-1", "source_ids": ["merchant-2"]}`,
+			setup: func(svc *handlermocks.MerchantService) {
+				svc.On("MergeMerchants", mock.Anything, userID.String(), merge).Return(models.MerchantSummary{}, merchant.ErrMerchantNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewMerchantService(t)
+			tc.setup(mockSvc)
+			h := hmerchant.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.name, userID, c)
+				c.Next()
+			})
+			router.GET("/merchants", h.ListMerchants)
+			router.POST("/merchants/merge", h.MergeMerchants)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package rules
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
+)
+
+type Handler struct {
+	ruleSvc RuleService
+}
+
+func NewHandler(ruleSvc RuleService) *Handler {
+	return &Handler{
+		ruleSvc: ruleSvc,
+	}
+}
+
+// ruleError responds with the status for a service error, using fallback as the
+// message for errors that are ours.
+func ruleError(ctx *gin.Context, err error, fallback string) {
+	status, msg := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, rules.ErrInvalidRule),
+		errors.Is(err, rules.ErrInvalidRun),
+		errors.Is(err, rules.ErrTooManyRules):
+		status, msg = http.StatusBadRequest, err.Error()
+	case errors.Is(err, rules.ErrRuleNotFound):
+		status, msg = http.StatusNotFound, err.Error()
+	}
+	ctx.JSON(status, gin.H{
+		"data": gin.H{
+			"error": msg,
+		},
+	})
+}
@@ -0,0 +1,194 @@
+package rules_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationRules(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	_, err := env.Db.Exec("INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES (41, 'Coffee', 'Coffee shops', 7)")
+	require.NoError(t, err)
+
+	pastID := uuid.New()
+	testhelpers.SeedTestTransaction(t, env.TxQ, userID, pastID, &models.NewTxRequest{
+		Date:             "2025-01-02",
+		Merchant:         "Joe's Coffee",
+		Amount:           4.5,
+		DetailedCategory: 40,
+	})
+
+	w := serveRules(t, env, userID, http.MethodPost, "/rules", `{
+		"name": "Coffee",
+		"conditions": {"merchant": {"match": "contains", "value": "COFFEE"}, "flow": "outflow"},
+		"actions": {"detailed_category": 41, "add_tags": ["Coffee"]}
+	}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// New transactions go through the rules.
+	created, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date:             "2025-02-01",
+		Merchant:         "Coffee Bar",
+		Amount:           3.75,
+		DetailedCategory: 40,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(41), created.DetailedCategory)
+	require.Equal(t, []string{"coffee"}, created.Tags)
+
+	// A dry run reports the change to the older transaction without making it.
+	w = serveRules(t, env, userID, http.MethodPost, "/rules/dry-run", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	dryRun := decodeRun(t, w)
+	require.Equal(t, 2, dryRun.Checked)
+	require.Equal(t, 1, dryRun.Changed)
+	require.Equal(t, pastID.String(), dryRun.Changes[0].TransactionID)
+	require.Equal(t, int64(41), *dryRun.Changes[0].Set.DetailedCategory)
+
+	past, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), pastID.String())
+	require.NoError(t, err)
+	require.Equal(t, int64(40), past.DetailedCategory)
+
+	// Applying makes it, and a second run finds nothing left to change.
+	w = serveRules(t, env, userID, http.MethodPost, "/rules/apply", `{"start_date": "2025-01-01"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, 1, decodeRun(t, w).Changed)
+
+	past, err = env.Services.TxService.GetTransactionByID(ctx, userID.String(), pastID.String())
+	require.NoError(t, err)
+	require.Equal(t, int64(41), past.DetailedCategory)
+	require.Equal(t, []string{"coffee"}, past.Tags)
+
+	w = serveRules(t, env, userID, http.MethodPost, "/rules/apply", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Zero(t, decodeRun(t, w).Changed)
+}
+
+func TestIntegrationApplyRulesSkipsSplits(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	_, err := env.Db.Exec("INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES (41, 'Coffee', 'Coffee shops', 7)")
+	require.NoError(t, err)
+
+	plain, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-01-02", Merchant: "Joe's Coffee", Amount: 4.5, DetailedCategory: 40,
+	})
+	require.NoError(t, err)
+	splits := []models.TxSplit{{Amount: 3, DetailedCategory: 40, Memo: "beans"}, {Amount: 1.5, DetailedCategory: 41}}
+	split, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-01-03", Merchant: "Joe's Coffee", Amount: 4.5, Splits: splits,
+	})
+	require.NoError(t, err)
+
+	w := serveRules(t, env, userID, http.MethodPost, "/rules", `{
+		"name": "Coffee",
+		"conditions": {"merchant": {"match": "contains", "value": "coffee"}},
+		"actions": {"detailed_category": 41}
+	}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	// The lines of a split transaction carry its category, so the run leaves it alone.
+	w = serveRules(t, env, userID, http.MethodPost, "/rules/apply", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	result := decodeRun(t, w)
+	require.Equal(t, 2, result.Checked)
+	require.Equal(t, 1, result.Changed)
+	require.Equal(t, 1, result.Skipped)
+	require.Equal(t, plain.ID, result.Changes[0].TransactionID)
+
+	stored, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), plain.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(41), stored.DetailedCategory)
+	stored, err = env.Services.TxService.GetTransactionByID(ctx, userID.String(), split.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), stored.DetailedCategory)
+	require.Equal(t, splits, stored.Splits)
+}
+
+func TestIntegrationApplyRulesSkipsArchivedCategory(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	coffee, err := env.Services.CategoryService.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "Coffee", PrimaryCategory: 7})
+	require.NoError(t, err)
+	txn, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-01-02", Merchant: "Joe's Coffee", Amount: 4.5, DetailedCategory: 40,
+	})
+	require.NoError(t, err)
+
+	w := serveRules(t, env, userID, http.MethodPost, "/rules", fmt.Sprintf(`{
+		"name": "Coffee",
+		"conditions": {"merchant": {"match": "contains", "value": "coffee"}},
+		"actions": {"detailed_category": %d}
+	}`, coffee.ID))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	archived := true
+	_, err = env.Services.CategoryService.UpdateCategory(ctx, userID.String(), coffee.ID, models.CategoryUpdate{Archived: &archived})
+	require.NoError(t, err)
+
+	// The database refuses the archived category, so the run skips the transaction.
+	w = serveRules(t, env, userID, http.MethodPost, "/rules/apply", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, models.RuleRunResult{Checked: 1, Skipped: 1, Changes: []models.RuleChange{}}, decodeRun(t, w))
+
+	stored, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txn.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), stored.DetailedCategory)
+}
+
+func serveRules(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	h := env.Handlers.RuleHandler
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.GET("/rules", h.ListRules)
+	router.POST("/rules", h.CreateRule)
+	router.PUT("/rules/:id", h.UpdateRule)
+	router.DELETE("/rules/:id", h.DeleteRule)
+	router.POST("/rules/dry-run", h.DryRun)
+	router.POST("/rules/apply", h.ApplyRules)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeRun(t *testing.T, w *httptest.ResponseRecorder) models.RuleRunResult {
+	t.Helper()
+	var resp struct {
+		Data models.RuleRunResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data
+}
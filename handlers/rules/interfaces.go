@@ -0,0 +1,16 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RuleService interface {
+	ListRules(ctx context.Context, userID string) ([]models.Rule, error)
+	CreateRule(ctx context.Context, userID string, rule models.Rule) (models.Rule, error)
+	UpdateRule(ctx context.Context, userID, ruleID string, rule models.Rule) (models.Rule, error)
+	DeleteRule(ctx context.Context, userID, ruleID string) error
+	DryRun(ctx context.Context, userID string, run models.RuleRunRequest) (models.RuleRunResult, error)
+	ApplyRules(ctx context.Context, userID string, run models.RuleRunRequest) (models.RuleRunResult, error)
+}
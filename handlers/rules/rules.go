@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+func (h *Handler) ListRules(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	rules, err := h.ruleSvc.ListRules(ctx.Request.Context(), userID.String())
+	if err != nil {
+		ruleError(ctx, err, "failed to list rules")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": rules,
+	})
+}
+
+func (h *Handler) CreateRule(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var rule models.Rule
+	if err := ctx.ShouldBindJSON(&rule); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	created, err := h.ruleSvc.CreateRule(ctx.Request.Context(), userID.String(), rule)
+	if err != nil {
+		ruleError(ctx, err, "failed to create rule")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"data": created,
+	})
+}
+
+// UpdateRule replaces a rule. Transactions it already changed stay as they are;
+// ApplyRules changes them again.
+func (h *Handler) UpdateRule(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var rule models.Rule
+	if err := ctx.ShouldBindJSON(&rule); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	updated, err := h.ruleSvc.UpdateRule(ctx.Request.Context(), userID.String(), ctx.Param("id"), rule)
+	if err != nil {
+		ruleError(ctx, err, "failed to update rule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": updated,
+	})
+}
+
+func (h *Handler) DeleteRule(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	if err := h.ruleSvc.DeleteRule(ctx.Request.Context(), userID.String(), ctx.Param("id")); err != nil {
+		ruleError(ctx, err, "failed to delete rule")
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DryRun reports what the saved rules, or the unsaved rule in the body, would change
+// on past transactions. The body is optional; start_date and end_date narrow the
+// history.
+func (h *Handler) DryRun(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	run, ok := bindRun(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.ruleSvc.DryRun(ctx.Request.Context(), userID.String(), run)
+	if err != nil {
+		ruleError(ctx, err, "failed to run rules")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// ApplyRules runs the saved rules over past transactions and saves what they change.
+func (h *Handler) ApplyRules(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	run, ok := bindRun(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.ruleSvc.ApplyRules(ctx.Request.Context(), userID.String(), run)
+	if err != nil {
+		ruleError(ctx, err, "failed to apply rules")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// bindRun reads the optional body of a rule run, responding with 400 when it is
+// not valid JSON.
+func bindRun(ctx *gin.Context) (models.RuleRunRequest, bool) {
+	var run models.RuleRunRequest
+	if err := ctx.ShouldBindJSON(&run); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return models.RuleRunRequest{}, false
+	}
+	return run, true
+}
@@ -0,0 +1,267 @@
+package rules_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	hrules "github.com/seanhuebl/unity-wealth/handlers/rules"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	body := `{"name": "Coffee", "conditions": {"merchant": {"match": "contains", "value": "coffee"}}, "actions": {"detailed_category": 41}}`
+	rule := models.Rule{
+		Name:       "Coffee",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "coffee"}},
+		Actions:    models.RuleActions{DetailedCategory: 41},
+	}
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		body      string
+		expectSvc bool
+		svcErr    error
+	}{
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "missing name",
+				UserID:             userID,
+				ExpectedError:      "invalid request body",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid request body",
+					},
+				},
+			},
+			body: `{"conditions": {}}`,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusCreated,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"id":       "rule-1",
+						"name":     "Coffee",
+						"priority": float64(0),
+						"conditions": map[string]interface{}{
+							"merchant": map[string]interface{}{"match": "contains", "value": "coffee"},
+						},
+						"actions": map[string]interface{}{"detailed_category": float64(41)},
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid rule",
+				UserID:             userID,
+				ExpectedError:      "invalid rule: at least one action is required",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid rule: at least one action is required",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    fmt.Errorf("%w: at least one action is required", rules.ErrInvalidRule),
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "failed to create rule",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "failed to create rule",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    errors.New("db down"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewRuleService(t)
+			if tc.expectSvc {
+				created := rule
+				created.ID = "rule-1"
+				if tc.svcErr != nil {
+					created = models.Rule{}
+				}
+				mockSvc.On("CreateRule", mock.Anything, userID.String(), rule).Return(created, tc.svcErr).Once()
+			}
+			h := hrules.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.POST("/rules", h.CreateRule)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/rules", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+		})
+	}
+}
+
+func TestRuleRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	rule := models.Rule{
+		Name:       "Coffee",
+		Conditions: models.RuleConditions{Flow: models.FlowOutflow},
+		Actions:    models.RuleActions{AddTags: []string{"coffee"}},
+	}
+	ruleBody := `{"name": "Coffee", "conditions": {"flow": "outflow"}, "actions": {"add_tags": ["coffee"]}}`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setup          func(svc *handlermocks.RuleService)
+		expectedStatus int
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			path:   "/rules",
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("ListRules", mock.Anything, userID.String()).Return([]models.Rule{rule}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "update",
+			method: http.MethodPut,
+			path:   "/rules/rule-1",
+			body:   ruleBody,
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("UpdateRule", mock.Anything, userID.String(), "rule-1", rule).Return(rule, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "update a missing rule",
+			method: http.MethodPut,
+			path:   "/rules/rule-1",
+			body:   ruleBody,
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("UpdateRule", mock.Anything, userID.String(), "rule-1", rule).Return(models.Rule{}, rules.ErrRuleNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "delete",
+			method: http.MethodDelete,
+			path:   "/rules/rule-1",
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("DeleteRule", mock.Anything, userID.String(), "rule-1").Return(nil).Once()
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "dry run without a body",
+			method: http.MethodPost,
+			path:   "/rules/dry-run",
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("DryRun", mock.Anything, userID.String(), models.RuleRunRequest{}).Return(models.RuleRunResult{Changes: []models.RuleChange{}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "dry run an unsaved rule",
+			method: http.MethodPost,
+			path:   "/rules/dry-run",
+			body:   `{"rule": ` + ruleBody + `, "start_date": "2025-01-01"}`,
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("DryRun", mock.Anything, userID.String(), models.RuleRunRequest{Rule: &rule, StartDate: "2025-01-01"}).Return(models.RuleRunResult{Changes: []models.RuleChange{}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "dry run with a bad body",
+			method:         http.MethodPost,
+			path:           "/rules/dry-run",
+			body:           `{"start_date": 1}`,
+			setup:          func(svc *handlermocks.RuleService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "apply with bad dates",
+			method: http.MethodPost,
+			path:   "/rules/apply",
+			body:   `{"start_date": "yesterday"}`,
+			setup: func(svc *handlermocks.RuleService) {
+				svc.On("ApplyRules", mock.Anything, userID.String(), models.RuleRunRequest{StartDate: "yesterday"}).Return(models.RuleRunResult{}, rules.ErrInvalidRun).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewRuleService(t)
+			tc.setup(mockSvc)
+			h := hrules.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.name, userID, c)
+				c.Next()
+			})
+			router.GET("/rules", h.ListRules)
+			router.PUT("/rules/:id", h.UpdateRule)
+			router.DELETE("/rules/:id", h.DeleteRule)
+			router.POST("/rules/dry-run", h.DryRun)
+			router.POST("/rules/apply", h.ApplyRules)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+)
+
+// BatchTransactions runs a batch of creates, updates and deletes. Every operation gets
+// a result with the status its single-transaction route would have returned. A
+// committed batch responds 200, even in best-effort mode with failed operations; an
+// atomic batch that was rolled back responds 422, or 500 when the failure was ours.
+func (h *Handler) BatchTransactions(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	var req models.TxBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+
+	resp, err := h.txSvc.BatchTransactions(ctx.Request.Context(), userID.String(), req)
+	if err != nil {
+		if errors.Is(err, transaction.ErrInvalidBatchOp) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "failed to run batch",
+			},
+		})
+		return
+	}
+
+	status := http.StatusOK
+	for i := range resp.Results {
+		result := &resp.Results[i]
+		result.Status, result.Error = batchResultStatus(result)
+		if !resp.Committed && result.Err != nil && !errors.Is(result.Err, transaction.ErrBatchAborted) {
+			status = http.StatusUnprocessableEntity
+			if result.Status == http.StatusInternalServerError {
+				status = http.StatusInternalServerError
+			}
+		}
+	}
+
+	ctx.JSON(status, gin.H{
+		"data": resp,
+	})
+}
+
+// batchResultStatus maps a batch operation's error the way the single-transaction
+// handlers map theirs.
+func batchResultStatus(result *models.TxBatchResult) (int, string) {
+	err := result.Err
+	switch {
+	case err == nil && result.Op == models.BatchOpCreate:
+		return http.StatusCreated, ""
+	case err == nil:
+		return http.StatusOK, ""
+	case errors.Is(err, transaction.ErrBatchAborted):
+		return http.StatusFailedDependency, err.Error()
+	case errors.Is(err, transaction.ErrInvalidBatchOp):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, transaction.ErrDuplicateExternalID):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, transaction.ErrVersionMismatch):
+		return http.StatusPreconditionFailed, "transaction has been modified"
+	case errors.Is(err, transaction.ErrCategoryUnavailable):
+		return http.StatusBadRequest, transaction.ErrCategoryUnavailable.Error()
+	case errors.Is(err, transaction.ErrInvalidSplit):
+		return http.StatusBadRequest, err.Error()
+	case strings.Contains(err.Error(), "invalid date format"):
+		return http.StatusBadRequest, "invalid date; use YYYY-MM-DD"
+	case strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound, "not found"
+	default:
+		return http.StatusInternalServerError, "failed to " + result.Op + " transaction"
+	}
+}
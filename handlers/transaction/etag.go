@@ -0,0 +1,52 @@
+package transaction
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errMultipleETags = errors.New("If-Match must contain a single ETag")
+
+// etag formats a transaction version as a strong entity tag.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion turns an If-Match header into the version a write must find. An
+// absent header or "*" gives 0, which makes the write unconditional. A tag that can
+// never match a transaction, such as a weak or malformed one, gives -1 so the write
+// fails its precondition.
+func ifMatchVersion(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, errMultipleETags
+	}
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return -1, nil
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return -1, nil
+	}
+	return version, nil
+}
+
+// ifNoneMatch reports whether an If-None-Match header matches version, using the weak
+// comparison RFC 9110 prescribes for this header.
+func ifNoneMatch(header string, version int64) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == current {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/export"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+)
+
+// ExportTransactions streams the user's transactions as a file download. format is
+// csv (the default), jsonl, ofx or xlsx, and the filters are the listing's. Once the
+// first bytes are sent the status can no longer change, so a failure after that
+// ends the response early and leaves the file incomplete.
+func (h *Handler) ExportTransactions(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	format, ok := export.Lookup(strings.ToLower(ctx.DefaultQuery("format", "csv")))
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid format; use " + strings.Join(export.Names(), ", "),
+			},
+		})
+		return
+	}
+
+	filter, err := parseTxFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": err.Error(),
+			},
+		})
+		return
+	}
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", format.ContentType)
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions.%s"`, format.Extension))
+	ctx.Status(http.StatusOK)
+
+	w := format.NewWriter(ctx.Writer, filter)
+	err = h.txSvc.ExportTransactions(ctx.Request.Context(), userID, filter, w.Write)
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		return
+	}
+	if ctx.Writer.Written() {
+		ctx.Abort()
+		return
+	}
+	header.Del("Content-Disposition")
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"data": gin.H{
+			"error": "unable to export transactions",
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+const maxMerchantFilterLength = 100
+
+// parseTxFilter reads the listing filters from the query string:
+//
+//	start_date, end_date    YYYY-MM-DD, inclusive
+//	merchant                case-insensitive substring
+//	primary_category        primary category ID
+//	detailed_category       detailed category ID
+//	min_amount, max_amount  dollars, compared by absolute value
+//	flow                    inflow or outflow
+//	sort                    desc (newest first, default) or asc
+//
+// Filters left out stay at their zero value, so a request with none of them returns a
+// zero TxFilter and can be continued with any cursor.
+//
+// The returned error message is safe to show to the client.
+func parseTxFilter(ctx *gin.Context) (models.TxFilter, error) {
+	var filter models.TxFilter
+
+	for name, dst := range map[string]*string{"start_date": &filter.StartDate, "end_date": &filter.EndDate} {
+		v := ctx.Query(name)
+		if v == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return models.TxFilter{}, fmt.Errorf("invalid %s; use YYYY-MM-DD", name)
+		}
+		*dst = v
+	}
+	if filter.StartDate != "" && filter.EndDate != "" && filter.StartDate > filter.EndDate {
+		return models.TxFilter{}, fmt.Errorf("start_date must not be after end_date")
+	}
+
+	filter.Merchant = strings.TrimSpace(ctx.Query("merchant"))
+	if len(filter.Merchant) > maxMerchantFilterLength {
+		return models.TxFilter{}, fmt.Errorf("merchant must be at most %d characters", maxMerchantFilterLength)
+	}
+
+	for name, dst := range map[string]*int64{"primary_category": &filter.PrimaryCategoryID, "detailed_category": &filter.DetailedCategoryID} {
+		v := ctx.Query(name)
+		if v == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return models.TxFilter{}, fmt.Errorf("invalid %s", name)
+		}
+		*dst = id
+	}
+
+	for name, dst := range map[string]*int64{"min_amount": &filter.MinAmountCents, "max_amount": &filter.MaxAmountCents} {
+		v := ctx.Query(name)
+		if v == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(v, 64)
+		if err != nil || amount <= 0 {
+			return models.TxFilter{}, fmt.Errorf("invalid %s; must be > 0", name)
+		}
+		*dst = helpers.ConvertToCents(amount)
+	}
+	if filter.MinAmountCents != 0 && filter.MaxAmountCents != 0 && filter.MinAmountCents > filter.MaxAmountCents {
+		return models.TxFilter{}, fmt.Errorf("min_amount must not be greater than max_amount")
+	}
+
+	switch flow := ctx.Query("flow"); flow {
+	case "", models.FlowInflow, models.FlowOutflow:
+		filter.Flow = flow
+	default:
+		return models.TxFilter{}, fmt.Errorf("invalid flow; use %s or %s", models.FlowInflow, models.FlowOutflow)
+	}
+
+	switch sort := strings.ToLower(ctx.Query("sort")); sort {
+	case "", models.SortNewestFirst, models.SortOldestFirst:
+		filter.Sort = sort
+	default:
+		return models.TxFilter{}, fmt.Errorf("invalid sort; use %s or %s", models.SortNewestFirst, models.SortOldestFirst)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,193 @@
+package transaction_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationBatchTransactions(t *testing.T) {
+	tests := []struct {
+		name              string
+		mode              string
+		ops               func(txID uuid.UUID) string
+		expectedStatus    int
+		expectedCommitted bool
+		expectedStatuses  []int
+		expectedMerchant  string
+		expectExisting    bool
+		expectedCount     int
+	}{
+		{
+			name: "atomic success",
+			mode: models.BatchModeAtomic,
+			ops: func(txID uuid.UUID) string {
+				return fmt.Sprintf(`[
+					{"op": "create", "transaction": {"date": "2025-03-06", "merchant": "target", "amount": 10.5, "detailed_category": 40}},
+					{"op": "update", "id": %q, "version": 1, "transaction": {"date": "2025-03-05", "merchant": "Costco Wholesale", "amount": 125.98, "detailed_category": 40}}
+				]`, txID)
+			},
+			expectedStatus:    http.StatusOK,
+			expectedCommitted: true,
+			expectedStatuses:  []int{http.StatusCreated, http.StatusOK},
+			expectedMerchant:  "Costco Wholesale",
+			expectExisting:    true,
+			expectedCount:     2,
+		},
+		{
+			name: "atomic failure rolls back",
+			mode: models.BatchModeAtomic,
+			ops: func(txID uuid.UUID) string {
+				return fmt.Sprintf(`[
+					{"op": "create", "transaction": {"date": "2025-03-06", "merchant": "target", "amount": 10.5, "detailed_category": 40}},
+					{"op": "delete", "id": %q},
+					{"op": "update", "id": %q, "transaction": {"date": "2025-03-05", "merchant": "x", "amount": 1, "detailed_category": 40}}
+				]`, txID, uuid.New())
+			},
+			expectedStatus:   http.StatusUnprocessableEntity,
+			expectedStatuses: []int{http.StatusFailedDependency, http.StatusFailedDependency, http.StatusNotFound},
+			expectedMerchant: "costco",
+			expectExisting:   true,
+			expectedCount:    1,
+		},
+		{
+			name: "best effort keeps successes",
+			mode: models.BatchModeBestEffort,
+			ops: func(txID uuid.UUID) string {
+				return fmt.Sprintf(`[
+					{"op": "create", "transaction": {"date": "2025-03-06", "merchant": "target", "amount": 10.5, "detailed_category": 40}},
+					{"op": "create", "transaction": {"date": "03/06/2025", "merchant": "target", "amount": 10.5, "detailed_category": 40}},
+					{"op": "update", "id": %q, "version": 9, "transaction": {"date": "2025-03-05", "merchant": "x", "amount": 1, "detailed_category": 40}},
+					{"op": "delete", "id": %q},
+					{"op": "archive", "id": %q}
+				]`, txID, txID, txID)
+			},
+			expectedStatus:    http.StatusOK,
+			expectedCommitted: true,
+			expectedStatuses:  []int{http.StatusCreated, http.StatusBadRequest, http.StatusPreconditionFailed, http.StatusOK, http.StatusBadRequest},
+			expectedCount:     1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			txID := uuid.New()
+			env := setupPatchTestEnv(t, userID, txID)
+			defer env.Db.Close()
+
+			w := batchTransactions(t, env, userID, fmt.Sprintf(`{"mode": %q, "operations": %s}`, tc.mode, tc.ops(txID)))
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+
+			var resp struct {
+				Data models.TxBatchResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, tc.expectedCommitted, resp.Data.Committed)
+			statuses := make([]int, 0, len(resp.Data.Results))
+			for i, result := range resp.Data.Results {
+				require.Equal(t, i, result.Index)
+				statuses = append(statuses, result.Status)
+			}
+			require.Equal(t, tc.expectedStatuses, statuses, w.Body.String())
+
+			var count int
+			require.NoError(t, env.Db.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", userID.String()).Scan(&count))
+			require.Equal(t, tc.expectedCount, count)
+			if tc.expectExisting {
+				assertTxExists(t, env, userID, txID)
+				txn, err := env.Services.TxService.GetTransactionByID(context.Background(), userID.String(), txID.String())
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedMerchant, txn.Merchant)
+			}
+		})
+	}
+}
+
+func TestIntegrationBatchTransactionsKeepsSplitsOfFailedUpdates(t *testing.T) {
+	userID := uuid.New()
+	txID := uuid.New()
+	env := setupPatchTestEnv(t, userID, txID)
+	defer env.Db.Close()
+	_, err := env.Db.Exec(`INSERT INTO primary_categories (id, name) VALUES (8, 'General merchandise')`)
+	require.NoError(t, err)
+	_, err = env.Db.Exec(`INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES (50, 'Household', 'Cleaning and household supplies', 8)`)
+	require.NoError(t, err)
+
+	w := batchTransactions(t, env, userID, fmt.Sprintf(`{"mode": "atomic", "operations": [
+		{"op": "update", "id": %q, "version": 1, "transaction": {"date": "2025-03-05", "merchant": "costco", "amount": 125.98, "splits": [
+			{"amount": 100, "detailed_category": 40, "memo": "food"},
+			{"amount": 25.98, "detailed_category": 50, "memo": "soap"}
+		]}}
+	]}`, txID))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	splits := []models.TxSplit{
+		{Amount: 100, DetailedCategory: 40, Memo: "food"},
+		{Amount: 25.98, DetailedCategory: 50, Memo: "soap"},
+	}
+
+	// A stale version and a bad second line both fail after the old lines are deleted.
+	w = batchTransactions(t, env, userID, fmt.Sprintf(`{"mode": "best_effort", "operations": [
+		{"op": "update", "id": %q, "version": 1, "transaction": {"date": "2025-03-05", "merchant": "costco", "amount": 125.98, "detailed_category": 40}},
+		{"op": "update", "id": %q, "version": 2, "transaction": {"date": "2025-03-05", "merchant": "costco", "amount": 125.98, "splits": [
+			{"amount": 100, "detailed_category": 40},
+			{"amount": 25.98, "detailed_category": 99999}
+		]}}
+	]}`, txID, txID))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp struct {
+		Data models.TxBatchResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.True(t, resp.Data.Committed)
+	require.Equal(t, http.StatusPreconditionFailed, resp.Data.Results[0].Status, w.Body.String())
+	require.GreaterOrEqual(t, resp.Data.Results[1].Status, http.StatusBadRequest, w.Body.String())
+
+	txn, err := env.Services.TxService.GetTransactionByID(context.Background(), userID.String(), txID.String())
+	require.NoError(t, err)
+	require.Equal(t, splits, txn.Splits)
+}
+
+func TestIntegrationBatchTransactionsLimits(t *testing.T) {
+	userID := uuid.New()
+	env := setupPatchTestEnv(t, userID, uuid.New())
+	defer env.Db.Close()
+
+	op := `{"op": "delete", "id": "` + uuid.NewString() + `"}`
+	tooMany := strings.TrimSuffix(strings.Repeat(op+",", models.MaxBatchOperations+1), ",")
+
+	for name, body := range map[string]string{
+		"no operations": `{"mode": "atomic", "operations": []}`,
+		"too many":      `{"mode": "atomic", "operations": [` + tooMany + `]}`,
+		"unknown mode":  `{"mode": "sometimes", "operations": [` + op + `]}`,
+	} {
+		w := batchTransactions(t, env, userID, body)
+		require.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
+
+func batchTransactions(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/transactions/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router := gin.New()
+	router.POST("/transactions/batch", func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		env.Handlers.TxHandler.BatchTransactions(c)
+	})
+	router.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,171 @@
+package transaction_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationTxETags(t *testing.T) {
+	userID := uuid.New()
+	txID := uuid.New()
+	env := setupPatchTestEnv(t, userID, txID)
+	defer env.Db.Close()
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.GET("/transactions/:id", env.Handlers.TxHandler.GetTransactionByID)
+	router.PUT("/transactions/:id", env.Handlers.TxHandler.UpdateTransaction)
+	router.PATCH("/transactions/:id", env.Handlers.TxHandler.PatchTransaction)
+	router.DELETE("/transactions/:id", env.Handlers.TxHandler.DeleteTransaction)
+
+	// Each step runs against the state the previous steps left behind.
+	steps := []struct {
+		name           string
+		method         string
+		body           string
+		headers        map[string]string
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:           "read returns the version as ETag",
+			method:         "GET",
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "matching If-None-Match",
+			method:         "GET",
+			headers:        map[string]string{"If-None-Match": `W/"1"`},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "patch with current version",
+			method:         "PATCH",
+			body:           `{"merchant": "Costco Wholesale"}`,
+			headers:        map[string]string{"If-Match": `"1"`},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:           "stale If-None-Match",
+			method:         "GET",
+			headers:        map[string]string{"If-None-Match": `"1"`},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:           "patch with stale version",
+			method:         "PATCH",
+			body:           `{"merchant": "Costco"}`,
+			headers:        map[string]string{"If-Match": `"1"`},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "put with stale version",
+			method:         "PUT",
+			body:           `{"date": "2025-03-05", "merchant": "costco", "amount": 1, "detailed_category": 40}`,
+			headers:        map[string]string{"If-Match": `"1"`},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "weak If-Match never matches",
+			method:         "PUT",
+			body:           `{"date": "2025-03-05", "merchant": "costco", "amount": 1, "detailed_category": 40}`,
+			headers:        map[string]string{"If-Match": `W/"2"`},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "put with current version",
+			method:         "PUT",
+			body:           `{"date": "2025-03-05", "merchant": "costco", "amount": 1, "detailed_category": 40}`,
+			headers:        map[string]string{"If-Match": `"2"`},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "unconditional patch",
+			method:         "PATCH",
+			body:           `{"notes": "no precondition"}`,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:           "delete with stale version",
+			method:         "DELETE",
+			headers:        map[string]string{"If-Match": `"3"`},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "delete with current version",
+			method:         "DELETE",
+			headers:        map[string]string{"If-Match": `"4"`},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "If-Match on a deleted transaction",
+			method:         "PATCH",
+			body:           `{"notes": "gone"}`,
+			headers:        map[string]string{"If-Match": `"4"`},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, step := range steps {
+		w := txRequest(router, step.method, txID, step.body, step.headers)
+		require.Equal(t, step.expectedStatus, w.Code, "%s: %s", step.name, w.Body.String())
+		if step.expectedETag != "" {
+			require.Equal(t, step.expectedETag, w.Header().Get("ETag"), step.name)
+		}
+		if step.expectedStatus == http.StatusNotModified {
+			require.Empty(t, w.Body.String(), step.name)
+		}
+	}
+}
+
+func TestIntegrationTxIfMatchList(t *testing.T) {
+	userID := uuid.New()
+	txID := uuid.New()
+	env := setupPatchTestEnv(t, userID, txID)
+	defer env.Db.Close()
+
+	router := gin.New()
+	router.DELETE("/transactions/:id", func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		env.Handlers.TxHandler.DeleteTransaction(c)
+	})
+	w := txRequest(router, "DELETE", txID, "", map[string]string{"If-Match": `"1", "2"`})
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assertTxExists(t, env, userID, txID)
+}
+
+func txRequest(router *gin.Engine, method string, txID uuid.UUID, body string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, fmt.Sprintf("/transactions/%v", txID), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func assertTxExists(t *testing.T, env *testmodels.TestEnv, userID, txID uuid.UUID) {
+	t.Helper()
+	_, err := env.Services.TxService.GetTransactionByID(context.Background(), userID.String(), txID.String())
+	require.NoError(t, err)
+}
@@ -0,0 +1,81 @@
+package transaction_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationExportCSVRoundTrip exports a user's transactions as CSV, deletes them
+// and imports the file without a mapping; the transactions must come back the same.
+func TestIntegrationExportCSVRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	userID := uuid.New()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	// The export writes the user's names for categories, which the import must read back.
+	renamed := "Weekly big shop"
+	_, err := env.Services.CategoryService.UpdateCategory(ctx, userID.String(), 40, models.CategoryUpdate{Name: &renamed})
+	require.NoError(t, err)
+	own, err := env.Services.CategoryService.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "Farmers' markets", PrimaryCategory: 7})
+	require.NoError(t, err)
+
+	created := []models.NewTxRequest{
+		{Date: "2025-03-05", Merchant: "Costco, Inc.", Amount: 125.98, DetailedCategory: 40, Notes: `Weekly "big" shop`, Tags: []string{"home", "bulk"}},
+		{Date: "2025-03-14", Merchant: "Refund", Amount: -10, DetailedCategory: 40},
+		{Date: "2025-03-22", Merchant: "Ferry Plaza", Amount: 18, DetailedCategory: own.ID},
+		{Date: "2025-04-01", Merchant: "Farmers Market", Amount: 22.5, DetailedCategory: 40},
+	}
+	for _, req := range created {
+		_, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), req)
+		require.NoError(t, err)
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.GET("/transactions/export", env.Handlers.TxHandler.ExportTransactions)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/transactions/export?format=csv&end_date=2025-03-31&sort=asc", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	_, err = env.Db.Exec("DELETE FROM transactions WHERE user_id = ?", userID.String())
+	require.NoError(t, err)
+	result, err := env.Services.ImportService.ImportCSV(ctx, userID.String(), "", w.Body.Bytes(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Imported)
+	require.Zero(t, result.Failed)
+
+	page, err := env.Services.TxService.ListUserTransactions(ctx, userID, models.TxFilter{Sort: models.SortOldestFirst}, "", 10)
+	require.NoError(t, err)
+	var imported []models.NewTxRequest
+	for _, txn := range page.Transactions {
+		imported = append(imported, models.NewTxRequest{
+			Date:             txn.Date,
+			Merchant:         txn.Merchant,
+			Amount:           txn.Amount,
+			DetailedCategory: txn.DetailedCategory,
+			Notes:            txn.Notes,
+			Tags:             txn.Tags,
+		})
+	}
+	if diff := cmp.Diff(created[:3], imported, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,8 @@
 package transaction_test
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,10 +11,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
 	"github.com/seanhuebl/unity-wealth/internal/models"
 	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
 	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
 	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIntegrationGetTransactionsByUserID(t *testing.T) {
@@ -35,15 +39,17 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 								"merchant":          "costco",
 								"amount":            125.98,
 								"detailed_category": 40,
+								"version":           float64(1),
 							},
 						},
-						"next_cursor_date": "",
-						"next_cursor_id":   "",
-						"has_more_data":    false,
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": false,
 					},
 				},
 			},
 			PageSize:      1,
+			Query:         "?sort=asc",
 			FirstPageTest: true,
 			MoreData:      false,
 		},
@@ -62,17 +68,20 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 								"merchant":          "costco",
 								"amount":            125.98,
 								"detailed_category": 40,
+								"version":           float64(1),
 							},
 						},
-						"next_cursor_date": "2025-03-05",
-						"next_cursor_id":   txID.String(),
-						"has_more_data":    true,
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": true,
 					},
 				},
 			},
 			PageSize:      1,
+			Query:         "?sort=asc",
 			FirstPageTest: true,
 			MoreData:      true,
+			ExpectedNext:  &models.TxCursor{Date: "2025-03-05", ID: txID.String(), Filter: models.TxFilter{Sort: models.SortOldestFirst}},
 		},
 		{
 			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
@@ -89,19 +98,21 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 								"merchant":          "costco",
 								"amount":            125.98,
 								"detailed_category": 40,
+								"version":           float64(1),
 							},
 						},
-						"next_cursor_date": "",
-						"next_cursor_id":   "",
-						"has_more_data":    false,
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": false,
 					},
 				},
 			},
-			CursorDate:    "2025-03-05",
-			CursorID:      txID.String(),
+			CursorPos:     &models.TxCursor{Date: "2025-03-05", ID: txID.String(), Filter: models.TxFilter{Sort: models.SortOldestFirst}},
 			PageSize:      1,
+			Query:         "?sort=asc",
 			FirstPageTest: false,
 			MoreData:      false,
+			ExpectedPrev:  &models.TxCursor{Date: "2025-03-06", ID: pagTxID.String(), Backward: true, Filter: models.TxFilter{Sort: models.SortOldestFirst}},
 		},
 		{
 			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
@@ -118,19 +129,22 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 								"merchant":          "costco",
 								"amount":            125.98,
 								"detailed_category": 40,
+								"version":           float64(1),
 							},
 						},
-						"next_cursor_date": "2025-03-06",
-						"next_cursor_id":   pagTxID.String(),
-						"has_more_data":    true,
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": true,
 					},
 				},
 			},
-			CursorDate:    "2025-03-05",
-			CursorID:      txID.String(),
+			CursorPos:     &models.TxCursor{Date: "2025-03-05", ID: txID.String(), Filter: models.TxFilter{Sort: models.SortOldestFirst}},
 			PageSize:      1,
+			Query:         "?sort=asc",
 			FirstPageTest: false,
 			MoreData:      true,
+			ExpectedNext:  &models.TxCursor{Date: "2025-03-06", ID: pagTxID.String(), Filter: models.TxFilter{Sort: models.SortOldestFirst}},
+			ExpectedPrev:  &models.TxCursor{Date: "2025-03-06", ID: pagTxID.String(), Backward: true, Filter: models.TxFilter{Sort: models.SortOldestFirst}},
 		},
 		{
 			BaseHTTPTestCase: testfixtures.NilUserID,
@@ -145,10 +159,10 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 				ExpectedStatusCode: http.StatusOK,
 				ExpectedResponse: map[string]interface{}{
 					"data": map[string]interface{}{
-						"transactions":     []interface{}{},
-						"next_cursor_date": "",
-						"next_cursor_id":   "",
-						"has_more_data":    false,
+						"transactions":  []interface{}{},
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": false,
 					},
 				},
 			},
@@ -162,17 +176,16 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 				ExpectedStatusCode: http.StatusOK,
 				ExpectedResponse: map[string]interface{}{
 					"data": map[string]interface{}{
-						"transactions":     []interface{}{},
-						"next_cursor_date": "",
-						"next_cursor_id":   "",
-						"has_more_data":    false,
+						"transactions":  []interface{}{},
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": false,
 					},
 				},
 			},
 			PageSize:      1,
 			FirstPageTest: false,
-			CursorDate:    "2025-03-05",
-			CursorID:      pagTxID.String(),
+			CursorPos:     &models.TxCursor{Date: "2025-03-05", ID: pagTxID.String(), Filter: models.TxFilter{Sort: models.SortNewestFirst}},
 		},
 	}
 	for _, tc := range tests {
@@ -217,12 +230,28 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 				}
 			}
 
+			cursors := env.Services.TxService.Cursors
+			cursor := ""
+			if tc.CursorPos != nil {
+				var err error
+				cursor, err = cursors.Encode(tc.CursorPos)
+				require.NoError(t, err)
+			}
+			if data, ok := tc.ExpectedResponse["data"].(map[string]interface{}); ok {
+				for key, pos := range map[string]*models.TxCursor{"next_cursor": tc.ExpectedNext, "prev_cursor": tc.ExpectedPrev} {
+					if pos != nil {
+						token, err := cursors.Encode(pos)
+						require.NoError(t, err)
+						data[key] = token
+					}
+				}
+			}
+
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/transactions", nil)
+			req := httptest.NewRequest("GET", "/transactions"+tc.Query, nil)
 			env.Router.GET("/transactions", func(c *gin.Context) {
 				c.Request = req
-				c.Set(string(constants.CursorDateKey), tc.CursorDate)
-				c.Set(string(constants.CursorIDKey), tc.CursorID)
+				c.Set(string(constants.CursorKey), cursor)
 				c.Set(string(constants.PageSizeKey), tc.PageSize)
 				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
 				env.Handlers.TxHandler.GetTransactionsByUserID(c)
@@ -233,3 +262,177 @@ func TestIntegrationGetTransactionsByUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegrationGetTransactionsByUserIDFilters(t *testing.T) {
+	userID := uuid.New()
+	txIDs := map[string]uuid.UUID{
+		"a": uuid.MustParse("00000000-0000-0000-0000-00000000000a"),
+		"b": uuid.MustParse("00000000-0000-0000-0000-00000000000b"),
+		"c": uuid.MustParse("00000000-0000-0000-0000-00000000000c"),
+		"d": uuid.MustParse("00000000-0000-0000-0000-00000000000d"),
+		"e": uuid.MustParse("00000000-0000-0000-0000-00000000000e"),
+	}
+	tests := []struct {
+		name    string
+		query   string
+		wantTxs []string
+	}{
+		{name: "default is newest first", query: "", wantTxs: []string{"e", "d", "c", "b", "a"}},
+		{name: "oldest first", query: "?sort=asc", wantTxs: []string{"a", "b", "c", "d", "e"}},
+		{name: "date range", query: "?start_date=2025-02-01&end_date=2025-03-15", wantTxs: []string{"d", "c", "b"}},
+		{name: "merchant substring, case insensitive", query: "?merchant=COSTCO", wantTxs: []string{"e", "a"}},
+		{name: "merchant wildcards match literally", query: "?merchant=%25_", wantTxs: []string{"d"}},
+		{name: "primary category", query: "?primary_category=1", wantTxs: []string{"c"}},
+		{name: "detailed category", query: "?detailed_category=40", wantTxs: []string{"e", "d", "b", "a"}},
+		{name: "amount range uses absolute value", query: "?min_amount=40&max_amount=2500", wantTxs: []string{"e", "c", "a"}},
+		{name: "inflow", query: "?flow=inflow", wantTxs: []string{"c"}},
+		{name: "outflow", query: "?flow=outflow", wantTxs: []string{"e", "d", "b", "a"}},
+		{name: "combined filters", query: "?merchant=costco&flow=outflow&sort=asc", wantTxs: []string{"a", "e"}},
+		{name: "no matches", query: "?merchant=nowhere", wantTxs: []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupFilterTestEnv(t, userID, txIDs)
+			defer env.Db.Close()
+
+			gotIDs, _, _, hasMoreData := listTransactionIDs(t, env, userID, tc.query, "", 10)
+			require.Equal(t, txNames(txIDs, tc.wantTxs), gotIDs)
+			require.False(t, hasMoreData)
+		})
+	}
+
+	pagingTests := []struct {
+		name      string
+		query     string
+		wantPages [][]string
+	}{
+		{name: "pages newest first", query: "", wantPages: [][]string{{"e", "d"}, {"c", "b"}, {"a"}}},
+		{name: "pages oldest first", query: "?sort=asc", wantPages: [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{name: "pages with filter", query: "?flow=outflow", wantPages: [][]string{{"e", "d"}, {"b", "a"}}},
+	}
+	for _, tc := range pagingTests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupFilterTestEnv(t, userID, txIDs)
+			defer env.Db.Close()
+
+			cursor := ""
+			var prevCursors []string
+			for i, page := range tc.wantPages {
+				gotIDs, nextCursor, prevCursor, hasMoreData := listTransactionIDs(t, env, userID, tc.query, cursor, 2)
+				require.Equal(t, txNames(txIDs, page), gotIDs, "page %d", i+1)
+				require.Equal(t, i < len(tc.wantPages)-1, hasMoreData, "page %d", i+1)
+				require.Equal(t, i > 0, prevCursor != "", "page %d", i+1)
+				prevCursors = append(prevCursors, prevCursor)
+				cursor = nextCursor
+			}
+
+			// Walking back from the last page gives the same pages in reverse.
+			for i := len(tc.wantPages) - 1; i > 0; i-- {
+				gotIDs, _, _, _ := listTransactionIDs(t, env, userID, "", prevCursors[i], 2)
+				require.Equal(t, txNames(txIDs, tc.wantPages[i-1]), gotIDs, "previous of page %d", i+1)
+			}
+		})
+	}
+
+	t.Run("cursor from another filter", func(t *testing.T) {
+		env := setupFilterTestEnv(t, userID, txIDs)
+		defer env.Db.Close()
+
+		_, nextCursor, _, _ := listTransactionIDs(t, env, userID, "?flow=outflow", "", 2)
+		w := listTransactions(t, env, userID, "?flow=inflow", nextCursor, 2)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.JSONEq(t, `{"data":{"error":"cursor does not match filters"}}`, w.Body.String())
+
+		w = listTransactions(t, env, userID, "", nextCursor[:len(nextCursor)-2]+"AA", 2)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.JSONEq(t, `{"data":{"error":"invalid cursor"}}`, w.Body.String())
+	})
+}
+
+// setupFilterTestEnv seeds five transactions for userID, two of them on the same day,
+// plus one for another user that must never be returned.
+func setupFilterTestEnv(t *testing.T, userID uuid.UUID, txIDs map[string]uuid.UUID) *testmodels.TestEnv {
+	t.Helper()
+	env := testhelpers.SetupTestEnv(t)
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	_, err := env.Db.Exec(`INSERT INTO primary_categories (id, name) VALUES (1, 'INCOME')`)
+	require.NoError(t, err)
+	_, err = env.Db.Exec(`INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES (50, 'INCOME_WAGES', 'Wages', 1)`)
+	require.NoError(t, err)
+
+	seed := []struct {
+		name     string
+		date     string
+		merchant string
+		amount   float64
+		category int64
+	}{
+		{"a", "2025-01-10", "Costco Wholesale", 120.00, 40},
+		{"b", "2025-02-01", "Trader Joe's", 35.50, 40},
+		{"c", "2025-02-01", "Payroll", -2500.00, 50},
+		{"d", "2025-03-15", "100%_Juice Bar", 8.25, 40},
+		{"e", "2025-03-20", "costco gas", 45.00, 40},
+	}
+	for _, s := range seed {
+		testhelpers.SeedTestTransaction(t, env.TxQ, userID, txIDs[s.name], &models.NewTxRequest{
+			Date:             s.date,
+			Merchant:         s.merchant,
+			Amount:           s.amount,
+			DetailedCategory: s.category,
+		})
+	}
+
+	otherUserID := uuid.New()
+	require.NoError(t, env.UserQ.CreateUser(context.Background(), database.CreateUserParams{
+		ID:             otherUserID.String(),
+		Email:          "other@example.com",
+		HashedPassword: "hashedpwd",
+	}))
+	testhelpers.SeedTestTransaction(t, env.TxQ, otherUserID, uuid.New(), &models.NewTxRequest{
+		Date:             "2025-02-01",
+		Merchant:         "Costco",
+		Amount:           50,
+		DetailedCategory: 40,
+	})
+	return env
+}
+
+func listTransactions(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, query, cursor string, pageSize int) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/transactions"+query, nil)
+	router := gin.New()
+	router.GET("/transactions", func(c *gin.Context) {
+		c.Set(string(constants.CursorKey), cursor)
+		c.Set(string(constants.PageSizeKey), pageSize)
+		c.Set(string(constants.UserIDKey), userID)
+		env.Handlers.TxHandler.GetTransactionsByUserID(c)
+	})
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func listTransactionIDs(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, query, cursor string, pageSize int) ([]string, string, string, bool) {
+	t.Helper()
+	w := listTransactions(t, env, userID, query, cursor, pageSize)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data models.TxPage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	ids := make([]string, 0, len(resp.Data.Transactions))
+	for _, txn := range resp.Data.Transactions {
+		ids = append(ids, txn.ID)
+	}
+	return ids, resp.Data.NextCursor, resp.Data.PrevCursor, resp.Data.HasMoreData
+}
+
+func txNames(txIDs map[string]uuid.UUID, names []string) []string {
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		ids = append(ids, txIDs[name].String())
+	}
+	return ids
+}
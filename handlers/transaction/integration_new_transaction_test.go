@@ -25,7 +25,8 @@ func TestIntegrationNewTx(t *testing.T) {
 				ExpectedResponse: map[string]interface{}{
 					"data": map[string]interface{}{
 						"date":              "2025-03-05",
-						"merchant":          "costco",
+						"merchant":          "Costco",
+						"raw_merchant":      "costco",
 						"amount":            125.98,
 						"detailed_category": 40,
 					},
@@ -79,6 +80,9 @@ func TestIntegrationNewTx(t *testing.T) {
 			env.Router.POST("/transactions", env.Handlers.TxHandler.NewTransaction)
 			env.Router.ServeHTTP(w, req)
 			actualResponse := testhelpers.ProcessResponse(w, t)
+			if tc.ExpectedStatusCode == http.StatusCreated {
+				tc.ExpectedResponse["data"].(map[string]interface{})["merchant_id"] = testhelpers.MerchantID(t, env.Db, tc.UserID, "Costco")
+			}
 			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
 		})
 	}
@@ -0,0 +1,178 @@
+package transaction_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationPatchTx(t *testing.T) {
+	userID := uuid.New()
+	txID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedError  string
+		check          func(t *testing.T, txn models.TxResponse)
+	}{
+		{
+			name:           "single field",
+			body:           `{"merchant": "Costco Wholesale"}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, txn models.TxResponse) {
+				require.Equal(t, "Costco Wholesale", txn.Merchant)
+				require.Equal(t, "2025-03-05", txn.Date)
+				require.Equal(t, 125.98, txn.Amount)
+				require.Equal(t, int64(40), txn.DetailedCategory)
+				require.Equal(t, "weekly shop", txn.Notes)
+				require.Equal(t, []string{"groceries"}, txn.Tags)
+			},
+		},
+		{
+			name:           "several fields",
+			body:           `{"date": "2025-04-01", "amount": -20.5, "tags": ["Refund", "groceries"]}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, txn models.TxResponse) {
+				require.Equal(t, "costco", txn.Merchant)
+				require.Equal(t, "2025-04-01", txn.Date)
+				require.Equal(t, -20.5, txn.Amount)
+				require.Equal(t, []string{"refund", "groceries"}, txn.Tags)
+			},
+		},
+		{
+			name:           "null clears notes and tags",
+			body:           `{"notes": null, "tags": null}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, txn models.TxResponse) {
+				require.Equal(t, "costco", txn.Merchant)
+				require.Empty(t, txn.Notes)
+				require.Empty(t, txn.Tags)
+			},
+		},
+		{
+			name:           "null required field",
+			body:           `{"merchant": null}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "merchant must be a non-empty string",
+		},
+		{
+			name:           "invalid date",
+			body:           `{"date": "3/5/2025"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid date; use YYYY-MM-DD",
+		},
+		{
+			name:           "read-only field",
+			body:           `{"id": "abc"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "id is read-only",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupPatchTestEnv(t, userID, txID)
+			defer env.Db.Close()
+
+			w := patchTransaction(t, env, userID, txID, tc.body)
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedError != "" {
+				var resp map[string]map[string]string
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, tc.expectedError, resp["data"]["error"])
+				return
+			}
+
+			var resp struct {
+				Data models.TxResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, `"2"`, w.Header().Get("ETag"))
+			tc.check(t, resp.Data)
+
+			stored, err := env.Services.TxService.GetTransactionByID(context.Background(), userID.String(), txID.String())
+			require.NoError(t, err)
+			require.Equal(t, resp.Data.Merchant, stored.Merchant)
+			require.Equal(t, resp.Data.Amount, stored.Amount)
+			require.Equal(t, resp.Data.Tags, stored.Tags)
+		})
+	}
+
+	t.Run("other user's transaction", func(t *testing.T) {
+		env := setupPatchTestEnv(t, userID, txID)
+		defer env.Db.Close()
+
+		otherUserID := uuid.New()
+		require.NoError(t, env.UserQ.CreateUser(context.Background(), database.CreateUserParams{
+			ID:             otherUserID.String(),
+			Email:          "other@example.com",
+			HashedPassword: "hashedpwd",
+		}))
+
+		w := patchTransaction(t, env, otherUserID, txID, `{"merchant": "stolen"}`)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/transactions/%v", txID), bytes.NewBufferString(
+			`{"date": "2025-03-05", "merchant": "stolen", "amount": 1, "detailed_category": 40}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		router := gin.New()
+		router.PUT("/transactions/:id", func(c *gin.Context) {
+			c.Set(string(constants.UserIDKey), otherUserID)
+			env.Handlers.TxHandler.UpdateTransaction(c)
+		})
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		stored, err := env.Services.TxService.GetTransactionByID(context.Background(), userID.String(), txID.String())
+		require.NoError(t, err)
+		require.Equal(t, "costco", stored.Merchant)
+	})
+}
+
+func setupPatchTestEnv(t *testing.T, userID, txID uuid.UUID) *testmodels.TestEnv {
+	t.Helper()
+	env := testhelpers.SetupTestEnv(t)
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	testhelpers.SeedTestTransaction(t, env.TxQ, userID, txID, &models.NewTxRequest{
+		Date:             "2025-03-05",
+		Merchant:         "costco",
+		Amount:           125.98,
+		DetailedCategory: 40,
+		Notes:            "weekly shop",
+		Tags:             []string{"groceries"},
+	})
+	return env
+}
+
+func patchTransaction(t *testing.T, env *testmodels.TestEnv, userID, txID uuid.UUID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/transactions/%v", txID), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	router := gin.New()
+	router.PATCH("/transactions/:id", func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		env.Handlers.TxHandler.PatchTransaction(c)
+	})
+	router.ServeHTTP(w, req)
+	return w
+}
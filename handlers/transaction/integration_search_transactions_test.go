@@ -0,0 +1,236 @@
+//go:build sqlite_fts5
+
+package transaction_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationSearchTransactions(t *testing.T) {
+	userID := uuid.New()
+	txIDs := map[string]uuid.UUID{
+		"merchant": uuid.MustParse("00000000-0000-0000-0000-00000000000a"),
+		"notes":    uuid.MustParse("00000000-0000-0000-0000-00000000000b"),
+		"tags":     uuid.MustParse("00000000-0000-0000-0000-00000000000c"),
+		"other":    uuid.MustParse("00000000-0000-0000-0000-00000000000d"),
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "noisy merchant string",
+			query:    "blue bottle",
+			expected: []string{"merchant", "notes"},
+		},
+		{
+			name:     "prefix match",
+			query:    "blu bot",
+			expected: []string{"merchant", "notes"},
+		},
+		{
+			name:     "case and diacritics are ignored",
+			query:    "BLÜE",
+			expected: []string{"merchant", "notes"},
+		},
+		{
+			name:     "tags",
+			query:    "coffee",
+			expected: []string{"tags"},
+		},
+		{
+			name:     "every word must match",
+			query:    "blue amazon",
+			expected: []string{},
+		},
+		{
+			name:     "other users' transactions are not returned",
+			query:    "costco",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupSearchTestEnv(t, userID, txIDs)
+			defer env.Db.Close()
+
+			results := searchTransactions(t, env, userID, tc.query)
+			ids := make([]string, 0, len(results))
+			for _, r := range results {
+				ids = append(ids, r.ID)
+			}
+			require.Equal(t, txNames(txIDs, tc.expected), ids)
+		})
+	}
+
+	t.Run("snippet highlights matches", func(t *testing.T) {
+		env := setupSearchTestEnv(t, userID, txIDs)
+		defer env.Db.Close()
+
+		results := searchTransactions(t, env, userID, "blue bot")
+		require.NotEmpty(t, results)
+		require.Equal(t, "SQ *<mark>BLUE</mark> <mark>BOTTLE</mark> 0423 SF", results[0].Snippet)
+		require.Equal(t, []string{"shop", "treats"}, results[0].Tags)
+	})
+
+	t.Run("snippet escapes stored text", func(t *testing.T) {
+		env := setupSearchTestEnv(t, userID, txIDs)
+		defer env.Db.Close()
+
+		_, err := env.Services.TxService.UpdateTransaction(context.Background(), txIDs["other"].String(), userID.String(), models.NewTxRequest{
+			Date:             "2025-04-23",
+			Merchant:         "Safeway",
+			Amount:           6.5,
+			DetailedCategory: 40,
+			Notes:            "<script>alert('ritual')</script>",
+		}, 0)
+		require.NoError(t, err)
+		results := searchTransactions(t, env, userID, "ritual")
+		require.Len(t, results, 1)
+		require.Equal(t, "&lt;script&gt;alert(&#39;<mark>ritual</mark>&#39;)&lt;/script&gt;", results[0].Snippet)
+	})
+
+	t.Run("index survives vacuum", func(t *testing.T) {
+		env := setupSearchTestEnv(t, userID, txIDs)
+		defer env.Db.Close()
+
+		require.NoError(t, env.Services.TxService.DeleteTransaction(context.Background(), txIDs["merchant"].String(), userID.String(), 0))
+		_, err := env.Db.Exec(`VACUUM`)
+		require.NoError(t, err)
+		results := searchTransactions(t, env, userID, "blue bottle")
+		require.Len(t, results, 1)
+		require.Equal(t, txIDs["notes"].String(), results[0].ID)
+		require.Equal(t, "beans from <mark>blue</mark> <mark>bottle</mark>", results[0].Snippet)
+	})
+
+	t.Run("index follows updates and deletes", func(t *testing.T) {
+		env := setupSearchTestEnv(t, userID, txIDs)
+		defer env.Db.Close()
+		ctx := context.Background()
+
+		_, err := env.Services.TxService.UpdateTransaction(ctx, txIDs["tags"].String(), userID.String(), models.NewTxRequest{
+			Date:             "2025-04-01",
+			Merchant:         "Ritual Roasters",
+			Amount:           18,
+			DetailedCategory: 40,
+			Notes:            "beans",
+		}, 0)
+		require.NoError(t, err)
+		require.Empty(t, searchTransactions(t, env, userID, "amazon"))
+		require.Empty(t, searchTransactions(t, env, userID, "coffee"))
+		results := searchTransactions(t, env, userID, "ritual beans")
+		require.Len(t, results, 1)
+		require.Equal(t, txIDs["tags"].String(), results[0].ID)
+
+		require.NoError(t, env.Services.TxService.DeleteTransaction(ctx, txIDs["merchant"].String(), userID.String(), 0))
+		results = searchTransactions(t, env, userID, "blue bottle")
+		require.Len(t, results, 1)
+		require.Equal(t, txIDs["notes"].String(), results[0].ID)
+	})
+
+	t.Run("results are capped at page size", func(t *testing.T) {
+		env := setupSearchTestEnv(t, userID, txIDs)
+		defer env.Db.Close()
+
+		w := searchRequest(t, env, userID, "blue", 1)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data struct {
+				Results []models.TxSearchResult `json:"results"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Results, 1)
+		require.Equal(t, txIDs["merchant"].String(), resp.Data.Results[0].ID)
+	})
+}
+
+func setupSearchTestEnv(t *testing.T, userID uuid.UUID, txIDs map[string]uuid.UUID) *testmodels.TestEnv {
+	t.Helper()
+	env := testhelpers.SetupTestEnv(t)
+	testhelpers.CreateSearchSchema(t, env.Db)
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	seed := []struct {
+		name     string
+		merchant string
+		notes    string
+		tags     []string
+	}{
+		{"merchant", "SQ *BLUE BOTTLE 0423 SF", "", []string{"shop", "treats"}},
+		{"notes", "Cafe", "beans from blue bottle", nil},
+		{"tags", "Amazon", "", []string{"coffee"}},
+		{"other", "Safeway", "", nil},
+	}
+	for _, s := range seed {
+		testhelpers.SeedTestTransaction(t, env.TxQ, userID, txIDs[s.name], &models.NewTxRequest{
+			Date:             "2025-04-23",
+			Merchant:         s.merchant,
+			Amount:           6.5,
+			DetailedCategory: 40,
+			Notes:            s.notes,
+			Tags:             s.tags,
+		})
+	}
+
+	otherUserID := uuid.New()
+	require.NoError(t, env.UserQ.CreateUser(context.Background(), database.CreateUserParams{
+		ID:             otherUserID.String(),
+		Email:          "other@example.com",
+		HashedPassword: "hashedpwd",
+	}))
+	testhelpers.SeedTestTransaction(t, env.TxQ, otherUserID, uuid.New(), &models.NewTxRequest{
+		Date:             "2025-04-23",
+		Merchant:         "Costco blue bottle",
+		Amount:           50,
+		DetailedCategory: 40,
+	})
+	return env
+}
+
+func searchRequest(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, query string, pageSize int) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/transactions/search?q="+url.QueryEscape(query), nil)
+	router := gin.New()
+	router.GET("/transactions/search", func(c *gin.Context) {
+		c.Set(string(constants.PageSizeKey), pageSize)
+		c.Set(string(constants.UserIDKey), userID)
+		env.Handlers.TxHandler.SearchTransactions(c)
+	})
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func searchTransactions(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, query string) []models.TxSearchResult {
+	t.Helper()
+	w := searchRequest(t, env, userID, query, 50)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data struct {
+			Results []models.TxSearchResult `json:"results"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Data.Results
+}
@@ -0,0 +1,195 @@
+package transaction_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationSplitTransactions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	_, err := env.Db.Exec(`INSERT INTO primary_categories (id, name) VALUES (8, 'General merchandise')`)
+	require.NoError(t, err)
+	_, err = env.Db.Exec(`INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES (50, 'Household', 'Cleaning and household supplies', 8)`)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.POST("/transactions", env.Handlers.TxHandler.NewTransaction)
+	router.GET("/transactions/:id", env.Handlers.TxHandler.GetTransactionByID)
+	router.PUT("/transactions/:id", env.Handlers.TxHandler.UpdateTransaction)
+	router.PATCH("/transactions/:id", env.Handlers.TxHandler.PatchTransaction)
+
+	body := `{"date": "2025-03-05", "merchant": "costco", "amount": 100, "splits": [
+		{"amount": 60, "detailed_category": 40, "memo": "food"},
+		{"amount": 40, "detailed_category": 50, "memo": "soap"}
+	]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Data models.TxResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, int64(40), created.Data.DetailedCategory)
+	splits := []models.TxSplit{
+		{Amount: 60, DetailedCategory: 40, Memo: "food"},
+		{Amount: 40, DetailedCategory: 50, Memo: "soap"},
+	}
+	require.Equal(t, splits, created.Data.Splits)
+
+	page, err := env.Services.TxService.ListUserTransactions(ctx, userID, models.TxFilter{}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, page.Transactions, 1)
+	txID := uuid.MustParse(page.Transactions[0].ID)
+
+	w = txRequest(router, http.MethodGet, txID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got struct {
+		Data models.TxResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Equal(t, splits, got.Data.Splits)
+
+	// Category filters look at the lines, not the category the transaction carries.
+	for _, query := range []string{"?detailed_category=50", "?primary_category=8"} {
+		ids, _, _, _ := listTransactionIDs(t, env, userID, query, "", 10)
+		require.Equal(t, []string{txID.String()}, ids, query)
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		body          string
+		expectedError string
+	}{
+		{
+			name:          "lines do not sum to amount",
+			method:        http.MethodPut,
+			body:          `{"date": "2025-03-05", "merchant": "costco", "amount": 100, "splits": [{"amount": 60, "detailed_category": 40}, {"amount": 30, "detailed_category": 50}]}`,
+			expectedError: "invalid split: lines sum to 90.00 but amount is 100.00",
+		},
+		{
+			name:          "line without a category",
+			method:        http.MethodPut,
+			body:          `{"date": "2025-03-05", "merchant": "costco", "amount": 100, "splits": [{"amount": 60, "detailed_category": 40}, {"amount": 40}]}`,
+			expectedError: "invalid split: line 2: detailed_category is required",
+		},
+		{
+			name:          "one line",
+			method:        http.MethodPut,
+			body:          `{"date": "2025-03-05", "merchant": "costco", "amount": 100, "splits": [{"amount": 100, "detailed_category": 40}]}`,
+			expectedError: "invalid split: a split takes 2 to 50 lines",
+		},
+		{
+			name:          "patch amount",
+			method:        http.MethodPatch,
+			body:          `{"amount": 120}`,
+			expectedError: "invalid split: the amount of a split transaction changes with its lines",
+		},
+		{
+			name:          "patch category",
+			method:        http.MethodPatch,
+			body:          `{"detailed_category": 50}`,
+			expectedError: "invalid split: the category of a split transaction changes with its lines",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := txRequest(router, tc.method, txID, tc.body, nil)
+			require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+			var resp map[string]map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			require.Equal(t, tc.expectedError, resp["data"]["error"])
+
+			// A rejected write leaves the lines as they were.
+			stored, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txID.String())
+			require.NoError(t, err)
+			require.Equal(t, 100.0, stored.Amount)
+			require.Equal(t, splits, stored.Splits)
+		})
+	}
+
+	// A patch answers like an update, lines included.
+	w = txRequest(router, http.MethodPatch, txID, `{"notes": "monthly run"}`, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var patched struct {
+		Data models.TxResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	require.Equal(t, "monthly run", patched.Data.Notes)
+	require.Equal(t, splits, patched.Data.Splits)
+
+	// An update replaces the lines, and one without lines removes them.
+	w = txRequest(router, http.MethodPut, txID, `{"date": "2025-03-05", "merchant": "costco", "amount": 120, "splits": [{"amount": 20, "detailed_category": 50}, {"amount": 100, "detailed_category": 40}]}`, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	stored, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txID.String())
+	require.NoError(t, err)
+	require.Equal(t, int64(50), stored.DetailedCategory)
+	require.Equal(t, []models.TxSplit{{Amount: 20, DetailedCategory: 50}, {Amount: 100, DetailedCategory: 40}}, stored.Splits)
+
+	w = txRequest(router, http.MethodPut, txID, `{"date": "2025-03-05", "merchant": "costco", "amount": 120, "detailed_category": 40}`, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	stored, err = env.Services.TxService.GetTransactionByID(ctx, userID.String(), txID.String())
+	require.NoError(t, err)
+	require.Nil(t, stored.Splits)
+	ids, _, _, _ := listTransactionIDs(t, env, userID, "?detailed_category=50", "", 10)
+	require.Empty(t, ids)
+}
+
+func TestIntegrationSplitTransactionCategories(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+
+	own, err := env.Services.CategoryService.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "Farmers markets", PrimaryCategory: 7})
+	require.NoError(t, err)
+	txn, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-03-05", Merchant: "Ferry Plaza", Amount: 50,
+		Splits: []models.TxSplit{{Amount: 30, DetailedCategory: own.ID}, {Amount: 20, DetailedCategory: 40}},
+	})
+	require.NoError(t, err)
+
+	// Merging moves the lines, and the transaction follows its first line.
+	_, err = env.Services.CategoryService.MergeCategories(ctx, userID.String(), models.CategoryMerge{TargetID: 40, SourceIDs: []int64{own.ID}})
+	require.NoError(t, err)
+	stored, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txn.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), stored.DetailedCategory)
+	require.Equal(t, []models.TxSplit{{Amount: 30, DetailedCategory: 40}, {Amount: 20, DetailedCategory: 40}}, stored.Splits)
+
+	// The merged category is archived, so no line can use it any more.
+	_, err = env.Services.TxService.UpdateTransaction(ctx, txn.ID, userID.String(), models.NewTxRequest{
+		Date: "2025-03-05", Merchant: "Ferry Plaza", Amount: 50,
+		Splits: []models.TxSplit{{Amount: 30, DetailedCategory: 40}, {Amount: 20, DetailedCategory: own.ID}},
+	}, 0)
+	require.EqualError(t, err, "invalid split: line 2: detailed_category is not available")
+	_, err = env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-03-05", Merchant: "Ferry Plaza", Amount: 50,
+		Splits: []models.TxSplit{{Amount: 30, DetailedCategory: own.ID}, {Amount: 20, DetailedCategory: 40}},
+	})
+	require.EqualError(t, err, "invalid split: line 1: detailed_category is not available")
+}
@@ -0,0 +1,69 @@
+package transaction_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationCategorySuggestions(t *testing.T) {
+	userID := uuid.New()
+	txID := uuid.New()
+	env := setupPatchTestEnv(t, userID, txID)
+	defer env.Db.Close()
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.POST("/transactions", env.Handlers.TxHandler.NewTransaction)
+	router.GET("/transactions/:id/suggestions", env.Handlers.TxHandler.GetSuggestions)
+
+	// The seeded costco transaction teaches the user's model, so a new one without a
+	// category is filed under groceries.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{"date": "2025-03-06", "merchant": "COSTCO WHSE #0042", "amount": 88.10}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created struct {
+		Data models.TxResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, int64(40), created.Data.DetailedCategory)
+	require.NotEmpty(t, created.Data.Suggestions)
+	require.Equal(t, int64(40), created.Data.Suggestions[0].DetailedCategory)
+
+	// A merchant nothing has been trained on still needs a category.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{"date": "2025-03-06", "merchant": "ZXQV", "amount": 3}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/transactions/%v/suggestions", txID), nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got struct {
+		Data struct {
+			Suggestions []models.CategorySuggestion `json:"suggestions"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Equal(t, []models.CategorySuggestion{{DetailedCategory: 40, Confidence: 1}}, got.Data.Suggestions)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/transactions/%v/suggestions", uuid.New()), nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -26,7 +26,7 @@ func TestIntegrationUpdateTx(t *testing.T) {
 					ExpectedResponse: map[string]interface{}{
 						"data": map[string]interface{}{
 							"date":              "2025-03-05",
-							"merchant":          "costco",
+							"merchant":          "Costco",
 							"amount":            400.00,
 							"detailed_category": 40,
 						},
@@ -116,6 +116,9 @@ func TestIntegrationUpdateTx(t *testing.T) {
 				env.Router.ServeHTTP(w, req)
 			}
 			actualResponse := testhelpers.ProcessResponse(w, t)
+			if tc.ExpectedStatusCode == http.StatusOK {
+				tc.ExpectedResponse["data"].(map[string]interface{})["merchant_id"] = testhelpers.MerchantID(t, env.Db, tc.UserID, "Costco")
+			}
 			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
 		})
 	}
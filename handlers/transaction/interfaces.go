@@ -9,14 +9,19 @@ import (
 
 type TransactionService interface {
 	CreateTransaction(ctx context.Context, userID string, req models.NewTxRequest) (*models.Tx, error)
-	UpdateTransaction(ctx context.Context, txnID, userID string, req models.NewTxRequest) (*models.Tx, error)
-	DeleteTransaction(ctx context.Context, txnID, userID string) error
+	UpdateTransaction(ctx context.Context, txnID, userID string, req models.NewTxRequest, expectedVersion int64) (*models.Tx, error)
+	PatchTransaction(ctx context.Context, txnID, userID string, patch models.TxPatch, expectedVersion int64) (*models.Tx, error)
+	DeleteTransaction(ctx context.Context, txnID, userID string, expectedVersion int64) error
 	GetTransactionByID(ctx context.Context, userID, txnID string) (*models.Tx, error)
+	SuggestCategories(ctx context.Context, userID, txnID string) ([]models.CategorySuggestion, error)
 	ListUserTransactions(
 		ctx context.Context,
 		userID uuid.UUID,
-		cursorDate *string,
-		cursorID *string,
+		filter models.TxFilter,
+		cursor string,
 		pageSize int64,
-	) (transactions []models.Tx, nextCursorDate, nextCursorID string, hasMoreData bool, err error)
+	) (models.TxPage, error)
+	SearchTransactions(ctx context.Context, userID uuid.UUID, query string, limit int64) ([]models.TxSearchResult, error)
+	ExportTransactions(ctx context.Context, userID uuid.UUID, filter models.TxFilter, fn func(models.TxExport) error) error
+	BatchTransactions(ctx context.Context, userID string, batch models.TxBatchRequest) (models.TxBatchResponse, error)
 }
@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	maxPatchBodyBytes     = 64 << 10
+)
+
+// parseTxPatch reads an RFC 7396 merge patch for a transaction. Only the members
+// present in the body are validated. The identifying fields are read-only, and null
+// is accepted only for notes and tags, which it clears.
+//
+// The returned error message is safe to show to the client.
+func parseTxPatch(body []byte) (models.TxPatch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return models.TxPatch{}, errors.New("patch must be a JSON object")
+	}
+	if len(fields) == 0 {
+		return models.TxPatch{}, errors.New("patch must change at least one field")
+	}
+
+	// Sorted so a body with several bad fields always reports the same one.
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var patch models.TxPatch
+	for _, name := range names {
+		raw := fields[name]
+		isNull := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+		switch name {
+		case "date":
+			var date string
+			if isNull || json.Unmarshal(raw, &date) != nil {
+				return models.TxPatch{}, errors.New("invalid date; use YYYY-MM-DD")
+			}
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				return models.TxPatch{}, errors.New("invalid date; use YYYY-MM-DD")
+			}
+			patch.Date = &date
+		case "merchant":
+			var merchant string
+			if isNull || json.Unmarshal(raw, &merchant) != nil || strings.TrimSpace(merchant) == "" {
+				return models.TxPatch{}, errors.New("merchant must be a non-empty string")
+			}
+			patch.Merchant = &merchant
+		case "amount":
+			var amount float64
+			if isNull || json.Unmarshal(raw, &amount) != nil || amount == 0 {
+				return models.TxPatch{}, errors.New("amount must be a non-zero number")
+			}
+			patch.Amount = &amount
+		case "detailed_category":
+			var category int64
+			if isNull || json.Unmarshal(raw, &category) != nil || category <= 0 {
+				return models.TxPatch{}, errors.New("invalid detailed_category")
+			}
+			patch.DetailedCategory = &category
+		case "notes":
+			notes := ""
+			if !isNull && json.Unmarshal(raw, &notes) != nil {
+				return models.TxPatch{}, errors.New("notes must be a string or null")
+			}
+			patch.Notes = &notes
+		case "tags":
+			tags := []string{}
+			if !isNull && json.Unmarshal(raw, &tags) != nil {
+				return models.TxPatch{}, errors.New("tags must be an array of strings or null")
+			}
+			patch.Tags = &tags
+		case "id", "user_id", "updated_at":
+			return models.TxPatch{}, fmt.Errorf("%s is read-only", name)
+		default:
+			return models.TxPatch{}, fmt.Errorf("unknown field %q", name)
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,53 @@
+package transaction
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+)
+
+// GetSuggestions returns up to three categories the categorizer suggests for a
+// transaction, most likely first, with their confidence.
+func (h *Handler) GetSuggestions(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	txId, ok := helpers.BindUUIDParam(ctx, "id")
+	if !ok {
+		// response is in the helper
+		return
+	}
+
+	suggestions, err := h.txSvc.SuggestCategories(ctx.Request.Context(), userID.String(), txId.String())
+	if err != nil {
+		if strings.Contains(err.Error(), "transaction not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"data": gin.H{
+					"error": "not found",
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "unable to suggest categories",
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"suggestions": suggestions,
+		},
+	})
+}
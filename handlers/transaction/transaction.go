@@ -1,6 +1,8 @@
 package transaction
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 
@@ -8,6 +10,8 @@ import (
 	"github.com/seanhuebl/unity-wealth/internal/constants"
 	"github.com/seanhuebl/unity-wealth/internal/helpers"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
 )
 
 func (h *Handler) NewTransaction(ctx *gin.Context) {
@@ -34,6 +38,30 @@ func (h *Handler) NewTransaction(ctx *gin.Context) {
 
 	txn, err := h.txSvc.CreateTransaction(ctx.Request.Context(), userID.String(), req)
 	if err != nil {
+		if errors.Is(err, transaction.ErrCategoryRequired) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+			return
+		}
+		if errors.Is(err, transaction.ErrCategoryUnavailable) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": transaction.ErrCategoryUnavailable.Error(),
+				},
+			})
+			return
+		}
+		if errors.Is(err, transaction.ErrInvalidSplit) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"data": gin.H{
 				"error": "failed to create transaction",
@@ -61,42 +89,111 @@ func (h *Handler) GetTransactionsByUserID(ctx *gin.Context) {
 		return
 	}
 
-	cursorDateVal, exists := ctx.Get(string(constants.CursorDateKey))
+	cursorVal, exists := ctx.Get(string(constants.CursorKey))
 	if !exists {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"data": gin.H{
-				"error": "cursor date key not set in context",
+				"error": "cursor key not set in context",
 			},
 		})
 		return
 	}
-	cursorDateStr, ok := cursorDateVal.(string)
+	cursor, ok := cursorVal.(string)
 	if !ok {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"data": gin.H{
-				"error": "invalid cursor date",
+				"error": "invalid cursor",
 			},
 		})
 		return
 	}
-	cursorIDVal, exists := ctx.Get(string(constants.CursorIDKey))
+	pageSizeVal, exists := ctx.Get(string(constants.PageSizeKey))
 	if !exists {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"data": gin.H{
-				"error": "cursor ID key not set in context",
+				"error": "page_size not provided",
 			},
 		})
 		return
 	}
-	cursorIDStr, ok := cursorIDVal.(string)
-	if !ok {
+	pageSizeInt, ok := pageSizeVal.(int)
+	if !ok || pageSizeInt <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid page_size; must be > 0",
+			},
+		})
+		return
+	}
+	pageSize := int64(pageSizeInt)
+
+	filter, err := parseTxFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": err.Error(),
+			},
+		})
+		return
+	}
+
+	page, err := h.txSvc.ListUserTransactions(ctx.Request.Context(), userID, filter, cursor, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, pagination.ErrInvalidCursor):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "invalid cursor",
+				},
+			})
+		case errors.Is(err, transaction.ErrCursorFilterMismatch):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": "cursor does not match filters",
+				},
+			})
+		case strings.Contains(err.Error(), "no transactions found"):
+			ctx.JSON(http.StatusOK, gin.H{
+				"data": gin.H{
+					"error": "transactions not found",
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "unable to get transactions",
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": page,
+	})
+}
+
+func (h *Handler) SearchTransactions(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"data": gin.H{
-				"error": "invalid cursor ID",
+				"error": "q is required",
 			},
 		})
 		return
 	}
+
 	pageSizeVal, exists := ctx.Get(string(constants.PageSizeKey))
 	if !exists {
 		ctx.JSON(http.StatusBadRequest, gin.H{
@@ -106,8 +203,8 @@ func (h *Handler) GetTransactionsByUserID(ctx *gin.Context) {
 		})
 		return
 	}
-	pageSizeInt, ok := pageSizeVal.(int)
-	if !ok || pageSizeInt <= 0 {
+	pageSize, ok := pageSizeVal.(int)
+	if !ok || pageSize <= 0 {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"data": gin.H{
 				"error": "invalid page_size; must be > 0",
@@ -115,43 +212,35 @@ func (h *Handler) GetTransactionsByUserID(ctx *gin.Context) {
 		})
 		return
 	}
-	pageSize := int64(pageSizeInt)
 
-	var cursorDatePtr *string
-	if cursorDateStr != "" {
-		cursorDatePtr = &cursorDateStr
-	}
-
-	var cursorIDPtr *string
-	if cursorIDStr != "" {
-		cursorIDPtr = &cursorIDStr
-	}
-
-	transactions, nextCursorDate, nextCursorID, hasMoreData, err :=
-		h.txSvc.ListUserTransactions(ctx.Request.Context(), userID, cursorDatePtr, cursorIDPtr, pageSize)
+	results, err := h.txSvc.SearchTransactions(ctx.Request.Context(), userID, query, int64(pageSize))
 	if err != nil {
-		if strings.Contains(err.Error(), "no transactions found") {
-			ctx.JSON(http.StatusOK, gin.H{
+		switch {
+		case errors.Is(err, transaction.ErrEmptySearchQuery):
+			ctx.JSON(http.StatusBadRequest, gin.H{
 				"data": gin.H{
-					"error": "transactions not found",
+					"error": "q must contain at least one letter or digit",
+				},
+			})
+		case errors.Is(err, transaction.ErrSearchQueryTooLong):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"data": gin.H{
+					"error": "unable to search transactions",
 				},
 			})
-			return
 		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"data": gin.H{
-				"error": "unable to get transactions",
-			},
-		})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"data": gin.H{
-			"transactions":     transactions,
-			"next_cursor_date": nextCursorDate,
-			"next_cursor_id":   nextCursorID,
-			"has_more_data":    hasMoreData,
+			"results": results,
 		},
 	})
 }
@@ -190,6 +279,12 @@ func (h *Handler) GetTransactionByID(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", etag(txn.Version))
+	if ifNoneMatch(ctx.GetHeader("If-None-Match"), txn.Version) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
 	response := models.ConvertToResponse(txn)
 
 	ctx.JSON(http.StatusOK, gin.H{
@@ -210,7 +305,9 @@ func (h *Handler) UpdateTransaction(ctx *gin.Context) {
 
 	var req models.NewTxRequest
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	// Only a new transaction can leave the category to the categorizer; a split one
+	// takes it from its first line.
+	if err := ctx.ShouldBindJSON(&req); err != nil || (req.DetailedCategory == 0 && len(req.Splits) == 0) {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"data": gin.H{
 				"error": "invalid request body",
@@ -225,8 +322,147 @@ func (h *Handler) UpdateTransaction(ctx *gin.Context) {
 		return
 	}
 
-	txn, err := h.txSvc.UpdateTransaction(ctx.Request.Context(), txId.String(), userID.String(), req)
+	expectedVersion, err := ifMatchVersion(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": err.Error(),
+			},
+		})
+		return
+	}
+
+	txn, err := h.txSvc.UpdateTransaction(ctx.Request.Context(), txId.String(), userID.String(), req, expectedVersion)
+	if err != nil {
+		if errors.Is(err, transaction.ErrVersionMismatch) {
+			ctx.JSON(http.StatusPreconditionFailed, gin.H{
+				"data": gin.H{
+					"error": "transaction has been modified",
+				},
+			})
+			return
+		}
+		if errors.Is(err, transaction.ErrCategoryUnavailable) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": transaction.ErrCategoryUnavailable.Error(),
+				},
+			})
+			return
+		}
+		if errors.Is(err, transaction.ErrInvalidSplit) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"data": gin.H{
+					"error": "not found",
+				},
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"data": gin.H{
+				"error": "failed to update transaction",
+			},
+		})
+		return
+	}
+
+	response := models.ConvertToResponse(txn)
+
+	ctx.Header("ETag", etag(txn.Version))
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": response,
+	})
+}
+
+func (h *Handler) PatchTransaction(ctx *gin.Context) {
+	userID, err := helpers.GetUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"data": gin.H{
+				"error": "unauthorized",
+			},
+		})
+		return
+	}
+
+	if ct := ctx.ContentType(); ct != mergePatchContentType && ct != "application/json" {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"data": gin.H{
+				"error": "Content-Type must be " + mergePatchContentType,
+			},
+		})
+		return
+	}
+
+	txId, ok := helpers.BindUUIDParam(ctx, "id")
+	if !ok {
+		// response is in the helper
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPatchBodyBytes))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": "invalid request body",
+			},
+		})
+		return
+	}
+	patch, err := parseTxPatch(body)
 	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": err.Error(),
+			},
+		})
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(ctx.GetHeader("If-Match"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": err.Error(),
+			},
+		})
+		return
+	}
+
+	txn, err := h.txSvc.PatchTransaction(ctx.Request.Context(), txId.String(), userID.String(), patch, expectedVersion)
+	if err != nil {
+		if errors.Is(err, transaction.ErrVersionMismatch) {
+			ctx.JSON(http.StatusPreconditionFailed, gin.H{
+				"data": gin.H{
+					"error": "transaction has been modified",
+				},
+			})
+			return
+		}
+		if errors.Is(err, transaction.ErrCategoryUnavailable) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": transaction.ErrCategoryUnavailable.Error(),
+				},
+			})
+			return
+		}
+		if errors.Is(err, transaction.ErrInvalidSplit) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"data": gin.H{
+					"error": err.Error(),
+				},
+			})
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			ctx.JSON(http.StatusNotFound, gin.H{
 				"data": gin.H{
@@ -245,6 +481,7 @@ func (h *Handler) UpdateTransaction(ctx *gin.Context) {
 
 	response := models.ConvertToResponse(txn)
 
+	ctx.Header("ETag", etag(txn.Version))
 	ctx.JSON(http.StatusOK, gin.H{
 		"data": response,
 	})
@@ -267,8 +504,26 @@ func (h *Handler) DeleteTransaction(ctx *gin.Context) {
 		return
 	}
 
-	err = h.txSvc.DeleteTransaction(ctx.Request.Context(), txId.String(), userID.String())
+	expectedVersion, err := ifMatchVersion(ctx.GetHeader("If-Match"))
 	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"data": gin.H{
+				"error": err.Error(),
+			},
+		})
+		return
+	}
+
+	err = h.txSvc.DeleteTransaction(ctx.Request.Context(), txId.String(), userID.String(), expectedVersion)
+	if err != nil {
+		if errors.Is(err, transaction.ErrVersionMismatch) {
+			ctx.JSON(http.StatusPreconditionFailed, gin.H{
+				"data": gin.H{
+					"error": "transaction has been modified",
+				},
+			})
+			return
+		}
 		if strings.Contains(err.Error(), "transaction not found") {
 			ctx.JSON(http.StatusNotFound, gin.H{
 				"data": gin.H{
@@ -0,0 +1,186 @@
+package transaction_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	htx "github.com/seanhuebl/unity-wealth/handlers/transaction"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBatchTransactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	txID := uuid.NewString()
+	body := fmt.Sprintf(`{"mode": "best_effort", "operations": [{"op": "delete", "id": %q}]}`, txID)
+	batch := models.TxBatchRequest{
+		Mode:       models.BatchModeBestEffort,
+		Operations: []models.TxBatchOp{{Op: models.BatchOpDelete, ID: txID}},
+	}
+
+	// Numbers inside results are left as float64 by ProcessResponse.
+	result := func(status int, msg string) map[string]interface{} {
+		r := map[string]interface{}{
+			"index":  float64(0),
+			"op":     "delete",
+			"id":     txID,
+			"status": float64(status),
+		}
+		if msg != "" {
+			r["error"] = msg
+		}
+		return r
+	}
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		body      string
+		expectSvc bool
+		resp      models.TxBatchResponse
+		svcErr    error
+	}{
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+			body:             body,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidReqBody,
+			body:             `{"mode": "atomic"}`,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "committed with a failed operation",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"mode":      "best_effort",
+						"committed": true,
+						"results":   []interface{}{result(http.StatusPreconditionFailed, "transaction has been modified")},
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			resp: models.TxBatchResponse{
+				Mode:      models.BatchModeBestEffort,
+				Committed: true,
+				Results:   []models.TxBatchResult{{Index: 0, Op: models.BatchOpDelete, ID: txID, Err: transaction.ErrVersionMismatch}},
+			},
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "rolled back",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusUnprocessableEntity,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"mode":      "best_effort",
+						"committed": false,
+						"results":   []interface{}{result(http.StatusNotFound, "not found")},
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			resp: models.TxBatchResponse{
+				Mode:    models.BatchModeBestEffort,
+				Results: []models.TxBatchResult{{Index: 0, Op: models.BatchOpDelete, ID: txID, Err: errors.New("transaction not found")}},
+			},
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "rolled back by a server error",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"mode":      "best_effort",
+						"committed": false,
+						"results":   []interface{}{result(http.StatusInternalServerError, "failed to delete transaction")},
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			resp: models.TxBatchResponse{
+				Mode:    models.BatchModeBestEffort,
+				Results: []models.TxBatchResult{{Index: 0, Op: models.BatchOpDelete, ID: txID, Err: errors.New("error deleting transaction")}},
+			},
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid batch",
+				UserID:             userID,
+				ExpectedError:      "invalid batch operation: unknown mode",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid batch operation: unknown mode",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    fmt.Errorf("%w: unknown mode", transaction.ErrInvalidBatchOp),
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "failed to run batch",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "failed to run batch",
+					},
+				},
+			},
+			body:      body,
+			expectSvc: true,
+			svcErr:    errors.New("failed to start transaction"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			if tc.expectSvc {
+				mockSvc.On("BatchTransactions", mock.Anything, userID.String(), batch).Return(tc.resp, tc.svcErr)
+			}
+			h := htx.NewHandler(mockSvc)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/transactions/batch", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.POST("/transactions/batch", h.BatchTransactions)
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
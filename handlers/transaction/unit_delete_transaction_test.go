@@ -119,7 +119,7 @@ func TestDeleteTransaction(t *testing.T) {
 			mockSvc := handlermocks.NewTransactionService(t)
 			req := httptest.NewRequest("DELETE", fmt.Sprintf("/transactions/%v", tc.TxID), nil)
 
-			mockSvc.On("DeleteTransaction", mock.Anything, tc.TxID, tc.UserID.String()).Return(tc.TxErr)
+			mockSvc.On("DeleteTransaction", mock.Anything, tc.TxID, tc.UserID.String(), int64(0)).Return(tc.TxErr)
 
 			h := htx.NewHandler(mockSvc)
 
@@ -0,0 +1,181 @@
+package transaction_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	htx "github.com/seanhuebl/unity-wealth/handlers/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPreconditions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	txID := uuid.NewString()
+	putBody := `{"date": "2025-03-05", "merchant": "costco", "amount": 1, "detailed_category": 40}`
+	stored := &models.Tx{ID: txID, UserID: userID.String(), Date: "2025-03-05", Merchant: "costco", Amount: 1, DetailedCategory: 40, Version: 7}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		headers        map[string]string
+		setup          func(m *handlermocks.TransactionService)
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:   "get sets ETag",
+			method: "GET",
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("GetTransactionByID", mock.Anything, userID.String(), txID).Return(stored, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"7"`,
+		},
+		{
+			name:    "get with matching If-None-Match",
+			method:  "GET",
+			headers: map[string]string{"If-None-Match": `"6", "7"`},
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("GetTransactionByID", mock.Anything, userID.String(), txID).Return(stored, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"7"`,
+		},
+		{
+			name:    "get with If-None-Match *",
+			method:  "GET",
+			headers: map[string]string{"If-None-Match": "*"},
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("GetTransactionByID", mock.Anything, userID.String(), txID).Return(stored, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"7"`,
+		},
+		{
+			name:    "put passes If-Match version",
+			method:  "PUT",
+			body:    putBody,
+			headers: map[string]string{"If-Match": `"7"`},
+			setup: func(m *handlermocks.TransactionService) {
+				updated := *stored
+				updated.Version = 8
+				m.On("UpdateTransaction", mock.Anything, txID, userID.String(), mock.AnythingOfType("models.NewTxRequest"), int64(7)).Return(&updated, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"8"`,
+		},
+		{
+			name:    "put version mismatch",
+			method:  "PUT",
+			body:    putBody,
+			headers: map[string]string{"If-Match": `"6"`},
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("UpdateTransaction", mock.Anything, txID, userID.String(), mock.AnythingOfType("models.NewTxRequest"), int64(6)).
+					Return(nil, transaction.ErrVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "malformed If-Match never matches",
+			method:  "PATCH",
+			body:    `{"merchant": "x"}`,
+			headers: map[string]string{"If-Match": "7"},
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("PatchTransaction", mock.Anything, txID, userID.String(), mock.AnythingOfType("models.TxPatch"), int64(-1)).
+					Return(nil, transaction.ErrVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "patch into an archived category",
+			method: "PATCH",
+			body:   `{"detailed_category": 1001}`,
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("PatchTransaction", mock.Anything, txID, userID.String(), mock.AnythingOfType("models.TxPatch"), int64(0)).
+					Return(nil, fmt.Errorf("error patching transaction: %w: it is archived or belongs to another user", transaction.ErrCategoryUnavailable))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "put with an invalid split",
+			method: "PUT",
+			body:   `{"date": "2025-03-05", "merchant": "costco", "amount": 1, "splits": [{"amount": 1, "detailed_category": 40}, {"detailed_category": 40}]}`,
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("UpdateTransaction", mock.Anything, txID, userID.String(), mock.AnythingOfType("models.NewTxRequest"), int64(0)).
+					Return(nil, fmt.Errorf("%w: line 2: amount is required", transaction.ErrInvalidSplit))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "If-Match * is unconditional",
+			method:  "DELETE",
+			headers: map[string]string{"If-Match": "*"},
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("DeleteTransaction", mock.Anything, txID, userID.String(), int64(0)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "delete version mismatch",
+			method:  "DELETE",
+			headers: map[string]string{"If-Match": `"6"`},
+			setup: func(m *handlermocks.TransactionService) {
+				m.On("DeleteTransaction", mock.Anything, txID, userID.String(), int64(6)).Return(transaction.ErrVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "If-Match list",
+			method:         "DELETE",
+			headers:        map[string]string{"If-Match": `"6", "7"`},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			if tc.setup != nil {
+				tc.setup(mockSvc)
+			}
+			h := htx.NewHandler(mockSvc)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set(string(constants.UserIDKey), userID)
+				c.Next()
+			})
+			router.GET("/transactions/:id", h.GetTransactionByID)
+			router.PUT("/transactions/:id", h.UpdateTransaction)
+			router.PATCH("/transactions/:id", h.PatchTransaction)
+			router.DELETE("/transactions/:id", h.DeleteTransaction)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, fmt.Sprintf("/transactions/%v", txID), bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			require.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			if tc.expectedStatus == http.StatusPreconditionFailed {
+				require.JSONEq(t, `{"data": {"error": "transaction has been modified"}}`, w.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	htx "github.com/seanhuebl/unity-wealth/handlers/transaction"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTransactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	txn := models.TxExport{
+		Tx:                   models.Tx{ID: "txn-1", UserID: userID.String(), Date: "2025-03-05", Merchant: "Costco", Amount: 125.98, DetailedCategory: 40},
+		PrimaryCategoryName:  "FOOD_AND_DRINK",
+		DetailedCategoryName: "GROCERIES",
+	}
+
+	tests := []struct {
+		name                string
+		userID              uuid.UUID
+		query               string
+		expectSvc           bool
+		filter              models.TxFilter
+		svcErr              error
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{name: testfixtures.NilUserID.Name, userID: uuid.Nil, expectedStatus: http.StatusUnauthorized},
+		{name: testfixtures.InvalidUserID.Name, userID: userID, expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "unknown format",
+			userID:         userID,
+			query:          "?format=pdf",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"error":"invalid format; use csv, jsonl, ofx, xlsx"}}`,
+		},
+		{
+			name:           "invalid filter",
+			userID:         userID,
+			query:          "?flow=sideways",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"error":"invalid flow; use inflow or outflow"}}`,
+		},
+		{
+			name:                "csv by default",
+			userID:              userID,
+			query:               "?start_date=2025-03-01&flow=outflow",
+			expectSvc:           true,
+			filter:              models.TxFilter{StartDate: "2025-03-01", Flow: models.FlowOutflow},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "Date,Merchant,Money Out,Money In,Category,Notes,Tags,ID\n" +
+				"2025-03-05,Costco,125.98,,FOOD_AND_DRINK_GROCERIES,,,txn-1\n",
+		},
+		{
+			name:                "jsonl",
+			userID:              userID,
+			query:               "?format=JSONL",
+			expectSvc:           true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody:        `{"id":"txn-1","user_id":"` + userID.String() + `","date":"2025-03-05","merchant":"Costco","amount":125.98,"detailed_category":40,"primary_category_name":"FOOD_AND_DRINK","detailed_category_name":"GROCERIES"}` + "\n",
+		},
+		{
+			name:           "service error before anything is sent",
+			userID:         userID,
+			query:          "?format=ofx",
+			expectSvc:      true,
+			svcErr:         errors.New("database is locked"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"data":{"error":"unable to export transactions"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			if tc.expectSvc {
+				mockSvc.On("ExportTransactions", mock.Anything, userID, tc.filter, mock.Anything).
+					Return(func(_ context.Context, _ uuid.UUID, _ models.TxFilter, fn func(models.TxExport) error) error {
+						if tc.svcErr != nil {
+							return tc.svcErr
+						}
+						return fn(txn)
+					}).Once()
+			}
+			h := htx.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.name, tc.userID, c)
+				c.Next()
+			})
+			router.GET("/transactions/export", h.ExportTransactions)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/transactions/export"+tc.query, nil))
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedBody != "" {
+				require.Equal(t, tc.expectedBody, w.Body.String())
+			}
+			if tc.expectedContentType != "" {
+				require.Equal(t, tc.expectedContentType, w.Header().Get("Content-Type"))
+				require.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=\"transactions.")
+			} else {
+				require.Empty(t, w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package transaction_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	"github.com/seanhuebl/unity-wealth/internal/constants"
 	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
 	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
 	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
 	"github.com/seanhuebl/unity-wealth/internal/testmodels"
@@ -41,14 +44,12 @@ func TestGetTransactionsByUserID(t *testing.T) {
 								"detailed_category": 40,
 							},
 						},
-						"next_cursor_date": "2025-03-19",
-						"next_cursor_id":   txID.String(),
-						"has_more_data":    true,
+						"next_cursor":   "next-cursor",
+						"prev_cursor":   "",
+						"has_more_data": true,
 					},
 				},
 			},
-			CursorDate:    "2025-03-19",
-			CursorID:      txID.String(),
 			PageSize:      1,
 			FirstPageTest: true,
 			MoreData:      true,
@@ -70,9 +71,9 @@ func TestGetTransactionsByUserID(t *testing.T) {
 								"detailed_category": 40,
 							},
 						},
-						"next_cursor_date": "",
-						"next_cursor_id":   "",
-						"has_more_data":    false,
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": false,
 					},
 				},
 			},
@@ -97,14 +98,13 @@ func TestGetTransactionsByUserID(t *testing.T) {
 								"detailed_category": 40,
 							},
 						},
-						"next_cursor_date": "2025-03-19",
-						"next_cursor_id":   txID.String(),
-						"has_more_data":    true,
+						"next_cursor":   "next-cursor",
+						"prev_cursor":   "prev-cursor",
+						"has_more_data": true,
 					},
 				},
 			},
-			CursorDate:    "2025-03-19",
-			CursorID:      txID.String(),
+			Cursor:        "some-cursor",
 			PageSize:      1,
 			FirstPageTest: false,
 			MoreData:      true,
@@ -126,51 +126,97 @@ func TestGetTransactionsByUserID(t *testing.T) {
 								"detailed_category": 40,
 							},
 						},
-						"next_cursor_date": "",
-						"next_cursor_id":   "",
-						"has_more_data":    false,
+						"next_cursor":   "",
+						"prev_cursor":   "prev-cursor",
+						"has_more_data": false,
 					},
 				},
 			},
+			Cursor:        "some-cursor",
 			PageSize:      1,
 			FirstPageTest: false,
 			MoreData:      false,
 		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "filters, success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"transactions": []interface{}{
+							map[string]interface{}{
+								"id":                txID.String(),
+								"user_id":           userID.String(),
+								"date":              "2025-03-19",
+								"merchant":          "costco",
+								"amount":            127.89,
+								"detailed_category": 40,
+							},
+						},
+						"next_cursor":   "",
+						"prev_cursor":   "",
+						"has_more_data": false,
+					},
+				},
+			},
+			PageSize:      1,
+			FirstPageTest: true,
+			Query:         "?start_date=2025-03-01&end_date=2025-03-31&merchant=%20cost%20&primary_category=7&detailed_category=40&min_amount=10.5&max_amount=200&flow=outflow&sort=ASC",
+			Filter: models.TxFilter{
+				StartDate:          "2025-03-01",
+				EndDate:            "2025-03-31",
+				Merchant:           "cost",
+				PrimaryCategoryID:  7,
+				DetailedCategoryID: 40,
+				MinAmountCents:     1050,
+				MaxAmountCents:     20000,
+				Flow:               models.FlowOutflow,
+				Sort:               models.SortOldestFirst,
+			},
+		},
 	}
 	for _, tc := range successTests {
 		tc := tc
 		t.Run(tc.Name, func(t *testing.T) {
-			txs := []models.Tx{
-				{
-					ID:               txID.String(),
-					UserID:           tc.UserID.String(),
-					Date:             "2025-03-19",
-					Merchant:         "costco",
-					Amount:           127.89,
-					DetailedCategory: 40,
+			page := models.TxPage{
+				Transactions: []models.Tx{
+					{
+						ID:               txID.String(),
+						UserID:           tc.UserID.String(),
+						Date:             "2025-03-19",
+						Merchant:         "costco",
+						Amount:           127.89,
+						DetailedCategory: 40,
+					},
 				},
+				HasMoreData: tc.MoreData,
+			}
+			if tc.MoreData {
+				page.NextCursor = "next-cursor"
+			}
+			if !tc.FirstPageTest {
+				page.PrevCursor = "prev-cursor"
 			}
 
 			mockSvc := handlermocks.NewTransactionService(t)
-
 			mockSvc.On(
 				"ListUserTransactions",
 				mock.Anything,
 				tc.UserID,
-				testhelpers.StrPtr(tc.CursorDate),
-				testhelpers.StrPtr(tc.CursorID),
+				tc.Filter,
+				tc.Cursor,
 				int64(tc.PageSize)).
-				Return(txs, tc.CursorDate, tc.CursorID, tc.MoreData, nil).Once()
+				Return(page, nil).Once()
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/transactions", nil)
+			req := httptest.NewRequest("GET", "/transactions"+tc.Query, nil)
 
 			h := htx.NewHandler(mockSvc)
 
 			router := gin.New()
 			router.Use(func(c *gin.Context) {
-				c.Set(string(constants.CursorDateKey), tc.CursorDate)
-				c.Set(string(constants.CursorIDKey), tc.CursorID)
+				c.Set(string(constants.CursorKey), tc.Cursor)
 				c.Set(string(constants.PageSizeKey), tc.PageSize)
 				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
 				c.Next()
@@ -218,12 +264,43 @@ func TestGetTransactionsByUserID(t *testing.T) {
 					},
 				},
 			},
-			CursorDate:        "2025-03-19",
-			CursorID:          txID.String(),
+			Cursor:            "some-cursor",
 			PageSize:          1,
 			FirstPageTest:     false,
 			GetTxPaginatedErr: errors.New("error getting transactions"),
 		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid cursor tx",
+				UserID:             userID,
+				ExpectedError:      "invalid cursor",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid cursor",
+					},
+				},
+			},
+			Cursor:            "forged-cursor",
+			PageSize:          1,
+			GetTxPaginatedErr: fmt.Errorf("failed to decode cursor: %w", pagination.ErrInvalidCursor),
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "cursor filter mismatch tx",
+				UserID:             userID,
+				ExpectedError:      "cursor does not match filters",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "cursor does not match filters",
+					},
+				},
+			},
+			Cursor:            "some-cursor",
+			PageSize:          1,
+			GetTxPaginatedErr: transaction.ErrCursorFilterMismatch,
+		},
 		{
 			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
 				Name:               "page size <= 0",
@@ -238,6 +315,111 @@ func TestGetTransactionsByUserID(t *testing.T) {
 			},
 			PageSize: -1,
 		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid start date",
+				UserID:             userID,
+				ExpectedError:      "invalid start_date; use YYYY-MM-DD",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid start_date; use YYYY-MM-DD",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?start_date=03-01-2025",
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "start date after end date",
+				UserID:             userID,
+				ExpectedError:      "start_date must not be after end_date",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "start_date must not be after end_date",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?start_date=2025-04-01&end_date=2025-03-01",
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid detailed category",
+				UserID:             userID,
+				ExpectedError:      "invalid detailed_category",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid detailed_category",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?detailed_category=groceries",
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid min amount",
+				UserID:             userID,
+				ExpectedError:      "invalid min_amount; must be > 0",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid min_amount; must be > 0",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?min_amount=-5",
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "min amount greater than max amount",
+				UserID:             userID,
+				ExpectedError:      "min_amount must not be greater than max_amount",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "min_amount must not be greater than max_amount",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?min_amount=50&max_amount=10",
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid flow",
+				UserID:             userID,
+				ExpectedError:      "invalid flow; use inflow or outflow",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid flow; use inflow or outflow",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?flow=sideways",
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid sort",
+				UserID:             userID,
+				ExpectedError:      "invalid sort; use desc or asc",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid sort; use desc or asc",
+					},
+				},
+			},
+			PageSize: 1,
+			Query:    "?sort=random",
+		},
 	}
 	for _, tc := range errorTests {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -245,26 +427,29 @@ func TestGetTransactionsByUserID(t *testing.T) {
 
 			mockSvc := handlermocks.NewTransactionService(t)
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/transactions", nil)
+			req := httptest.NewRequest("GET", "/transactions"+tc.Query, nil)
 
 			h := htx.NewHandler(mockSvc)
 
-			if strings.Contains(tc.Name, "tx") {
+			if strings.HasSuffix(tc.Name, "tx") {
+				svcErr := tc.GetFirstPageErr
+				if tc.GetTxPaginatedErr != nil {
+					svcErr = tc.GetTxPaginatedErr
+				}
 				mockSvc.On(
 					"ListUserTransactions",
 					mock.Anything,
 					tc.UserID,
-					testhelpers.StrPtr(tc.CursorDate),
-					testhelpers.StrPtr(tc.CursorID),
+					models.TxFilter{},
+					tc.Cursor,
 					int64(tc.PageSize)).
-					Return(nil, "", "", false, errors.New(tc.ExpectedError))
+					Return(models.TxPage{}, svcErr)
 			}
 
 			router := gin.New()
 
 			router.Use(func(c *gin.Context) {
-				c.Set(string(constants.CursorDateKey), tc.CursorDate)
-				c.Set(string(constants.CursorIDKey), tc.CursorID)
+				c.Set(string(constants.CursorKey), tc.Cursor)
 				c.Set(string(constants.PageSizeKey), tc.PageSize)
 				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
 				c.Next()
@@ -0,0 +1,239 @@
+package transaction_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	htx "github.com/seanhuebl/unity-wealth/handlers/transaction"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPatchTransaction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	txID := uuid.NewString()
+	merchant := "Costco Wholesale"
+	notes := ""
+	tags := []string{"bulk"}
+
+	badRequest := func(name, msg string) testmodels.BaseHTTPTestCase {
+		return testmodels.BaseHTTPTestCase{
+			Name:               name,
+			UserID:             userID,
+			ExpectedError:      msg,
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedResponse: map[string]interface{}{
+				"data": map[string]interface{}{
+					"error": msg,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		txID          string
+		contentType   string
+		body          string
+		expectedPatch *models.TxPatch
+		svcErr        error
+	}{
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"date":              "2025-03-05",
+						"merchant":          merchant,
+						"amount":            125.98,
+						"detailed_category": 40,
+						"tags":              []interface{}{"bulk"},
+					},
+				},
+			},
+			txID:          txID,
+			body:          `{"merchant": "Costco Wholesale", "notes": null, "tags": ["bulk"]}`,
+			expectedPatch: &models.TxPatch{Merchant: &merchant, Notes: &notes, Tags: &tags},
+		},
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+			txID:             txID,
+			body:             `{"merchant": "x"}`,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+			txID:             txID,
+			body:             `{"merchant": "x"}`,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidTxID,
+			txID:             "INVALID",
+			body:             `{"merchant": "x"}`,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "unsupported content type",
+				UserID:             userID,
+				ExpectedError:      "Content-Type must be application/merge-patch+json",
+				ExpectedStatusCode: http.StatusUnsupportedMediaType,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "Content-Type must be application/merge-patch+json",
+					},
+				},
+			},
+			txID:        txID,
+			contentType: "text/plain",
+			body:        `{"merchant": "x"}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("not an object", "patch must be a JSON object"),
+			txID:             txID,
+			body:             `["merchant"]`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("malformed json", "patch must be a JSON object"),
+			txID:             txID,
+			body:             `{"merchant": "x"`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("empty patch", "patch must change at least one field"),
+			txID:             txID,
+			body:             `{}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("null date", "invalid date; use YYYY-MM-DD"),
+			txID:             txID,
+			body:             `{"date": null}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("blank merchant", "merchant must be a non-empty string"),
+			txID:             txID,
+			body:             `{"merchant": "  "}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("zero amount", "amount must be a non-zero number"),
+			txID:             txID,
+			body:             `{"amount": 0}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("amount as string", "amount must be a non-zero number"),
+			txID:             txID,
+			body:             `{"amount": "12.00"}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("invalid category", "invalid detailed_category"),
+			txID:             txID,
+			body:             `{"detailed_category": 1.5}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("invalid notes", "notes must be a string or null"),
+			txID:             txID,
+			body:             `{"notes": 5}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("invalid tags", "tags must be an array of strings or null"),
+			txID:             txID,
+			body:             `{"tags": "food"}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("read-only field", "updated_at is read-only"),
+			txID:             txID,
+			body:             `{"updated_at": "2025-01-01T00:00:00Z"}`,
+		},
+		{
+			BaseHTTPTestCase: badRequest("unknown field", `unknown field "category"`),
+			txID:             txID,
+			body:             `{"category": 40}`,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "not found",
+				UserID:             userID,
+				ExpectedError:      "not found",
+				ExpectedStatusCode: http.StatusNotFound,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "not found",
+					},
+				},
+			},
+			txID:          txID,
+			body:          `{"merchant": "Costco Wholesale"}`,
+			expectedPatch: &models.TxPatch{Merchant: &merchant},
+			svcErr:        errors.New("transaction not found"),
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "failed to update transaction",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "failed to update transaction",
+					},
+				},
+			},
+			txID:          txID,
+			body:          `{"merchant": "Costco Wholesale"}`,
+			expectedPatch: &models.TxPatch{Merchant: &merchant},
+			svcErr:        errors.New("error patching transaction"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			if tc.expectedPatch != nil {
+				var txn *models.Tx
+				if tc.svcErr == nil {
+					txn = &models.Tx{
+						ID:               tc.txID,
+						UserID:           userID.String(),
+						Date:             "2025-03-05",
+						Merchant:         merchant,
+						Amount:           125.98,
+						DetailedCategory: 40,
+						Tags:             tags,
+					}
+				}
+				mockSvc.On("PatchTransaction", mock.Anything, tc.txID, tc.UserID.String(), *tc.expectedPatch, int64(0)).Return(txn, tc.svcErr)
+			}
+			h := htx.NewHandler(mockSvc)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", fmt.Sprintf("/transactions/%v", tc.txID), bytes.NewBufferString(tc.body))
+			contentType := tc.contentType
+			if contentType == "" {
+				contentType = "application/merge-patch+json"
+			}
+			req.Header.Set("Content-Type", contentType)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.PATCH("/transactions/:id", h.PatchTransaction)
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
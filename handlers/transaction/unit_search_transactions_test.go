@@ -0,0 +1,204 @@
+package transaction_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	htx "github.com/seanhuebl/unity-wealth/handlers/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchTransactions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	txID := uuid.New()
+	userID := uuid.New()
+
+	tests := []testmodels.SearchTxTestCase{
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "success",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"results": []interface{}{
+							map[string]interface{}{
+								"id":                txID.String(),
+								"user_id":           userID.String(),
+								"date":              "2025-04-23",
+								"merchant":          "SQ *BLUE BOTTLE 0423 SF",
+								"amount":            6.5,
+								"detailed_category": 40,
+								"tags":              []interface{}{"coffee"},
+								"snippet":           "SQ *<mark>BLUE</mark> <mark>BOTTLE</mark> 0423 SF",
+							},
+						},
+					},
+				},
+			},
+			Query:    "blue bottle",
+			PageSize: 50,
+			Results: []models.TxSearchResult{
+				{
+					Tx: models.Tx{
+						ID:               txID.String(),
+						UserID:           userID.String(),
+						Date:             "2025-04-23",
+						Merchant:         "SQ *BLUE BOTTLE 0423 SF",
+						Amount:           6.5,
+						DetailedCategory: 40,
+						Tags:             []string{"coffee"},
+					},
+					Snippet: "SQ *<mark>BLUE</mark> <mark>BOTTLE</mark> 0423 SF",
+				},
+			},
+			CallsSvc: true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "no results",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusOK,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"results": []interface{}{},
+					},
+				},
+			},
+			Query:    "nothing",
+			PageSize: 50,
+			Results:  []models.TxSearchResult{},
+			CallsSvc: true,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.NilUserID,
+			Query:            "blue",
+			PageSize:         50,
+		},
+		{
+			BaseHTTPTestCase: testfixtures.InvalidUserID,
+			Query:            "blue",
+			PageSize:         50,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "missing q",
+				UserID:             userID,
+				ExpectedError:      "q is required",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "q is required",
+					},
+				},
+			},
+			Query:    "   ",
+			PageSize: 50,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "q without words",
+				UserID:             userID,
+				ExpectedError:      "q must contain at least one letter or digit",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "q must contain at least one letter or digit",
+					},
+				},
+			},
+			Query:     `*"`,
+			PageSize:  50,
+			SearchErr: transaction.ErrEmptySearchQuery,
+			CallsSvc:  true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "q too long",
+				UserID:             userID,
+				ExpectedError:      "search query must be at most",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": transaction.ErrSearchQueryTooLong.Error(),
+					},
+				},
+			},
+			Query:     "blue",
+			PageSize:  50,
+			SearchErr: transaction.ErrSearchQueryTooLong,
+			CallsSvc:  true,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "invalid page size",
+				UserID:             userID,
+				ExpectedError:      "invalid page_size; must be > 0",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "invalid page_size; must be > 0",
+					},
+				},
+			},
+			Query:    "blue",
+			PageSize: 0,
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "service error",
+				UserID:             userID,
+				ExpectedError:      "unable to search transactions",
+				ExpectedStatusCode: http.StatusInternalServerError,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "unable to search transactions",
+					},
+				},
+			},
+			Query:     "blue",
+			PageSize:  50,
+			SearchErr: errors.New("database is locked"),
+			CallsSvc:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			if tc.CallsSvc {
+				mockSvc.On("SearchTransactions", mock.Anything, tc.UserID, tc.Query, int64(tc.PageSize)).
+					Return(tc.Results, tc.SearchErr)
+			}
+			h := htx.NewHandler(mockSvc)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/transactions/search?q="+url.QueryEscape(tc.Query), nil)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Set(string(constants.PageSizeKey), tc.PageSize)
+				c.Next()
+			})
+			router.GET("/transactions/search", h.SearchTransactions)
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
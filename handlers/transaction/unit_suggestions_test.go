@@ -0,0 +1,195 @@
+package transaction_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	htx "github.com/seanhuebl/unity-wealth/handlers/transaction"
+	handlermocks "github.com/seanhuebl/unity-wealth/internal/mocks/handlers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	txID := uuid.NewString()
+
+	tests := []struct {
+		testmodels.GetTxTestCase
+		expectSvc   bool
+		suggestions []models.CategorySuggestion
+	}{
+		{
+			GetTxTestCase: testmodels.GetTxTestCase{BaseHTTPTestCase: testfixtures.NilUserID, TxID: txID},
+		},
+		{
+			GetTxTestCase: testmodels.GetTxTestCase{BaseHTTPTestCase: testfixtures.InvalidUserID, TxID: txID},
+		},
+		{
+			GetTxTestCase: testmodels.GetTxTestCase{BaseHTTPTestCase: testfixtures.InvalidTxID, TxID: "INVALID"},
+		},
+		{
+			GetTxTestCase: testmodels.GetTxTestCase{
+				BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+					Name:               "success",
+					UserID:             userID,
+					ExpectedStatusCode: http.StatusOK,
+					ExpectedResponse: map[string]interface{}{
+						"data": map[string]interface{}{
+							"suggestions": []interface{}{
+								map[string]interface{}{"detailed_category": float64(41), "confidence": 0.9},
+								map[string]interface{}{"detailed_category": float64(40), "confidence": 0.1},
+							},
+						},
+					},
+				},
+				TxID: txID,
+			},
+			expectSvc:   true,
+			suggestions: []models.CategorySuggestion{{DetailedCategory: 41, Confidence: 0.9}, {DetailedCategory: 40, Confidence: 0.1}},
+		},
+		{
+			GetTxTestCase: testmodels.GetTxTestCase{
+				BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+					Name:               "not found",
+					UserID:             userID,
+					ExpectedError:      "not found",
+					ExpectedStatusCode: http.StatusNotFound,
+					ExpectedResponse: map[string]interface{}{
+						"data": map[string]interface{}{
+							"error": "not found",
+						},
+					},
+				},
+				TxID:  txID,
+				TxErr: errors.New("transaction not found"),
+			},
+			expectSvc: true,
+		},
+		{
+			GetTxTestCase: testmodels.GetTxTestCase{
+				BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+					Name:               "service error",
+					UserID:             userID,
+					ExpectedError:      "unable to suggest categories",
+					ExpectedStatusCode: http.StatusInternalServerError,
+					ExpectedResponse: map[string]interface{}{
+						"data": map[string]interface{}{
+							"error": "unable to suggest categories",
+						},
+					},
+				},
+				TxID:  txID,
+				TxErr: errors.New("unable to suggest a category: db down"),
+			},
+			expectSvc: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			if tc.expectSvc {
+				mockSvc.On("SuggestCategories", mock.Anything, userID.String(), tc.TxID).Return(tc.suggestions, tc.TxErr).Once()
+			}
+			h := htx.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.GET("/transactions/:id/suggestions", h.GetSuggestions)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/transactions/%s/suggestions", tc.TxID), nil)
+			router.ServeHTTP(w, req)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+		})
+	}
+}
+
+func TestNewTransactionWithoutCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	req := models.NewTxRequest{Date: "2025-03-05", Merchant: "Shell", Amount: 40}
+	suggestions := []models.CategorySuggestion{{DetailedCategory: 42, Confidence: 0.75}}
+
+	tests := []struct {
+		testmodels.BaseHTTPTestCase
+		txn   *models.Tx
+		txErr error
+	}{
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "suggested category",
+				UserID:             userID,
+				ExpectedStatusCode: http.StatusCreated,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"date":              "2025-03-05",
+						"merchant":          "Shell",
+						"amount":            float64(40),
+						"detailed_category": 42,
+						"suggestions": []interface{}{
+							map[string]interface{}{"detailed_category": float64(42), "confidence": 0.75},
+						},
+					},
+				},
+			},
+			txn: &models.Tx{Date: "2025-03-05", Merchant: "Shell", Amount: 40, DetailedCategory: 42, Suggestions: suggestions},
+		},
+		{
+			BaseHTTPTestCase: testmodels.BaseHTTPTestCase{
+				Name:               "nothing to suggest",
+				UserID:             userID,
+				ExpectedError:      "detailed_category is required",
+				ExpectedStatusCode: http.StatusBadRequest,
+				ExpectedResponse: map[string]interface{}{
+					"data": map[string]interface{}{
+						"error": "detailed_category is required: no category could be suggested for this merchant",
+					},
+				},
+			},
+			txErr: fmt.Errorf("%w: no category could be suggested for this merchant", transaction.ErrCategoryRequired),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			mockSvc := handlermocks.NewTransactionService(t)
+			mockSvc.On("CreateTransaction", mock.Anything, userID.String(), req).Return(tc.txn, tc.txErr).Once()
+			h := htx.NewHandler(mockSvc)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				testhelpers.CheckForUserIDIssues(tc.Name, tc.UserID, c)
+				c.Next()
+			})
+			router.POST("/transactions", h.NewTransaction)
+
+			w := httptest.NewRecorder()
+			httpReq := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{"date": "2025-03-05", "merchant": "Shell", "amount": 40}`))
+			httpReq.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, httpReq)
+
+			actualResponse := testhelpers.ProcessResponse(w, t)
+			testhelpers.CheckHTTPResponse(t, w, tc.ExpectedError, tc.ExpectedStatusCode, tc.ExpectedResponse, actualResponse)
+		})
+	}
+}
@@ -100,7 +100,7 @@ func TestUpdateTransaction(t *testing.T) {
 			req := httptest.NewRequest("POST", fmt.Sprintf("/transactions/%v", tc.TxID), bytes.NewBufferString(tc.ReqBody))
 			req.Header.Set("Content-Type", "application/json")
 
-			mockSvc.On("UpdateTransaction", mock.Anything, tc.TxID, tc.UserID.String(), mock.AnythingOfType("models.NewTxRequest")).Return(nil, tc.UpdateTxErr)
+			mockSvc.On("UpdateTransaction", mock.Anything, tc.TxID, tc.UserID.String(), mock.AnythingOfType("models.NewTxRequest"), int64(0)).Return(nil, tc.UpdateTxErr)
 			h := htx.NewHandler(mockSvc)
 
 			router := gin.New()
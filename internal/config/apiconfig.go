@@ -10,4 +10,7 @@ type ApiConfig struct {
 	Port     string
 	Queries  *database.Queries
 	Database *sql.DB
+	// TrustedProxies are the addresses or CIDRs whose X-Forwarded-For headers are
+	// believed when working out the client IP. With none, the peer address is used.
+	TrustedProxies []string
 }
@@ -3,12 +3,14 @@ package constants
 type contextKey string
 
 const (
-	ClaimsKey     = contextKey("claims")
-	UserIDKey     = contextKey("userID")
-	RequestKey    = contextKey("httpRequest")
-	CursorDateKey = contextKey("cursor_date")
-	CursorIDKey   = contextKey("cursor_id")
-	PageSizeKey   = contextKey("page_size")
+	ClaimsKey   = contextKey("claims")
+	UserIDKey   = contextKey("userID")
+	RequestKey  = contextKey("httpRequest")
+	ClientIPKey = contextKey("client_ip")
+	APIKeyIDKey = contextKey("api_key_id")
+	ScopesKey   = contextKey("scopes")
+	CursorKey   = contextKey("cursor")
+	PageSizeKey = contextKey("page_size")
 
 	CreateTxTable = `
 			CREATE TABLE IF NOT EXISTS transactions (
@@ -20,6 +22,13 @@ const (
 			detailed_category_id INTEGER NOT NULL,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			notes TEXT NOT NULL DEFAULT '',
+			tags TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL DEFAULT 1,
+			external_id TEXT,
+			merchant_id TEXT,
+			raw_merchant TEXT NOT NULL DEFAULT '',
+			UNIQUE (user_id, external_id),
 			FOREIGN KEY (user_id) REFERENCES users (id),
 			FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id)
 			);
@@ -35,7 +44,11 @@ const (
 			stripe_subscription_id TEXT,
 			scholarship_flag INTEGER DEFAULT 0,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			email_verified_at DATETIME,
+			totp_secret TEXT,
+			totp_enabled_at DATETIME,
+			totp_last_step INTEGER
 			);
 		`
 
@@ -80,8 +93,263 @@ const (
 		revoked_at DATETIME,
 		user_id TEXT NOT NULL,
 		device_info_id TEXT NOT NULL,
+		family_id TEXT,
 		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
 		FOREIGN KEY (device_info_id) REFERENCES device_info_logs (id) ON DELETE CASCADE
 		);
 	` // #nosec
+	CreatePwdResetTokenTable = `
+		CREATE TABLE IF NOT EXISTS password_reset_tokens (
+		id TEXT PRIMARY KEY,
+		token_hash TEXT NOT NULL UNIQUE,
+		user_id TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateEmailVerifyTokenTable = `
+		CREATE TABLE IF NOT EXISTS email_verification_tokens (
+		id TEXT PRIMARY KEY,
+		token_hash TEXT NOT NULL UNIQUE,
+		user_id TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateMFARecoveryCodeTable = `
+		CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		code_hash TEXT NOT NULL,
+		used_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	`
+	CreateMFAChallengeTable = `
+		CREATE TABLE IF NOT EXISTS mfa_challenges (
+		id TEXT PRIMARY KEY,
+		token_hash TEXT NOT NULL UNIQUE,
+		user_id TEXT NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		expires_at DATETIME NOT NULL,
+		used_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateMFATrustedDeviceTable = `
+		CREATE TABLE IF NOT EXISTS mfa_trusted_devices (
+		id TEXT PRIMARY KEY,
+		token_hash TEXT NOT NULL UNIQUE,
+		user_id TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateAPIKeyTable = `
+		CREATE TABLE IF NOT EXISTS api_keys (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		prefix TEXT NOT NULL UNIQUE,
+		key_hash TEXT NOT NULL,
+		scopes TEXT NOT NULL,
+		last_used_at DATETIME,
+		revoked_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateImportMappingTable = `
+		CREATE TABLE IF NOT EXISTS import_mappings (
+		user_id TEXT NOT NULL,
+		bank TEXT NOT NULL,
+		mapping TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, bank),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateRuleTable = `
+		CREATE TABLE IF NOT EXISTS categorization_rules (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		priority INTEGER NOT NULL DEFAULT 0,
+		conditions TEXT NOT NULL,
+		actions TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateCategoryModelTables = `
+		CREATE TABLE IF NOT EXISTS category_model_features (
+		user_id TEXT NOT NULL,
+		feature TEXT NOT NULL,
+		detailed_category_id INTEGER NOT NULL,
+		count INTEGER NOT NULL,
+		PRIMARY KEY (user_id, feature, detailed_category_id),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+		FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id)
+		);
+		CREATE TABLE IF NOT EXISTS category_model_examples (
+		transaction_id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		detailed_category_id INTEGER NOT NULL,
+		features TEXT NOT NULL,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateMerchantTables = `
+		CREATE TABLE IF NOT EXISTS merchants (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		default_category_id INTEGER,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+		FOREIGN KEY (default_category_id) REFERENCES detailed_categories (id)
+		);
+		CREATE TABLE IF NOT EXISTS merchant_aliases (
+		user_id TEXT NOT NULL,
+		alias TEXT NOT NULL,
+		merchant_id TEXT NOT NULL,
+		PRIMARY KEY (user_id, alias),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+		FOREIGN KEY (merchant_id) REFERENCES merchants (id) ON DELETE CASCADE
+		);
+	` // #nosec
+	CreateUserCategoryTables = `
+		CREATE TABLE IF NOT EXISTS user_categories (
+		detailed_category_id INTEGER PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		archived_at DATETIME,
+		merged_into_id INTEGER,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+		FOREIGN KEY (merged_into_id) REFERENCES detailed_categories (id)
+		);
+		CREATE TABLE IF NOT EXISTS category_overrides (
+		user_id TEXT NOT NULL,
+		detailed_category_id INTEGER NOT NULL,
+		name TEXT,
+		hidden BOOLEAN NOT NULL DEFAULT FALSE,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, detailed_category_id),
+		FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+		FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id) ON DELETE CASCADE
+		);
+		CREATE TRIGGER IF NOT EXISTS transactions_user_category_insert
+		BEFORE INSERT ON transactions
+		WHEN EXISTS (
+		SELECT 1 FROM user_categories u
+		WHERE u.detailed_category_id = new.detailed_category_id
+		AND (u.user_id <> new.user_id OR u.archived_at IS NOT NULL)
+		) BEGIN
+		SELECT RAISE(ABORT, 'detailed_category is not available');
+		END;
+		CREATE TRIGGER IF NOT EXISTS transactions_user_category_update
+		BEFORE UPDATE OF detailed_category_id ON transactions
+		WHEN new.detailed_category_id <> old.detailed_category_id
+		AND EXISTS (
+		SELECT 1 FROM user_categories u
+		WHERE u.detailed_category_id = new.detailed_category_id
+		AND (u.user_id <> new.user_id OR u.archived_at IS NOT NULL)
+		) BEGIN
+		SELECT RAISE(ABORT, 'detailed_category is not available');
+		END;
+	` // #nosec
+	CreateTxSplitTables = `
+		CREATE TABLE IF NOT EXISTS transaction_splits (
+		transaction_id TEXT NOT NULL,
+		line INTEGER NOT NULL CHECK(line > 0),
+		amount_cents INTEGER NOT NULL CHECK(amount_cents <> 0),
+		detailed_category_id INTEGER NOT NULL,
+		memo TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (transaction_id, line),
+		FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+		FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id)
+		);
+		CREATE VIEW IF NOT EXISTS transaction_lines AS
+		SELECT t.id AS transaction_id, t.user_id, t.transaction_date, 0 AS line,
+		t.amount_cents, t.detailed_category_id, '' AS memo
+		FROM transactions t
+		WHERE NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id)
+		UNION ALL
+		SELECT s.transaction_id, t.user_id, t.transaction_date, s.line,
+		s.amount_cents, s.detailed_category_id, s.memo
+		FROM transaction_splits s
+		JOIN transactions t ON t.id = s.transaction_id;
+		CREATE TRIGGER IF NOT EXISTS transaction_splits_user_category_insert
+		BEFORE INSERT ON transaction_splits
+		WHEN EXISTS (
+		SELECT 1 FROM user_categories u
+		JOIN transactions t ON t.id = new.transaction_id
+		WHERE u.detailed_category_id = new.detailed_category_id
+		AND (u.user_id <> t.user_id OR u.archived_at IS NOT NULL)
+		) BEGIN
+		SELECT RAISE(ABORT, 'detailed_category is not available');
+		END;
+		CREATE TRIGGER IF NOT EXISTS transactions_split_amount_update
+		BEFORE UPDATE OF amount_cents ON transactions
+		WHEN EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = new.id)
+		AND new.amount_cents <> (SELECT SUM(s.amount_cents) FROM transaction_splits s WHERE s.transaction_id = new.id)
+		BEGIN
+		SELECT RAISE(ABORT, 'splits must sum to amount_cents');
+		END;
+		CREATE TRIGGER IF NOT EXISTS transactions_split_category_update
+		BEFORE UPDATE OF detailed_category_id ON transactions
+		WHEN new.detailed_category_id <> (
+		SELECT s.detailed_category_id FROM transaction_splits s
+		WHERE s.transaction_id = new.id AND s.line = 1
+		) BEGIN
+		SELECT RAISE(ABORT, 'a split transaction has the category of its first line');
+		END;
+	` // #nosec
+	// CreateTxSearchTable needs FTS5, which go-sqlite3 only builds with the
+	// sqlite_fts5 tag.
+	CreateTxSearchTable = `
+		CREATE TABLE IF NOT EXISTS transactions_fts_keys (
+		id INTEGER PRIMARY KEY,
+		transaction_id TEXT NOT NULL UNIQUE
+		);
+		CREATE VIRTUAL TABLE IF NOT EXISTS transactions_fts USING fts5(
+		merchant,
+		notes,
+		tags,
+		tokenize = 'unicode61 remove_diacritics 2',
+		prefix = '2 3'
+		);
+		CREATE TRIGGER IF NOT EXISTS transactions_fts_insert
+		AFTER INSERT ON transactions BEGIN
+		INSERT INTO transactions_fts_keys (transaction_id)
+		VALUES (new.id);
+		INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+		VALUES ((SELECT id FROM transactions_fts_keys WHERE transaction_id = new.id), new.merchant, new.notes, new.tags);
+		END;
+		CREATE TRIGGER IF NOT EXISTS transactions_fts_delete
+		AFTER DELETE ON transactions BEGIN
+		DELETE FROM transactions_fts
+		WHERE rowid = (SELECT id FROM transactions_fts_keys WHERE transaction_id = old.id);
+		DELETE FROM transactions_fts_keys
+		WHERE transaction_id = old.id;
+		END;
+		CREATE TRIGGER IF NOT EXISTS transactions_fts_update
+		AFTER UPDATE OF merchant, notes, tags ON transactions BEGIN
+		UPDATE transactions_fts
+		SET merchant = new.merchant, notes = new.notes, tags = new.tags
+		WHERE rowid = (SELECT id FROM transactions_fts_keys WHERE transaction_id = new.id);
+		END;
+	`
 )
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealAPIKeyQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealAPIKeyQuerier(q SqlTransactionalQuerier) APIKeyQuerier {
+	return &RealAPIKeyQuerier{
+		q: q,
+	}
+}
+
+func (ra *RealAPIKeyQuerier) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error {
+	return ra.q.CreateAPIKey(ctx, arg)
+}
+
+func (ra *RealAPIKeyQuerier) GetAPIKeyByPrefix(ctx context.Context, prefix string) (models.ApiKey, error) {
+	return ra.q.GetAPIKeyByPrefix(ctx, prefix)
+}
+
+func (ra *RealAPIKeyQuerier) ListUserAPIKeys(ctx context.Context, userID string) ([]models.ApiKey, error) {
+	return ra.q.ListUserAPIKeys(ctx, userID)
+}
+
+func (ra *RealAPIKeyQuerier) RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+	return ra.q.RevokeAPIKey(ctx, arg)
+}
+
+func (ra *RealAPIKeyQuerier) UpdateAPIKeyLastUsed(ctx context.Context, arg UpdateAPIKeyLastUsedParams) error {
+	return ra.q.UpdateAPIKeyLastUsed(ctx, arg)
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealCategoryModelQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealCategoryModelQuerier(q SqlTransactionalQuerier) CategoryModelQuerier {
+	return &RealCategoryModelQuerier{
+		q: q,
+	}
+}
+
+func (rc *RealCategoryModelQuerier) AddCategoryFeature(ctx context.Context, arg AddCategoryFeatureParams) error {
+	return rc.q.AddCategoryFeature(ctx, arg)
+}
+
+func (rc *RealCategoryModelQuerier) DeleteEmptyCategoryFeatures(ctx context.Context, userID string) error {
+	return rc.q.DeleteEmptyCategoryFeatures(ctx, userID)
+}
+
+func (rc *RealCategoryModelQuerier) ListCategoryFeatures(ctx context.Context, userID string) ([]models.CategoryModelFeature, error) {
+	return rc.q.ListCategoryFeatures(ctx, userID)
+}
+
+func (rc *RealCategoryModelQuerier) GetCategoryExample(ctx context.Context, arg GetCategoryExampleParams) (models.CategoryModelExample, error) {
+	return rc.q.GetCategoryExample(ctx, arg)
+}
+
+func (rc *RealCategoryModelQuerier) SaveCategoryExample(ctx context.Context, arg SaveCategoryExampleParams) error {
+	return rc.q.SaveCategoryExample(ctx, arg)
+}
+
+func (rc *RealCategoryModelQuerier) DeleteCategoryExample(ctx context.Context, arg DeleteCategoryExampleParams) error {
+	return rc.q.DeleteCategoryExample(ctx, arg)
+}
+
+func (rc *RealCategoryModelQuerier) ListUnlearnedTransactions(ctx context.Context, arg ListUnlearnedTransactionsParams) ([]ListUnlearnedTransactionsRow, error) {
+	return rc.q.ListUnlearnedTransactions(ctx, arg)
+}
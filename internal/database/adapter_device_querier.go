@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
 )
 
 type RealDeviceQuerier struct {
@@ -21,3 +23,15 @@ func (rd *RealDeviceQuerier) GetDeviceInfoByUser(ctx context.Context, arg GetDev
 func (rd *RealDeviceQuerier) CreateDeviceInfo(ctx context.Context, arg CreateDeviceInfoParams) (string, error) {
 	return rd.q.CreateDeviceInfo(ctx, arg)
 }
+
+func (rd *RealDeviceQuerier) GetDeviceInfoByID(ctx context.Context, arg GetDeviceInfoByIDParams) (models.DeviceInfoLog, error) {
+	return rd.q.GetDeviceInfoByID(ctx, arg)
+}
+
+func (rd *RealDeviceQuerier) UpdateDeviceLastUsed(ctx context.Context, arg UpdateDeviceLastUsedParams) error {
+	return rd.q.UpdateDeviceLastUsed(ctx, arg)
+}
+
+func (rd *RealDeviceQuerier) ListUserSessions(ctx context.Context, userID string) ([]ListUserSessionsRow, error) {
+	return rd.q.ListUserSessions(ctx, userID)
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealEmailVerificationQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealEmailVerificationQuerier(q SqlTransactionalQuerier) EmailVerificationQuerier {
+	return &RealEmailVerificationQuerier{
+		q: q,
+	}
+}
+
+func (re *RealEmailVerificationQuerier) CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error {
+	return re.q.CreateEmailVerificationToken(ctx, arg)
+}
+
+func (re *RealEmailVerificationQuerier) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (models.EmailVerificationToken, error) {
+	return re.q.GetEmailVerificationTokenByHash(ctx, tokenHash)
+}
+
+func (re *RealEmailVerificationQuerier) GetLatestEmailVerificationToken(ctx context.Context, userID string) (models.EmailVerificationToken, error) {
+	return re.q.GetLatestEmailVerificationToken(ctx, userID)
+}
+
+func (re *RealEmailVerificationQuerier) MarkEmailVerificationTokenUsed(ctx context.Context, arg MarkEmailVerificationTokenUsedParams) (int64, error) {
+	return re.q.MarkEmailVerificationTokenUsed(ctx, arg)
+}
+
+func (re *RealEmailVerificationQuerier) InvalidateUserEmailVerificationTokens(ctx context.Context, arg InvalidateUserEmailVerificationTokensParams) error {
+	return re.q.InvalidateUserEmailVerificationTokens(ctx, arg)
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealImportQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealImportQuerier(q SqlTransactionalQuerier) ImportQuerier {
+	return &RealImportQuerier{
+		q: q,
+	}
+}
+
+func (ri *RealImportQuerier) DeleteImportMapping(ctx context.Context, arg DeleteImportMappingParams) (int64, error) {
+	return ri.q.DeleteImportMapping(ctx, arg)
+}
+
+func (ri *RealImportQuerier) GetImportMapping(ctx context.Context, arg GetImportMappingParams) (models.ImportMapping, error) {
+	return ri.q.GetImportMapping(ctx, arg)
+}
+
+func (ri *RealImportQuerier) ListImportMappings(ctx context.Context, userID string) ([]models.ImportMapping, error) {
+	return ri.q.ListImportMappings(ctx, userID)
+}
+
+func (ri *RealImportQuerier) UpsertImportMapping(ctx context.Context, arg UpsertImportMappingParams) error {
+	return ri.q.UpsertImportMapping(ctx, arg)
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealMerchantQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealMerchantQuerier(q SqlTransactionalQuerier) MerchantQuerier {
+	return &RealMerchantQuerier{
+		q: q,
+	}
+}
+
+func (rm *RealMerchantQuerier) CreateMerchant(ctx context.Context, arg CreateMerchantParams) error {
+	return rm.q.CreateMerchant(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) CreateMerchantAlias(ctx context.Context, arg CreateMerchantAliasParams) (int64, error) {
+	return rm.q.CreateMerchantAlias(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) DeleteMerchant(ctx context.Context, arg DeleteMerchantParams) (int64, error) {
+	return rm.q.DeleteMerchant(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) GetMerchant(ctx context.Context, arg GetMerchantParams) (models.Merchant, error) {
+	return rm.q.GetMerchant(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) GetMerchantByAlias(ctx context.Context, arg GetMerchantByAliasParams) (models.Merchant, error) {
+	return rm.q.GetMerchantByAlias(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) LinkTransactionMerchant(ctx context.Context, arg LinkTransactionMerchantParams) error {
+	return rm.q.LinkTransactionMerchant(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) ListMerchantAliases(ctx context.Context, userID string) ([]models.MerchantAlias, error) {
+	return rm.q.ListMerchantAliases(ctx, userID)
+}
+
+func (rm *RealMerchantQuerier) ListUnlinkedTransactions(ctx context.Context, arg ListUnlinkedTransactionsParams) ([]ListUnlinkedTransactionsRow, error) {
+	return rm.q.ListUnlinkedTransactions(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) ListUserMerchants(ctx context.Context, userID string) ([]ListUserMerchantsRow, error) {
+	return rm.q.ListUserMerchants(ctx, userID)
+}
+
+func (rm *RealMerchantQuerier) MoveMerchantAliases(ctx context.Context, arg MoveMerchantAliasesParams) error {
+	return rm.q.MoveMerchantAliases(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (int64, error) {
+	return rm.q.UpdateMerchant(ctx, arg)
+}
+
+func (rm *RealMerchantQuerier) UpdateMerchantTransactions(ctx context.Context, arg UpdateMerchantTransactionsParams) (int64, error) {
+	return rm.q.UpdateMerchantTransactions(ctx, arg)
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealMFAQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealMFAQuerier(q SqlTransactionalQuerier) MFAQuerier {
+	return &RealMFAQuerier{
+		q: q,
+	}
+}
+
+func (rm *RealMFAQuerier) CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) error {
+	return rm.q.CreateMFARecoveryCode(ctx, arg)
+}
+
+func (rm *RealMFAQuerier) UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (int64, error) {
+	return rm.q.UseMFARecoveryCode(ctx, arg)
+}
+
+func (rm *RealMFAQuerier) DeleteUserMFARecoveryCodes(ctx context.Context, userID string) error {
+	return rm.q.DeleteUserMFARecoveryCodes(ctx, userID)
+}
+
+func (rm *RealMFAQuerier) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) error {
+	return rm.q.CreateMFAChallenge(ctx, arg)
+}
+
+func (rm *RealMFAQuerier) GetMFAChallengeByHash(ctx context.Context, tokenHash string) (models.MfaChallenge, error) {
+	return rm.q.GetMFAChallengeByHash(ctx, tokenHash)
+}
+
+func (rm *RealMFAQuerier) IncrementMFAChallengeAttempts(ctx context.Context, id string) error {
+	return rm.q.IncrementMFAChallengeAttempts(ctx, id)
+}
+
+func (rm *RealMFAQuerier) MarkMFAChallengeUsed(ctx context.Context, arg MarkMFAChallengeUsedParams) (int64, error) {
+	return rm.q.MarkMFAChallengeUsed(ctx, arg)
+}
+
+func (rm *RealMFAQuerier) CreateMFATrustedDevice(ctx context.Context, arg CreateMFATrustedDeviceParams) error {
+	return rm.q.CreateMFATrustedDevice(ctx, arg)
+}
+
+func (rm *RealMFAQuerier) GetMFATrustedDeviceByHash(ctx context.Context, tokenHash string) (models.MfaTrustedDevice, error) {
+	return rm.q.GetMFATrustedDeviceByHash(ctx, tokenHash)
+}
+
+func (rm *RealMFAQuerier) DeleteUserMFATrustedDevices(ctx context.Context, userID string) error {
+	return rm.q.DeleteUserMFATrustedDevices(ctx, userID)
+}
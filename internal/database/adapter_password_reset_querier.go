@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealPasswordResetQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealPasswordResetQuerier(q SqlTransactionalQuerier) PasswordResetQuerier {
+	return &RealPasswordResetQuerier{
+		q: q,
+	}
+}
+
+func (rp *RealPasswordResetQuerier) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error {
+	return rp.q.CreatePasswordResetToken(ctx, arg)
+}
+
+func (rp *RealPasswordResetQuerier) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	return rp.q.GetPasswordResetTokenByHash(ctx, tokenHash)
+}
+
+func (rp *RealPasswordResetQuerier) MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) (int64, error) {
+	return rp.q.MarkPasswordResetTokenUsed(ctx, arg)
+}
+
+func (rp *RealPasswordResetQuerier) InvalidateUserPasswordResetTokens(ctx context.Context, arg InvalidateUserPasswordResetTokensParams) error {
+	return rp.q.InvalidateUserPasswordResetTokens(ctx, arg)
+}
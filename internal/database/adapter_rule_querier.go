@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type RealRuleQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealRuleQuerier(q SqlTransactionalQuerier) RuleQuerier {
+	return &RealRuleQuerier{
+		q: q,
+	}
+}
+
+func (rr *RealRuleQuerier) CountUserRules(ctx context.Context, userID string) (int64, error) {
+	return rr.q.CountUserRules(ctx, userID)
+}
+
+func (rr *RealRuleQuerier) CreateRule(ctx context.Context, arg CreateRuleParams) error {
+	return rr.q.CreateRule(ctx, arg)
+}
+
+func (rr *RealRuleQuerier) DeleteRule(ctx context.Context, arg DeleteRuleParams) (int64, error) {
+	return rr.q.DeleteRule(ctx, arg)
+}
+
+func (rr *RealRuleQuerier) GetRule(ctx context.Context, arg GetRuleParams) (models.CategorizationRule, error) {
+	return rr.q.GetRule(ctx, arg)
+}
+
+func (rr *RealRuleQuerier) ListUserRules(ctx context.Context, userID string) ([]models.CategorizationRule, error) {
+	return rr.q.ListUserRules(ctx, userID)
+}
+
+func (rr *RealRuleQuerier) UpdateRule(ctx context.Context, arg UpdateRuleParams) (int64, error) {
+	return rr.q.UpdateRule(ctx, arg)
+}
@@ -36,6 +36,18 @@ func (r *RealTransactionalQuerier) CreateDeviceInfo(ctx context.Context, arg Cre
 	return r.q.CreateDeviceInfo(ctx, arg)
 }
 
+func (r *RealTransactionalQuerier) GetDeviceInfoByID(ctx context.Context, arg GetDeviceInfoByIDParams) (models.DeviceInfoLog, error) {
+	return r.q.GetDeviceInfoByID(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UpdateDeviceLastUsed(ctx context.Context, arg UpdateDeviceLastUsedParams) error {
+	return r.q.UpdateDeviceLastUsed(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListUserSessions(ctx context.Context, userID string) ([]ListUserSessionsRow, error) {
+	return r.q.ListUserSessions(ctx, userID)
+}
+
 // TokenQuerier methods.
 func (r *RealTransactionalQuerier) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
 	return r.q.CreateRefreshToken(ctx, arg)
@@ -49,6 +61,242 @@ func (r *RealTransactionalQuerier) GetRefreshByUserAndDevice(ctx context.Context
 	return r.q.GetRefreshByUserAndDevice(ctx, arg)
 }
 
+func (r *RealTransactionalQuerier) GetRefreshTokenByID(ctx context.Context, id string) (models.RefreshToken, error) {
+	return r.q.GetRefreshTokenByID(ctx, id)
+}
+
+func (r *RealTransactionalQuerier) RevokeTokenByID(ctx context.Context, arg RevokeTokenByIDParams) (int64, error) {
+	return r.q.RevokeTokenByID(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) RevokeTokenFamily(ctx context.Context, arg RevokeTokenFamilyParams) error {
+	return r.q.RevokeTokenFamily(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) RevokeAllUserTokens(ctx context.Context, arg RevokeAllUserTokensParams) error {
+	return r.q.RevokeAllUserTokens(ctx, arg)
+}
+
+// PasswordResetQuerier methods.
+func (r *RealTransactionalQuerier) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error {
+	return r.q.CreatePasswordResetToken(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	return r.q.GetPasswordResetTokenByHash(ctx, tokenHash)
+}
+
+func (r *RealTransactionalQuerier) MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) (int64, error) {
+	return r.q.MarkPasswordResetTokenUsed(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) InvalidateUserPasswordResetTokens(ctx context.Context, arg InvalidateUserPasswordResetTokensParams) error {
+	return r.q.InvalidateUserPasswordResetTokens(ctx, arg)
+}
+
+// EmailVerificationQuerier methods.
+func (r *RealTransactionalQuerier) CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error {
+	return r.q.CreateEmailVerificationToken(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (models.EmailVerificationToken, error) {
+	return r.q.GetEmailVerificationTokenByHash(ctx, tokenHash)
+}
+
+func (r *RealTransactionalQuerier) GetLatestEmailVerificationToken(ctx context.Context, userID string) (models.EmailVerificationToken, error) {
+	return r.q.GetLatestEmailVerificationToken(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) MarkEmailVerificationTokenUsed(ctx context.Context, arg MarkEmailVerificationTokenUsedParams) (int64, error) {
+	return r.q.MarkEmailVerificationTokenUsed(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) InvalidateUserEmailVerificationTokens(ctx context.Context, arg InvalidateUserEmailVerificationTokensParams) error {
+	return r.q.InvalidateUserEmailVerificationTokens(ctx, arg)
+}
+
+// MFAQuerier methods.
+func (r *RealTransactionalQuerier) CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) error {
+	return r.q.CreateMFARecoveryCode(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (int64, error) {
+	return r.q.UseMFARecoveryCode(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteUserMFARecoveryCodes(ctx context.Context, userID string) error {
+	return r.q.DeleteUserMFARecoveryCodes(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) error {
+	return r.q.CreateMFAChallenge(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetMFAChallengeByHash(ctx context.Context, tokenHash string) (models.MfaChallenge, error) {
+	return r.q.GetMFAChallengeByHash(ctx, tokenHash)
+}
+
+func (r *RealTransactionalQuerier) IncrementMFAChallengeAttempts(ctx context.Context, id string) error {
+	return r.q.IncrementMFAChallengeAttempts(ctx, id)
+}
+
+func (r *RealTransactionalQuerier) MarkMFAChallengeUsed(ctx context.Context, arg MarkMFAChallengeUsedParams) (int64, error) {
+	return r.q.MarkMFAChallengeUsed(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) CreateMFATrustedDevice(ctx context.Context, arg CreateMFATrustedDeviceParams) error {
+	return r.q.CreateMFATrustedDevice(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetMFATrustedDeviceByHash(ctx context.Context, tokenHash string) (models.MfaTrustedDevice, error) {
+	return r.q.GetMFATrustedDeviceByHash(ctx, tokenHash)
+}
+
+func (r *RealTransactionalQuerier) DeleteUserMFATrustedDevices(ctx context.Context, userID string) error {
+	return r.q.DeleteUserMFATrustedDevices(ctx, userID)
+}
+
+// APIKeyQuerier methods.
+func (r *RealTransactionalQuerier) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error {
+	return r.q.CreateAPIKey(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetAPIKeyByPrefix(ctx context.Context, prefix string) (models.ApiKey, error) {
+	return r.q.GetAPIKeyByPrefix(ctx, prefix)
+}
+
+func (r *RealTransactionalQuerier) ListUserAPIKeys(ctx context.Context, userID string) ([]models.ApiKey, error) {
+	return r.q.ListUserAPIKeys(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+	return r.q.RevokeAPIKey(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UpdateAPIKeyLastUsed(ctx context.Context, arg UpdateAPIKeyLastUsedParams) error {
+	return r.q.UpdateAPIKeyLastUsed(ctx, arg)
+}
+
+// ImportQuerier methods.
+func (r *RealTransactionalQuerier) GetImportMapping(ctx context.Context, arg GetImportMappingParams) (models.ImportMapping, error) {
+	return r.q.GetImportMapping(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListImportMappings(ctx context.Context, userID string) ([]models.ImportMapping, error) {
+	return r.q.ListImportMappings(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) UpsertImportMapping(ctx context.Context, arg UpsertImportMappingParams) error {
+	return r.q.UpsertImportMapping(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteImportMapping(ctx context.Context, arg DeleteImportMappingParams) (int64, error) {
+	return r.q.DeleteImportMapping(ctx, arg)
+}
+
+// CategoryModelQuerier methods.
+func (r *RealTransactionalQuerier) AddCategoryFeature(ctx context.Context, arg AddCategoryFeatureParams) error {
+	return r.q.AddCategoryFeature(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteEmptyCategoryFeatures(ctx context.Context, userID string) error {
+	return r.q.DeleteEmptyCategoryFeatures(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) ListCategoryFeatures(ctx context.Context, userID string) ([]models.CategoryModelFeature, error) {
+	return r.q.ListCategoryFeatures(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) GetCategoryExample(ctx context.Context, arg GetCategoryExampleParams) (models.CategoryModelExample, error) {
+	return r.q.GetCategoryExample(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) SaveCategoryExample(ctx context.Context, arg SaveCategoryExampleParams) error {
+	return r.q.SaveCategoryExample(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteCategoryExample(ctx context.Context, arg DeleteCategoryExampleParams) error {
+	return r.q.DeleteCategoryExample(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListUnlearnedTransactions(ctx context.Context, arg ListUnlearnedTransactionsParams) ([]ListUnlearnedTransactionsRow, error) {
+	return r.q.ListUnlearnedTransactions(ctx, arg)
+}
+
+// MerchantQuerier methods.
+func (r *RealTransactionalQuerier) CreateMerchant(ctx context.Context, arg CreateMerchantParams) error {
+	return r.q.CreateMerchant(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) CreateMerchantAlias(ctx context.Context, arg CreateMerchantAliasParams) (int64, error) {
+	return r.q.CreateMerchantAlias(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetMerchant(ctx context.Context, arg GetMerchantParams) (models.Merchant, error) {
+	return r.q.GetMerchant(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetMerchantByAlias(ctx context.Context, arg GetMerchantByAliasParams) (models.Merchant, error) {
+	return r.q.GetMerchantByAlias(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListUserMerchants(ctx context.Context, userID string) ([]ListUserMerchantsRow, error) {
+	return r.q.ListUserMerchants(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) ListMerchantAliases(ctx context.Context, userID string) ([]models.MerchantAlias, error) {
+	return r.q.ListMerchantAliases(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (int64, error) {
+	return r.q.UpdateMerchant(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteMerchant(ctx context.Context, arg DeleteMerchantParams) (int64, error) {
+	return r.q.DeleteMerchant(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) MoveMerchantAliases(ctx context.Context, arg MoveMerchantAliasesParams) error {
+	return r.q.MoveMerchantAliases(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListUnlinkedTransactions(ctx context.Context, arg ListUnlinkedTransactionsParams) ([]ListUnlinkedTransactionsRow, error) {
+	return r.q.ListUnlinkedTransactions(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) LinkTransactionMerchant(ctx context.Context, arg LinkTransactionMerchantParams) error {
+	return r.q.LinkTransactionMerchant(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UpdateMerchantTransactions(ctx context.Context, arg UpdateMerchantTransactionsParams) (int64, error) {
+	return r.q.UpdateMerchantTransactions(ctx, arg)
+}
+
+// RuleQuerier methods.
+func (r *RealTransactionalQuerier) CountUserRules(ctx context.Context, userID string) (int64, error) {
+	return r.q.CountUserRules(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) CreateRule(ctx context.Context, arg CreateRuleParams) error {
+	return r.q.CreateRule(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetRule(ctx context.Context, arg GetRuleParams) (models.CategorizationRule, error) {
+	return r.q.GetRule(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListUserRules(ctx context.Context, userID string) ([]models.CategorizationRule, error) {
+	return r.q.ListUserRules(ctx, userID)
+}
+
+func (r *RealTransactionalQuerier) UpdateRule(ctx context.Context, arg UpdateRuleParams) (int64, error) {
+	return r.q.UpdateRule(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteRule(ctx context.Context, arg DeleteRuleParams) (int64, error) {
+	return r.q.DeleteRule(ctx, arg)
+}
+
 // TransactionQuerier methods.
 func (r *RealTransactionalQuerier) CreateTransaction(ctx context.Context, arg CreateTransactionParams) error {
 	return r.q.CreateTransaction(ctx, arg)
@@ -62,18 +310,42 @@ func (r *RealTransactionalQuerier) DeleteTransactionByID(ctx context.Context, ar
 	return r.q.DeleteTransactionByID(ctx, arg)
 }
 
-func (r *RealTransactionalQuerier) GetUserTransactionsFirstPage(ctx context.Context, arg GetUserTransactionsFirstPageParams) ([]GetUserTransactionsFirstPageRow, error) {
-	return r.q.GetUserTransactionsFirstPage(ctx, arg)
+func (r *RealTransactionalQuerier) ListUserTransactionsNewestFirst(ctx context.Context, arg ListUserTransactionsNewestFirstParams) ([]ListUserTransactionsNewestFirstRow, error) {
+	return r.q.ListUserTransactionsNewestFirst(ctx, arg)
 }
 
-func (r *RealTransactionalQuerier) GetUserTransactionsPaginated(ctx context.Context, arg GetUserTransactionsPaginatedParams) ([]GetUserTransactionsPaginatedRow, error) {
-	return r.q.GetUserTransactionsPaginated(ctx, arg)
+func (r *RealTransactionalQuerier) ListUserTransactionsOldestFirst(ctx context.Context, arg ListUserTransactionsOldestFirstParams) ([]ListUserTransactionsOldestFirstRow, error) {
+	return r.q.ListUserTransactionsOldestFirst(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetTransactionIDByExternalID(ctx context.Context, arg GetTransactionIDByExternalIDParams) (string, error) {
+	return r.q.GetTransactionIDByExternalID(ctx, arg)
 }
 
 func (r *RealTransactionalQuerier) GetUserTransactionByID(ctx context.Context, arg GetUserTransactionByIDParams) (GetUserTransactionByIDRow, error) {
 	return r.q.GetUserTransactionByID(ctx, arg)
 }
 
+func (r *RealTransactionalQuerier) PatchTransactionByID(ctx context.Context, arg PatchTransactionByIDParams) (PatchTransactionByIDRow, error) {
+	return r.q.PatchTransactionByID(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) SearchUserTransactions(ctx context.Context, arg SearchUserTransactionsParams) ([]SearchUserTransactionsRow, error) {
+	return r.q.SearchUserTransactions(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) CreateTransactionSplit(ctx context.Context, arg CreateTransactionSplitParams) error {
+	return r.q.CreateTransactionSplit(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteTransactionSplits(ctx context.Context, arg DeleteTransactionSplitsParams) error {
+	return r.q.DeleteTransactionSplits(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListTransactionSplits(ctx context.Context, transactionID string) ([]models.TransactionSplit, error) {
+	return r.q.ListTransactionSplits(ctx, transactionID)
+}
+
 func (r *RealTransactionalQuerier) GetPrimaryCategories(ctx context.Context) ([]models.PrimaryCategory, error) {
 	return r.q.GetPrimaryCategories(ctx)
 }
@@ -82,8 +354,61 @@ func (r *RealTransactionalQuerier) GetDetailedCategories(ctx context.Context) ([
 	return r.q.GetDetailedCategories(ctx)
 }
 
-func (r *RealTransactionalQuerier) GetDetailedCategoryID(ctx context.Context, name string) (int64, error) {
-	return r.q.GetDetailedCategoryID(ctx, name)
+func (r *RealTransactionalQuerier) GetDetailedCategoryID(ctx context.Context, arg GetDetailedCategoryIDParams) (int64, error) {
+	return r.q.GetDetailedCategoryID(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ListUserCategories(ctx context.Context, userID string) ([]ListUserCategoriesRow, error) {
+	return r.q.ListUserCategories(ctx, userID)
+}
+
+// UserCategoryQuerier methods. ListUserCategories is shared with TransactionQuerier.
+func (r *RealTransactionalQuerier) CreateDetailedCategory(ctx context.Context, arg CreateDetailedCategoryParams) (int64, error) {
+	return r.q.CreateDetailedCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) CreateUserCategory(ctx context.Context, arg CreateUserCategoryParams) error {
+	return r.q.CreateUserCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) RenameUserCategory(ctx context.Context, arg RenameUserCategoryParams) (int64, error) {
+	return r.q.RenameUserCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) SetUserCategoryArchived(ctx context.Context, arg SetUserCategoryArchivedParams) (int64, error) {
+	return r.q.SetUserCategoryArchived(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UpsertCategoryOverride(ctx context.Context, arg UpsertCategoryOverrideParams) error {
+	return r.q.UpsertCategoryOverride(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ReassignTransactionCategory(ctx context.Context, arg ReassignTransactionCategoryParams) (int64, error) {
+	return r.q.ReassignTransactionCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ReassignMerchantCategory(ctx context.Context, arg ReassignMerchantCategoryParams) error {
+	return r.q.ReassignMerchantCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ReassignRuleCategory(ctx context.Context, arg ReassignRuleCategoryParams) error {
+	return r.q.ReassignRuleCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) ReassignSplitCategory(ctx context.Context, arg ReassignSplitCategoryParams) error {
+	return r.q.ReassignSplitCategory(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) MoveCategoryModelFeatures(ctx context.Context, arg MoveCategoryModelFeaturesParams) error {
+	return r.q.MoveCategoryModelFeatures(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DeleteCategoryModelFeatures(ctx context.Context, arg DeleteCategoryModelFeaturesParams) error {
+	return r.q.DeleteCategoryModelFeatures(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) MoveCategoryModelExamples(ctx context.Context, arg MoveCategoryModelExamplesParams) error {
+	return r.q.MoveCategoryModelExamples(ctx, arg)
 }
 
 // User methods
@@ -95,3 +420,35 @@ func (r *RealTransactionalQuerier) CreateUser(ctx context.Context, arg CreateUse
 func (r *RealTransactionalQuerier) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
 	return r.q.GetUserByEmail(ctx, email)
 }
+
+func (r *RealTransactionalQuerier) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	return r.q.UpdateUserPassword(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error {
+	return r.q.MarkUserEmailVerified(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) GetUserMFAByID(ctx context.Context, id string) (GetUserMFAByIDRow, error) {
+	return r.q.GetUserMFAByID(ctx, id)
+}
+
+func (r *RealTransactionalQuerier) SetUserTOTPSecret(ctx context.Context, arg SetUserTOTPSecretParams) error {
+	return r.q.SetUserTOTPSecret(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error) {
+	return r.q.EnableUserTOTP(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) DisableUserTOTP(ctx context.Context, arg DisableUserTOTPParams) error {
+	return r.q.DisableUserTOTP(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UpdateUserTOTPLastStep(ctx context.Context, arg UpdateUserTOTPLastStepParams) (int64, error) {
+	return r.q.UpdateUserTOTPLastStep(ctx, arg)
+}
+
+func (r *RealTransactionalQuerier) UpgradeUserPasswordHash(ctx context.Context, arg UpgradeUserPasswordHashParams) (int64, error) {
+	return r.q.UpgradeUserPasswordHash(ctx, arg)
+}
@@ -27,3 +27,19 @@ func (rt *RealTokenQuerier) RevokeToken(ctx context.Context, arg RevokeTokenPara
 func (rt *RealTokenQuerier) GetRefreshByUserAndDevice(ctx context.Context, arg GetRefreshByUserAndDeviceParams) (models.RefreshToken, error) {
 	return rt.q.GetRefreshByUserAndDevice(ctx, arg)
 }
+
+func (rt *RealTokenQuerier) GetRefreshTokenByID(ctx context.Context, id string) (models.RefreshToken, error) {
+	return rt.q.GetRefreshTokenByID(ctx, id)
+}
+
+func (rt *RealTokenQuerier) RevokeTokenByID(ctx context.Context, arg RevokeTokenByIDParams) (int64, error) {
+	return rt.q.RevokeTokenByID(ctx, arg)
+}
+
+func (rt *RealTokenQuerier) RevokeTokenFamily(ctx context.Context, arg RevokeTokenFamilyParams) error {
+	return rt.q.RevokeTokenFamily(ctx, arg)
+}
+
+func (rt *RealTokenQuerier) RevokeAllUserTokens(ctx context.Context, arg RevokeAllUserTokensParams) error {
+	return rt.q.RevokeAllUserTokens(ctx, arg)
+}
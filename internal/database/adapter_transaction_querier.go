@@ -28,18 +28,42 @@ func (rt *RealTransactionQuerier) DeleteTransactionByID(ctx context.Context, arg
 	return rt.q.DeleteTransactionByID(ctx, arg)
 }
 
-func (rt *RealTransactionQuerier) GetUserTransactionsFirstPage(ctx context.Context, arg GetUserTransactionsFirstPageParams) ([]GetUserTransactionsFirstPageRow, error) {
-	return rt.q.GetUserTransactionsFirstPage(ctx, arg)
+func (rt *RealTransactionQuerier) ListUserTransactionsNewestFirst(ctx context.Context, arg ListUserTransactionsNewestFirstParams) ([]ListUserTransactionsNewestFirstRow, error) {
+	return rt.q.ListUserTransactionsNewestFirst(ctx, arg)
 }
 
-func (rt *RealTransactionQuerier) GetUserTransactionsPaginated(ctx context.Context, arg GetUserTransactionsPaginatedParams) ([]GetUserTransactionsPaginatedRow, error) {
-	return rt.q.GetUserTransactionsPaginated(ctx, arg)
+func (rt *RealTransactionQuerier) ListUserTransactionsOldestFirst(ctx context.Context, arg ListUserTransactionsOldestFirstParams) ([]ListUserTransactionsOldestFirstRow, error) {
+	return rt.q.ListUserTransactionsOldestFirst(ctx, arg)
+}
+
+func (rt *RealTransactionQuerier) GetTransactionIDByExternalID(ctx context.Context, arg GetTransactionIDByExternalIDParams) (string, error) {
+	return rt.q.GetTransactionIDByExternalID(ctx, arg)
 }
 
 func (rt *RealTransactionQuerier) GetUserTransactionByID(ctx context.Context, arg GetUserTransactionByIDParams) (GetUserTransactionByIDRow, error) {
 	return rt.q.GetUserTransactionByID(ctx, arg)
 }
 
+func (rt *RealTransactionQuerier) PatchTransactionByID(ctx context.Context, arg PatchTransactionByIDParams) (PatchTransactionByIDRow, error) {
+	return rt.q.PatchTransactionByID(ctx, arg)
+}
+
+func (rt *RealTransactionQuerier) SearchUserTransactions(ctx context.Context, arg SearchUserTransactionsParams) ([]SearchUserTransactionsRow, error) {
+	return rt.q.SearchUserTransactions(ctx, arg)
+}
+
+func (rt *RealTransactionQuerier) CreateTransactionSplit(ctx context.Context, arg CreateTransactionSplitParams) error {
+	return rt.q.CreateTransactionSplit(ctx, arg)
+}
+
+func (rt *RealTransactionQuerier) DeleteTransactionSplits(ctx context.Context, arg DeleteTransactionSplitsParams) error {
+	return rt.q.DeleteTransactionSplits(ctx, arg)
+}
+
+func (rt *RealTransactionQuerier) ListTransactionSplits(ctx context.Context, transactionID string) ([]models.TransactionSplit, error) {
+	return rt.q.ListTransactionSplits(ctx, transactionID)
+}
+
 func (rt *RealTransactionQuerier) GetPrimaryCategories(ctx context.Context) ([]models.PrimaryCategory, error) {
 	return rt.q.GetPrimaryCategories(ctx)
 }
@@ -48,6 +72,10 @@ func (rt *RealTransactionQuerier) GetDetailedCategories(ctx context.Context) ([]
 	return rt.q.GetDetailedCategories(ctx)
 }
 
-func (rt *RealTransactionQuerier) GetDetailedCategoryID(ctx context.Context, name string) (int64, error) {
-	return rt.q.GetDetailedCategoryID(ctx, name)
+func (rt *RealTransactionQuerier) GetDetailedCategoryID(ctx context.Context, arg GetDetailedCategoryIDParams) (int64, error) {
+	return rt.q.GetDetailedCategoryID(ctx, arg)
+}
+
+func (rt *RealTransactionQuerier) ListUserCategories(ctx context.Context, userID string) ([]ListUserCategoriesRow, error) {
+	return rt.q.ListUserCategories(ctx, userID)
 }
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+)
+
+type RealUserCategoryQuerier struct {
+	q SqlTransactionalQuerier
+}
+
+func NewRealUserCategoryQuerier(q SqlTransactionalQuerier) UserCategoryQuerier {
+	return &RealUserCategoryQuerier{
+		q: q,
+	}
+}
+
+func (ru *RealUserCategoryQuerier) CreateDetailedCategory(ctx context.Context, arg CreateDetailedCategoryParams) (int64, error) {
+	return ru.q.CreateDetailedCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) CreateUserCategory(ctx context.Context, arg CreateUserCategoryParams) error {
+	return ru.q.CreateUserCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) DeleteCategoryModelFeatures(ctx context.Context, arg DeleteCategoryModelFeaturesParams) error {
+	return ru.q.DeleteCategoryModelFeatures(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) ListUserCategories(ctx context.Context, userID string) ([]ListUserCategoriesRow, error) {
+	return ru.q.ListUserCategories(ctx, userID)
+}
+
+func (ru *RealUserCategoryQuerier) MoveCategoryModelExamples(ctx context.Context, arg MoveCategoryModelExamplesParams) error {
+	return ru.q.MoveCategoryModelExamples(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) MoveCategoryModelFeatures(ctx context.Context, arg MoveCategoryModelFeaturesParams) error {
+	return ru.q.MoveCategoryModelFeatures(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) ReassignMerchantCategory(ctx context.Context, arg ReassignMerchantCategoryParams) error {
+	return ru.q.ReassignMerchantCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) ReassignRuleCategory(ctx context.Context, arg ReassignRuleCategoryParams) error {
+	return ru.q.ReassignRuleCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) ReassignSplitCategory(ctx context.Context, arg ReassignSplitCategoryParams) error {
+	return ru.q.ReassignSplitCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) ReassignTransactionCategory(ctx context.Context, arg ReassignTransactionCategoryParams) (int64, error) {
+	return ru.q.ReassignTransactionCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) RenameUserCategory(ctx context.Context, arg RenameUserCategoryParams) (int64, error) {
+	return ru.q.RenameUserCategory(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) SetUserCategoryArchived(ctx context.Context, arg SetUserCategoryArchivedParams) (int64, error) {
+	return ru.q.SetUserCategoryArchived(ctx, arg)
+}
+
+func (ru *RealUserCategoryQuerier) UpsertCategoryOverride(ctx context.Context, arg UpsertCategoryOverrideParams) error {
+	return ru.q.UpsertCategoryOverride(ctx, arg)
+}
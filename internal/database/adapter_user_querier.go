@@ -21,3 +21,35 @@ func (ru *RealUserQuerier) CreateUser(ctx context.Context, params CreateUserPara
 func (ru *RealUserQuerier) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
 	return ru.q.GetUserByEmail(ctx, email)
 }
+
+func (ru *RealUserQuerier) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	return ru.q.UpdateUserPassword(ctx, arg)
+}
+
+func (ru *RealUserQuerier) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error {
+	return ru.q.MarkUserEmailVerified(ctx, arg)
+}
+
+func (ru *RealUserQuerier) GetUserMFAByID(ctx context.Context, id string) (GetUserMFAByIDRow, error) {
+	return ru.q.GetUserMFAByID(ctx, id)
+}
+
+func (ru *RealUserQuerier) SetUserTOTPSecret(ctx context.Context, arg SetUserTOTPSecretParams) error {
+	return ru.q.SetUserTOTPSecret(ctx, arg)
+}
+
+func (ru *RealUserQuerier) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error) {
+	return ru.q.EnableUserTOTP(ctx, arg)
+}
+
+func (ru *RealUserQuerier) DisableUserTOTP(ctx context.Context, arg DisableUserTOTPParams) error {
+	return ru.q.DisableUserTOTP(ctx, arg)
+}
+
+func (ru *RealUserQuerier) UpdateUserTOTPLastStep(ctx context.Context, arg UpdateUserTOTPLastStepParams) (int64, error) {
+	return ru.q.UpdateUserTOTPLastStep(ctx, arg)
+}
+
+func (ru *RealUserQuerier) UpgradeUserPasswordHash(ctx context.Context, arg UpgradeUserPasswordHashParams) (int64, error) {
+	return ru.q.UpgradeUserPasswordHash(ctx, arg)
+}
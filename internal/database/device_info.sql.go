@@ -7,6 +7,9 @@ package database
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
 )
 
 const createDeviceInfo = `-- name: CreateDeviceInfo :one
@@ -17,7 +20,8 @@ INSERT INTO device_info_logs (
         browser,
         browser_version,
         os,
-        os_version
+        os_version,
+        app_info
     )
 VALUES (
         ?1,
@@ -26,7 +30,8 @@ VALUES (
         ?4,
         ?5,
         ?6,
-        ?7
+        ?7,
+        ?8
     )
 RETURNING id
 `
@@ -39,6 +44,7 @@ type CreateDeviceInfoParams struct {
 	BrowserVersion string
 	Os             string
 	OsVersion      string
+	AppInfo        sql.NullString
 }
 
 func (q *Queries) CreateDeviceInfo(ctx context.Context, arg CreateDeviceInfoParams) (string, error) {
@@ -50,12 +56,52 @@ func (q *Queries) CreateDeviceInfo(ctx context.Context, arg CreateDeviceInfoPara
 		arg.BrowserVersion,
 		arg.Os,
 		arg.OsVersion,
+		arg.AppInfo,
 	)
 	var id string
 	err := row.Scan(&id)
 	return id, err
 }
 
+const getDeviceInfoByID = `-- name: GetDeviceInfoByID :one
+SELECT id,
+    user_id,
+    device_type,
+    browser,
+    browser_version,
+    os,
+    os_version,
+    app_info,
+    created_at,
+    last_used_at
+FROM device_info_logs
+WHERE id = ?1
+    AND user_id = ?2
+`
+
+type GetDeviceInfoByIDParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) GetDeviceInfoByID(ctx context.Context, arg GetDeviceInfoByIDParams) (models.DeviceInfoLog, error) {
+	row := q.db.QueryRowContext(ctx, getDeviceInfoByID, arg.ID, arg.UserID)
+	var i models.DeviceInfoLog
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.DeviceType,
+		&i.Browser,
+		&i.BrowserVersion,
+		&i.Os,
+		&i.OsVersion,
+		&i.AppInfo,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
 const getDeviceInfoByUser = `-- name: GetDeviceInfoByUser :one
 SELECT id
 FROM device_info_logs
@@ -90,3 +136,86 @@ func (q *Queries) GetDeviceInfoByUser(ctx context.Context, arg GetDeviceInfoByUs
 	err := row.Scan(&id)
 	return id, err
 }
+
+const listUserSessions = `-- name: ListUserSessions :many
+SELECT d.id,
+    d.device_type,
+    d.browser,
+    d.browser_version,
+    d.os,
+    d.os_version,
+    d.app_info,
+    d.created_at,
+    d.last_used_at,
+    r.expires_at
+FROM device_info_logs d
+    LEFT JOIN refresh_tokens r ON r.device_info_id = d.id
+    AND r.revoked_at IS NULL
+WHERE d.user_id = ?1
+ORDER BY d.last_used_at DESC
+`
+
+type ListUserSessionsRow struct {
+	ID             string
+	DeviceType     string
+	Browser        string
+	BrowserVersion string
+	Os             string
+	OsVersion      string
+	AppInfo        sql.NullString
+	CreatedAt      sql.NullTime
+	LastUsedAt     sql.NullTime
+	ExpiresAt      sql.NullTime
+}
+
+func (q *Queries) ListUserSessions(ctx context.Context, userID string) ([]ListUserSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserSessionsRow
+	for rows.Next() {
+		var i ListUserSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DeviceType,
+			&i.Browser,
+			&i.BrowserVersion,
+			&i.Os,
+			&i.OsVersion,
+			&i.AppInfo,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateDeviceLastUsed = `-- name: UpdateDeviceLastUsed :exec
+UPDATE device_info_logs
+SET last_used_at = ?1,
+    app_info = COALESCE(?2, app_info)
+WHERE id = ?3
+`
+
+type UpdateDeviceLastUsedParams struct {
+	LastUsedAt sql.NullTime
+	AppInfo    sql.NullString
+	ID         string
+}
+
+func (q *Queries) UpdateDeviceLastUsed(ctx context.Context, arg UpdateDeviceLastUsedParams) error {
+	_, err := q.db.ExecContext(ctx, updateDeviceLastUsed, arg.LastUsedAt, arg.AppInfo, arg.ID)
+	return err
+}
@@ -10,29 +10,144 @@ import (
 type UserQuerier interface {
 	CreateUser(ctx context.Context, params CreateUserParams) error
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error
+	GetUserMFAByID(ctx context.Context, id string) (GetUserMFAByIDRow, error)
+	SetUserTOTPSecret(ctx context.Context, arg SetUserTOTPSecretParams) error
+	EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error)
+	DisableUserTOTP(ctx context.Context, arg DisableUserTOTPParams) error
+	UpdateUserTOTPLastStep(ctx context.Context, arg UpdateUserTOTPLastStepParams) (int64, error)
+	UpgradeUserPasswordHash(ctx context.Context, arg UpgradeUserPasswordHashParams) (int64, error)
 }
 
 type DeviceQuerier interface {
 	GetDeviceInfoByUser(ctx context.Context, arg GetDeviceInfoByUserParams) (string, error)
 	CreateDeviceInfo(ctx context.Context, arg CreateDeviceInfoParams) (string, error)
+	GetDeviceInfoByID(ctx context.Context, arg GetDeviceInfoByIDParams) (models.DeviceInfoLog, error)
+	UpdateDeviceLastUsed(ctx context.Context, arg UpdateDeviceLastUsedParams) error
+	ListUserSessions(ctx context.Context, userID string) ([]ListUserSessionsRow, error)
 }
 
 type TokenQuerier interface {
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
 	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
 	GetRefreshByUserAndDevice(ctx context.Context, arg GetRefreshByUserAndDeviceParams) (models.RefreshToken, error)
+	GetRefreshTokenByID(ctx context.Context, id string) (models.RefreshToken, error)
+	RevokeTokenByID(ctx context.Context, arg RevokeTokenByIDParams) (int64, error)
+	RevokeTokenFamily(ctx context.Context, arg RevokeTokenFamilyParams) error
+	RevokeAllUserTokens(ctx context.Context, arg RevokeAllUserTokensParams) error
+}
+
+type PasswordResetQuerier interface {
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) (int64, error)
+	InvalidateUserPasswordResetTokens(ctx context.Context, arg InvalidateUserPasswordResetTokensParams) error
+}
+
+type EmailVerificationQuerier interface {
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error
+	GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (models.EmailVerificationToken, error)
+	GetLatestEmailVerificationToken(ctx context.Context, userID string) (models.EmailVerificationToken, error)
+	MarkEmailVerificationTokenUsed(ctx context.Context, arg MarkEmailVerificationTokenUsedParams) (int64, error)
+	InvalidateUserEmailVerificationTokens(ctx context.Context, arg InvalidateUserEmailVerificationTokensParams) error
+}
+
+type MFAQuerier interface {
+	CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) error
+	UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (int64, error)
+	DeleteUserMFARecoveryCodes(ctx context.Context, userID string) error
+	CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) error
+	GetMFAChallengeByHash(ctx context.Context, tokenHash string) (models.MfaChallenge, error)
+	IncrementMFAChallengeAttempts(ctx context.Context, id string) error
+	MarkMFAChallengeUsed(ctx context.Context, arg MarkMFAChallengeUsedParams) (int64, error)
+	CreateMFATrustedDevice(ctx context.Context, arg CreateMFATrustedDeviceParams) error
+	GetMFATrustedDeviceByHash(ctx context.Context, tokenHash string) (models.MfaTrustedDevice, error)
+	DeleteUserMFATrustedDevices(ctx context.Context, userID string) error
+}
+
+type APIKeyQuerier interface {
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (models.ApiKey, error)
+	ListUserAPIKeys(ctx context.Context, userID string) ([]models.ApiKey, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	UpdateAPIKeyLastUsed(ctx context.Context, arg UpdateAPIKeyLastUsedParams) error
+}
+
+type ImportQuerier interface {
+	GetImportMapping(ctx context.Context, arg GetImportMappingParams) (models.ImportMapping, error)
+	ListImportMappings(ctx context.Context, userID string) ([]models.ImportMapping, error)
+	UpsertImportMapping(ctx context.Context, arg UpsertImportMappingParams) error
+	DeleteImportMapping(ctx context.Context, arg DeleteImportMappingParams) (int64, error)
+}
+
+type CategoryModelQuerier interface {
+	AddCategoryFeature(ctx context.Context, arg AddCategoryFeatureParams) error
+	DeleteEmptyCategoryFeatures(ctx context.Context, userID string) error
+	ListCategoryFeatures(ctx context.Context, userID string) ([]models.CategoryModelFeature, error)
+	GetCategoryExample(ctx context.Context, arg GetCategoryExampleParams) (models.CategoryModelExample, error)
+	SaveCategoryExample(ctx context.Context, arg SaveCategoryExampleParams) error
+	DeleteCategoryExample(ctx context.Context, arg DeleteCategoryExampleParams) error
+	ListUnlearnedTransactions(ctx context.Context, arg ListUnlearnedTransactionsParams) ([]ListUnlearnedTransactionsRow, error)
+}
+
+type MerchantQuerier interface {
+	CreateMerchant(ctx context.Context, arg CreateMerchantParams) error
+	CreateMerchantAlias(ctx context.Context, arg CreateMerchantAliasParams) (int64, error)
+	GetMerchant(ctx context.Context, arg GetMerchantParams) (models.Merchant, error)
+	GetMerchantByAlias(ctx context.Context, arg GetMerchantByAliasParams) (models.Merchant, error)
+	ListUserMerchants(ctx context.Context, userID string) ([]ListUserMerchantsRow, error)
+	ListMerchantAliases(ctx context.Context, userID string) ([]models.MerchantAlias, error)
+	UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (int64, error)
+	DeleteMerchant(ctx context.Context, arg DeleteMerchantParams) (int64, error)
+	MoveMerchantAliases(ctx context.Context, arg MoveMerchantAliasesParams) error
+	ListUnlinkedTransactions(ctx context.Context, arg ListUnlinkedTransactionsParams) ([]ListUnlinkedTransactionsRow, error)
+	LinkTransactionMerchant(ctx context.Context, arg LinkTransactionMerchantParams) error
+	UpdateMerchantTransactions(ctx context.Context, arg UpdateMerchantTransactionsParams) (int64, error)
+}
+
+type RuleQuerier interface {
+	CountUserRules(ctx context.Context, userID string) (int64, error)
+	CreateRule(ctx context.Context, arg CreateRuleParams) error
+	GetRule(ctx context.Context, arg GetRuleParams) (models.CategorizationRule, error)
+	ListUserRules(ctx context.Context, userID string) ([]models.CategorizationRule, error)
+	UpdateRule(ctx context.Context, arg UpdateRuleParams) (int64, error)
+	DeleteRule(ctx context.Context, arg DeleteRuleParams) (int64, error)
+}
+
+type UserCategoryQuerier interface {
+	ListUserCategories(ctx context.Context, userID string) ([]ListUserCategoriesRow, error)
+	CreateDetailedCategory(ctx context.Context, arg CreateDetailedCategoryParams) (int64, error)
+	CreateUserCategory(ctx context.Context, arg CreateUserCategoryParams) error
+	RenameUserCategory(ctx context.Context, arg RenameUserCategoryParams) (int64, error)
+	SetUserCategoryArchived(ctx context.Context, arg SetUserCategoryArchivedParams) (int64, error)
+	UpsertCategoryOverride(ctx context.Context, arg UpsertCategoryOverrideParams) error
+	ReassignTransactionCategory(ctx context.Context, arg ReassignTransactionCategoryParams) (int64, error)
+	ReassignSplitCategory(ctx context.Context, arg ReassignSplitCategoryParams) error
+	ReassignMerchantCategory(ctx context.Context, arg ReassignMerchantCategoryParams) error
+	ReassignRuleCategory(ctx context.Context, arg ReassignRuleCategoryParams) error
+	MoveCategoryModelFeatures(ctx context.Context, arg MoveCategoryModelFeaturesParams) error
+	DeleteCategoryModelFeatures(ctx context.Context, arg DeleteCategoryModelFeaturesParams) error
+	MoveCategoryModelExamples(ctx context.Context, arg MoveCategoryModelExamplesParams) error
 }
 
 type TransactionQuerier interface {
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) error
 	UpdateTransactionByID(ctx context.Context, arg UpdateTransactionByIDParams) (UpdateTransactionByIDRow, error)
 	DeleteTransactionByID(ctx context.Context, arg DeleteTransactionByIDParams) (string, error)
-	GetUserTransactionsFirstPage(ctx context.Context, arg GetUserTransactionsFirstPageParams) ([]GetUserTransactionsFirstPageRow, error)
-	GetUserTransactionsPaginated(ctx context.Context, arg GetUserTransactionsPaginatedParams) ([]GetUserTransactionsPaginatedRow, error)
+	ListUserTransactionsNewestFirst(ctx context.Context, arg ListUserTransactionsNewestFirstParams) ([]ListUserTransactionsNewestFirstRow, error)
+	ListUserTransactionsOldestFirst(ctx context.Context, arg ListUserTransactionsOldestFirstParams) ([]ListUserTransactionsOldestFirstRow, error)
 	GetUserTransactionByID(ctx context.Context, arg GetUserTransactionByIDParams) (GetUserTransactionByIDRow, error)
+	GetTransactionIDByExternalID(ctx context.Context, arg GetTransactionIDByExternalIDParams) (string, error)
+	PatchTransactionByID(ctx context.Context, arg PatchTransactionByIDParams) (PatchTransactionByIDRow, error)
+	SearchUserTransactions(ctx context.Context, arg SearchUserTransactionsParams) ([]SearchUserTransactionsRow, error)
+	CreateTransactionSplit(ctx context.Context, arg CreateTransactionSplitParams) error
+	DeleteTransactionSplits(ctx context.Context, arg DeleteTransactionSplitsParams) error
+	ListTransactionSplits(ctx context.Context, transactionID string) ([]models.TransactionSplit, error)
 	GetPrimaryCategories(ctx context.Context) ([]models.PrimaryCategory, error)
 	GetDetailedCategories(ctx context.Context) ([]models.DetailedCategory, error)
-	GetDetailedCategoryID(ctx context.Context, name string) (int64, error)
+	GetDetailedCategoryID(ctx context.Context, arg GetDetailedCategoryIDParams) (int64, error)
+	ListUserCategories(ctx context.Context, userID string) ([]ListUserCategoriesRow, error)
 }
 
 type SqlTxQuerier interface {
@@ -44,6 +159,15 @@ type SqlTransactionalQuerier interface {
 	SqlTxQuerier
 	DeviceQuerier
 	TokenQuerier
+	PasswordResetQuerier
+	EmailVerificationQuerier
+	MFAQuerier
+	APIKeyQuerier
+	ImportQuerier
+	CategoryModelQuerier
+	MerchantQuerier
+	RuleQuerier
 	TransactionQuerier
+	UserCategoryQuerier
 	UserQuerier
 }
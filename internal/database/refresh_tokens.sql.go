@@ -19,7 +19,8 @@ INSERT INTO refresh_tokens (
         expires_at,
         revoked_at,
         user_id,
-        device_info_id
+        device_info_id,
+        family_id
     )
 VALUES (
         ?1,
@@ -27,7 +28,8 @@ VALUES (
         ?3,
         NULL,
         ?4,
-        ?5
+        ?5,
+        ?6
     )
 ` // #nosec
 
@@ -37,6 +39,7 @@ type CreateRefreshTokenParams struct {
 	ExpiresAt    sql.NullTime
 	UserID       string
 	DeviceInfoID string
+	FamilyID     sql.NullString
 }
 
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
@@ -46,15 +49,19 @@ func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshToken
 		arg.ExpiresAt,
 		arg.UserID,
 		arg.DeviceInfoID,
+		arg.FamilyID,
 	)
 	return err
 }
 
 const getRefreshByUserAndDevice = `-- name: GetRefreshByUserAndDevice :one
-SELECT id, token_hash, created_at, updated_at, expires_at, revoked_at, user_id, device_info_id
+SELECT id, token_hash, created_at, updated_at, expires_at, revoked_at, user_id, device_info_id, family_id
 FROM refresh_tokens
 WHERE user_id = ?1
     AND device_info_id = ?2
+    AND revoked_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1
 `
 
 type GetRefreshByUserAndDeviceParams struct {
@@ -74,10 +81,51 @@ func (q *Queries) GetRefreshByUserAndDevice(ctx context.Context, arg GetRefreshB
 		&i.RevokedAt,
 		&i.UserID,
 		&i.DeviceInfoID,
+		&i.FamilyID,
+	)
+	return i, err
+}
+
+const getRefreshTokenByID = `-- name: GetRefreshTokenByID :one
+SELECT id, token_hash, created_at, updated_at, expires_at, revoked_at, user_id, device_info_id, family_id
+FROM refresh_tokens
+WHERE id = ?1
+` // #nosec
+
+func (q *Queries) GetRefreshTokenByID(ctx context.Context, id string) (models.RefreshToken, error) {
+	row := q.db.QueryRowContext(ctx, getRefreshTokenByID, id)
+	var i models.RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.UserID,
+		&i.DeviceInfoID,
+		&i.FamilyID,
 	)
 	return i, err
 }
 
+const revokeAllUserTokens = `-- name: RevokeAllUserTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = ?1
+WHERE user_id = ?2
+    AND revoked_at IS NULL
+` // #nosec
+
+type RevokeAllUserTokensParams struct {
+	RevokedAt sql.NullTime
+	UserID    string
+}
+
+func (q *Queries) RevokeAllUserTokens(ctx context.Context, arg RevokeAllUserTokensParams) error {
+	_, err := q.db.ExecContext(ctx, revokeAllUserTokens, arg.RevokedAt, arg.UserID)
+	return err
+}
+
 const revokeToken = `-- name: RevokeToken :exec
 UPDATE refresh_tokens
 SET revoked_at = ?1
@@ -96,3 +144,40 @@ func (q *Queries) RevokeToken(ctx context.Context, arg RevokeTokenParams) error
 	_, err := q.db.ExecContext(ctx, revokeToken, arg.RevokedAt, arg.UserID, arg.DeviceInfoID)
 	return err
 }
+
+const revokeTokenByID = `-- name: RevokeTokenByID :execrows
+UPDATE refresh_tokens
+SET revoked_at = ?1
+WHERE id = ?2
+    AND revoked_at IS NULL
+` // #nosec
+
+type RevokeTokenByIDParams struct {
+	RevokedAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) RevokeTokenByID(ctx context.Context, arg RevokeTokenByIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeTokenByID, arg.RevokedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeTokenFamily = `-- name: RevokeTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = ?1
+WHERE family_id = ?2
+    AND revoked_at IS NULL
+` // #nosec
+
+type RevokeTokenFamilyParams struct {
+	RevokedAt sql.NullTime
+	FamilyID  sql.NullString
+}
+
+func (q *Queries) RevokeTokenFamily(ctx context.Context, arg RevokeTokenFamilyParams) error {
+	_, err := q.db.ExecContext(ctx, revokeTokenFamily, arg.RevokedAt, arg.FamilyID)
+	return err
+}
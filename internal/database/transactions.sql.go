@@ -19,9 +19,14 @@ INSERT INTO transactions (
         transaction_date,
         merchant,
         amount_cents,
-        detailed_category_id
+        detailed_category_id,
+        notes,
+        tags,
+        external_id,
+        merchant_id,
+        raw_merchant
     )
-VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
 `
 
 type CreateTransactionParams struct {
@@ -31,6 +36,11 @@ type CreateTransactionParams struct {
 	Merchant           string
 	AmountCents        int64
 	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	ExternalID         sql.NullString
+	MerchantID         sql.NullString
+	RawMerchant        string
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) error {
@@ -41,6 +51,11 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.Merchant,
 		arg.AmountCents,
 		arg.DetailedCategoryID,
+		arg.Notes,
+		arg.Tags,
+		arg.ExternalID,
+		arg.MerchantID,
+		arg.RawMerchant,
 	)
 	return err
 }
@@ -49,24 +64,31 @@ const deleteTransactionByID = `-- name: DeleteTransactionByID :one
 DELETE FROM transactions
 WHERE id = ?1
     AND user_id = ?2
+    AND version = COALESCE(?3, version)
 RETURNING id
 `
 
 type DeleteTransactionByIDParams struct {
-	ID     string
-	UserID string
+	ID              string
+	UserID          string
+	ExpectedVersion sql.NullInt64
 }
 
 func (q *Queries) DeleteTransactionByID(ctx context.Context, arg DeleteTransactionByIDParams) (string, error) {
-	row := q.db.QueryRowContext(ctx, deleteTransactionByID, arg.ID, arg.UserID)
+	row := q.db.QueryRowContext(ctx, deleteTransactionByID, arg.ID, arg.UserID, arg.ExpectedVersion)
 	var id string
 	err := row.Scan(&id)
 	return id, err
 }
 
 const getDetailedCategories = `-- name: GetDetailedCategories :many
-SELECT id, name, description, primary_category_id
-FROM detailed_categories
+SELECT d.id, d.name, d.description, d.primary_category_id
+FROM detailed_categories d
+WHERE NOT EXISTS (
+        SELECT 1
+        FROM user_categories u
+        WHERE u.detailed_category_id = d.id
+    )
 `
 
 func (q *Queries) GetDetailedCategories(ctx context.Context) ([]models.DetailedCategory, error) {
@@ -98,13 +120,29 @@ func (q *Queries) GetDetailedCategories(ctx context.Context) ([]models.DetailedC
 }
 
 const getDetailedCategoryID = `-- name: GetDetailedCategoryID :one
-SELECT id
-FROM detailed_categories
-WHERE name = ?1
+SELECT d.id
+FROM detailed_categories d
+    JOIN primary_categories p ON p.id = d.primary_category_id
+    LEFT JOIN user_categories u ON u.detailed_category_id = d.id
+WHERE upper(p.name || '_' || d.name) = upper(?1)
+    AND (
+        u.user_id IS NULL
+        OR (
+            u.user_id = ?2
+            AND u.archived_at IS NULL
+        )
+    )
+ORDER BY d.id
+LIMIT 1
 `
 
-func (q *Queries) GetDetailedCategoryID(ctx context.Context, name string) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getDetailedCategoryID, name)
+type GetDetailedCategoryIDParams struct {
+	Name   string
+	UserID string
+}
+
+func (q *Queries) GetDetailedCategoryID(ctx context.Context, arg GetDetailedCategoryIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getDetailedCategoryID, arg.Name, arg.UserID)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -138,13 +176,37 @@ func (q *Queries) GetPrimaryCategories(ctx context.Context) ([]models.PrimaryCat
 	return items, nil
 }
 
+const getTransactionIDByExternalID = `-- name: GetTransactionIDByExternalID :one
+SELECT id
+FROM transactions
+WHERE user_id = ?1
+    AND external_id = ?2
+`
+
+type GetTransactionIDByExternalIDParams struct {
+	UserID     string
+	ExternalID sql.NullString
+}
+
+func (q *Queries) GetTransactionIDByExternalID(ctx context.Context, arg GetTransactionIDByExternalIDParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getTransactionIDByExternalID, arg.UserID, arg.ExternalID)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getUserTransactionByID = `-- name: GetUserTransactionByID :one
 SELECT id,
     user_id,
     transaction_date,
     merchant,
     amount_cents,
-    detailed_category_id
+    detailed_category_id,
+    notes,
+    tags,
+    version,
+    merchant_id,
+    raw_merchant
 FROM transactions
 WHERE user_id = ?1
     AND id = ?2
@@ -163,6 +225,11 @@ type GetUserTransactionByIDRow struct {
 	Merchant           string
 	AmountCents        int64
 	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	Version            int64
+	MerchantID         sql.NullString
+	RawMerchant        string
 }
 
 func (q *Queries) GetUserTransactionByID(ctx context.Context, arg GetUserTransactionByIDParams) (GetUserTransactionByIDRow, error) {
@@ -175,47 +242,143 @@ func (q *Queries) GetUserTransactionByID(ctx context.Context, arg GetUserTransac
 		&i.Merchant,
 		&i.AmountCents,
 		&i.DetailedCategoryID,
+		&i.Notes,
+		&i.Tags,
+		&i.Version,
+		&i.MerchantID,
+		&i.RawMerchant,
 	)
 	return i, err
 }
 
-const getUserTransactionsFirstPage = `-- name: GetUserTransactionsFirstPage :many
-SELECT id,
-    user_id,
-    transaction_date,
-    merchant,
-    amount_cents,
-    detailed_category_id
-FROM transactions
-WHERE user_id = ?1
-ORDER BY transaction_date ASC,
-    id ASC
-LIMIT ?2
+const listUserTransactionsNewestFirst = `-- name: ListUserTransactionsNewestFirst :many
+SELECT t.id,
+    t.user_id,
+    t.transaction_date,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id,
+    t.notes,
+    t.tags,
+    t.merchant_id,
+    t.raw_merchant,
+    t.version
+FROM transactions t
+WHERE t.user_id = ?1
+    AND (
+        ?2 IS NULL
+        OR t.transaction_date >= ?2
+    )
+    AND (
+        ?3 IS NULL
+        OR t.transaction_date <= ?3
+    )
+    AND (
+        ?4 IS NULL
+        OR t.merchant LIKE '%' || ?4 || '%' ESCAPE '\'
+    )
+    AND (
+        ?5 IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+                JOIN detailed_categories ldc ON ldc.id = l.detailed_category_id
+            WHERE l.transaction_id = t.id
+                AND ldc.primary_category_id = ?5
+        )
+    )
+    AND (
+        ?6 IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+            WHERE l.transaction_id = t.id
+                AND l.detailed_category_id = ?6
+        )
+    )
+    AND (
+        ?7 IS NULL
+        OR ABS(t.amount_cents) >= ?7
+    )
+    AND (
+        ?8 IS NULL
+        OR ABS(t.amount_cents) <= ?8
+    )
+    AND (
+        ?9 IS NULL
+        OR (
+            ?9 = 'inflow'
+            AND t.amount_cents < 0
+        )
+        OR (
+            ?9 = 'outflow'
+            AND t.amount_cents > 0
+        )
+    )
+    AND (
+        ?10 IS NULL
+        OR t.transaction_date < ?10
+        OR (
+            t.transaction_date = ?10
+            AND t.id < ?11
+        )
+    )
+ORDER BY t.transaction_date DESC,
+    t.id DESC
+LIMIT ?12
 `
 
-type GetUserTransactionsFirstPageParams struct {
-	UserID string
-	Limit  int64
+type ListUserTransactionsNewestFirstParams struct {
+	UserID             string
+	StartDate          sql.NullString
+	EndDate            sql.NullString
+	Merchant           sql.NullString
+	PrimaryCategoryID  sql.NullInt64
+	DetailedCategoryID sql.NullInt64
+	MinAmountCents     sql.NullInt64
+	MaxAmountCents     sql.NullInt64
+	Flow               sql.NullString
+	CursorDate         sql.NullString
+	CursorID           sql.NullString
+	PageLimit          int64
 }
 
-type GetUserTransactionsFirstPageRow struct {
+type ListUserTransactionsNewestFirstRow struct {
 	ID                 string
 	UserID             string
 	TransactionDate    string
 	Merchant           string
 	AmountCents        int64
 	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	MerchantID         sql.NullString
+	RawMerchant        string
+	Version            int64
 }
 
-func (q *Queries) GetUserTransactionsFirstPage(ctx context.Context, arg GetUserTransactionsFirstPageParams) ([]GetUserTransactionsFirstPageRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserTransactionsFirstPage, arg.UserID, arg.Limit)
+func (q *Queries) ListUserTransactionsNewestFirst(ctx context.Context, arg ListUserTransactionsNewestFirstParams) ([]ListUserTransactionsNewestFirstRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserTransactionsNewestFirst,
+		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Merchant,
+		arg.PrimaryCategoryID,
+		arg.DetailedCategoryID,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.Flow,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetUserTransactionsFirstPageRow
+	var items []ListUserTransactionsNewestFirstRow
 	for rows.Next() {
-		var i GetUserTransactionsFirstPageRow
+		var i ListUserTransactionsNewestFirstRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -223,6 +386,11 @@ func (q *Queries) GetUserTransactionsFirstPage(ctx context.Context, arg GetUserT
 			&i.Merchant,
 			&i.AmountCents,
 			&i.DetailedCategoryID,
+			&i.Notes,
+			&i.Tags,
+			&i.MerchantID,
+			&i.RawMerchant,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -237,57 +405,312 @@ func (q *Queries) GetUserTransactionsFirstPage(ctx context.Context, arg GetUserT
 	return items, nil
 }
 
-const getUserTransactionsPaginated = `-- name: GetUserTransactionsPaginated :many
-SELECT id,
-    user_id,
-    transaction_date,
-    merchant,
-    amount_cents,
-    detailed_category_id
-FROM transactions
-WHERE user_id = ?1
+const listUserTransactionsOldestFirst = `-- name: ListUserTransactionsOldestFirst :many
+SELECT t.id,
+    t.user_id,
+    t.transaction_date,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id,
+    t.notes,
+    t.tags,
+    t.merchant_id,
+    t.raw_merchant,
+    t.version
+FROM transactions t
+WHERE t.user_id = ?1
+    AND (
+        ?2 IS NULL
+        OR t.transaction_date >= ?2
+    )
+    AND (
+        ?3 IS NULL
+        OR t.transaction_date <= ?3
+    )
+    AND (
+        ?4 IS NULL
+        OR t.merchant LIKE '%' || ?4 || '%' ESCAPE '\'
+    )
+    AND (
+        ?5 IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+                JOIN detailed_categories ldc ON ldc.id = l.detailed_category_id
+            WHERE l.transaction_id = t.id
+                AND ldc.primary_category_id = ?5
+        )
+    )
+    AND (
+        ?6 IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+            WHERE l.transaction_id = t.id
+                AND l.detailed_category_id = ?6
+        )
+    )
     AND (
-        transaction_date > ?2
+        ?7 IS NULL
+        OR ABS(t.amount_cents) >= ?7
+    )
+    AND (
+        ?8 IS NULL
+        OR ABS(t.amount_cents) <= ?8
+    )
+    AND (
+        ?9 IS NULL
+        OR (
+            ?9 = 'inflow'
+            AND t.amount_cents < 0
+        )
         OR (
-            transaction_date = ?2
-            AND id < ?3
+            ?9 = 'outflow'
+            AND t.amount_cents > 0
         )
     )
-ORDER BY transaction_date ASC,
-    id ASC
-LIMIT ?4
+    AND (
+        ?10 IS NULL
+        OR t.transaction_date > ?10
+        OR (
+            t.transaction_date = ?10
+            AND t.id > ?11
+        )
+    )
+ORDER BY t.transaction_date ASC,
+    t.id ASC
+LIMIT ?12
 `
 
-type GetUserTransactionsPaginatedParams struct {
-	UserID          string
-	TransactionDate string
-	ID              string
-	Limit           int64
+type ListUserTransactionsOldestFirstParams struct {
+	UserID             string
+	StartDate          sql.NullString
+	EndDate            sql.NullString
+	Merchant           sql.NullString
+	PrimaryCategoryID  sql.NullInt64
+	DetailedCategoryID sql.NullInt64
+	MinAmountCents     sql.NullInt64
+	MaxAmountCents     sql.NullInt64
+	Flow               sql.NullString
+	CursorDate         sql.NullString
+	CursorID           sql.NullString
+	PageLimit          int64
 }
 
-type GetUserTransactionsPaginatedRow struct {
+type ListUserTransactionsOldestFirstRow struct {
 	ID                 string
 	UserID             string
 	TransactionDate    string
 	Merchant           string
 	AmountCents        int64
 	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	MerchantID         sql.NullString
+	RawMerchant        string
+	Version            int64
 }
 
-func (q *Queries) GetUserTransactionsPaginated(ctx context.Context, arg GetUserTransactionsPaginatedParams) ([]GetUserTransactionsPaginatedRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserTransactionsPaginated,
+func (q *Queries) ListUserTransactionsOldestFirst(ctx context.Context, arg ListUserTransactionsOldestFirstParams) ([]ListUserTransactionsOldestFirstRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserTransactionsOldestFirst,
 		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Merchant,
+		arg.PrimaryCategoryID,
+		arg.DetailedCategoryID,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.Flow,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserTransactionsOldestFirstRow
+	for rows.Next() {
+		var i ListUserTransactionsOldestFirstRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TransactionDate,
+			&i.Merchant,
+			&i.AmountCents,
+			&i.DetailedCategoryID,
+			&i.Notes,
+			&i.Tags,
+			&i.MerchantID,
+			&i.RawMerchant,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchTransactionByID = `-- name: PatchTransactionByID :one
+UPDATE transactions
+SET transaction_date = COALESCE(?1, transaction_date),
+    merchant = COALESCE(?2, merchant),
+    merchant_id = CASE
+        WHEN ?2 IS NULL THEN merchant_id
+        ELSE ?3
+    END,
+    raw_merchant = CASE
+        WHEN ?4 IS NULL
+        OR ?4 = merchant THEN raw_merchant
+        ELSE ?4
+    END,
+    amount_cents = COALESCE(?5, amount_cents),
+    detailed_category_id = COALESCE(
+        ?6,
+        detailed_category_id
+    ),
+    notes = COALESCE(?7, notes),
+    tags = COALESCE(?8, tags),
+    updated_at = ?9,
+    version = version + 1
+WHERE id = ?10
+    AND user_id = ?11
+    AND version = COALESCE(?12, version)
+RETURNING id,
+    user_id,
+    transaction_date,
+    merchant,
+    amount_cents,
+    detailed_category_id,
+    notes,
+    tags,
+    updated_at,
+    version,
+    merchant_id,
+    raw_merchant
+`
+
+type PatchTransactionByIDParams struct {
+	TransactionDate    sql.NullString
+	Merchant           sql.NullString
+	MerchantID         sql.NullString
+	RawMerchant        sql.NullString
+	AmountCents        sql.NullInt64
+	DetailedCategoryID sql.NullInt64
+	Notes              sql.NullString
+	Tags               sql.NullString
+	UpdatedAt          sql.NullTime
+	ID                 string
+	UserID             string
+	ExpectedVersion    sql.NullInt64
+}
+
+type PatchTransactionByIDRow struct {
+	ID                 string
+	UserID             string
+	TransactionDate    string
+	Merchant           string
+	AmountCents        int64
+	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	UpdatedAt          sql.NullTime
+	Version            int64
+	MerchantID         sql.NullString
+	RawMerchant        string
+}
+
+func (q *Queries) PatchTransactionByID(ctx context.Context, arg PatchTransactionByIDParams) (PatchTransactionByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, patchTransactionByID,
 		arg.TransactionDate,
+		arg.Merchant,
+		arg.MerchantID,
+		arg.RawMerchant,
+		arg.AmountCents,
+		arg.DetailedCategoryID,
+		arg.Notes,
+		arg.Tags,
+		arg.UpdatedAt,
 		arg.ID,
-		arg.Limit,
+		arg.UserID,
+		arg.ExpectedVersion,
 	)
+	var i PatchTransactionByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TransactionDate,
+		&i.Merchant,
+		&i.AmountCents,
+		&i.DetailedCategoryID,
+		&i.Notes,
+		&i.Tags,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.MerchantID,
+		&i.RawMerchant,
+	)
+	return i, err
+}
+
+const searchUserTransactions = `-- name: SearchUserTransactions :many
+SELECT t.id,
+    t.user_id,
+    t.transaction_date,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id,
+    t.notes,
+    t.tags,
+    CAST(
+        snippet(transactions_fts, -1, char(2), char(3), '…', 12) AS TEXT
+    ) AS snippet
+FROM transactions_fts
+    JOIN transactions_fts_keys k ON k.id = transactions_fts.rowid
+    JOIN transactions t ON t.id = k.transaction_id
+WHERE transactions_fts MATCH ?1
+    AND t.user_id = ?2
+ORDER BY bm25(transactions_fts, 10.0, 2.0, 5.0),
+    t.transaction_date DESC,
+    t.id DESC
+LIMIT ?3
+`
+
+type SearchUserTransactionsParams struct {
+	Query     string
+	UserID    string
+	PageLimit int64
+}
+
+type SearchUserTransactionsRow struct {
+	ID                 string
+	UserID             string
+	TransactionDate    string
+	Merchant           string
+	AmountCents        int64
+	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	Snippet            string
+}
+
+func (q *Queries) SearchUserTransactions(ctx context.Context, arg SearchUserTransactionsParams) ([]SearchUserTransactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUserTransactions, arg.Query, arg.UserID, arg.PageLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetUserTransactionsPaginatedRow
+	var items []SearchUserTransactionsRow
 	for rows.Next() {
-		var i GetUserTransactionsPaginatedRow
+		var i SearchUserTransactionsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -295,6 +718,9 @@ func (q *Queries) GetUserTransactionsPaginated(ctx context.Context, arg GetUserT
 			&i.Merchant,
 			&i.AmountCents,
 			&i.DetailedCategoryID,
+			&i.Notes,
+			&i.Tags,
+			&i.Snippet,
 		); err != nil {
 			return nil, err
 		}
@@ -313,24 +739,46 @@ const updateTransactionByID = `-- name: UpdateTransactionByID :one
 UPDATE transactions
 SET transaction_date = ?1,
     merchant = ?2,
-    amount_cents = ?3,
-    detailed_category_id = ?4,
-    updated_at = ?5
-WHERE id = ?6
+    merchant_id = ?3,
+    raw_merchant = CASE
+        WHEN ?4 = merchant THEN raw_merchant
+        ELSE ?4
+    END,
+    amount_cents = ?5,
+    detailed_category_id = ?6,
+    notes = ?7,
+    tags = ?8,
+    updated_at = ?9,
+    version = version + 1
+WHERE id = ?10
+    AND user_id = ?11
+    AND version = COALESCE(?12, version)
 RETURNING id,
     transaction_date,
     merchant,
     amount_cents,
-    detailed_category_id
+    detailed_category_id,
+    notes,
+    tags,
+    updated_at,
+    version,
+    merchant_id,
+    raw_merchant
 `
 
 type UpdateTransactionByIDParams struct {
 	TransactionDate    string
 	Merchant           string
+	MerchantID         sql.NullString
+	RawMerchant        string
 	AmountCents        int64
 	DetailedCategoryID int64
+	Notes              string
+	Tags               string
 	UpdatedAt          sql.NullTime
 	ID                 string
+	UserID             string
+	ExpectedVersion    sql.NullInt64
 }
 
 type UpdateTransactionByIDRow struct {
@@ -339,16 +787,28 @@ type UpdateTransactionByIDRow struct {
 	Merchant           string
 	AmountCents        int64
 	DetailedCategoryID int64
+	Notes              string
+	Tags               string
+	UpdatedAt          sql.NullTime
+	Version            int64
+	MerchantID         sql.NullString
+	RawMerchant        string
 }
 
 func (q *Queries) UpdateTransactionByID(ctx context.Context, arg UpdateTransactionByIDParams) (UpdateTransactionByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, updateTransactionByID,
 		arg.TransactionDate,
 		arg.Merchant,
+		arg.MerchantID,
+		arg.RawMerchant,
 		arg.AmountCents,
 		arg.DetailedCategoryID,
+		arg.Notes,
+		arg.Tags,
 		arg.UpdatedAt,
 		arg.ID,
+		arg.UserID,
+		arg.ExpectedVersion,
 	)
 	var i UpdateTransactionByIDRow
 	err := row.Scan(
@@ -357,6 +817,12 @@ func (q *Queries) UpdateTransactionByID(ctx context.Context, arg UpdateTransacti
 		&i.Merchant,
 		&i.AmountCents,
 		&i.DetailedCategoryID,
+		&i.Notes,
+		&i.Tags,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.MerchantID,
+		&i.RawMerchant,
 	)
 	return i, err
 }
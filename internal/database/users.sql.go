@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createUser = `-- name: CreateUser :exec
@@ -25,21 +26,211 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 	return err
 }
 
+const disableUserTOTP = `-- name: DisableUserTOTP :exec
+UPDATE users
+SET totp_secret = NULL,
+    totp_enabled_at = NULL,
+    totp_last_step = NULL,
+    updated_at = ?1
+WHERE id = ?2
+`
+
+type DisableUserTOTPParams struct {
+	UpdatedAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) DisableUserTOTP(ctx context.Context, arg DisableUserTOTPParams) error {
+	_, err := q.db.ExecContext(ctx, disableUserTOTP, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const enableUserTOTP = `-- name: EnableUserTOTP :execrows
+UPDATE users
+SET totp_enabled_at = ?1,
+    totp_last_step = ?2,
+    updated_at = ?1
+WHERE id = ?3
+    AND totp_secret IS NOT NULL
+    AND totp_enabled_at IS NULL
+`
+
+type EnableUserTOTPParams struct {
+	TotpEnabledAt sql.NullTime
+	TotpLastStep  sql.NullInt64
+	ID            string
+}
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enableUserTOTP, arg.TotpEnabledAt, arg.TotpLastStep, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT id,
-    hashed_password
+    hashed_password,
+    email_verified_at,
+    totp_enabled_at
 FROM users
 WHERE email = ?1
 `
 
 type GetUserByEmailRow struct {
-	ID             string
-	HashedPassword string
+	ID              string
+	HashedPassword  string
+	EmailVerifiedAt sql.NullTime
+	TotpEnabledAt   sql.NullTime
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
 	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
 	var i GetUserByEmailRow
-	err := row.Scan(&i.ID, &i.HashedPassword)
+	err := row.Scan(
+		&i.ID,
+		&i.HashedPassword,
+		&i.EmailVerifiedAt,
+		&i.TotpEnabledAt,
+	)
 	return i, err
 }
+
+const getUserMFAByID = `-- name: GetUserMFAByID :one
+SELECT email,
+    totp_secret,
+    totp_enabled_at,
+    totp_last_step,
+    email_verified_at
+FROM users
+WHERE id = ?1
+`
+
+type GetUserMFAByIDRow struct {
+	Email           string
+	TotpSecret      sql.NullString
+	TotpEnabledAt   sql.NullTime
+	TotpLastStep    sql.NullInt64
+	EmailVerifiedAt sql.NullTime
+}
+
+func (q *Queries) GetUserMFAByID(ctx context.Context, id string) (GetUserMFAByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserMFAByID, id)
+	var i GetUserMFAByIDRow
+	err := row.Scan(
+		&i.Email,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = ?1,
+    updated_at = ?1
+WHERE id = ?2
+    AND email_verified_at IS NULL
+`
+
+type MarkUserEmailVerifiedParams struct {
+	EmailVerifiedAt sql.NullTime
+	ID              string
+}
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markUserEmailVerified, arg.EmailVerifiedAt, arg.ID)
+	return err
+}
+
+const setUserTOTPSecret = `-- name: SetUserTOTPSecret :exec
+UPDATE users
+SET totp_secret = ?1,
+    totp_last_step = NULL,
+    updated_at = ?2
+WHERE id = ?3
+    AND totp_enabled_at IS NULL
+` // #nosec
+
+type SetUserTOTPSecretParams struct {
+	TotpSecret sql.NullString
+	UpdatedAt  sql.NullTime
+	ID         string
+}
+
+func (q *Queries) SetUserTOTPSecret(ctx context.Context, arg SetUserTOTPSecretParams) error {
+	_, err := q.db.ExecContext(ctx, setUserTOTPSecret, arg.TotpSecret, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET hashed_password = ?1,
+    updated_at = ?2
+WHERE id = ?3
+`
+
+type UpdateUserPasswordParams struct {
+	HashedPassword string
+	UpdatedAt      sql.NullTime
+	ID             string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.HashedPassword, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const updateUserTOTPLastStep = `-- name: UpdateUserTOTPLastStep :execrows
+UPDATE users
+SET totp_last_step = ?1
+WHERE id = ?2
+    AND (
+        totp_last_step IS NULL
+        OR totp_last_step < ?1
+    )
+`
+
+type UpdateUserTOTPLastStepParams struct {
+	TotpLastStep sql.NullInt64
+	ID           string
+}
+
+func (q *Queries) UpdateUserTOTPLastStep(ctx context.Context, arg UpdateUserTOTPLastStepParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserTOTPLastStep, arg.TotpLastStep, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upgradeUserPasswordHash = `-- name: UpgradeUserPasswordHash :execrows
+UPDATE users
+SET hashed_password = ?1,
+    updated_at = ?2
+WHERE id = ?3
+    AND hashed_password = ?4
+`
+
+type UpgradeUserPasswordHashParams struct {
+	HashedPassword   string
+	UpdatedAt        sql.NullTime
+	ID               string
+	HashedPassword_2 string
+}
+
+func (q *Queries) UpgradeUserPasswordHash(ctx context.Context, arg UpgradeUserPasswordHashParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upgradeUserPasswordHash,
+		arg.HashedPassword,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.HashedPassword_2,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+type csvWriter struct {
+	w         *csv.Writer
+	wroteHead bool
+}
+
+func newCSVWriter(w io.Writer, _ models.TxFilter) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(txn models.TxExport) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.w.Write(record(txn))
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.wroteHead {
+		return nil
+	}
+	c.wroteHead = true
+	return c.w.Write(columns)
+}
@@ -0,0 +1,96 @@
+// Package export writes transactions as files for spreadsheets, accounting tools and
+// other apps. Writers stream: each transaction is written as it is passed in.
+package export
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// Writer writes transactions in one format. Close finishes the file; nothing written
+// is complete until it returns.
+type Writer interface {
+	Write(txn models.TxExport) error
+	Close() error
+}
+
+// Format is a file format transactions can be exported in.
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	// NewWriter starts a file on w. filter is the filter the export was made with;
+	// formats that describe a statement period take it from there.
+	NewWriter func(w io.Writer, filter models.TxFilter) Writer
+}
+
+var formats = []Format{
+	{Name: "csv", ContentType: "text/csv; charset=utf-8", Extension: "csv", NewWriter: newCSVWriter},
+	{Name: "jsonl", ContentType: "application/x-ndjson", Extension: "jsonl", NewWriter: newJSONLWriter},
+	{Name: "ofx", ContentType: "application/x-ofx", Extension: "ofx", NewWriter: newOFXWriter},
+	{Name: "xlsx", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx", NewWriter: newXLSXWriter},
+}
+
+// Lookup returns the format with the given name, such as "csv".
+func Lookup(name string) (Format, bool) {
+	for _, f := range formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// Names returns the names of the supported formats.
+func Names() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// columns are the columns of the CSV and XLSX exports. The CSV import recognizes each
+// of them, so an exported file can be imported again without a mapping. Money out
+// and money in are separate columns so the import does not have to guess the sign.
+var columns = []string{"Date", "Merchant", "Money Out", "Money In", "Category", "Notes", "Tags", "ID"}
+
+const (
+	moneyOutColumn = 2
+	moneyInColumn  = 3
+)
+
+// record returns the values of txn for columns.
+func record(txn models.TxExport) []string {
+	var out, in string
+	if txn.Amount >= 0 {
+		out = formatAmount(txn.Amount)
+	} else {
+		in = formatAmount(-txn.Amount)
+	}
+	return []string{txn.Date, txn.Merchant, out, in, categoryName(txn), txn.Notes, strings.Join(txn.Tags, ","), txn.ID}
+}
+
+// categoryName is the PRIMARY_DETAILED name the CSV import looks categories up by.
+func categoryName(txn models.TxExport) string {
+	if txn.PrimaryCategoryName == "" || txn.DetailedCategoryName == "" {
+		return ""
+	}
+	return txn.PrimaryCategoryName + "_" + txn.DetailedCategoryName
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', 2, 64)
+}
+
+// compactDate turns a YYYY-MM-DD date into YYYYMMDD.
+func compactDate(date string) string {
+	if len(date) >= 10 {
+		date = date[:10]
+	}
+	return strings.ReplaceAll(date, "-", "")
+}
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// jsonlWriter writes one JSON object per line.
+type jsonlWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer, _ models.TxFilter) Writer {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &jsonlWriter{buf: buf, enc: enc}
+}
+
+func (j *jsonlWriter) Write(txn models.TxExport) error {
+	return j.enc.Encode(txn)
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.buf.Flush()
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// ofxAccountID is the account the OFX export describes. Transactions are not kept
+// per account, so all of them are in this one.
+const ofxAccountID = "UNITYWEALTH"
+
+// maxOFXNameLength is the longest NAME OFX allows; longer merchants are cut.
+const maxOFXNameLength = 32
+
+// ofxWriter writes an OFX 2.2 bank statement. The statement period is the filter's
+// date range, or from 1970 to today where the filter leaves it open. There is no
+// balance to report, so LEDGERBAL is left out.
+type ofxWriter struct {
+	buf       *bufio.Writer
+	filter    models.TxFilter
+	wroteHead bool
+}
+
+func newOFXWriter(w io.Writer, filter models.TxFilter) Writer {
+	return &ofxWriter{buf: bufio.NewWriter(w), filter: filter}
+}
+
+func (o *ofxWriter) Write(txn models.TxExport) error {
+	o.writeHeader()
+	// Statements show money leaving the account as negative.
+	trnType := "DEBIT"
+	if txn.Amount < 0 {
+		trnType = "CREDIT"
+	}
+	fmt.Fprintf(o.buf, "<STMTTRN>\n<TRNTYPE>%s</TRNTYPE>\n<DTPOSTED>%s</DTPOSTED>\n<TRNAMT>%s</TRNAMT>\n<FITID>%s</FITID>\n<NAME>%s</NAME>\n",
+		trnType, compactDate(txn.Date), formatAmount(-txn.Amount), escapeXML(txn.ID), escapeXML(ofxName(txn.Merchant)))
+	if txn.Notes != "" {
+		fmt.Fprintf(o.buf, "<MEMO>%s</MEMO>\n", escapeXML(txn.Notes))
+	}
+	_, err := o.buf.WriteString("</STMTTRN>\n")
+	return err
+}
+
+func (o *ofxWriter) Close() error {
+	o.writeHeader()
+	o.buf.WriteString("</BANKTRANLIST>\n</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+	return o.buf.Flush()
+}
+
+func (o *ofxWriter) writeHeader() {
+	if o.wroteHead {
+		return
+	}
+	o.wroteHead = true
+	today := time.Now().UTC()
+	start, end := compactDate(o.filter.StartDate), compactDate(o.filter.EndDate)
+	if start == "" {
+		start = "19700101"
+	}
+	if end == "" {
+		end = today.Format("20060102")
+	}
+	fmt.Fprintf(o.buf, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1>
+<SONRS>
+<STATUS>
+<CODE>0</CODE>
+<SEVERITY>INFO</SEVERITY>
+</STATUS>
+<DTSERVER>%s</DTSERVER>
+<LANGUAGE>ENG</LANGUAGE>
+</SONRS>
+</SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>0</TRNUID>
+<STATUS>
+<CODE>0</CODE>
+<SEVERITY>INFO</SEVERITY>
+</STATUS>
+<STMTRS>
+<CURDEF>USD</CURDEF>
+<BANKACCTFROM>
+<BANKID>000000000</BANKID>
+<ACCTID>%s</ACCTID>
+<ACCTTYPE>CHECKING</ACCTTYPE>
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>%s</DTSTART>
+<DTEND>%s</DTEND>
+`, today.Format("20060102150405"), ofxAccountID, start, end)
+}
+
+// ofxName cuts merchant to the length OFX allows without splitting a character.
+func ofxName(merchant string) string {
+	if utf8.RuneCountInString(merchant) <= maxOFXNameLength {
+		return merchant
+	}
+	return string([]rune(merchant)[:maxOFXNameLength])
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
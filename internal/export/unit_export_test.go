@@ -0,0 +1,149 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/seanhuebl/unity-wealth/internal/export"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+var testTxns = []models.TxExport{
+	{
+		Tx:                   models.Tx{ID: "txn-1", UserID: "user-1", Date: "2025-03-05", Merchant: "Costco", Amount: 125.98, DetailedCategory: 40, Notes: "Weekly shop, bulk", Tags: []string{"home", "food"}},
+		PrimaryCategoryName:  "FOOD_AND_DRINK",
+		DetailedCategoryName: "GROCERIES",
+	},
+	{
+		Tx:                   models.Tx{ID: "txn-2", UserID: "user-1", Date: "2025-03-14", Merchant: "Acme Corp Payroll & Benefits Department", Amount: -2000, DetailedCategory: 1},
+		PrimaryCategoryName:  "INCOME",
+		DetailedCategoryName: "WAGES",
+	},
+}
+
+func exportAll(t *testing.T, format string, filter models.TxFilter) []byte {
+	t.Helper()
+	f, ok := export.Lookup(format)
+	require.True(t, ok)
+	var buf bytes.Buffer
+	w := f.NewWriter(&buf, filter)
+	for _, txn := range testTxns {
+		require.NoError(t, w.Write(txn))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestLookup(t *testing.T) {
+	require.Equal(t, []string{"csv", "jsonl", "ofx", "xlsx"}, export.Names())
+	f, ok := export.Lookup("xlsx")
+	require.True(t, ok)
+	require.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", f.ContentType)
+	_, ok = export.Lookup("pdf")
+	require.False(t, ok)
+}
+
+func TestCSVWriter(t *testing.T) {
+	expected := "Date,Merchant,Money Out,Money In,Category,Notes,Tags,ID\n" +
+		"2025-03-05,Costco,125.98,,FOOD_AND_DRINK_GROCERIES,\"Weekly shop, bulk\",\"home,food\",txn-1\n" +
+		"2025-03-14,Acme Corp Payroll & Benefits Department,,2000.00,INCOME_WAGES,,,txn-2\n"
+	require.Equal(t, expected, string(exportAll(t, "csv", models.TxFilter{})))
+
+	// An empty export still has the header.
+	f, _ := export.Lookup("csv")
+	var buf bytes.Buffer
+	require.NoError(t, f.NewWriter(&buf, models.TxFilter{}).Close())
+	require.Equal(t, "Date,Merchant,Money Out,Money In,Category,Notes,Tags,ID\n", buf.String())
+}
+
+func TestJSONLWriter(t *testing.T) {
+	scanner := bufio.NewScanner(bytes.NewReader(exportAll(t, "jsonl", models.TxFilter{})))
+	var got []models.TxExport
+	for scanner.Scan() {
+		var txn models.TxExport
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &txn))
+		got = append(got, txn)
+	}
+	if diff := cmp.Diff(testTxns, got); diff != "" {
+		t.Errorf("exported mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOFXWriter(t *testing.T) {
+	out := string(exportAll(t, "ofx", models.TxFilter{StartDate: "2025-03-01", EndDate: "2025-03-31"}))
+
+	// The export is well-formed XML.
+	dec := xml.NewDecoder(strings.NewReader(out))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.Contains(t, out, "<DTSTART>20250301</DTSTART>\n<DTEND>20250331</DTEND>")
+	require.Contains(t, out, "<STMTTRN>\n<TRNTYPE>DEBIT</TRNTYPE>\n<DTPOSTED>20250305</DTPOSTED>\n<TRNAMT>-125.98</TRNAMT>\n<FITID>txn-1</FITID>\n<NAME>Costco</NAME>\n<MEMO>Weekly shop, bulk</MEMO>\n</STMTTRN>")
+	// Names are cut to OFX's 32 characters and escaped.
+	require.Contains(t, out, "<TRNTYPE>CREDIT</TRNTYPE>\n<DTPOSTED>20250314</DTPOSTED>\n<TRNAMT>2000.00</TRNAMT>\n<FITID>txn-2</FITID>\n<NAME>Acme Corp Payroll &amp; Benefits Dep</NAME>\n</STMTTRN>")
+	require.True(t, strings.HasSuffix(out, "</BANKTRANLIST>\n</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n"))
+}
+
+func TestXLSXWriter(t *testing.T) {
+	out := exportAll(t, "xlsx", models.TxFilter{})
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+
+	var names []string
+	var sheet []byte
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		r, err := f.Open()
+		require.NoError(t, err)
+		sheet, err = io.ReadAll(r)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, names)
+
+	var ws struct {
+		Rows []struct {
+			R     string `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				T      string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(sheet, &ws))
+	require.Len(t, ws.Rows, 3)
+	require.Equal(t, "Money Out", ws.Rows[0].Cells[2].Inline)
+
+	cells := make(map[string]string)
+	for _, c := range ws.Rows[2].Cells {
+		if c.T == "inlineStr" {
+			cells[c.R] = c.Inline
+		} else {
+			cells[c.R] = c.Value
+		}
+	}
+	require.Equal(t, map[string]string{
+		"A3": "2025-03-14",
+		"B3": "Acme Corp Payroll & Benefits Department",
+		"D3": "2000.00",
+		"E3": "INCOME_WAGES",
+		"H3": "txn-2",
+	}, cells)
+}
@@ -0,0 +1,15 @@
+package helpers
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+)
+
+// GetClientIP returns the client IP stored by the request context middleware, or "".
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(constants.ClientIPKey).(string); ok {
+		return ip
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const recordPrefix = "idempotency:"
+
+// Record is what a Store keeps for one idempotency key: the fingerprint of the request
+// that claimed it and, once that request finished, the response to replay with the
+// headers a client acts on. A record with no Status is still in progress.
+type Record struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	ETag        string `json:"etag,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Done reports whether the record holds a finished response.
+func (r *Record) Done() bool {
+	return r.Status != 0
+}
+
+// Store keeps idempotency records. Begin must be atomic so that only one of several
+// concurrent requests with the same key gets to run.
+type Store interface {
+	// Begin claims key for a request with fingerprint for up to lockTTL. It returns nil
+	// when the claim succeeded and the existing record when the key is already taken.
+	Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error)
+	// Complete stores the finished response under key for ttl.
+	Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error
+	// Extend renews the claim on key for another lockTTL while the request with
+	// fingerprint is still running. It leaves a completed or lost claim alone.
+	Extend(ctx context.Context, key, fingerprint string, lockTTL time.Duration) error
+	// Release drops the claim on key so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// RedisStore keeps records in Redis so replays work across instances.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (r *RedisStore) Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	pending, err := json.Marshal(Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+	// The record can expire between SETNX and GET, so try again once when it does.
+	for attempt := 0; attempt < 2; attempt++ {
+		ok, err := r.client.SetNX(ctx, recordPrefix+key, pending, lockTTL).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return nil, nil
+		}
+		raw, err := r.client.Get(ctx, recordPrefix+key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rec Record
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return nil, err
+		}
+		return &rec, nil
+	}
+	return nil, errors.New("idempotency key changed while claiming it")
+}
+
+func (r *RedisStore) Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error {
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, recordPrefix+key, raw, ttl).Err()
+}
+
+// extendScript renews a claim only while it still holds the pending record, so a
+// renewal cannot cut short the TTL of a stored response.
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+func (r *RedisStore) Extend(ctx context.Context, key, fingerprint string, lockTTL time.Duration) error {
+	pending, err := json.Marshal(Record{Fingerprint: fingerprint})
+	if err != nil {
+		return err
+	}
+	return extendScript.Run(ctx, r.client, []string{recordPrefix + key}, pending, lockTTL.Milliseconds()).Err()
+}
+
+func (r *RedisStore) Release(ctx context.Context, key string) error {
+	return r.client.Del(ctx, recordPrefix+key).Err()
+}
+
+// MemoryStore is an in-process Store for tests and single-instance deployments.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]memoryRecord
+}
+
+type memoryRecord struct {
+	rec       Record
+	expiresAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]memoryRecord)}
+}
+
+func (m *MemoryStore) Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	if existing, ok := m.records[key]; ok {
+		rec := existing.rec
+		return &rec, nil
+	}
+	m.records[key] = memoryRecord{
+		rec:       Record{Fingerprint: fingerprint},
+		expiresAt: time.Now().Add(lockTTL),
+	}
+	return nil, nil
+}
+
+func (m *MemoryStore) Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[key] = memoryRecord{rec: rec, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (m *MemoryStore) Extend(ctx context.Context, key, fingerprint string, lockTTL time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	existing, ok := m.records[key]
+	if !ok || existing.rec.Done() || existing.rec.Fingerprint != fingerprint {
+		return nil
+	}
+	existing.expiresAt = time.Now().Add(lockTTL)
+	m.records[key] = existing
+	return nil
+}
+
+func (m *MemoryStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, key)
+	return nil
+}
+
+func (m *MemoryStore) evictExpired() {
+	now := time.Now()
+	for key, r := range m.records {
+		if !now.Before(r.expiresAt) {
+			delete(m.records, key)
+		}
+	}
+}
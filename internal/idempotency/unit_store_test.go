@@ -0,0 +1,156 @@
+package idempotency_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/seanhuebl/unity-wealth/internal/idempotency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first claim wins", func(t *testing.T) {
+		store := idempotency.NewMemoryStore()
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, rec)
+
+		rec, err = store.Begin(ctx, "key", "other", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &idempotency.Record{Fingerprint: "fp"}, rec)
+		require.False(t, rec.Done())
+	})
+
+	t.Run("completed record is returned", func(t *testing.T) {
+		store := idempotency.NewMemoryStore()
+		_, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		done := idempotency.Record{Fingerprint: "fp", Status: 201, ContentType: "application/json", Body: []byte(`{}`)}
+		require.NoError(t, store.Complete(ctx, "key", done, time.Hour))
+
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &done, rec)
+		require.True(t, rec.Done())
+	})
+
+	t.Run("expired claim can be taken again", func(t *testing.T) {
+		store := idempotency.NewMemoryStore()
+		_, err := store.Begin(ctx, "key", "fp", 20*time.Millisecond)
+		require.NoError(t, err)
+
+		time.Sleep(30 * time.Millisecond)
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, rec)
+	})
+
+	t.Run("extend keeps a running claim", func(t *testing.T) {
+		store := idempotency.NewMemoryStore()
+		_, err := store.Begin(ctx, "key", "fp", 20*time.Millisecond)
+		require.NoError(t, err)
+		require.NoError(t, store.Extend(ctx, "key", "other", time.Millisecond))
+		require.NoError(t, store.Extend(ctx, "key", "fp", time.Minute))
+
+		time.Sleep(30 * time.Millisecond)
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &idempotency.Record{Fingerprint: "fp"}, rec)
+	})
+
+	t.Run("extend leaves a completed record", func(t *testing.T) {
+		store := idempotency.NewMemoryStore()
+		done := idempotency.Record{Fingerprint: "fp", Status: 201}
+		require.NoError(t, store.Complete(ctx, "key", done, 20*time.Millisecond))
+		require.NoError(t, store.Extend(ctx, "key", "fp", time.Minute))
+
+		time.Sleep(30 * time.Millisecond)
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, rec)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		store := idempotency.NewMemoryStore()
+		_, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, store.Release(ctx, "key"))
+
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, rec)
+	})
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	pending, err := json.Marshal(idempotency.Record{Fingerprint: "fp"})
+	require.NoError(t, err)
+
+	t.Run("claim", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := idempotency.NewRedisStore(client)
+		redisMock.ExpectSetNX("idempotency:key", pending, time.Minute).SetVal(true)
+
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, rec)
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("existing record", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := idempotency.NewRedisStore(client)
+		done := idempotency.Record{Fingerprint: "fp", Status: 201, Body: []byte(`{}`)}
+		raw, err := json.Marshal(done)
+		require.NoError(t, err)
+		redisMock.ExpectSetNX("idempotency:key", pending, time.Minute).SetVal(false)
+		redisMock.ExpectGet("idempotency:key").SetVal(string(raw))
+
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, &done, rec)
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("record expires between calls", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := idempotency.NewRedisStore(client)
+		redisMock.ExpectSetNX("idempotency:key", pending, time.Minute).SetVal(false)
+		redisMock.ExpectGet("idempotency:key").RedisNil()
+		redisMock.ExpectSetNX("idempotency:key", pending, time.Minute).SetVal(true)
+
+		rec, err := store.Begin(ctx, "key", "fp", time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, rec)
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("extend", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := idempotency.NewRedisStore(client)
+		redisMock.Regexp().ExpectEvalSha(`^[0-9a-f]{40}$`, []string{"idempotency:key"}, pending, time.Minute.Milliseconds()).SetVal(int64(1))
+
+		require.NoError(t, store.Extend(ctx, "key", "fp", time.Minute))
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("complete and release", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := idempotency.NewRedisStore(client)
+		done := idempotency.Record{Fingerprint: "fp", Status: 200}
+		raw, err := json.Marshal(done)
+		require.NoError(t, err)
+		redisMock.ExpectSet("idempotency:key", raw, 24*time.Hour).SetVal("OK")
+		redisMock.ExpectDel("idempotency:key").SetVal(1)
+
+		require.NoError(t, store.Complete(ctx, "key", done, 24*time.Hour))
+		require.NoError(t, store.Release(ctx, "key"))
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+}
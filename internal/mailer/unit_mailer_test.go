@@ -0,0 +1,77 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      mailer.Message
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "plain message",
+			msg:  mailer.Message{To: "user@example.com", Subject: "Hello", Body: "line one\nline two"},
+			contains: []string{
+				"From: noreply@example.com\r\n",
+				"To: user@example.com\r\n",
+				"Subject: Hello\r\n",
+				"\r\n\r\nline one\r\nline two",
+			},
+		},
+		{
+			name:     "header injection is stripped",
+			msg:      mailer.Message{To: "user@example.com\r\nBcc: victim@example.com", Subject: "Hi"},
+			contains: []string{"To: user@example.comBcc: victim@example.com\r\n"},
+			excludes: []string{"\r\nBcc:"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := string(mailer.FormatMessage("noreply@example.com", tc.msg))
+			for _, want := range tc.contains {
+				require.Contains(t, raw, want)
+			}
+			for _, unwanted := range tc.excludes {
+				require.NotContains(t, raw, unwanted)
+			}
+		})
+	}
+}
+
+func TestMemoryMailer(t *testing.T) {
+	m := mailer.NewMemoryMailer()
+	msg := mailer.Message{To: "user@example.com", Subject: "Hello", Body: "body"}
+	require.NoError(t, m.Send(context.Background(), msg))
+
+	sent := m.Messages()
+	require.Equal(t, []mailer.Message{msg}, sent)
+
+	// Messages returns a copy.
+	sent[0].Subject = "changed"
+	require.Equal(t, "Hello", m.Messages()[0].Subject)
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m := mailer.NewFileMailer(dir, "noreply@example.com")
+	require.NoError(t, m.Send(context.Background(), mailer.Message{To: "user@example.com", Subject: "Hello", Body: "body"}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.True(t, strings.HasSuffix(entries[0].Name(), ".eml"))
+
+	raw, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "Subject: Hello\r\n")
+}
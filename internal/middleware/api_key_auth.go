@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+// ApiKeyAuthMiddleware authenticates requests sent with "Authorization: ApiKey <key>".
+// Other requests pass through untouched so UserAuthMiddleware can handle them. On
+// success it stores claims for the key's owner, so ClaimsAuthMiddleware works as usual,
+// plus the key ID and scopes for RequireScope.
+func (m *Middleware) ApiKeyAuthMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if m.APIKeys == nil || !strings.HasPrefix(ctx.GetHeader("Authorization"), "ApiKey ") {
+			ctx.Next()
+			return
+		}
+
+		key, err := m.tokenExtractor.GetAPIKey(ctx.Request.Header)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		principal, err := m.APIKeys.AuthenticateAPIKey(ctx.Request.Context(), key)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidAPIKey) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error": "invalid api key",
+				})
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error": "unable to verify api key",
+			})
+			return
+		}
+
+		ctx.Set("claims", &jwt.RegisteredClaims{Subject: principal.UserID.String()})
+		ctx.Set(string(constants.APIKeyIDKey), principal.KeyID)
+		ctx.Set(string(constants.ScopesKey), principal.Scopes)
+		ctx.Next()
+	}
+}
+
+// RequireScope rejects API key requests whose key lacks scope. Requests authenticated
+// with a user access token are not scope limited.
+func (m *Middleware) RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, ok := ctx.Get(string(constants.ScopesKey))
+		if !ok {
+			ctx.Next()
+			return
+		}
+		scopes, _ := value.([]string)
+		for _, s := range scopes {
+			if s == scope {
+				ctx.Next()
+				return
+			}
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "insufficient scope",
+		})
+	}
+}
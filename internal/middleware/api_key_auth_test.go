@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKeyAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenGen := auth.NewRealTokenGenerator("dummysecret", models.TokenType("dummytype"))
+	userID := uuid.New()
+	jwtToken, err := tokenGen.MakeJWT(userID, uuid.New(), time.Hour)
+	require.NoError(t, err)
+	restrictedToken, err := tokenGen.MakeRestrictedJWT(userID, time.Hour)
+	require.NoError(t, err)
+
+	readOnly := models.APIKeyPrincipal{KeyID: "key-1", UserID: userID, Scopes: []string{auth.ScopeTransactionsRead}}
+
+	tests := []struct {
+		name           string
+		method         string
+		authHeader     string
+		principal      models.APIKeyPrincipal
+		authErr        error
+		expectsAuth    bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "read scope allows GET",
+			method:         http.MethodGet,
+			authHeader:     "ApiKey uw_abc_def",
+			principal:      readOnly,
+			expectsAuth:    true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"user_id":"` + userID.String() + `"}`,
+		},
+		{
+			name:           "read scope cannot POST",
+			method:         http.MethodPost,
+			authHeader:     "ApiKey uw_abc_def",
+			principal:      readOnly,
+			expectsAuth:    true,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"insufficient scope"}`,
+		},
+		{
+			name:           "invalid key",
+			method:         http.MethodGet,
+			authHeader:     "ApiKey uw_abc_bad",
+			authErr:        auth.ErrInvalidAPIKey,
+			expectsAuth:    true,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"invalid api key"}`,
+		},
+		{
+			name:           "lookup failure",
+			method:         http.MethodGet,
+			authHeader:     "ApiKey uw_abc_def",
+			authErr:        errors.New("database is locked"),
+			expectsAuth:    true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"error":"unable to verify api key"}`,
+		},
+		{
+			name:           "empty key",
+			method:         http.MethodGet,
+			authHeader:     "ApiKey ",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"malformed authorization header"}`,
+		},
+		{
+			name:           "bearer token is not scope limited",
+			method:         http.MethodPost,
+			authHeader:     "Bearer " + jwtToken,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"user_id":"` + userID.String() + `"}`,
+		},
+		{
+			name:           "restricted bearer token is refused",
+			method:         http.MethodGet,
+			authHeader:     "Bearer " + restrictedToken,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"email not verified"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			apiKeys := authmocks.NewAPIKeyAuthenticator(t)
+			if tc.expectsAuth {
+				apiKeys.On("AuthenticateAPIKey", mock.Anything, mock.AnythingOfType("string")).Return(tc.principal, tc.authErr).Once()
+			}
+
+			m := NewMiddleware(tokenGen, auth.NewRealTokenExtractor(), auth.NewMemoryTokenDenylist(time.Hour))
+			m.APIKeys = apiKeys
+
+			router := gin.New()
+			router.Use(m.ApiKeyAuthMiddleware(), m.UserAuthMiddleware(), m.ClaimsAuthMiddleware())
+			respond := func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"user_id": c.MustGet(string(constants.UserIDKey))})
+			}
+			router.GET("/transactions", m.RequireScope(auth.ScopeTransactionsRead), respond)
+			router.POST("/transactions", m.RequireScope(auth.ScopeTransactionsWrite), respond)
+
+			req := httptest.NewRequest(tc.method, "/transactions", nil).WithContext(context.Background())
+			req.Header.Set("Authorization", tc.authHeader)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expectedStatus, rr.Code)
+			require.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestApiKeyAuthMiddlewareDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(nil, auth.NewRealTokenExtractor(), nil)
+	router := gin.New()
+	router.GET("/transactions", m.ApiKeyAuthMiddleware(), m.UserAuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("Authorization", "ApiKey uw_abc_def")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
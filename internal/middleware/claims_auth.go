@@ -8,8 +8,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/seanhuebl/unity-wealth/internal/constants"
 	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
 )
 
+// ClaimsAuthMiddleware puts the caller's claims and user ID in the context. Restricted
+// tokens, issued to users who have not verified their email, are refused.
 func (m *Middleware) ClaimsAuthMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		claims, err := helpers.ValidateClaims(ctx)
@@ -19,6 +22,12 @@ func (m *Middleware) ClaimsAuthMiddleware() gin.HandlerFunc {
 			})
 			return
 		}
+		if auth.IsRestricted(claims) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "email not verified",
+			})
+			return
+		}
 
 		userID, err := uuid.Parse(claims.Subject)
 		if err != nil {
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/idempotency"
+)
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+	// idempotencyTTL is how long a finished response can be replayed.
+	idempotencyTTL = 24 * time.Hour
+	// idempotencyWait is how long a duplicate waits for the first request to finish.
+	idempotencyWait     = 10 * time.Second
+	idempotencyPollStep = 50 * time.Millisecond
+	// maxIdempotentBodyBytes is the largest body any route takes, an import upload
+	// with its multipart overhead. The body is read whole before the handler runs.
+	maxIdempotentBodyBytes = 6 << 20
+)
+
+// idempotencyLockTTL bounds how long a crashed request can hold a key. A running
+// request renews its claim every half TTL, so slow handlers keep it.
+var idempotencyLockTTL = time.Minute
+
+// IdempotencyMiddleware makes POST and PATCH requests that carry an Idempotency-Key
+// header safe to retry. The first request with a key runs and its response is kept
+// for 24 hours; later requests with the same key and body get that response back,
+// with its ETag and Location and with Idempotent-Replayed set, and ones with a
+// different body are rejected with 422. A duplicate that arrives while the first is
+// still running waits for it. Keys are scoped to the user set by
+// ClaimsAuthMiddleware. Responses with a 5xx status are not kept, so the request can
+// be retried. Bodies over maxIdempotentBodyBytes are rejected with 413. It is a no-op
+// when no store is configured.
+func (m *Middleware) IdempotencyMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		method := ctx.Request.Method
+		if m.Idempotency == nil || key == "" || (method != http.MethodPost && method != http.MethodPatch) {
+			ctx.Next()
+			return
+		}
+		if !validIdempotencyKey(key) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "Idempotency-Key must be 1-255 printable ASCII characters",
+			})
+			return
+		}
+		userID, ok := ctx.Get(string(constants.UserIDKey))
+		if !ok {
+			ctx.Next()
+			return
+		}
+		uid, ok := userID.(uuid.UUID)
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxIdempotentBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": "request body too large",
+			})
+			return
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "unable to read request body",
+			})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := uid.String() + ":" + key
+		fingerprint := requestFingerprint(method, ctx.Request.URL.Path, body)
+		reqCtx := ctx.Request.Context()
+
+		deadline := time.Now().Add(idempotencyWait)
+		for {
+			existing, err := m.Idempotency.Begin(reqCtx, storeKey, fingerprint, idempotencyLockTTL)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"error": "unable to process Idempotency-Key",
+				})
+				return
+			}
+			if existing == nil {
+				break
+			}
+			if existing.Fingerprint != fingerprint {
+				ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+					"error": "Idempotency-Key was already used for a different request",
+				})
+				return
+			}
+			if existing.Done() {
+				ctx.Header("Idempotent-Replayed", "true")
+				if existing.ETag != "" {
+					ctx.Header("ETag", existing.ETag)
+				}
+				if existing.Location != "" {
+					ctx.Header("Location", existing.Location)
+				}
+				ctx.Data(existing.Status, existing.ContentType, existing.Body)
+				ctx.Abort()
+				return
+			}
+			if time.Now().After(deadline) {
+				ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{
+					"error": "a request with this Idempotency-Key is still in progress",
+				})
+				return
+			}
+			select {
+			case <-reqCtx.Done():
+				ctx.Abort()
+				return
+			case <-time.After(idempotencyPollStep):
+			}
+		}
+
+		writer := &capturingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		// The handler has run by the time the record is written, so a client that hangs
+		// up must not keep its response from being stored.
+		storeCtx := context.WithoutCancel(reqCtx)
+		completed := false
+		// Release the key if a handler panics so the client can retry.
+		defer func() {
+			if !completed {
+				_ = m.Idempotency.Release(storeCtx, storeKey)
+			}
+		}()
+		stopRenewing := m.renewIdempotencyClaim(storeCtx, storeKey, fingerprint)
+		defer stopRenewing()
+
+		ctx.Next()
+		stopRenewing()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		err = m.Idempotency.Complete(storeCtx, storeKey, idempotency.Record{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: writer.Header().Get("Content-Type"),
+			ETag:        writer.Header().Get("ETag"),
+			Location:    writer.Header().Get("Location"),
+			Body:        writer.body.Bytes(),
+		}, idempotencyTTL)
+		completed = err == nil
+	}
+}
+
+// renewIdempotencyClaim extends the claim on key every half lock TTL until the
+// returned function is called. The function waits for a renewal in flight, so none
+// lands after the response is stored.
+func (m *Middleware) renewIdempotencyClaim(ctx context.Context, key, fingerprint string) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(idempotencyLockTTL / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = m.Idempotency.Extend(ctx, key, fingerprint, idempotencyLockTTL)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// capturingWriter keeps a copy of the response body for replays.
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
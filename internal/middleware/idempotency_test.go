@@ -0,0 +1,296 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/idempotency"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotencyRouter(t *testing.T, userID uuid.UUID, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	return newIdempotencyRouterWithStore(t, userID, idempotency.NewMemoryStore(), handler)
+}
+
+func newIdempotencyRouterWithStore(t *testing.T, userID uuid.UUID, store idempotency.Store, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(nil, nil, nil)
+	m.Idempotency = store
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), userID)
+		c.Next()
+	})
+	router.Use(m.IdempotencyMiddleware())
+	router.POST("/transactions", handler)
+	router.PUT("/transactions", handler)
+	return router
+}
+
+func idempotentRequest(router *gin.Engine, method, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/transactions", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	userID := uuid.New()
+	var calls atomic.Int64
+	router := newIdempotencyRouter(t, userID, func(c *gin.Context) {
+		var req map[string]interface{}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid"})
+			return
+		}
+		n := calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"call": n, "merchant": req["merchant"]})
+	})
+
+	first := idempotentRequest(router, http.MethodPost, "key-1", `{"merchant": "costco"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	require.JSONEq(t, `{"call": 1, "merchant": "costco"}`, first.Body.String())
+
+	replay := idempotentRequest(router, http.MethodPost, "key-1", `{"merchant": "costco"}`)
+	require.Equal(t, http.StatusCreated, replay.Code)
+	require.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, first.Header().Get("Content-Type"), replay.Header().Get("Content-Type"))
+	require.JSONEq(t, first.Body.String(), replay.Body.String())
+
+	mismatch := idempotentRequest(router, http.MethodPost, "key-1", `{"merchant": "target"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+	require.JSONEq(t, `{"error": "Idempotency-Key was already used for a different request"}`, mismatch.Body.String())
+
+	other := idempotentRequest(router, http.MethodPost, "key-2", `{"merchant": "target"}`)
+	require.Equal(t, http.StatusCreated, other.Code)
+	require.JSONEq(t, `{"call": 2, "merchant": "target"}`, other.Body.String())
+
+	noKey := idempotentRequest(router, http.MethodPost, "", `{"merchant": "costco"}`)
+	require.JSONEq(t, `{"call": 3, "merchant": "costco"}`, noKey.Body.String())
+
+	// PUT is idempotent already, so the header is ignored.
+	put := idempotentRequest(router, http.MethodPut, "key-1", `{"merchant": "costco"}`)
+	require.JSONEq(t, `{"call": 4, "merchant": "costco"}`, put.Body.String())
+
+	// A client error is stored and replayed as well.
+	bad := idempotentRequest(router, http.MethodPost, "key-3", `{`)
+	require.Equal(t, http.StatusBadRequest, bad.Code)
+	bad = idempotentRequest(router, http.MethodPost, "key-3", `{`)
+	require.Equal(t, http.StatusBadRequest, bad.Code)
+	require.Equal(t, "true", bad.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, int64(4), calls.Load())
+}
+
+func TestIdempotencyMiddlewareScopesKeysByUser(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	m := NewMiddleware(nil, nil, nil)
+	m.Idempotency = store
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int64
+	router := gin.New()
+	router.POST("/transactions/:user", func(c *gin.Context) {
+		c.Set(string(constants.UserIDKey), uuid.MustParse(c.Param("user")))
+	}, m.IdempotencyMiddleware(), func(c *gin.Context) {
+		calls.Add(1)
+		c.Status(http.StatusCreated)
+	})
+
+	for _, user := range []uuid.UUID{uuid.New(), uuid.New()} {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/"+user.String(), nil)
+		req.Header.Set(IdempotencyKeyHeader, "shared")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Empty(t, rr.Header().Get("Idempotent-Replayed"))
+	}
+	require.Equal(t, int64(2), calls.Load())
+}
+
+func TestIdempotencyMiddlewareInvalidKey(t *testing.T) {
+	router := newIdempotencyRouter(t, uuid.New(), func(c *gin.Context) {
+		t.Fatal("handler should not run")
+	})
+
+	for _, key := range []string{strings.Repeat("k", 256), "tab\tkey", "ключ"} {
+		rr := idempotentRequest(router, http.MethodPost, key, `{}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code, key)
+	}
+}
+
+func TestIdempotencyMiddlewareServerErrorIsNotStored(t *testing.T) {
+	var calls atomic.Int64
+	router := newIdempotencyRouter(t, uuid.New(), func(c *gin.Context) {
+		if calls.Add(1) == 1 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	require.Equal(t, http.StatusInternalServerError, idempotentRequest(router, http.MethodPost, "key", `{}`).Code)
+	retry := idempotentRequest(router, http.MethodPost, "key", `{}`)
+	require.Equal(t, http.StatusCreated, retry.Code)
+	require.Empty(t, retry.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, int64(2), calls.Load())
+}
+
+func TestIdempotencyMiddlewareSerializesDuplicates(t *testing.T) {
+	var calls atomic.Int64
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := newIdempotencyRouter(t, uuid.New(), func(c *gin.Context) {
+		calls.Add(1)
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{"id": "tx-1"})
+	})
+
+	const duplicates = 5
+	responses := make([]*httptest.ResponseRecorder, duplicates+1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		responses[0] = idempotentRequest(router, http.MethodPost, "key", `{}`)
+	}()
+	<-started
+	for i := 1; i <= duplicates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = idempotentRequest(router, http.MethodPost, "key", `{}`)
+		}(i)
+	}
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int64(1), calls.Load())
+	for _, rr := range responses {
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.JSONEq(t, `{"id": "tx-1"}`, rr.Body.String())
+	}
+}
+
+func TestIdempotencyMiddlewareReplaysHeaders(t *testing.T) {
+	router := newIdempotencyRouter(t, uuid.New(), func(c *gin.Context) {
+		c.Header("ETag", `"1"`)
+		c.Header("Location", "/transactions/tx-1")
+		c.Header("X-Request-Only", "yes")
+		c.JSON(http.StatusCreated, gin.H{"id": "tx-1"})
+	})
+
+	require.Equal(t, http.StatusCreated, idempotentRequest(router, http.MethodPost, "key", `{}`).Code)
+	replay := idempotentRequest(router, http.MethodPost, "key", `{}`)
+	require.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, `"1"`, replay.Header().Get("ETag"))
+	require.Equal(t, "/transactions/tx-1", replay.Header().Get("Location"))
+	require.Empty(t, replay.Header().Get("X-Request-Only"))
+}
+
+func TestIdempotencyMiddlewareStoresAfterClientHangsUp(t *testing.T) {
+	var calls atomic.Int64
+	router := newIdempotencyRouterWithStore(t, uuid.New(), contextStore{idempotency.NewMemoryStore()}, func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"id": "tx-1"})
+	})
+
+	// The client goes away once the handler has written its response.
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(`{}`)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, "key")
+	router.ServeHTTP(&cancelingRecorder{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}, req)
+
+	replay := idempotentRequest(router, http.MethodPost, "key", `{}`)
+	require.Equal(t, http.StatusCreated, replay.Code)
+	require.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, int64(1), calls.Load())
+}
+
+func TestIdempotencyMiddlewareRejectsLargeBody(t *testing.T) {
+	var calls atomic.Int64
+	router := newIdempotencyRouter(t, uuid.New(), func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"id": "tx-1"})
+	})
+
+	rr := idempotentRequest(router, http.MethodPost, "key", strings.Repeat("a", maxIdempotentBodyBytes+1))
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	require.Zero(t, calls.Load())
+}
+
+func TestIdempotencyMiddlewareRenewsClaim(t *testing.T) {
+	defer func(ttl time.Duration) { idempotencyLockTTL = ttl }(idempotencyLockTTL)
+	idempotencyLockTTL = 40 * time.Millisecond
+
+	userID := uuid.New()
+	store := idempotency.NewMemoryStore()
+	var duplicate *idempotency.Record
+	router := newIdempotencyRouterWithStore(t, userID, store, func(c *gin.Context) {
+		// A slow handler outlives several lock TTLs.
+		time.Sleep(5 * idempotencyLockTTL)
+		var err error
+		duplicate, err = store.Begin(c.Request.Context(), userID.String()+":key", "other", time.Minute)
+		require.NoError(t, err)
+		c.JSON(http.StatusCreated, gin.H{"id": "tx-1"})
+	})
+
+	require.Equal(t, http.StatusCreated, idempotentRequest(router, http.MethodPost, "key", `{}`).Code)
+	require.NotNil(t, duplicate, "the claim expired while the handler ran")
+	require.False(t, duplicate.Done())
+
+	// The stored response keeps its own TTL.
+	time.Sleep(2 * idempotencyLockTTL)
+	replay := idempotentRequest(router, http.MethodPost, "key", `{}`)
+	require.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+}
+
+// contextStore fails like a network store does once the context is done.
+type contextStore struct {
+	*idempotency.MemoryStore
+}
+
+func (s contextStore) Complete(ctx context.Context, key string, rec idempotency.Record, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryStore.Complete(ctx, key, rec, ttl)
+}
+
+func (s contextStore) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryStore.Release(ctx, key)
+}
+
+// cancelingRecorder cancels the request context as soon as the response is written.
+type cancelingRecorder struct {
+	*httptest.ResponseRecorder
+	cancel context.CancelFunc
+}
+
+func (r *cancelingRecorder) Write(b []byte) (int, error) {
+	defer r.cancel()
+	return r.ResponseRecorder.Write(b)
+}
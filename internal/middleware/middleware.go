@@ -1,14 +1,25 @@
 package middleware
 
 import (
+	"github.com/seanhuebl/unity-wealth/internal/idempotency"
 	"github.com/seanhuebl/unity-wealth/internal/services/auth"
 )
 
 type Middleware struct {
 	tokenGen       auth.TokenGenerator
 	tokenExtractor auth.TokenExtractor
+	denylist       auth.TokenDenylist
+	// SignupLimiter caps signups per client IP. Nil disables the limit.
+	SignupLimiter *auth.RateLimiter
+	// ForgotPasswordLimiter caps password reset requests per client IP. Nil disables
+	// the limit.
+	ForgotPasswordLimiter *auth.RateLimiter
+	// APIKeys authenticates "Authorization: ApiKey" requests. Nil disables API keys.
+	APIKeys auth.APIKeyAuthenticator
+	// Idempotency stores responses for Idempotency-Key replays. Nil disables the header.
+	Idempotency idempotency.Store
 }
 
-func NewMiddleware(tokenGen auth.TokenGenerator, tokenExtractor auth.TokenExtractor) *Middleware {
-	return &Middleware{tokenGen: tokenGen, tokenExtractor: tokenExtractor}
+func NewMiddleware(tokenGen auth.TokenGenerator, tokenExtractor auth.TokenExtractor, denylist auth.TokenDenylist) *Middleware {
+	return &Middleware{tokenGen: tokenGen, tokenExtractor: tokenExtractor, denylist: denylist}
 }
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
+)
+
+// PaginationMiddleware reads the page_size and cursor query parameters into the gin
+// context under PageSizeKey (int) and CursorKey (string). page_size defaults to
+// defaultSize and is capped at maxSize. The cursor is passed through as is; the handler
+// that issued it verifies it.
+func (m *Middleware) PaginationMiddleware(defaultSize, maxSize int) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		pageSize := defaultSize
+		if raw := ctx.Query("page_size"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 {
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": "invalid page_size; must be > 0",
+				})
+				return
+			}
+			pageSize = n
+		}
+		if pageSize > maxSize {
+			pageSize = maxSize
+		}
+
+		cursor := ctx.Query("cursor")
+		if len(cursor) > pagination.MaxCursorLength {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "invalid cursor",
+			})
+			return
+		}
+
+		ctx.Set(string(constants.PageSizeKey), pageSize)
+		ctx.Set(string(constants.CursorKey), cursor)
+		ctx.Next()
+	}
+}
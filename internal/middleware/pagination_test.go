@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedPageSize int
+		expectedCursor   string
+		expectedBody     string
+	}{
+		{name: "defaults", query: "", expectedStatus: http.StatusOK, expectedPageSize: 20},
+		{name: "page size and cursor", query: "?page_size=5&cursor=abc", expectedStatus: http.StatusOK, expectedPageSize: 5, expectedCursor: "abc"},
+		{name: "page size capped", query: "?page_size=500", expectedStatus: http.StatusOK, expectedPageSize: 100},
+		{name: "page size zero", query: "?page_size=0", expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"invalid page_size; must be > 0"}`},
+		{name: "page size not a number", query: "?page_size=ten", expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"invalid page_size; must be > 0"}`},
+		{name: "cursor too long", query: "?cursor=" + strings.Repeat("A", 2049), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"invalid cursor"}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMiddleware(nil, nil, nil)
+			router := gin.New()
+			router.GET("/items", m.PaginationMiddleware(20, 100), func(c *gin.Context) {
+				require.Equal(t, tc.expectedPageSize, c.GetInt(string(constants.PageSizeKey)))
+				require.Equal(t, tc.expectedCursor, c.GetString(string(constants.CursorKey)))
+				c.Status(http.StatusOK)
+			})
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items"+tc.query, nil))
+			require.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
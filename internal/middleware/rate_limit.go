@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+)
+
+// SignupRateLimitMiddleware limits signups per client IP with SignupLimiter. It is a
+// no-op when no limiter is configured.
+func (m *Middleware) SignupRateLimitMiddleware() gin.HandlerFunc {
+	return clientIPRateLimit(m.SignupLimiter)
+}
+
+// ForgotPasswordRateLimitMiddleware limits password reset requests per client IP with
+// ForgotPasswordLimiter. It is a no-op when no limiter is configured.
+func (m *Middleware) ForgotPasswordRateLimitMiddleware() gin.HandlerFunc {
+	return clientIPRateLimit(m.ForgotPasswordLimiter)
+}
+
+func clientIPRateLimit(limiter *auth.RateLimiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if limiter == nil {
+			ctx.Next()
+			return
+		}
+
+		err := limiter.Allow(ctx.Request.Context(), ctx.ClientIP())
+		var retryErr *auth.RetryAfterError
+		if errors.As(err, &retryErr) {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "too many requests",
+			})
+			return
+		}
+		// Let requests through if the limiter itself is failing.
+		ctx.Next()
+	}
+}
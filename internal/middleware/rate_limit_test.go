@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignupRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(nil, nil, nil)
+	m.SignupLimiter = auth.NewRateLimiter(auth.NewMemoryAttemptStore(), "signup", 2, time.Hour)
+
+	router := gin.New()
+	router.POST("/signup", m.SignupRateLimitMiddleware(), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"message": "created"})
+	})
+
+	signup := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/signup", nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	require.Equal(t, http.StatusCreated, signup("203.0.113.7:1000").Code)
+	require.Equal(t, http.StatusCreated, signup("203.0.113.7:1001").Code)
+
+	rr := signup("203.0.113.7:1002")
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	require.Equal(t, "3600", rr.Header().Get("Retry-After"))
+	require.JSONEq(t, `{"error":"too many requests"}`, rr.Body.String())
+
+	require.Equal(t, http.StatusCreated, signup("198.51.100.1:1000").Code)
+}
+
+func TestSignupRateLimitMiddlewareDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(nil, nil, nil)
+	router := gin.New()
+	router.POST("/signup", m.SignupRateLimitMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	for i := 0; i < 10; i++ {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/signup", nil))
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+}
+
+func TestForgotPasswordRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(nil, nil, nil)
+	m.ForgotPasswordLimiter = auth.NewRateLimiter(auth.NewMemoryAttemptStore(), "password_reset:ip", 1, time.Hour)
+
+	router := gin.New()
+	router.POST("/password/forgot", m.ForgotPasswordRateLimitMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+	})
+
+	forgot := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/password/forgot", nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	require.Equal(t, http.StatusAccepted, forgot("203.0.113.7:1000").Code)
+
+	rr := forgot("203.0.113.7:1001")
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	require.Equal(t, "3600", rr.Header().Get("Retry-After"))
+
+	require.Equal(t, http.StatusAccepted, forgot("198.51.100.1:1000").Code)
+}
+
+func TestRequestContextMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(nil, nil, nil)
+	router := gin.New()
+	router.Use(m.RequestContextMiddleware())
+	router.GET("/test", func(c *gin.Context) {
+		req, err := helpers.GetRequestFromContext(c.Request.Context())
+		require.NoError(t, err)
+		require.Equal(t, "/test", req.URL.Path)
+		require.Equal(t, "203.0.113.7", helpers.GetClientIP(c.Request.Context()))
+		require.Equal(t, "203.0.113.7", c.Request.Context().Value(constants.ClientIPKey))
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+}
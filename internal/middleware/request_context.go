@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+)
+
+// RequestContextMiddleware stores the request and client IP in the standard context
+// for services that run outside ClaimsAuthMiddleware, such as login.
+func (m *Middleware) RequestContextMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqCtx := context.WithValue(ctx.Request.Context(), constants.ClientIPKey, ctx.ClientIP())
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), constants.RequestKey, ctx.Request))
+		ctx.Next()
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
 )
 
 func (m *Middleware) UserAuthMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		// Already authenticated by ApiKeyAuthMiddleware.
+		if _, ok := ctx.Get(string(constants.APIKeyIDKey)); ok {
+			ctx.Next()
+			return
+		}
 
 		token, err := m.tokenExtractor.GetBearerToken(ctx.Request.Header)
 		if err != nil {
@@ -23,8 +29,21 @@ func (m *Middleware) UserAuthMiddleware() gin.HandlerFunc {
 			})
 			return
 		}
+		denied, err := m.denylist.IsDenied(ctx.Request.Context(), claims)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error": "unable to verify token",
+			})
+			return
+		}
+		if denied {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "invalid token",
+			})
+			return
+		}
 
-		ctx.Set("claims", claims)
+		ctx.Set("claims", &claims.RegisteredClaims)
 		ctx.Next()
 	}
 }
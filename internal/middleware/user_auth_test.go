@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -18,12 +19,24 @@ func TestUserAuthMiddleware_TableDriven(t *testing.T) {
 
 	tokenGen := auth.NewRealTokenGenerator("dummysecret", models.TokenType("dummytype"))
 	tokenExtractor := auth.NewRealTokenExtractor()
+	denylist := auth.NewMemoryTokenDenylist(time.Hour)
 
 	testUserID := uuid.New()
-	validToken, err := tokenGen.MakeJWT(testUserID, time.Hour)
+	validToken, err := tokenGen.MakeJWT(testUserID, uuid.New(), time.Hour)
 	if err != nil {
 		t.Fatalf("failed to generate valid token: %v", err)
 	}
+	revokedToken, err := tokenGen.MakeJWT(testUserID, uuid.New(), time.Hour)
+	if err != nil {
+		t.Fatalf("failed to generate revoked token: %v", err)
+	}
+	revokedClaims, err := tokenGen.ValidateJWT(revokedToken)
+	if err != nil {
+		t.Fatalf("failed to validate revoked token: %v", err)
+	}
+	if err := denylist.DenyToken(context.Background(), &revokedClaims.RegisteredClaims); err != nil {
+		t.Fatalf("failed to deny token: %v", err)
+	}
 
 	// Define test cases.
 	tests := []struct {
@@ -44,6 +57,12 @@ func TestUserAuthMiddleware_TableDriven(t *testing.T) {
 			expectedStatus: http.StatusUnauthorized,
 			expectedSubstr: `{"error":"invalid token"}`,
 		},
+		{
+			name:           "revoked token",
+			authHeader:     "Bearer " + revokedToken,
+			expectedStatus: http.StatusUnauthorized,
+			expectedSubstr: `{"error":"invalid token"}`,
+		},
 		{
 			name:           "valid token",
 			authHeader:     "Bearer " + validToken,
@@ -58,7 +77,7 @@ func TestUserAuthMiddleware_TableDriven(t *testing.T) {
 			// Create a new Gin engine for this sub-test.
 			router := gin.New()
 
-			m := NewMiddleware(tokenGen, tokenExtractor)
+			m := NewMiddleware(tokenGen, tokenExtractor, denylist)
 			router.Use(m.UserAuthMiddleware())
 
 			// Define a dummy final handler that will return a JSON response if the request passes.
@@ -3,12 +3,15 @@
 package authmocks
 
 import (
-	jwt "github.com/golang-jwt/jwt/v5"
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
 
 	uuid "github.com/google/uuid"
+
+	models "github.com/seanhuebl/unity-wealth/internal/models"
+
+	auth "github.com/seanhuebl/unity-wealth/internal/services/auth"
 )
 
 // TokenGenerator is an autogenerated mock type for the TokenGenerator type
@@ -16,9 +19,27 @@ type TokenGenerator struct {
 	mock.Mock
 }
 
-// MakeJWT provides a mock function with given fields: userID, expiresIn
-func (_m *TokenGenerator) MakeJWT(userID uuid.UUID, expiresIn time.Duration) (string, error) {
-	ret := _m.Called(userID, expiresIn)
+// JWKS provides a mock function with no fields
+func (_m *TokenGenerator) JWKS() models.JWKSet {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 models.JWKSet
+	if rf, ok := ret.Get(0).(func() models.JWKSet); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(models.JWKSet)
+	}
+
+	return r0
+}
+
+// MakeJWT provides a mock function with given fields: userID, sessionID, expiresIn
+func (_m *TokenGenerator) MakeJWT(userID uuid.UUID, sessionID uuid.UUID, expiresIn time.Duration) (string, error) {
+	ret := _m.Called(userID, sessionID, expiresIn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MakeJWT")
@@ -26,17 +47,17 @@ func (_m *TokenGenerator) MakeJWT(userID uuid.UUID, expiresIn time.Duration) (st
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Duration) (string, error)); ok {
-		return rf(userID, expiresIn)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, time.Duration) (string, error)); ok {
+		return rf(userID, sessionID, expiresIn)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Duration) string); ok {
-		r0 = rf(userID, expiresIn)
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, time.Duration) string); ok {
+		r0 = rf(userID, sessionID, expiresIn)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Duration) error); ok {
-		r1 = rf(userID, expiresIn)
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, time.Duration) error); ok {
+		r1 = rf(userID, sessionID, expiresIn)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,24 +93,52 @@ func (_m *TokenGenerator) MakeRefreshToken() (string, error) {
 	return r0, r1
 }
 
+// MakeRestrictedJWT provides a mock function with given fields: userID, expiresIn
+func (_m *TokenGenerator) MakeRestrictedJWT(userID uuid.UUID, expiresIn time.Duration) (string, error) {
+	ret := _m.Called(userID, expiresIn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MakeRestrictedJWT")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Duration) (string, error)); ok {
+		return rf(userID, expiresIn)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Duration) string); ok {
+		r0 = rf(userID, expiresIn)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Duration) error); ok {
+		r1 = rf(userID, expiresIn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateJWT provides a mock function with given fields: tokenString
-func (_m *TokenGenerator) ValidateJWT(tokenString string) (*jwt.RegisteredClaims, error) {
+func (_m *TokenGenerator) ValidateJWT(tokenString string) (*auth.AccessClaims, error) {
 	ret := _m.Called(tokenString)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateJWT")
 	}
 
-	var r0 *jwt.RegisteredClaims
+	var r0 *auth.AccessClaims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*jwt.RegisteredClaims, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (*auth.AccessClaims, error)); ok {
 		return rf(tokenString)
 	}
-	if rf, ok := ret.Get(0).(func(string) *jwt.RegisteredClaims); ok {
+	if rf, ok := ret.Get(0).(func(string) *auth.AccessClaims); ok {
 		r0 = rf(tokenString)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*jwt.RegisteredClaims)
+			r0 = ret.Get(0).(*auth.AccessClaims)
 		}
 	}
 
@@ -7,6 +7,8 @@ import (
 
 	database "github.com/seanhuebl/unity-wealth/internal/database"
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/seanhuebl/unity-wealth/internal/models"
 )
 
 // DeviceQuerier is an autogenerated mock type for the DeviceQuerier type
@@ -42,6 +44,34 @@ func (_m *DeviceQuerier) CreateDeviceInfo(ctx context.Context, arg database.Crea
 	return r0, r1
 }
 
+// GetDeviceInfoByID provides a mock function with given fields: ctx, arg
+func (_m *DeviceQuerier) GetDeviceInfoByID(ctx context.Context, arg database.GetDeviceInfoByIDParams) (models.DeviceInfoLog, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceInfoByID")
+	}
+
+	var r0 models.DeviceInfoLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByIDParams) (models.DeviceInfoLog, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByIDParams) models.DeviceInfoLog); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.DeviceInfoLog)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetDeviceInfoByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeviceInfoByUser provides a mock function with given fields: ctx, arg
 func (_m *DeviceQuerier) GetDeviceInfoByUser(ctx context.Context, arg database.GetDeviceInfoByUserParams) (string, error) {
 	ret := _m.Called(ctx, arg)
@@ -70,6 +100,54 @@ func (_m *DeviceQuerier) GetDeviceInfoByUser(ctx context.Context, arg database.G
 	return r0, r1
 }
 
+// ListUserSessions provides a mock function with given fields: ctx, userID
+func (_m *DeviceQuerier) ListUserSessions(ctx context.Context, userID string) ([]database.ListUserSessionsRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserSessions")
+	}
+
+	var r0 []database.ListUserSessionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]database.ListUserSessionsRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []database.ListUserSessionsRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserSessionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDeviceLastUsed provides a mock function with given fields: ctx, arg
+func (_m *DeviceQuerier) UpdateDeviceLastUsed(ctx context.Context, arg database.UpdateDeviceLastUsedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateDeviceLastUsedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewDeviceQuerier creates a new instance of DeviceQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDeviceQuerier(t interface {
@@ -18,6 +18,24 @@ type SqlTransactionalQuerier struct {
 	mock.Mock
 }
 
+// AddCategoryFeature provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) AddCategoryFeature(ctx context.Context, arg database.AddCategoryFeatureParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCategoryFeature")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.AddCategoryFeatureParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BeginTx provides a mock function with given fields: ctx, opts
 func (_m *SqlTransactionalQuerier) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	ret := _m.Called(ctx, opts)
@@ -39,8 +57,1996 @@ func (_m *SqlTransactionalQuerier) BeginTx(ctx context.Context, opts *sql.TxOpti
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *sql.TxOptions) error); ok {
-		r1 = rf(ctx, opts)
+	if rf, ok := ret.Get(1).(func(context.Context, *sql.TxOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUserRules provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) CountUserRules(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUserRules")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateAPIKey(ctx context.Context, arg database.CreateAPIKeyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateAPIKeyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateDetailedCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateDetailedCategory(ctx context.Context, arg database.CreateDetailedCategoryParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDetailedCategory")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateDetailedCategoryParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateDetailedCategoryParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateDetailedCategoryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDeviceInfo provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateDeviceInfo(ctx context.Context, arg database.CreateDeviceInfoParams) (string, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceInfo")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateDeviceInfoParams) (string, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateDeviceInfoParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateDeviceInfoParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEmailVerificationToken provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateEmailVerificationToken(ctx context.Context, arg database.CreateEmailVerificationTokenParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailVerificationToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateEmailVerificationTokenParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMFAChallenge provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateMFAChallenge(ctx context.Context, arg database.CreateMFAChallengeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMFAChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateMFAChallengeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMFARecoveryCode provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateMFARecoveryCode(ctx context.Context, arg database.CreateMFARecoveryCodeParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMFARecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateMFARecoveryCodeParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMFATrustedDevice provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateMFATrustedDevice(ctx context.Context, arg database.CreateMFATrustedDeviceParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMFATrustedDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateMFATrustedDeviceParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMerchant provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateMerchant(ctx context.Context, arg database.CreateMerchantParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMerchant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateMerchantParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMerchantAlias provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateMerchantAlias(ctx context.Context, arg database.CreateMerchantAliasParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMerchantAlias")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateMerchantAliasParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateMerchantAliasParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateMerchantAliasParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePasswordResetToken provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreatePasswordResetToken(ctx context.Context, arg database.CreatePasswordResetTokenParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreatePasswordResetTokenParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateRefreshToken(ctx context.Context, arg database.CreateRefreshTokenParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateRefreshTokenParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRule provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateRule(ctx context.Context, arg database.CreateRuleParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateRuleParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTransaction provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateTransaction(ctx context.Context, arg database.CreateTransactionParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateTransactionParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTransactionSplit provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateTransactionSplit(ctx context.Context, arg database.CreateTransactionSplitParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransactionSplit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateTransactionSplitParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUser provides a mock function with given fields: ctx, params
+func (_m *SqlTransactionalQuerier) CreateUser(ctx context.Context, params database.CreateUserParams) error {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserParams) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) CreateUserCategory(ctx context.Context, arg database.CreateUserCategoryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserCategoryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCategoryExample provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteCategoryExample(ctx context.Context, arg database.DeleteCategoryExampleParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryExample")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteCategoryExampleParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCategoryModelFeatures provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteCategoryModelFeatures(ctx context.Context, arg database.DeleteCategoryModelFeaturesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryModelFeatures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteCategoryModelFeaturesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEmptyCategoryFeatures provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) DeleteEmptyCategoryFeatures(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmptyCategoryFeatures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteImportMapping provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteImportMapping(ctx context.Context, arg database.DeleteImportMappingParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteImportMapping")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteImportMappingParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteImportMappingParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.DeleteImportMappingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMerchant provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteMerchant(ctx context.Context, arg database.DeleteMerchantParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMerchant")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteMerchantParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteMerchantParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.DeleteMerchantParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRule provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteRule(ctx context.Context, arg database.DeleteRuleParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteRuleParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteRuleParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.DeleteRuleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTransactionByID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteTransactionByID(ctx context.Context, arg database.DeleteTransactionByIDParams) (string, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTransactionByID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteTransactionByIDParams) (string, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteTransactionByIDParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.DeleteTransactionByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTransactionSplits provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DeleteTransactionSplits(ctx context.Context, arg database.DeleteTransactionSplitsParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTransactionSplits")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteTransactionSplitsParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserMFARecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) DeleteUserMFARecoveryCodes(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserMFARecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserMFATrustedDevices provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) DeleteUserMFATrustedDevices(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserMFATrustedDevices")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableUserTOTP provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) DisableUserTOTP(ctx context.Context, arg database.DisableUserTOTPParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableUserTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DisableUserTOTPParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableUserTOTP provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) EnableUserTOTP(ctx context.Context, arg database.EnableUserTOTPParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUserTOTP")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.EnableUserTOTPParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.EnableUserTOTPParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.EnableUserTOTPParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPIKeyByPrefix provides a mock function with given fields: ctx, prefix
+func (_m *SqlTransactionalQuerier) GetAPIKeyByPrefix(ctx context.Context, prefix string) (models.ApiKey, error) {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeyByPrefix")
+	}
+
+	var r0 models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.ApiKey, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ApiKey); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(models.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategoryExample provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetCategoryExample(ctx context.Context, arg database.GetCategoryExampleParams) (models.CategoryModelExample, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryExample")
+	}
+
+	var r0 models.CategoryModelExample
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCategoryExampleParams) (models.CategoryModelExample, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCategoryExampleParams) models.CategoryModelExample); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.CategoryModelExample)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCategoryExampleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDetailedCategories provides a mock function with given fields: ctx
+func (_m *SqlTransactionalQuerier) GetDetailedCategories(ctx context.Context) ([]models.DetailedCategory, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDetailedCategories")
+	}
+
+	var r0 []models.DetailedCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.DetailedCategory, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.DetailedCategory); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DetailedCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDetailedCategoryID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetDetailedCategoryID(ctx context.Context, arg database.GetDetailedCategoryIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDetailedCategoryID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDetailedCategoryIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDetailedCategoryIDParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetDetailedCategoryIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceInfoByID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetDeviceInfoByID(ctx context.Context, arg database.GetDeviceInfoByIDParams) (models.DeviceInfoLog, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceInfoByID")
+	}
+
+	var r0 models.DeviceInfoLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByIDParams) (models.DeviceInfoLog, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByIDParams) models.DeviceInfoLog); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.DeviceInfoLog)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetDeviceInfoByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceInfoByUser provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetDeviceInfoByUser(ctx context.Context, arg database.GetDeviceInfoByUserParams) (string, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceInfoByUser")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByUserParams) (string, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByUserParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetDeviceInfoByUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailVerificationTokenByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *SqlTransactionalQuerier) GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (models.EmailVerificationToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailVerificationTokenByHash")
+	}
+
+	var r0 models.EmailVerificationToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.EmailVerificationToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.EmailVerificationToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.EmailVerificationToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImportMapping provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetImportMapping(ctx context.Context, arg database.GetImportMappingParams) (models.ImportMapping, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportMapping")
+	}
+
+	var r0 models.ImportMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetImportMappingParams) (models.ImportMapping, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetImportMappingParams) models.ImportMapping); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.ImportMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetImportMappingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestEmailVerificationToken provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) GetLatestEmailVerificationToken(ctx context.Context, userID string) (models.EmailVerificationToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestEmailVerificationToken")
+	}
+
+	var r0 models.EmailVerificationToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.EmailVerificationToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.EmailVerificationToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(models.EmailVerificationToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMFAChallengeByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *SqlTransactionalQuerier) GetMFAChallengeByHash(ctx context.Context, tokenHash string) (models.MfaChallenge, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMFAChallengeByHash")
+	}
+
+	var r0 models.MfaChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.MfaChallenge, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.MfaChallenge); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.MfaChallenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMFATrustedDeviceByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *SqlTransactionalQuerier) GetMFATrustedDeviceByHash(ctx context.Context, tokenHash string) (models.MfaTrustedDevice, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMFATrustedDeviceByHash")
+	}
+
+	var r0 models.MfaTrustedDevice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.MfaTrustedDevice, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.MfaTrustedDevice); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.MfaTrustedDevice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMerchant provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetMerchant(ctx context.Context, arg database.GetMerchantParams) (models.Merchant, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMerchant")
+	}
+
+	var r0 models.Merchant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetMerchantParams) (models.Merchant, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetMerchantParams) models.Merchant); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.Merchant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetMerchantParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMerchantByAlias provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetMerchantByAlias(ctx context.Context, arg database.GetMerchantByAliasParams) (models.Merchant, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMerchantByAlias")
+	}
+
+	var r0 models.Merchant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetMerchantByAliasParams) (models.Merchant, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetMerchantByAliasParams) models.Merchant); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.Merchant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetMerchantByAliasParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPasswordResetTokenByHash provides a mock function with given fields: ctx, tokenHash
+func (_m *SqlTransactionalQuerier) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (models.PasswordResetToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordResetTokenByHash")
+	}
+
+	var r0 models.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.PasswordResetToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.PasswordResetToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.PasswordResetToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrimaryCategories provides a mock function with given fields: ctx
+func (_m *SqlTransactionalQuerier) GetPrimaryCategories(ctx context.Context) ([]models.PrimaryCategory, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPrimaryCategories")
+	}
+
+	var r0 []models.PrimaryCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.PrimaryCategory, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.PrimaryCategory); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PrimaryCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshByUserAndDevice provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetRefreshByUserAndDevice(ctx context.Context, arg database.GetRefreshByUserAndDeviceParams) (models.RefreshToken, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshByUserAndDevice")
+	}
+
+	var r0 models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetRefreshByUserAndDeviceParams) (models.RefreshToken, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetRefreshByUserAndDeviceParams) models.RefreshToken); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetRefreshByUserAndDeviceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefreshTokenByID provides a mock function with given fields: ctx, id
+func (_m *SqlTransactionalQuerier) GetRefreshTokenByID(ctx context.Context, id string) (models.RefreshToken, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenByID")
+	}
+
+	var r0 models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.RefreshToken, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.RefreshToken); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRule provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetRule(ctx context.Context, arg database.GetRuleParams) (models.CategorizationRule, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRule")
+	}
+
+	var r0 models.CategorizationRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetRuleParams) (models.CategorizationRule, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetRuleParams) models.CategorizationRule); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(models.CategorizationRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetRuleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionIDByExternalID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetTransactionIDByExternalID(ctx context.Context, arg database.GetTransactionIDByExternalIDParams) (string, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionIDByExternalID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetTransactionIDByExternalIDParams) (string, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetTransactionIDByExternalIDParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetTransactionIDByExternalIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *SqlTransactionalQuerier) GetUserByEmail(ctx context.Context, email string) (database.GetUserByEmailRow, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 database.GetUserByEmailRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.GetUserByEmailRow, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.GetUserByEmailRow); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(database.GetUserByEmailRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserMFAByID provides a mock function with given fields: ctx, id
+func (_m *SqlTransactionalQuerier) GetUserMFAByID(ctx context.Context, id string) (database.GetUserMFAByIDRow, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMFAByID")
+	}
+
+	var r0 database.GetUserMFAByIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.GetUserMFAByIDRow, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.GetUserMFAByIDRow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.GetUserMFAByIDRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserTransactionByID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) GetUserTransactionByID(ctx context.Context, arg database.GetUserTransactionByIDParams) (database.GetUserTransactionByIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserTransactionByID")
+	}
+
+	var r0 database.GetUserTransactionByIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionByIDParams) (database.GetUserTransactionByIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionByIDParams) database.GetUserTransactionByIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GetUserTransactionByIDRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetUserTransactionByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementMFAChallengeAttempts provides a mock function with given fields: ctx, id
+func (_m *SqlTransactionalQuerier) IncrementMFAChallengeAttempts(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementMFAChallengeAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateUserEmailVerificationTokens provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) InvalidateUserEmailVerificationTokens(ctx context.Context, arg database.InvalidateUserEmailVerificationTokensParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateUserEmailVerificationTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.InvalidateUserEmailVerificationTokensParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateUserPasswordResetTokens provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) InvalidateUserPasswordResetTokens(ctx context.Context, arg database.InvalidateUserPasswordResetTokensParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateUserPasswordResetTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.InvalidateUserPasswordResetTokensParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LinkTransactionMerchant provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) LinkTransactionMerchant(ctx context.Context, arg database.LinkTransactionMerchantParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkTransactionMerchant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.LinkTransactionMerchantParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListCategoryFeatures provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListCategoryFeatures(ctx context.Context, userID string) ([]models.CategoryModelFeature, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategoryFeatures")
+	}
+
+	var r0 []models.CategoryModelFeature
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.CategoryModelFeature, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.CategoryModelFeature); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CategoryModelFeature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListImportMappings provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListImportMappings(ctx context.Context, userID string) ([]models.ImportMapping, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListImportMappings")
+	}
+
+	var r0 []models.ImportMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ImportMapping, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ImportMapping); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ImportMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMerchantAliases provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListMerchantAliases(ctx context.Context, userID string) ([]models.MerchantAlias, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMerchantAliases")
+	}
+
+	var r0 []models.MerchantAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.MerchantAlias, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.MerchantAlias); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MerchantAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTransactionSplits provides a mock function with given fields: ctx, transactionID
+func (_m *SqlTransactionalQuerier) ListTransactionSplits(ctx context.Context, transactionID string) ([]models.TransactionSplit, error) {
+	ret := _m.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactionSplits")
+	}
+
+	var r0 []models.TransactionSplit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.TransactionSplit, error)); ok {
+		return rf(ctx, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.TransactionSplit); ok {
+		r0 = rf(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransactionSplit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnlearnedTransactions provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ListUnlearnedTransactions(ctx context.Context, arg database.ListUnlearnedTransactionsParams) ([]database.ListUnlearnedTransactionsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnlearnedTransactions")
+	}
+
+	var r0 []database.ListUnlearnedTransactionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUnlearnedTransactionsParams) ([]database.ListUnlearnedTransactionsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUnlearnedTransactionsParams) []database.ListUnlearnedTransactionsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUnlearnedTransactionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListUnlearnedTransactionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnlinkedTransactions provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ListUnlinkedTransactions(ctx context.Context, arg database.ListUnlinkedTransactionsParams) ([]database.ListUnlinkedTransactionsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnlinkedTransactions")
+	}
+
+	var r0 []database.ListUnlinkedTransactionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUnlinkedTransactionsParams) ([]database.ListUnlinkedTransactionsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUnlinkedTransactionsParams) []database.ListUnlinkedTransactionsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUnlinkedTransactionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListUnlinkedTransactionsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserAPIKeys provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListUserAPIKeys(ctx context.Context, userID string) ([]models.ApiKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserAPIKeys")
+	}
+
+	var r0 []models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ApiKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ApiKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserCategories provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListUserCategories(ctx context.Context, userID string) ([]database.ListUserCategoriesRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserCategories")
+	}
+
+	var r0 []database.ListUserCategoriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]database.ListUserCategoriesRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []database.ListUserCategoriesRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserCategoriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserMerchants provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListUserMerchants(ctx context.Context, userID string) ([]database.ListUserMerchantsRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserMerchants")
+	}
+
+	var r0 []database.ListUserMerchantsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]database.ListUserMerchantsRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []database.ListUserMerchantsRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserMerchantsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserRules provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListUserRules(ctx context.Context, userID string) ([]models.CategorizationRule, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserRules")
+	}
+
+	var r0 []models.CategorizationRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.CategorizationRule, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.CategorizationRule); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CategorizationRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserSessions provides a mock function with given fields: ctx, userID
+func (_m *SqlTransactionalQuerier) ListUserSessions(ctx context.Context, userID string) ([]database.ListUserSessionsRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserSessions")
+	}
+
+	var r0 []database.ListUserSessionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]database.ListUserSessionsRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []database.ListUserSessionsRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserSessionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserTransactionsNewestFirst provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ListUserTransactionsNewestFirst(ctx context.Context, arg database.ListUserTransactionsNewestFirstParams) ([]database.ListUserTransactionsNewestFirstRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTransactionsNewestFirst")
+	}
+
+	var r0 []database.ListUserTransactionsNewestFirstRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsNewestFirstParams) ([]database.ListUserTransactionsNewestFirstRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsNewestFirstParams) []database.ListUserTransactionsNewestFirstRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserTransactionsNewestFirstRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListUserTransactionsNewestFirstParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserTransactionsOldestFirst provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ListUserTransactionsOldestFirst(ctx context.Context, arg database.ListUserTransactionsOldestFirstParams) ([]database.ListUserTransactionsOldestFirstRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTransactionsOldestFirst")
+	}
+
+	var r0 []database.ListUserTransactionsOldestFirstRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsOldestFirstParams) ([]database.ListUserTransactionsOldestFirstRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsOldestFirstParams) []database.ListUserTransactionsOldestFirstRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserTransactionsOldestFirstRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListUserTransactionsOldestFirstParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkEmailVerificationTokenUsed provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MarkEmailVerificationTokenUsed(ctx context.Context, arg database.MarkEmailVerificationTokenUsedParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkEmailVerificationTokenUsed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkEmailVerificationTokenUsedParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkEmailVerificationTokenUsedParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.MarkEmailVerificationTokenUsedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkMFAChallengeUsed provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MarkMFAChallengeUsed(ctx context.Context, arg database.MarkMFAChallengeUsedParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkMFAChallengeUsed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkMFAChallengeUsedParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkMFAChallengeUsedParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.MarkMFAChallengeUsedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPasswordResetTokenUsed provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MarkPasswordResetTokenUsed(ctx context.Context, arg database.MarkPasswordResetTokenUsedParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPasswordResetTokenUsed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkPasswordResetTokenUsedParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkPasswordResetTokenUsedParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.MarkPasswordResetTokenUsedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkUserEmailVerified provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MarkUserEmailVerified(ctx context.Context, arg database.MarkUserEmailVerifiedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUserEmailVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkUserEmailVerifiedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveCategoryModelExamples provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MoveCategoryModelExamples(ctx context.Context, arg database.MoveCategoryModelExamplesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveCategoryModelExamples")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MoveCategoryModelExamplesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveCategoryModelFeatures provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MoveCategoryModelFeatures(ctx context.Context, arg database.MoveCategoryModelFeaturesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveCategoryModelFeatures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MoveCategoryModelFeaturesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveMerchantAliases provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) MoveMerchantAliases(ctx context.Context, arg database.MoveMerchantAliasesParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveMerchantAliases")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MoveMerchantAliasesParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PatchTransactionByID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) PatchTransactionByID(ctx context.Context, arg database.PatchTransactionByIDParams) (database.PatchTransactionByIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTransactionByID")
+	}
+
+	var r0 database.PatchTransactionByIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.PatchTransactionByIDParams) (database.PatchTransactionByIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.PatchTransactionByIDParams) database.PatchTransactionByIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.PatchTransactionByIDRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.PatchTransactionByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignMerchantCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ReassignMerchantCategory(ctx context.Context, arg database.ReassignMerchantCategoryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignMerchantCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ReassignMerchantCategoryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReassignRuleCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ReassignRuleCategory(ctx context.Context, arg database.ReassignRuleCategoryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignRuleCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ReassignRuleCategoryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReassignSplitCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ReassignSplitCategory(ctx context.Context, arg database.ReassignSplitCategoryParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignSplitCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ReassignSplitCategoryParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReassignTransactionCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) ReassignTransactionCategory(ctx context.Context, arg database.ReassignTransactionCategoryParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignTransactionCategory")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ReassignTransactionCategoryParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ReassignTransactionCategoryParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ReassignTransactionCategoryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenameUserCategory provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) RenameUserCategory(ctx context.Context, arg database.RenameUserCategoryParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameUserCategory")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RenameUserCategoryParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.RenameUserCategoryParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.RenameUserCategoryParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -48,26 +2054,26 @@ func (_m *SqlTransactionalQuerier) BeginTx(ctx context.Context, opts *sql.TxOpti
 	return r0, r1
 }
 
-// CreateDeviceInfo provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) CreateDeviceInfo(ctx context.Context, arg database.CreateDeviceInfoParams) (string, error) {
+// RevokeAPIKey provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) RevokeAPIKey(ctx context.Context, arg database.RevokeAPIKeyParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateDeviceInfo")
+		panic("no return value specified for RevokeAPIKey")
 	}
 
-	var r0 string
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.CreateDeviceInfoParams) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeAPIKeyParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.CreateDeviceInfoParams) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeAPIKeyParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.CreateDeviceInfoParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.RevokeAPIKeyParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -76,16 +2082,16 @@ func (_m *SqlTransactionalQuerier) CreateDeviceInfo(ctx context.Context, arg dat
 	return r0, r1
 }
 
-// CreateRefreshToken provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) CreateRefreshToken(ctx context.Context, arg database.CreateRefreshTokenParams) error {
+// RevokeAllUserTokens provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) RevokeAllUserTokens(ctx context.Context, arg database.RevokeAllUserTokensParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRefreshToken")
+		panic("no return value specified for RevokeAllUserTokens")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.CreateRefreshTokenParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeAllUserTokensParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -94,16 +2100,16 @@ func (_m *SqlTransactionalQuerier) CreateRefreshToken(ctx context.Context, arg d
 	return r0
 }
 
-// CreateTransaction provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) CreateTransaction(ctx context.Context, arg database.CreateTransactionParams) error {
+// RevokeToken provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) RevokeToken(ctx context.Context, arg database.RevokeTokenParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTransaction")
+		panic("no return value specified for RevokeToken")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.CreateTransactionParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -112,75 +2118,93 @@ func (_m *SqlTransactionalQuerier) CreateTransaction(ctx context.Context, arg da
 	return r0
 }
 
-// CreateUser provides a mock function with given fields: ctx, params
-func (_m *SqlTransactionalQuerier) CreateUser(ctx context.Context, params database.CreateUserParams) error {
-	ret := _m.Called(ctx, params)
+// RevokeTokenByID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) RevokeTokenByID(ctx context.Context, arg database.RevokeTokenByIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for RevokeTokenByID")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserParams) error); ok {
-		r0 = rf(ctx, params)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenByIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenByIDParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, database.RevokeTokenByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteTransactionByID provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) DeleteTransactionByID(ctx context.Context, arg database.DeleteTransactionByIDParams) (string, error) {
+// RevokeTokenFamily provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) RevokeTokenFamily(ctx context.Context, arg database.RevokeTokenFamilyParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTransactionByID")
+		panic("no return value specified for RevokeTokenFamily")
 	}
 
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteTransactionByIDParams) (string, error)); ok {
-		return rf(ctx, arg)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteTransactionByIDParams) string); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenFamilyParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.DeleteTransactionByIDParams) error); ok {
-		r1 = rf(ctx, arg)
+	return r0
+}
+
+// SaveCategoryExample provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) SaveCategoryExample(ctx context.Context, arg database.SaveCategoryExampleParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCategoryExample")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.SaveCategoryExampleParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetDetailedCategories provides a mock function with given fields: ctx
-func (_m *SqlTransactionalQuerier) GetDetailedCategories(ctx context.Context) ([]models.DetailedCategory, error) {
-	ret := _m.Called(ctx)
+// SearchUserTransactions provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) SearchUserTransactions(ctx context.Context, arg database.SearchUserTransactionsParams) ([]database.SearchUserTransactionsRow, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDetailedCategories")
+		panic("no return value specified for SearchUserTransactions")
 	}
 
-	var r0 []models.DetailedCategory
+	var r0 []database.SearchUserTransactionsRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]models.DetailedCategory, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, database.SearchUserTransactionsParams) ([]database.SearchUserTransactionsRow, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []models.DetailedCategory); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, database.SearchUserTransactionsParams) []database.SearchUserTransactionsRow); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.DetailedCategory)
+			r0 = ret.Get(0).([]database.SearchUserTransactionsRow)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, database.SearchUserTransactionsParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -188,27 +2212,27 @@ func (_m *SqlTransactionalQuerier) GetDetailedCategories(ctx context.Context) ([
 	return r0, r1
 }
 
-// GetDetailedCategoryID provides a mock function with given fields: ctx, name
-func (_m *SqlTransactionalQuerier) GetDetailedCategoryID(ctx context.Context, name string) (int64, error) {
-	ret := _m.Called(ctx, name)
+// SetUserCategoryArchived provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) SetUserCategoryArchived(ctx context.Context, arg database.SetUserCategoryArchivedParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDetailedCategoryID")
+		panic("no return value specified for SetUserCategoryArchived")
 	}
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, database.SetUserCategoryArchivedParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, database.SetUserCategoryArchivedParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, database.SetUserCategoryArchivedParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -216,57 +2240,81 @@ func (_m *SqlTransactionalQuerier) GetDetailedCategoryID(ctx context.Context, na
 	return r0, r1
 }
 
-// GetDeviceInfoByUser provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) GetDeviceInfoByUser(ctx context.Context, arg database.GetDeviceInfoByUserParams) (string, error) {
+// SetUserTOTPSecret provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) SetUserTOTPSecret(ctx context.Context, arg database.SetUserTOTPSecretParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDeviceInfoByUser")
+		panic("no return value specified for SetUserTOTPSecret")
 	}
 
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByUserParams) (string, error)); ok {
-		return rf(ctx, arg)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.SetUserTOTPSecretParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetDeviceInfoByUserParams) string); ok {
+
+	return r0
+}
+
+// UpdateAPIKeyLastUsed provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateAPIKeyLastUsed(ctx context.Context, arg database.UpdateAPIKeyLastUsedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAPIKeyLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateAPIKeyLastUsedParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetDeviceInfoByUserParams) error); ok {
-		r1 = rf(ctx, arg)
+	return r0
+}
+
+// UpdateDeviceLastUsed provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateDeviceLastUsed(ctx context.Context, arg database.UpdateDeviceLastUsedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateDeviceLastUsedParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetPrimaryCategories provides a mock function with given fields: ctx
-func (_m *SqlTransactionalQuerier) GetPrimaryCategories(ctx context.Context) ([]models.PrimaryCategory, error) {
-	ret := _m.Called(ctx)
+// UpdateMerchant provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateMerchant(ctx context.Context, arg database.UpdateMerchantParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPrimaryCategories")
+		panic("no return value specified for UpdateMerchant")
 	}
 
-	var r0 []models.PrimaryCategory
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]models.PrimaryCategory, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateMerchantParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []models.PrimaryCategory); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateMerchantParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PrimaryCategory)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateMerchantParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -274,26 +2322,26 @@ func (_m *SqlTransactionalQuerier) GetPrimaryCategories(ctx context.Context) ([]
 	return r0, r1
 }
 
-// GetRefreshByUserAndDevice provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) GetRefreshByUserAndDevice(ctx context.Context, arg database.GetRefreshByUserAndDeviceParams) (models.RefreshToken, error) {
+// UpdateMerchantTransactions provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateMerchantTransactions(ctx context.Context, arg database.UpdateMerchantTransactionsParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRefreshByUserAndDevice")
+		panic("no return value specified for UpdateMerchantTransactions")
 	}
 
-	var r0 models.RefreshToken
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetRefreshByUserAndDeviceParams) (models.RefreshToken, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateMerchantTransactionsParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetRefreshByUserAndDeviceParams) models.RefreshToken); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateMerchantTransactionsParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(models.RefreshToken)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetRefreshByUserAndDeviceParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateMerchantTransactionsParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -302,27 +2350,27 @@ func (_m *SqlTransactionalQuerier) GetRefreshByUserAndDevice(ctx context.Context
 	return r0, r1
 }
 
-// GetUserByEmail provides a mock function with given fields: ctx, email
-func (_m *SqlTransactionalQuerier) GetUserByEmail(ctx context.Context, email string) (database.GetUserByEmailRow, error) {
-	ret := _m.Called(ctx, email)
+// UpdateRule provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateRule(ctx context.Context, arg database.UpdateRuleParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByEmail")
+		panic("no return value specified for UpdateRule")
 	}
 
-	var r0 database.GetUserByEmailRow
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (database.GetUserByEmailRow, error)); ok {
-		return rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateRuleParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) database.GetUserByEmailRow); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateRuleParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(database.GetUserByEmailRow)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateRuleParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -330,26 +2378,26 @@ func (_m *SqlTransactionalQuerier) GetUserByEmail(ctx context.Context, email str
 	return r0, r1
 }
 
-// GetUserTransactionByID provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) GetUserTransactionByID(ctx context.Context, arg database.GetUserTransactionByIDParams) (database.GetUserTransactionByIDRow, error) {
+// UpdateTransactionByID provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateTransactionByID(ctx context.Context, arg database.UpdateTransactionByIDParams) (database.UpdateTransactionByIDRow, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserTransactionByID")
+		panic("no return value specified for UpdateTransactionByID")
 	}
 
-	var r0 database.GetUserTransactionByIDRow
+	var r0 database.UpdateTransactionByIDRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionByIDParams) (database.GetUserTransactionByIDRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateTransactionByIDParams) (database.UpdateTransactionByIDRow, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionByIDParams) database.GetUserTransactionByIDRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateTransactionByIDParams) database.UpdateTransactionByIDRow); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(database.GetUserTransactionByIDRow)
+		r0 = ret.Get(0).(database.UpdateTransactionByIDRow)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetUserTransactionByIDParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateTransactionByIDParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -358,28 +2406,44 @@ func (_m *SqlTransactionalQuerier) GetUserTransactionByID(ctx context.Context, a
 	return r0, r1
 }
 
-// GetUserTransactionsFirstPage provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) GetUserTransactionsFirstPage(ctx context.Context, arg database.GetUserTransactionsFirstPageParams) ([]database.GetUserTransactionsFirstPageRow, error) {
+// UpdateUserPassword provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserPasswordParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserTOTPLastStep provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpdateUserTOTPLastStep(ctx context.Context, arg database.UpdateUserTOTPLastStepParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserTransactionsFirstPage")
+		panic("no return value specified for UpdateUserTOTPLastStep")
 	}
 
-	var r0 []database.GetUserTransactionsFirstPageRow
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsFirstPageParams) ([]database.GetUserTransactionsFirstPageRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserTOTPLastStepParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsFirstPageParams) []database.GetUserTransactionsFirstPageRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserTOTPLastStepParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]database.GetUserTransactionsFirstPageRow)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetUserTransactionsFirstPageParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserTOTPLastStepParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -388,28 +2452,26 @@ func (_m *SqlTransactionalQuerier) GetUserTransactionsFirstPage(ctx context.Cont
 	return r0, r1
 }
 
-// GetUserTransactionsPaginated provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) GetUserTransactionsPaginated(ctx context.Context, arg database.GetUserTransactionsPaginatedParams) ([]database.GetUserTransactionsPaginatedRow, error) {
+// UpgradeUserPasswordHash provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpgradeUserPasswordHash(ctx context.Context, arg database.UpgradeUserPasswordHashParams) (int64, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserTransactionsPaginated")
+		panic("no return value specified for UpgradeUserPasswordHash")
 	}
 
-	var r0 []database.GetUserTransactionsPaginatedRow
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsPaginatedParams) ([]database.GetUserTransactionsPaginatedRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpgradeUserPasswordHashParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsPaginatedParams) []database.GetUserTransactionsPaginatedRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpgradeUserPasswordHashParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]database.GetUserTransactionsPaginatedRow)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetUserTransactionsPaginatedParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpgradeUserPasswordHashParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -418,16 +2480,16 @@ func (_m *SqlTransactionalQuerier) GetUserTransactionsPaginated(ctx context.Cont
 	return r0, r1
 }
 
-// RevokeToken provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) RevokeToken(ctx context.Context, arg database.RevokeTokenParams) error {
+// UpsertCategoryOverride provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpsertCategoryOverride(ctx context.Context, arg database.UpsertCategoryOverrideParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RevokeToken")
+		panic("no return value specified for UpsertCategoryOverride")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertCategoryOverrideParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -436,26 +2498,44 @@ func (_m *SqlTransactionalQuerier) RevokeToken(ctx context.Context, arg database
 	return r0
 }
 
-// UpdateTransactionByID provides a mock function with given fields: ctx, arg
-func (_m *SqlTransactionalQuerier) UpdateTransactionByID(ctx context.Context, arg database.UpdateTransactionByIDParams) (database.UpdateTransactionByIDRow, error) {
+// UpsertImportMapping provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UpsertImportMapping(ctx context.Context, arg database.UpsertImportMappingParams) error {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTransactionByID")
+		panic("no return value specified for UpsertImportMapping")
 	}
 
-	var r0 database.UpdateTransactionByIDRow
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertImportMappingParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseMFARecoveryCode provides a mock function with given fields: ctx, arg
+func (_m *SqlTransactionalQuerier) UseMFARecoveryCode(ctx context.Context, arg database.UseMFARecoveryCodeParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseMFARecoveryCode")
+	}
+
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateTransactionByIDParams) (database.UpdateTransactionByIDRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UseMFARecoveryCodeParams) (int64, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateTransactionByIDParams) database.UpdateTransactionByIDRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.UseMFARecoveryCodeParams) int64); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(database.UpdateTransactionByIDRow)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateTransactionByIDParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.UseMFARecoveryCodeParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
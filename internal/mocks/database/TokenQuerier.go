@@ -62,6 +62,52 @@ func (_m *TokenQuerier) GetRefreshByUserAndDevice(ctx context.Context, arg datab
 	return r0, r1
 }
 
+// GetRefreshTokenByID provides a mock function with given fields: ctx, id
+func (_m *TokenQuerier) GetRefreshTokenByID(ctx context.Context, id string) (models.RefreshToken, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshTokenByID")
+	}
+
+	var r0 models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.RefreshToken, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.RefreshToken); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAllUserTokens provides a mock function with given fields: ctx, arg
+func (_m *TokenQuerier) RevokeAllUserTokens(ctx context.Context, arg database.RevokeAllUserTokensParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllUserTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeAllUserTokensParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RevokeToken provides a mock function with given fields: ctx, arg
 func (_m *TokenQuerier) RevokeToken(ctx context.Context, arg database.RevokeTokenParams) error {
 	ret := _m.Called(ctx, arg)
@@ -80,6 +126,52 @@ func (_m *TokenQuerier) RevokeToken(ctx context.Context, arg database.RevokeToke
 	return r0
 }
 
+// RevokeTokenByID provides a mock function with given fields: ctx, arg
+func (_m *TokenQuerier) RevokeTokenByID(ctx context.Context, arg database.RevokeTokenByIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokenByID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenByIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenByIDParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.RevokeTokenByIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeTokenFamily provides a mock function with given fields: ctx, arg
+func (_m *TokenQuerier) RevokeTokenFamily(ctx context.Context, arg database.RevokeTokenFamilyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RevokeTokenFamilyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTokenQuerier creates a new instance of TokenQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenQuerier(t interface {
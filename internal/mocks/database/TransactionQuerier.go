@@ -34,6 +34,24 @@ func (_m *TransactionQuerier) CreateTransaction(ctx context.Context, arg databas
 	return r0
 }
 
+// CreateTransactionSplit provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) CreateTransactionSplit(ctx context.Context, arg database.CreateTransactionSplitParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransactionSplit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateTransactionSplitParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTransactionByID provides a mock function with given fields: ctx, arg
 func (_m *TransactionQuerier) DeleteTransactionByID(ctx context.Context, arg database.DeleteTransactionByIDParams) (string, error) {
 	ret := _m.Called(ctx, arg)
@@ -62,6 +80,24 @@ func (_m *TransactionQuerier) DeleteTransactionByID(ctx context.Context, arg dat
 	return r0, r1
 }
 
+// DeleteTransactionSplits provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) DeleteTransactionSplits(ctx context.Context, arg database.DeleteTransactionSplitsParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTransactionSplits")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteTransactionSplitsParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetDetailedCategories provides a mock function with given fields: ctx
 func (_m *TransactionQuerier) GetDetailedCategories(ctx context.Context) ([]models.DetailedCategory, error) {
 	ret := _m.Called(ctx)
@@ -92,9 +128,9 @@ func (_m *TransactionQuerier) GetDetailedCategories(ctx context.Context) ([]mode
 	return r0, r1
 }
 
-// GetDetailedCategoryID provides a mock function with given fields: ctx, name
-func (_m *TransactionQuerier) GetDetailedCategoryID(ctx context.Context, name string) (int64, error) {
-	ret := _m.Called(ctx, name)
+// GetDetailedCategoryID provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) GetDetailedCategoryID(ctx context.Context, arg database.GetDetailedCategoryIDParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDetailedCategoryID")
@@ -102,17 +138,17 @@ func (_m *TransactionQuerier) GetDetailedCategoryID(ctx context.Context, name st
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDetailedCategoryIDParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetDetailedCategoryIDParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetDetailedCategoryIDParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,6 +186,34 @@ func (_m *TransactionQuerier) GetPrimaryCategories(ctx context.Context) ([]model
 	return r0, r1
 }
 
+// GetTransactionIDByExternalID provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) GetTransactionIDByExternalID(ctx context.Context, arg database.GetTransactionIDByExternalIDParams) (string, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionIDByExternalID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetTransactionIDByExternalIDParams) (string, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetTransactionIDByExternalIDParams) string); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetTransactionIDByExternalIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserTransactionByID provides a mock function with given fields: ctx, arg
 func (_m *TransactionQuerier) GetUserTransactionByID(ctx context.Context, arg database.GetUserTransactionByIDParams) (database.GetUserTransactionByIDRow, error) {
 	ret := _m.Called(ctx, arg)
@@ -178,28 +242,146 @@ func (_m *TransactionQuerier) GetUserTransactionByID(ctx context.Context, arg da
 	return r0, r1
 }
 
-// GetUserTransactionsFirstPage provides a mock function with given fields: ctx, arg
-func (_m *TransactionQuerier) GetUserTransactionsFirstPage(ctx context.Context, arg database.GetUserTransactionsFirstPageParams) ([]database.GetUserTransactionsFirstPageRow, error) {
+// ListTransactionSplits provides a mock function with given fields: ctx, transactionID
+func (_m *TransactionQuerier) ListTransactionSplits(ctx context.Context, transactionID string) ([]models.TransactionSplit, error) {
+	ret := _m.Called(ctx, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactionSplits")
+	}
+
+	var r0 []models.TransactionSplit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.TransactionSplit, error)); ok {
+		return rf(ctx, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.TransactionSplit); ok {
+		r0 = rf(ctx, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TransactionSplit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserCategories provides a mock function with given fields: ctx, userID
+func (_m *TransactionQuerier) ListUserCategories(ctx context.Context, userID string) ([]database.ListUserCategoriesRow, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserCategories")
+	}
+
+	var r0 []database.ListUserCategoriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]database.ListUserCategoriesRow, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []database.ListUserCategoriesRow); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserCategoriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserTransactionsNewestFirst provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) ListUserTransactionsNewestFirst(ctx context.Context, arg database.ListUserTransactionsNewestFirstParams) ([]database.ListUserTransactionsNewestFirstRow, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserTransactionsFirstPage")
+		panic("no return value specified for ListUserTransactionsNewestFirst")
 	}
 
-	var r0 []database.GetUserTransactionsFirstPageRow
+	var r0 []database.ListUserTransactionsNewestFirstRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsFirstPageParams) ([]database.GetUserTransactionsFirstPageRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsNewestFirstParams) ([]database.ListUserTransactionsNewestFirstRow, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsFirstPageParams) []database.GetUserTransactionsFirstPageRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsNewestFirstParams) []database.ListUserTransactionsNewestFirstRow); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]database.GetUserTransactionsFirstPageRow)
+			r0 = ret.Get(0).([]database.ListUserTransactionsNewestFirstRow)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetUserTransactionsFirstPageParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListUserTransactionsNewestFirstParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserTransactionsOldestFirst provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) ListUserTransactionsOldestFirst(ctx context.Context, arg database.ListUserTransactionsOldestFirstParams) ([]database.ListUserTransactionsOldestFirstRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTransactionsOldestFirst")
+	}
+
+	var r0 []database.ListUserTransactionsOldestFirstRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsOldestFirstParams) ([]database.ListUserTransactionsOldestFirstRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListUserTransactionsOldestFirstParams) []database.ListUserTransactionsOldestFirstRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListUserTransactionsOldestFirstRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListUserTransactionsOldestFirstParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchTransactionByID provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) PatchTransactionByID(ctx context.Context, arg database.PatchTransactionByIDParams) (database.PatchTransactionByIDRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTransactionByID")
+	}
+
+	var r0 database.PatchTransactionByIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.PatchTransactionByIDParams) (database.PatchTransactionByIDRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.PatchTransactionByIDParams) database.PatchTransactionByIDRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.PatchTransactionByIDRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.PatchTransactionByIDParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -208,28 +390,28 @@ func (_m *TransactionQuerier) GetUserTransactionsFirstPage(ctx context.Context,
 	return r0, r1
 }
 
-// GetUserTransactionsPaginated provides a mock function with given fields: ctx, arg
-func (_m *TransactionQuerier) GetUserTransactionsPaginated(ctx context.Context, arg database.GetUserTransactionsPaginatedParams) ([]database.GetUserTransactionsPaginatedRow, error) {
+// SearchUserTransactions provides a mock function with given fields: ctx, arg
+func (_m *TransactionQuerier) SearchUserTransactions(ctx context.Context, arg database.SearchUserTransactionsParams) ([]database.SearchUserTransactionsRow, error) {
 	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserTransactionsPaginated")
+		panic("no return value specified for SearchUserTransactions")
 	}
 
-	var r0 []database.GetUserTransactionsPaginatedRow
+	var r0 []database.SearchUserTransactionsRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsPaginatedParams) ([]database.GetUserTransactionsPaginatedRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.SearchUserTransactionsParams) ([]database.SearchUserTransactionsRow, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, database.GetUserTransactionsPaginatedParams) []database.GetUserTransactionsPaginatedRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, database.SearchUserTransactionsParams) []database.SearchUserTransactionsRow); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]database.GetUserTransactionsPaginatedRow)
+			r0 = ret.Get(0).([]database.SearchUserTransactionsRow)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, database.GetUserTransactionsPaginatedParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, database.SearchUserTransactionsParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -32,6 +32,52 @@ func (_m *UserQuerier) CreateUser(ctx context.Context, params database.CreateUse
 	return r0
 }
 
+// DisableUserTOTP provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) DisableUserTOTP(ctx context.Context, arg database.DisableUserTOTPParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableUserTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DisableUserTOTPParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableUserTOTP provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) EnableUserTOTP(ctx context.Context, arg database.EnableUserTOTPParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUserTOTP")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.EnableUserTOTPParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.EnableUserTOTPParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.EnableUserTOTPParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserQuerier) GetUserByEmail(ctx context.Context, email string) (database.GetUserByEmailRow, error) {
 	ret := _m.Called(ctx, email)
@@ -60,6 +106,144 @@ func (_m *UserQuerier) GetUserByEmail(ctx context.Context, email string) (databa
 	return r0, r1
 }
 
+// GetUserMFAByID provides a mock function with given fields: ctx, id
+func (_m *UserQuerier) GetUserMFAByID(ctx context.Context, id string) (database.GetUserMFAByIDRow, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMFAByID")
+	}
+
+	var r0 database.GetUserMFAByIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.GetUserMFAByIDRow, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.GetUserMFAByIDRow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.GetUserMFAByIDRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkUserEmailVerified provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) MarkUserEmailVerified(ctx context.Context, arg database.MarkUserEmailVerifiedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUserEmailVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkUserEmailVerifiedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserTOTPSecret provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) SetUserTOTPSecret(ctx context.Context, arg database.SetUserTOTPSecretParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserTOTPSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.SetUserTOTPSecretParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserPasswordParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserTOTPLastStep provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) UpdateUserTOTPLastStep(ctx context.Context, arg database.UpdateUserTOTPLastStepParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserTOTPLastStep")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserTOTPLastStepParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserTOTPLastStepParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserTOTPLastStepParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpgradeUserPasswordHash provides a mock function with given fields: ctx, arg
+func (_m *UserQuerier) UpgradeUserPasswordHash(ctx context.Context, arg database.UpgradeUserPasswordHashParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradeUserPasswordHash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpgradeUserPasswordHashParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpgradeUserPasswordHashParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpgradeUserPasswordHashParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserQuerier creates a new instance of UserQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserQuerier(t interface {
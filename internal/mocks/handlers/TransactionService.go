@@ -16,6 +16,34 @@ type TransactionService struct {
 	mock.Mock
 }
 
+// BatchTransactions provides a mock function with given fields: ctx, userID, batch
+func (_m *TransactionService) BatchTransactions(ctx context.Context, userID string, batch models.TxBatchRequest) (models.TxBatchResponse, error) {
+	ret := _m.Called(ctx, userID, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchTransactions")
+	}
+
+	var r0 models.TxBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TxBatchRequest) (models.TxBatchResponse, error)); ok {
+		return rf(ctx, userID, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TxBatchRequest) models.TxBatchResponse); ok {
+		r0 = rf(ctx, userID, batch)
+	} else {
+		r0 = ret.Get(0).(models.TxBatchResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TxBatchRequest) error); ok {
+		r1 = rf(ctx, userID, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTransaction provides a mock function with given fields: ctx, userID, req
 func (_m *TransactionService) CreateTransaction(ctx context.Context, userID string, req models.NewTxRequest) (*models.Tx, error) {
 	ret := _m.Called(ctx, userID, req)
@@ -46,17 +74,35 @@ func (_m *TransactionService) CreateTransaction(ctx context.Context, userID stri
 	return r0, r1
 }
 
-// DeleteTransaction provides a mock function with given fields: ctx, txnID, userID
-func (_m *TransactionService) DeleteTransaction(ctx context.Context, txnID string, userID string) error {
-	ret := _m.Called(ctx, txnID, userID)
+// DeleteTransaction provides a mock function with given fields: ctx, txnID, userID, expectedVersion
+func (_m *TransactionService) DeleteTransaction(ctx context.Context, txnID string, userID string, expectedVersion int64) error {
+	ret := _m.Called(ctx, txnID, userID, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTransaction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, txnID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(ctx, txnID, userID, expectedVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportTransactions provides a mock function with given fields: ctx, userID, filter, fn
+func (_m *TransactionService) ExportTransactions(ctx context.Context, userID uuid.UUID, filter models.TxFilter, fn func(models.TxExport) error) error {
+	ret := _m.Called(ctx, userID, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTransactions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.TxFilter, func(models.TxExport) error) error); ok {
+		r0 = rf(ctx, userID, filter, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -94,60 +140,127 @@ func (_m *TransactionService) GetTransactionByID(ctx context.Context, userID str
 	return r0, r1
 }
 
-// ListUserTransactions provides a mock function with given fields: ctx, userID, cursorDate, cursorID, pageSize
-func (_m *TransactionService) ListUserTransactions(ctx context.Context, userID uuid.UUID, cursorDate *string, cursorID *string, pageSize int64) ([]models.Tx, string, string, bool, error) {
-	ret := _m.Called(ctx, userID, cursorDate, cursorID, pageSize)
+// ListUserTransactions provides a mock function with given fields: ctx, userID, filter, cursor, pageSize
+func (_m *TransactionService) ListUserTransactions(ctx context.Context, userID uuid.UUID, filter models.TxFilter, cursor string, pageSize int64) (models.TxPage, error) {
+	ret := _m.Called(ctx, userID, filter, cursor, pageSize)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListUserTransactions")
 	}
 
-	var r0 []models.Tx
-	var r1 string
-	var r2 string
-	var r3 bool
-	var r4 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string, *string, int64) ([]models.Tx, string, string, bool, error)); ok {
-		return rf(ctx, userID, cursorDate, cursorID, pageSize)
+	var r0 models.TxPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.TxFilter, string, int64) (models.TxPage, error)); ok {
+		return rf(ctx, userID, filter, cursor, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.TxFilter, string, int64) models.TxPage); ok {
+		r0 = rf(ctx, userID, filter, cursor, pageSize)
+	} else {
+		r0 = ret.Get(0).(models.TxPage)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string, *string, int64) []models.Tx); ok {
-		r0 = rf(ctx, userID, cursorDate, cursorID, pageSize)
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.TxFilter, string, int64) error); ok {
+		r1 = rf(ctx, userID, filter, cursor, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchTransaction provides a mock function with given fields: ctx, txnID, userID, patch, expectedVersion
+func (_m *TransactionService) PatchTransaction(ctx context.Context, txnID string, userID string, patch models.TxPatch, expectedVersion int64) (*models.Tx, error) {
+	ret := _m.Called(ctx, txnID, userID, patch, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTransaction")
+	}
+
+	var r0 *models.Tx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.TxPatch, int64) (*models.Tx, error)); ok {
+		return rf(ctx, txnID, userID, patch, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.TxPatch, int64) *models.Tx); ok {
+		r0 = rf(ctx, txnID, userID, patch, expectedVersion)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Tx)
+			r0 = ret.Get(0).(*models.Tx)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *string, *string, int64) string); ok {
-		r1 = rf(ctx, userID, cursorDate, cursorID, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.TxPatch, int64) error); ok {
+		r1 = rf(ctx, txnID, userID, patch, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTransactions provides a mock function with given fields: ctx, userID, query, limit
+func (_m *TransactionService) SearchTransactions(ctx context.Context, userID uuid.UUID, query string, limit int64) ([]models.TxSearchResult, error) {
+	ret := _m.Called(ctx, userID, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTransactions")
+	}
+
+	var r0 []models.TxSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int64) ([]models.TxSearchResult, error)); ok {
+		return rf(ctx, userID, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int64) []models.TxSearchResult); ok {
+		r0 = rf(ctx, userID, query, limit)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TxSearchResult)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, *string, *string, int64) string); ok {
-		r2 = rf(ctx, userID, cursorDate, cursorID, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, int64) error); ok {
+		r1 = rf(ctx, userID, query, limit)
 	} else {
-		r2 = ret.Get(2).(string)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuggestCategories provides a mock function with given fields: ctx, userID, txnID
+func (_m *TransactionService) SuggestCategories(ctx context.Context, userID string, txnID string) ([]models.CategorySuggestion, error) {
+	ret := _m.Called(ctx, userID, txnID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestCategories")
 	}
 
-	if rf, ok := ret.Get(3).(func(context.Context, uuid.UUID, *string, *string, int64) bool); ok {
-		r3 = rf(ctx, userID, cursorDate, cursorID, pageSize)
+	var r0 []models.CategorySuggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.CategorySuggestion, error)); ok {
+		return rf(ctx, userID, txnID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.CategorySuggestion); ok {
+		r0 = rf(ctx, userID, txnID)
 	} else {
-		r3 = ret.Get(3).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CategorySuggestion)
+		}
 	}
 
-	if rf, ok := ret.Get(4).(func(context.Context, uuid.UUID, *string, *string, int64) error); ok {
-		r4 = rf(ctx, userID, cursorDate, cursorID, pageSize)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, txnID)
 	} else {
-		r4 = ret.Error(4)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2, r3, r4
+	return r0, r1
 }
 
-// UpdateTransaction provides a mock function with given fields: ctx, txnID, userID, req
-func (_m *TransactionService) UpdateTransaction(ctx context.Context, txnID string, userID string, req models.NewTxRequest) (*models.Tx, error) {
-	ret := _m.Called(ctx, txnID, userID, req)
+// UpdateTransaction provides a mock function with given fields: ctx, txnID, userID, req, expectedVersion
+func (_m *TransactionService) UpdateTransaction(ctx context.Context, txnID string, userID string, req models.NewTxRequest, expectedVersion int64) (*models.Tx, error) {
+	ret := _m.Called(ctx, txnID, userID, req, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTransaction")
@@ -155,19 +268,19 @@ func (_m *TransactionService) UpdateTransaction(ctx context.Context, txnID strin
 
 	var r0 *models.Tx
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.NewTxRequest) (*models.Tx, error)); ok {
-		return rf(ctx, txnID, userID, req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.NewTxRequest, int64) (*models.Tx, error)); ok {
+		return rf(ctx, txnID, userID, req, expectedVersion)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.NewTxRequest) *models.Tx); ok {
-		r0 = rf(ctx, txnID, userID, req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.NewTxRequest, int64) *models.Tx); ok {
+		r0 = rf(ctx, txnID, userID, req, expectedVersion)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Tx)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.NewTxRequest) error); ok {
-		r1 = rf(ctx, txnID, userID, req)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.NewTxRequest, int64) error); ok {
+		r1 = rf(ctx, txnID, userID, req, expectedVersion)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,17 +23,102 @@ var RandReader = rand.Read
 type LoginInput struct {
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// TrustedDeviceToken comes from the trusted device cookie, never the body.
+	TrustedDeviceToken string `json:"-"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ResendVerificationInput struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type MFACodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type MFALoginInput struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	TrustDevice  bool   `json:"trust_device"`
+}
+
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
 }
 
 type LoginResponse struct {
 	UserID       uuid.UUID
 	RefreshToken string
 	JWTToken     string
+	// Restricted is set when the login was admitted without a refresh token
+	// because the account's email is unverified.
+	Restricted bool
+	// MFARequired is set when the password was accepted but a second factor is
+	// still owed. MFAToken identifies the pending login for POST /login/mfa.
+	MFARequired bool
+	MFAToken    string
+	// TrustedDeviceToken is set when an MFA login asked to trust the device. Sent
+	// back with later logins, it skips the second factor until MFATrustTTL passes.
+	TrustedDeviceToken string
 }
 
 type LoginResponseData struct {
-	Message string `json:"message"`
-	Token   string `json:"token"`
+	Message    string `json:"message"`
+	Token      string `json:"token"`
+	Restricted bool   `json:"restricted,omitempty"`
+}
+
+type CreateAPIKeyInput struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes"`
+}
+
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKey is returned once, when the key is created. Key is never stored.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyPrincipal is the identity behind an authenticated API key.
+type APIKeyPrincipal struct {
+	KeyID  string
+	UserID uuid.UUID
+	Scopes []string
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
 }
 
 type DeviceInfo struct {
@@ -41,6 +127,20 @@ type DeviceInfo struct {
 	BrowserVersion string `json:"browser_version"`
 	Os             string `json:"os"`
 	OsVersion      string `json:"os_version"`
+	AppInfo        string `json:"app_info"`
+}
+
+type Session struct {
+	ID             string    `json:"id"`
+	DeviceType     string    `json:"device_type"`
+	Browser        string    `json:"browser"`
+	BrowserVersion string    `json:"browser_version"`
+	Os             string    `json:"os"`
+	OsVersion      string    `json:"os_version"`
+	AppInfo        string    `json:"app_info,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastUsedAt     time.Time `json:"last_used_at"`
+	Active         bool      `json:"active"`
 }
 
 func IsValidEmail(email string) bool {
@@ -0,0 +1,40 @@
+package models
+
+// Category is a detailed category as one user sees it: a system category, under the
+// user's name for it if they renamed it, or a category of the user's own.
+type Category struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	PrimaryCategory     int64  `json:"primary_category"`
+	PrimaryCategoryName string `json:"primary_category_name"`
+	Custom              bool   `json:"custom"`
+	Hidden              bool   `json:"hidden,omitempty"`
+	Archived            bool   `json:"archived,omitempty"`
+	MergedInto          *int64 `json:"merged_into,omitempty"`
+}
+
+// NewCategoryRequest creates a category of the user's own under a system primary
+// category.
+type NewCategoryRequest struct {
+	Name            string `json:"name" binding:"required"`
+	Description     string `json:"description"`
+	PrimaryCategory int64  `json:"primary_category" binding:"required"`
+}
+
+// CategoryUpdate changes how the user sees a category. Name renames either kind; an
+// empty Name gives a system category back its own name. Hidden applies to system
+// categories and Archived to the user's own. Nil fields are left as they are.
+type CategoryUpdate struct {
+	Name     *string `json:"name"`
+	Hidden   *bool   `json:"hidden"`
+	Archived *bool   `json:"archived"`
+}
+
+// CategoryMerge moves the user's transactions, merchant defaults, rules and trained
+// suggestions from the SourceIDs categories to TargetID. Sources of the user's own
+// are archived and system sources hidden.
+type CategoryMerge struct {
+	TargetID  int64   `json:"target_id" binding:"required"`
+	SourceIDs []int64 `json:"source_ids" binding:"required,min=1"`
+}
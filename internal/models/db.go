@@ -6,8 +6,54 @@ package models
 
 import (
 	"database/sql"
+	"time"
 )
 
+type ApiKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     string
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	CreatedAt  sql.NullTime
+}
+
+type CategorizationRule struct {
+	ID         string
+	UserID     string
+	Name       string
+	Priority   int64
+	Conditions string
+	Actions    string
+	CreatedAt  sql.NullTime
+	UpdatedAt  sql.NullTime
+}
+
+type CategoryModelExample struct {
+	TransactionID      string
+	UserID             string
+	DetailedCategoryID int64
+	Features           string
+}
+
+type CategoryModelFeature struct {
+	UserID             string
+	Feature            string
+	DetailedCategoryID int64
+	Count              int64
+}
+
+type CategoryOverride struct {
+	UserID             string
+	DetailedCategoryID int64
+	Name               sql.NullString
+	Hidden             bool
+	UpdatedAt          sql.NullTime
+}
+
 type DetailedCategory struct {
 	ID                int64
 	Name              string
@@ -28,6 +74,73 @@ type DeviceInfoLog struct {
 	LastUsedAt     sql.NullTime
 }
 
+type EmailVerificationToken struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt sql.NullTime
+}
+
+type ImportMapping struct {
+	UserID    string
+	Bank      string
+	Mapping   string
+	CreatedAt sql.NullTime
+	UpdatedAt sql.NullTime
+}
+
+type Merchant struct {
+	ID                string
+	UserID            string
+	Name              string
+	DefaultCategoryID sql.NullInt64
+	CreatedAt         sql.NullTime
+	UpdatedAt         sql.NullTime
+}
+
+type MerchantAlias struct {
+	UserID     string
+	Alias      string
+	MerchantID string
+}
+
+type MfaChallenge struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	Attempts  int64
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt sql.NullTime
+}
+
+type MfaRecoveryCode struct {
+	ID        string
+	UserID    string
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt sql.NullTime
+}
+
+type MfaTrustedDevice struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	ExpiresAt time.Time
+	CreatedAt sql.NullTime
+}
+
+type PasswordResetToken struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt sql.NullTime
+}
+
 type PrimaryCategory struct {
 	ID   int64
 	Name string
@@ -42,6 +155,7 @@ type RefreshToken struct {
 	RevokedAt    sql.NullTime
 	UserID       string
 	DeviceInfoID string
+	FamilyID     sql.NullString
 }
 
 type Transaction struct {
@@ -53,6 +167,30 @@ type Transaction struct {
 	DetailedCategoryID int64
 	CreatedAt          sql.NullTime
 	UpdatedAt          sql.NullTime
+	Notes              string
+	Tags               string
+	Version            int64
+	ExternalID         sql.NullString
+	MerchantID         sql.NullString
+	RawMerchant        string
+}
+
+type TransactionLine struct {
+	TransactionID      string
+	UserID             string
+	TransactionDate    string
+	Line               int64
+	AmountCents        int64
+	DetailedCategoryID int64
+	Memo               string
+}
+
+type TransactionSplit struct {
+	TransactionID      string
+	Line               int64
+	AmountCents        int64
+	DetailedCategoryID int64
+	Memo               string
 }
 
 type User struct {
@@ -66,4 +204,17 @@ type User struct {
 	ScholarshipFlag      sql.NullInt64
 	CreatedAt            sql.NullTime
 	UpdatedAt            sql.NullTime
+	EmailVerifiedAt      sql.NullTime
+	TotpSecret           sql.NullString
+	TotpEnabledAt        sql.NullTime
+	TotpLastStep         sql.NullInt64
+}
+
+type UserCategory struct {
+	DetailedCategoryID int64
+	UserID             string
+	ArchivedAt         sql.NullTime
+	MergedIntoID       sql.NullInt64
+	CreatedAt          sql.NullTime
+	UpdatedAt          sql.NullTime
 }
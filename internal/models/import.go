@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+const (
+	MappingSourceRequest  = "request"
+	MappingSourceSaved    = "saved"
+	MappingSourceDetected = "detected"
+)
+
+// CSVMapping says how the columns of a bank's CSV export map to transaction fields.
+// Columns are named by their header text. Amounts come from AmountColumn, or from
+// DebitColumn and CreditColumn for exports that split money out and money in.
+type CSVMapping struct {
+	Delimiter      string `json:"delimiter"`
+	DateColumn     string `json:"date_column"`
+	DateFormat     string `json:"date_format"`
+	MerchantColumn string `json:"merchant_column"`
+	AmountColumn   string `json:"amount_column,omitempty"`
+	// NegateAmounts flips AmountColumn for exports that show money leaving the account
+	// as negative. Transactions follow Plaid and store it as positive.
+	NegateAmounts  bool   `json:"negate_amounts,omitempty"`
+	DebitColumn    string `json:"debit_column,omitempty"`
+	CreditColumn   string `json:"credit_column,omitempty"`
+	CategoryColumn string `json:"category_column,omitempty"`
+	NotesColumn    string `json:"notes_column,omitempty"`
+	// TagsColumn holds comma-separated tags.
+	TagsColumn string `json:"tags_column,omitempty"`
+	// DefaultCategory is a PRIMARY_DETAILED category name, such as
+	// FOOD_AND_DRINK_GROCERIES, for rows without a category of their own.
+	DefaultCategory string `json:"default_category,omitempty"`
+}
+
+// SavedCSVMapping is a mapping the user confirmed for one bank.
+type SavedCSVMapping struct {
+	Bank      string     `json:"bank"`
+	Mapping   CSVMapping `json:"mapping"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ImportRow is one data row of an import file. Line is the row's line number in the
+// file. Transaction is set when the row parsed; Errors explains why it did not.
+type ImportRow struct {
+	Line        int           `json:"line"`
+	Transaction *NewTxRequest `json:"transaction,omitempty"`
+	Errors      []string      `json:"errors,omitempty"`
+}
+
+type ImportPreview struct {
+	Bank    string     `json:"bank,omitempty"`
+	Mapping CSVMapping `json:"mapping"`
+	// MappingSource says where Mapping came from: the request, the mapping saved for
+	// Bank, or detection from the file itself.
+	MappingSource string      `json:"mapping_source"`
+	Headers       []string    `json:"headers"`
+	Rows          []ImportRow `json:"rows"`
+	ValidRows     int         `json:"valid_rows"`
+	ErrorRows     int         `json:"error_rows"`
+	Warnings      []string    `json:"warnings,omitempty"`
+}
+
+type ImportResult struct {
+	Imported int `json:"imported"`
+	// Skipped counts rows that were imported before, going by their file's
+	// transaction IDs.
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+	// Errors holds the rows that were not imported.
+	Errors []ImportRow `json:"errors,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// MerchantSummary is one entry of the user's merchant directory. Aliases are the
+// normalized merchant strings that resolve to it.
+type MerchantSummary struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	DefaultCategory  *int64   `json:"default_category"`
+	Aliases          []string `json:"aliases"`
+	TransactionCount int64    `json:"transaction_count"`
+}
+
+// MerchantUpdate renames a merchant or sets the category its new transactions get
+// when sent without one. Nil fields are left as they are; a DefaultCategory of 0
+// clears it.
+type MerchantUpdate struct {
+	Name            *string `json:"name"`
+	DefaultCategory *int64  `json:"default_category"`
+}
+
+// MerchantMerge folds the SourceIDs merchants into TargetID. Their transactions and
+// aliases move to the target and they are deleted.
+type MerchantMerge struct {
+	TargetID  string   `json:"target_id" binding:"required"`
+	SourceIDs []string `json:"source_ids" binding:"required,min=1"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+const (
+	MerchantContains = "contains"
+	MerchantEquals   = "equals"
+	MerchantRegex    = "regex"
+)
+
+// Rule is a user's categorization rule. When a transaction meets every one of its
+// conditions, its actions are applied. Rules run in ascending Priority; a value set
+// by an earlier rule is not changed by a later one, while tags add up.
+type Rule struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name" binding:"required"`
+	Priority   int64          `json:"priority"`
+	Conditions RuleConditions `json:"conditions"`
+	Actions    RuleActions    `json:"actions"`
+	CreatedAt  *time.Time     `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time     `json:"updated_at,omitempty"`
+}
+
+// RuleConditions are the tests a transaction must pass. Zero values are not tested,
+// but a rule needs at least one condition.
+type RuleConditions struct {
+	Merchant *MerchantCondition `json:"merchant,omitempty"`
+	// MinAmount and MaxAmount are dollars, compared by absolute value like the
+	// listing filters.
+	MinAmount float64 `json:"min_amount,omitempty"`
+	MaxAmount float64 `json:"max_amount,omitempty"`
+	// Flow is FlowInflow or FlowOutflow.
+	Flow string `json:"flow,omitempty"`
+	// DayOfMonthFrom and DayOfMonthTo bound the day of the transaction date,
+	// inclusive. A range such as 28 to 3 wraps around the end of the month.
+	DayOfMonthFrom int `json:"day_of_month_from,omitempty"`
+	DayOfMonthTo   int `json:"day_of_month_to,omitempty"`
+}
+
+// MerchantCondition compares the merchant with Value. Contains and equals ignore
+// case; a regex is used as written, so (?i) makes it ignore case.
+type MerchantCondition struct {
+	Match string `json:"match"`
+	Value string `json:"value"`
+}
+
+// RuleActions are the changes a matching rule makes. MarkTransfer sets the
+// TRANSFER_OUT or TRANSFER_IN account transfer category, by the sign of the amount,
+// so it cannot be combined with DetailedCategory.
+type RuleActions struct {
+	DetailedCategory int64    `json:"detailed_category,omitempty"`
+	Merchant         string   `json:"merchant,omitempty"`
+	AddTags          []string `json:"add_tags,omitempty"`
+	MarkTransfer     bool     `json:"mark_transfer,omitempty"`
+}
+
+// RuleRunRequest picks the rules and the history a dry run or retroactive run goes
+// over. Rule, only read by dry runs, tries an unsaved rule instead of the saved ones.
+type RuleRunRequest struct {
+	Rule      *Rule  `json:"rule"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// RuleRunResult reports a run of rules over past transactions. Changed counts the
+// transactions the rules change; Changes lists the first of them. Skipped counts the
+// transactions the rules would change but leave alone: split ones, since their lines
+// carry the category, and ones that changed, were deleted or had their new category
+// archived while the rules ran.
+type RuleRunResult struct {
+	Checked int          `json:"checked"`
+	Changed int          `json:"changed"`
+	Skipped int          `json:"skipped"`
+	Changes []RuleChange `json:"changes"`
+}
+
+// RuleChange is what the rules change on one transaction. Set holds only the fields
+// that change.
+type RuleChange struct {
+	TransactionID string     `json:"transaction_id"`
+	Date          string     `json:"date"`
+	Merchant      string     `json:"merchant"`
+	RuleIDs       []string   `json:"rule_ids"`
+	Set           RuleUpdate `json:"set"`
+}
+
+type RuleUpdate struct {
+	DetailedCategory *int64   `json:"detailed_category,omitempty"`
+	Merchant         *string  `json:"merchant,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+}
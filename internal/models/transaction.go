@@ -1,26 +1,95 @@
 package models
 
+import "time"
+
+// NewTxRequest is a transaction as sent to create or replace one. DetailedCategory can
+// be left out when creating a transaction; the categorizer then picks it. A request
+// with Splits takes the category of its first line instead.
 type NewTxRequest struct {
-	Date             string  `json:"date" binding:"required"`
-	Merchant         string  `json:"merchant" binding:"required"`
-	Amount           float64 `json:"amount" binding:"required"`
-	DetailedCategory int64   `json:"detailed_category" binding:"required"`
+	Date             string    `json:"date" binding:"required"`
+	Merchant         string    `json:"merchant" binding:"required"`
+	Amount           float64   `json:"amount" binding:"required"`
+	DetailedCategory int64     `json:"detailed_category"`
+	Notes            string    `json:"notes"`
+	Tags             []string  `json:"tags"`
+	Splits           []TxSplit `json:"splits"`
+	// ExternalID identifies the transaction in the file it was imported from, such as
+	// an OFX FITID. Creating a second transaction with the same one fails. It is not
+	// read from request bodies.
+	ExternalID string `json:"-"`
 }
 
 type Tx struct {
-	ID               string  `json:"id"`
-	UserID           string  `json:"user_id"`
-	Date             string  `json:"date" binding:"required"`
-	Merchant         string  `json:"merchant" binding:"required"`
-	Amount           float64 `json:"amount" binding:"required"`
-	DetailedCategory int64   `json:"detailed_category" binding:"required"`
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	Date             string     `json:"date" binding:"required"`
+	Merchant         string     `json:"merchant" binding:"required"`
+	Amount           float64    `json:"amount" binding:"required"`
+	DetailedCategory int64      `json:"detailed_category" binding:"required"`
+	Notes            string     `json:"notes,omitempty"`
+	Tags             []string   `json:"tags,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	// Version goes up by one on every write. Handlers send it as the ETag.
+	Version int64 `json:"version,omitempty"`
+	// Merchant is the canonical name of the merchant the transaction is linked to,
+	// MerchantID that merchant, and RawMerchant the merchant string as it was sent.
+	MerchantID  string `json:"merchant_id,omitempty"`
+	RawMerchant string `json:"raw_merchant,omitempty"`
+	// Suggestions are the categories the categorizer offered when the transaction was
+	// created without one. The first of them was used.
+	Suggestions []CategorySuggestion `json:"suggestions,omitempty"`
+	// Splits divide the amount of a split transaction across categories. Reports and
+	// budgets count the lines instead of the transaction.
+	Splits []TxSplit `json:"splits,omitempty"`
 }
 
-type TxResponse struct {
-	Date             string  `json:"date"`
-	Merchant         string  `json:"merchant"`
+// TxSplit is one line of a split transaction. The lines of a transaction sum to its
+// amount.
+type TxSplit struct {
 	Amount           float64 `json:"amount"`
 	DetailedCategory int64   `json:"detailed_category"`
+	Memo             string  `json:"memo,omitempty"`
+}
+
+// TxPatch is a JSON merge patch (RFC 7396) for a transaction. Nil fields are left as
+// they are. Notes and Tags are cleared by a null in the patch, which arrives here as a
+// pointer to the empty value. The splits of a transaction are only replaced by an
+// update, so a patch cannot change the amount or category of a split transaction.
+type TxPatch struct {
+	Date             *string
+	Merchant         *string
+	Amount           *float64
+	DetailedCategory *int64
+	Notes            *string
+	Tags             *[]string
+}
+
+// TxPatchOp is a patch to one transaction in a run of patches. A non-zero Version
+// makes it conditional, like If-Match on the single transaction routes.
+type TxPatchOp struct {
+	ID      string
+	Version int64
+	Patch   TxPatch
+}
+
+type TxResponse struct {
+	Date             string               `json:"date"`
+	Merchant         string               `json:"merchant"`
+	MerchantID       string               `json:"merchant_id,omitempty"`
+	RawMerchant      string               `json:"raw_merchant,omitempty"`
+	Amount           float64              `json:"amount"`
+	DetailedCategory int64                `json:"detailed_category"`
+	Notes            string               `json:"notes,omitempty"`
+	Tags             []string             `json:"tags,omitempty"`
+	Suggestions      []CategorySuggestion `json:"suggestions,omitempty"`
+	Splits           []TxSplit            `json:"splits,omitempty"`
+}
+
+// CategorySuggestion is a detailed category the categorizer thinks fits a transaction.
+// Confidence is its probability among the categories the model knows.
+type CategorySuggestion struct {
+	DetailedCategory int64   `json:"detailed_category"`
+	Confidence       float64 `json:"confidence"`
 }
 
 func NewTransaction(id, userID, date, merchant string, amount float64, detailedCategory int64) *Tx {
@@ -38,7 +107,116 @@ func ConvertToResponse(txn *Tx) *TxResponse {
 	return &TxResponse{
 		Date:             txn.Date,
 		Merchant:         txn.Merchant,
+		MerchantID:       txn.MerchantID,
+		RawMerchant:      txn.RawMerchant,
 		Amount:           txn.Amount,
 		DetailedCategory: txn.DetailedCategory,
+		Notes:            txn.Notes,
+		Tags:             txn.Tags,
+		Suggestions:      txn.Suggestions,
+		Splits:           txn.Splits,
 	}
 }
+
+const (
+	SortNewestFirst = "desc"
+	SortOldestFirst = "asc"
+
+	FlowInflow  = "inflow"
+	FlowOutflow = "outflow"
+)
+
+// TxFilter narrows a transaction listing. Zero values mean "no filter". Amounts are
+// compared by absolute value; Flow picks the sign, following Plaid's convention that
+// money leaving the account is positive.
+type TxFilter struct {
+	StartDate          string `json:"start_date,omitempty"`
+	EndDate            string `json:"end_date,omitempty"`
+	Merchant           string `json:"merchant,omitempty"`
+	PrimaryCategoryID  int64  `json:"primary_category,omitempty"`
+	DetailedCategoryID int64  `json:"detailed_category,omitempty"`
+	MinAmountCents     int64  `json:"min_amount_cents,omitempty"`
+	MaxAmountCents     int64  `json:"max_amount_cents,omitempty"`
+	Flow               string `json:"flow,omitempty"`
+	// Sort is SortNewestFirst or SortOldestFirst. Empty means newest first.
+	Sort string `json:"sort,omitempty"`
+}
+
+// TxCursor is the position a transaction page cursor points at: the date and ID of a
+// transaction, whether to read the page before it instead of after, and the filter the
+// listing was made with.
+type TxCursor struct {
+	Date     string   `json:"d"`
+	ID       string   `json:"i"`
+	Backward bool     `json:"b,omitempty"`
+	Filter   TxFilter `json:"f"`
+}
+
+type TxPage struct {
+	Transactions []Tx   `json:"transactions"`
+	NextCursor   string `json:"next_cursor"`
+	PrevCursor   string `json:"prev_cursor"`
+	HasMoreData  bool   `json:"has_more_data"`
+}
+
+// TxSearchResult is a transaction matched by full-text search. Snippet is the best
+// matching part of the merchant, notes or tags as escaped HTML, with each hit wrapped
+// in <mark> tags.
+type TxSearchResult struct {
+	Tx
+	Snippet string `json:"snippet"`
+}
+
+// TxExport is a transaction with the names of its categories, as written by exports.
+type TxExport struct {
+	Tx
+	PrimaryCategoryName  string `json:"primary_category_name"`
+	DetailedCategoryName string `json:"detailed_category_name"`
+}
+
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+
+	MaxBatchOperations = 1000
+)
+
+// TxBatchRequest is a list of writes to run in one database transaction. In atomic
+// mode the first failure rolls everything back; in best-effort mode the operations
+// that succeed are kept.
+type TxBatchRequest struct {
+	Mode       string      `json:"mode" binding:"required,oneof=atomic best_effort"`
+	Operations []TxBatchOp `json:"operations" binding:"required,min=1,max=1000"`
+}
+
+// TxBatchOp is one write in a batch. Create and update take Transaction; update and
+// delete take ID. A non-zero Version makes an update or delete conditional, like
+// If-Match on the single transaction routes.
+type TxBatchOp struct {
+	Op          string        `json:"op"`
+	ID          string        `json:"id,omitempty"`
+	Version     int64         `json:"version,omitempty"`
+	Transaction *NewTxRequest `json:"transaction,omitempty"`
+}
+
+// TxBatchResult is the outcome of one batch operation. The service sets Err; handlers
+// turn it into Status and Error.
+type TxBatchResult struct {
+	Index       int    `json:"index"`
+	Op          string `json:"op"`
+	ID          string `json:"id,omitempty"`
+	Status      int    `json:"status"`
+	Error       string `json:"error,omitempty"`
+	Transaction *Tx    `json:"transaction,omitempty"`
+	Err         error  `json:"-"`
+}
+
+type TxBatchResponse struct {
+	Mode      string          `json:"mode"`
+	Committed bool            `json:"committed"`
+	Results   []TxBatchResult `json:"results"`
+}
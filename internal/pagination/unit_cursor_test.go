@@ -0,0 +1,72 @@
+package pagination_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type position struct {
+	Date string `json:"d"`
+	ID   string `json:"i"`
+}
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	codec := pagination.NewCursorCodec([]byte("cursor-secret"))
+	token, err := codec.Encode(position{Date: "2025-03-05", ID: "abc"})
+	require.NoError(t, err)
+	require.NotContains(t, token, "2025-03-05")
+
+	var got position
+	require.NoError(t, codec.Decode(token, &got))
+	require.Equal(t, position{Date: "2025-03-05", ID: "abc"}, got)
+
+	again, err := codec.Encode(position{Date: "2025-03-05", ID: "abc"})
+	require.NoError(t, err)
+	require.Equal(t, token, again)
+}
+
+func TestCursorCodecRejects(t *testing.T) {
+	codec := pagination.NewCursorCodec([]byte("cursor-secret"))
+	token, err := codec.Encode(position{Date: "2025-03-05", ID: "abc"})
+	require.NoError(t, err)
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	tampered := append([]byte(nil), raw...)
+	tampered[len(tampered)-3] ^= 1
+
+	otherKey, err := pagination.NewCursorCodec([]byte("other-secret")).Encode(position{Date: "2025-03-05", ID: "abc"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "tampered payload", token: base64.RawURLEncoding.EncodeToString(tampered)},
+		{name: "signed with another key", token: otherKey},
+		{name: "not base64", token: "not a cursor!"},
+		{name: "too short", token: base64.RawURLEncoding.EncodeToString([]byte("short"))},
+		{name: "too long", token: strings.Repeat("A", pagination.MaxCursorLength+1)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got position
+			require.ErrorIs(t, codec.Decode(tc.token, &got), pagination.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestCursorCodecRandomKey(t *testing.T) {
+	a := pagination.NewCursorCodec(nil)
+	b := pagination.NewCursorCodec(nil)
+	token, err := a.Encode(position{ID: "abc"})
+	require.NoError(t, err)
+
+	var got position
+	require.NoError(t, a.Decode(token, &got))
+	require.ErrorIs(t, b.Decode(token, &got), pagination.ErrInvalidCursor)
+}
@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+const (
+	ScopeTransactionsRead  = "transactions:read"
+	ScopeTransactionsWrite = "transactions:write"
+
+	apiKeyTag       = "uw"
+	apiKeyNameLimit = 100
+	// apiKeyLastUsedInterval limits how often authentication writes last_used_at.
+	apiKeyLastUsedInterval = time.Minute
+)
+
+// APIKeyScopes lists every scope a key can hold. Keys created without scopes get all of them.
+var APIKeyScopes = []string{ScopeTransactionsRead, ScopeTransactionsWrite}
+
+var apiKeyEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// CreateAPIKey issues a new key for the user. Keys look like uw_<prefix>_<secret>; the
+// "uw_<prefix>" part is stored in the clear so keys can be told apart, the whole key
+// only as a hash.
+func (a *AuthService) CreateAPIKey(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (models.CreatedAPIKey, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > apiKeyNameLimit {
+		return models.CreatedAPIKey{}, ErrInvalidAPIKeyName
+	}
+	scopes, err := normalizeScopes(input.Scopes)
+	if err != nil {
+		return models.CreatedAPIKey{}, err
+	}
+
+	prefix, key, err := generateAPIKey()
+	if err != nil {
+		return models.CreatedAPIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CreatedAPIKey{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	keyQ := database.NewRealAPIKeyQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	keyID := uuid.NewString()
+	if err := keyQ.CreateAPIKey(ctx, database.CreateAPIKeyParams{
+		ID:      keyID,
+		UserID:  userID.String(),
+		Name:    name,
+		Prefix:  prefix,
+		KeyHash: HashOneTimeToken(key),
+		Scopes:  strings.Join(scopes, " "),
+	}); err != nil {
+		return models.CreatedAPIKey{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CreatedAPIKey{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return models.CreatedAPIKey{
+		APIKey: models.APIKey{
+			ID:        keyID,
+			Name:      name,
+			Prefix:    prefix,
+			Scopes:    scopes,
+			CreatedAt: time.Now().UTC(),
+		},
+		Key: key,
+	}, nil
+}
+
+// ListAPIKeys returns the user's unrevoked keys, newest first.
+func (a *AuthService) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error) {
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	keyQ := database.NewRealAPIKeyQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	rows, err := keyQ.ListUserAPIKeys(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys := make([]models.APIKey, 0, len(rows))
+	for _, row := range rows {
+		key := models.APIKey{
+			ID:        row.ID,
+			Name:      row.Name,
+			Prefix:    row.Prefix,
+			Scopes:    strings.Fields(row.Scopes),
+			CreatedAt: row.CreatedAt.Time,
+		}
+		if row.LastUsedAt.Valid {
+			lastUsed := row.LastUsedAt.Time
+			key.LastUsedAt = &lastUsed
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes one of the user's keys.
+func (a *AuthService) RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	keyQ := database.NewRealAPIKeyQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	rows, err := keyQ.RevokeAPIKey(ctx, database.RevokeAPIKeyParams{
+		RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:        keyID.String(),
+		UserID:    userID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if rows == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateAPIKey resolves a raw key to its owner and scopes and records its use.
+func (a *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (models.APIKeyPrincipal, error) {
+	prefix, ok := parseAPIKeyPrefix(key)
+	if !ok {
+		return models.APIKeyPrincipal{}, ErrInvalidAPIKey
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return models.APIKeyPrincipal{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	keyQ := database.NewRealAPIKeyQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	stored, err := keyQ.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.APIKeyPrincipal{}, ErrInvalidAPIKey
+		}
+		return models.APIKeyPrincipal{}, fmt.Errorf("failed to fetch api key: %w", err)
+	}
+	if stored.RevokedAt.Valid || subtle.ConstantTimeCompare([]byte(HashOneTimeToken(key)), []byte(stored.KeyHash)) != 1 {
+		return models.APIKeyPrincipal{}, ErrInvalidAPIKey
+	}
+	userID, err := uuid.Parse(stored.UserID)
+	if err != nil {
+		return models.APIKeyPrincipal{}, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+
+	now := time.Now()
+	if !stored.LastUsedAt.Valid || now.Sub(stored.LastUsedAt.Time) >= apiKeyLastUsedInterval {
+		if err := keyQ.UpdateAPIKeyLastUsed(ctx, database.UpdateAPIKeyLastUsedParams{
+			LastUsedAt: sql.NullTime{Time: now, Valid: true},
+			ID:         stored.ID,
+		}); err != nil {
+			return models.APIKeyPrincipal{}, fmt.Errorf("failed to update api key last used: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return models.APIKeyPrincipal{}, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+
+	return models.APIKeyPrincipal{
+		KeyID:  stored.ID,
+		UserID: userID,
+		Scopes: strings.Fields(stored.Scopes),
+	}, nil
+}
+
+// Helpers
+func generateAPIKey() (string, string, error) {
+	prefixBytes := make([]byte, 5)
+	if _, err := models.RandReader(prefixBytes); err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, 20)
+	if _, err := models.RandReader(secretBytes); err != nil {
+		return "", "", err
+	}
+	prefix := apiKeyTag + "_" + apiKeyEncoding.EncodeToString(prefixBytes)
+	return prefix, prefix + "_" + apiKeyEncoding.EncodeToString(secretBytes), nil
+}
+
+func parseAPIKeyPrefix(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyTag || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[0] + "_" + parts[1], true
+}
+
+func normalizeScopes(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return append([]string(nil), APIKeyScopes...), nil
+	}
+	seen := make(map[string]bool, len(requested))
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		scope = strings.TrimSpace(scope)
+		if !isKnownScope(scope) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range APIKeyScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+const (
+	attemptCountPrefix = "attempts:count:"
+	attemptBlockPrefix = "attempts:block:"
+)
+
+// RedisAttemptStore keeps attempt counters in Redis so limits hold across instances.
+type RedisAttemptStore struct {
+	client *redis.Client
+}
+
+func NewRedisAttemptStore(client *redis.Client) *RedisAttemptStore {
+	return &RedisAttemptStore{client: client}
+}
+
+// incrScript bumps a counter and starts its window on the first hit in one atomic
+// step, so a failure between the two cannot leave a counter that never expires.
+var incrScript = redis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if n == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n
+`)
+
+func (r *RedisAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return incrScript.Run(ctx, r.client, []string{attemptCountPrefix + key}, window.Milliseconds()).Int64()
+}
+
+func (r *RedisAttemptStore) Block(ctx context.Context, key string, d time.Duration) error {
+	return r.client.Set(ctx, attemptBlockPrefix+key, 1, d).Err()
+}
+
+func (r *RedisAttemptStore) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, attemptBlockPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// Missing keys report a negative TTL.
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (r *RedisAttemptStore) Reset(ctx context.Context, key string) error {
+	return r.client.Del(ctx, attemptCountPrefix+key, attemptBlockPrefix+key).Err()
+}
+
+// MemoryAttemptStore is an in-process AttemptStore for tests and single-instance deployments.
+type MemoryAttemptStore struct {
+	mu       sync.Mutex
+	counters map[string]attemptCounter
+	blocks   map[string]time.Time
+}
+
+type attemptCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+func NewMemoryAttemptStore() *MemoryAttemptStore {
+	return &MemoryAttemptStore{
+		counters: make(map[string]attemptCounter),
+		blocks:   make(map[string]time.Time),
+	}
+}
+
+func (m *MemoryAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	c, ok := m.counters[key]
+	if !ok {
+		c.expiresAt = time.Now().Add(window)
+	}
+	c.count++
+	m.counters[key] = c
+	return c.count, nil
+}
+
+func (m *MemoryAttemptStore) Block(ctx context.Context, key string, d time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	m.blocks[key] = time.Now().Add(d)
+	return nil
+}
+
+func (m *MemoryAttemptStore) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	until, ok := m.blocks[key]
+	if !ok {
+		return 0, nil
+	}
+	if remaining := time.Until(until); remaining > 0 {
+		return remaining, nil
+	}
+	return 0, nil
+}
+
+func (m *MemoryAttemptStore) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.counters, key)
+	delete(m.blocks, key)
+	return nil
+}
+
+func (m *MemoryAttemptStore) evictExpired() {
+	now := time.Now()
+	for key, c := range m.counters {
+		if !now.Before(c.expiresAt) {
+			delete(m.counters, key)
+		}
+	}
+	for key, until := range m.blocks {
+		if !now.Before(until) {
+			delete(m.blocks, key)
+		}
+	}
+}
+
+// FallbackAttemptStore uses primary and switches to fallback for any call that fails,
+// so an unreachable Redis degrades to per-instance limits instead of none.
+type FallbackAttemptStore struct {
+	primary  AttemptStore
+	fallback AttemptStore
+	logger   *zap.Logger
+}
+
+func NewFallbackAttemptStore(primary, fallback AttemptStore, logger *zap.Logger) *FallbackAttemptStore {
+	return &FallbackAttemptStore{
+		primary:  primary,
+		fallback: fallback,
+		logger:   logger,
+	}
+}
+
+func (f *FallbackAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	n, err := f.primary.Incr(ctx, key, window)
+	if err != nil {
+		f.warn(err)
+		return f.fallback.Incr(ctx, key, window)
+	}
+	return n, nil
+}
+
+func (f *FallbackAttemptStore) Block(ctx context.Context, key string, d time.Duration) error {
+	if err := f.primary.Block(ctx, key, d); err != nil {
+		f.warn(err)
+		return f.fallback.Block(ctx, key, d)
+	}
+	return nil
+}
+
+func (f *FallbackAttemptStore) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	d, err := f.primary.BlockedFor(ctx, key)
+	if err != nil {
+		f.warn(err)
+		return f.fallback.BlockedFor(ctx, key)
+	}
+	// A block recorded while the primary was down still applies.
+	if d == 0 {
+		return f.fallback.BlockedFor(ctx, key)
+	}
+	return d, nil
+}
+
+func (f *FallbackAttemptStore) Reset(ctx context.Context, key string) error {
+	if err := f.fallback.Reset(ctx, key); err != nil {
+		return err
+	}
+	if err := f.primary.Reset(ctx, key); err != nil {
+		f.warn(err)
+	}
+	return nil
+}
+
+func (f *FallbackAttemptStore) warn(err error) {
+	f.logger.Warn("attempt store unavailable, using in-memory fallback", zap.Error(err))
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	emailVerificationTTL = 24 * time.Hour
+	// VerifyEmailResendCooldown is the minimum gap between verification emails for one account.
+	VerifyEmailResendCooldown = time.Minute
+)
+
+// UnverifiedEmailPolicy controls how Login treats accounts that have not confirmed their email.
+type UnverifiedEmailPolicy int
+
+const (
+	// UnverifiedEmailAllow signs unverified users in as normal.
+	UnverifiedEmailAllow UnverifiedEmailPolicy = iota
+	// UnverifiedEmailRestrict issues an access token only. No refresh token or
+	// device session is created, so the session ends when the access token expires.
+	UnverifiedEmailRestrict
+	// UnverifiedEmailRefuse rejects the login with ErrEmailNotVerified.
+	UnverifiedEmailRefuse
+)
+
+// ParseUnverifiedEmailPolicy maps "allow", "restrict" or "refuse" to a policy.
+// An empty string is treated as "allow".
+func ParseUnverifiedEmailPolicy(s string) (UnverifiedEmailPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "allow":
+		return UnverifiedEmailAllow, nil
+	case "restrict":
+		return UnverifiedEmailRestrict, nil
+	case "refuse":
+		return UnverifiedEmailRefuse, nil
+	default:
+		return UnverifiedEmailAllow, fmt.Errorf("unknown unverified email policy %q", s)
+	}
+}
+
+// SendVerificationEmail issues a fresh verification token for the user and emails it,
+// invalidating any link sent earlier.
+func (a *AuthService) SendVerificationEmail(ctx context.Context, userID, email string) error {
+	token, err := a.TokenGen.MakeRefreshToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	verifyQ := database.NewRealEmailVerificationQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	now := time.Now()
+	if err := verifyQ.InvalidateUserEmailVerificationTokens(ctx, database.InvalidateUserEmailVerificationTokensParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		UserID: userID,
+	}); err != nil {
+		return fmt.Errorf("failed to invalidate verification tokens: %w", err)
+	}
+	if err := verifyQ.CreateEmailVerificationToken(ctx, database.CreateEmailVerificationTokenParams{
+		ID:        uuid.NewString(),
+		TokenHash: HashOneTimeToken(token),
+		UserID:    userID,
+		ExpiresAt: now.Add(emailVerificationTTL),
+	}); err != nil {
+		return fmt.Errorf("failed to create verification token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := a.Mailer.Send(ctx, verificationMessage(email, token)); err != nil {
+		a.logger.Error("verification email failed", zap.String("user_id", userID), zap.Error(err))
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// ResendVerificationEmail sends a new verification link to an unverified account.
+// Unknown and already verified addresses succeed silently so the endpoint cannot be
+// used to probe for accounts.
+func (a *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	if !models.IsValidEmail(email) {
+		return ErrInvalidEmail
+	}
+
+	user, err := a.UserQuerier.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if user.EmailVerifiedAt.Valid {
+		return nil
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	verifyQ := database.NewRealEmailVerificationQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	latest, err := verifyQ.GetLatestEmailVerificationToken(ctx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to fetch verification token: %w", err)
+	}
+	if err == nil && latest.CreatedAt.Valid && time.Since(latest.CreatedAt.Time) < VerifyEmailResendCooldown {
+		return ErrVerifyEmailThrottled
+	}
+	// Release the read transaction before SendVerificationEmail opens its own.
+	if err := tx.Rollback(); err != nil {
+		return fmt.Errorf("failed to close transaction: %w", err)
+	}
+
+	return a.SendVerificationEmail(ctx, user.ID, email)
+}
+
+// VerifyEmail consumes a verification token and marks the owning account as verified.
+func (a *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	verifyQ := database.NewRealEmailVerificationQuerier(queriesTx)
+	userQ := database.NewRealUserQuerier(queriesTx)
+
+	stored, err := verifyQ.GetEmailVerificationTokenByHash(ctx, HashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidVerifyToken
+		}
+		return fmt.Errorf("failed to fetch verification token: %w", err)
+	}
+	if stored.UsedAt.Valid {
+		return ErrInvalidVerifyToken
+	}
+	now := time.Now()
+	if now.After(stored.ExpiresAt) {
+		return ErrVerifyTokenExpired
+	}
+
+	rows, err := verifyQ.MarkEmailVerificationTokenUsed(ctx, database.MarkEmailVerificationTokenUsedParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		ID:     stored.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to consume verification token: %w", err)
+	}
+	if rows == 0 {
+		return ErrInvalidVerifyToken
+	}
+
+	if err := userQ.MarkUserEmailVerified(ctx, database.MarkUserEmailVerifiedParams{
+		EmailVerifiedAt: sql.NullTime{Time: now, Valid: true},
+		ID:              stored.UserID,
+	}); err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Helpers
+func verificationMessage(to, token string) mailer.Message {
+	baseURL := os.Getenv("APP_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	link := baseURL + "/verify-email?token=" + url.QueryEscape(token)
+
+	return mailer.Message{
+		To:      to,
+		Subject: "Confirm your Unity Wealth email address",
+		Body: "Thanks for signing up for Unity Wealth.\n\n" +
+			"Confirm your email address within 24 hours using the link below:\n\n" +
+			link + "\n\n" +
+			"If you did not create an account, you can ignore this email.\n",
+	}
+}
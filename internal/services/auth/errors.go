@@ -6,4 +6,26 @@ var (
 	ErrNoAuthHeaderIncluded = errors.New("no authorization header included")
 	ErrInvalidEmail         = errors.New("invalid email")
 	ErrInvalidPassword      = errors.New("invalid password")
+	ErrInvalidRefreshToken  = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired  = errors.New("refresh token expired")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected")
+	ErrSessionNotFound      = errors.New("session not found")
+	ErrInvalidResetToken    = errors.New("invalid password reset token")
+	ErrResetTokenExpired    = errors.New("password reset token expired")
+	ErrInvalidVerifyToken   = errors.New("invalid email verification token")
+	ErrVerifyTokenExpired   = errors.New("email verification token expired")
+	ErrVerifyEmailThrottled = errors.New("verification email requested too recently")
+	ErrEmailNotVerified     = errors.New("email not verified")
+	ErrMFAAlreadyEnabled    = errors.New("mfa already enabled")
+	ErrMFANotEnabled        = errors.New("mfa not enabled")
+	ErrInvalidMFACode       = errors.New("invalid mfa code")
+	ErrInvalidMFAChallenge  = errors.New("invalid or expired mfa challenge")
+	ErrInvalidCredentials   = errors.New("invalid email / password")
+	ErrTooManyAttempts      = errors.New("too many attempts")
+	ErrInvalidAPIKey        = errors.New("invalid api key")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrInvalidAPIKeyName    = errors.New("invalid api key name")
+	ErrInvalidScope         = errors.New("invalid scope")
+	ErrPasswordMismatch     = errors.New("password does not match hash")
+	ErrMalformedHash        = errors.New("malformed password hash")
 )
@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAPIKeysIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+	pwdHasher := auth.NewRealPwdHasher()
+	userID := seedTestUserForAuth(t, pwdHasher, userQ)
+
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		_, err := svc.CreateAPIKey(ctx, userID, models.CreateAPIKeyInput{Name: "   "})
+		require.ErrorIs(t, err, auth.ErrInvalidAPIKeyName)
+
+		_, err = svc.CreateAPIKey(ctx, userID, models.CreateAPIKeyInput{Name: "script", Scopes: []string{"admin"}})
+		require.ErrorIs(t, err, auth.ErrInvalidScope)
+	})
+
+	readOnly, err := svc.CreateAPIKey(ctx, userID, models.CreateAPIKeyInput{
+		Name:   "read only",
+		Scopes: []string{auth.ScopeTransactionsRead, auth.ScopeTransactionsRead},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(readOnly.Key, readOnly.Prefix+"_"))
+	require.Equal(t, []string{auth.ScopeTransactionsRead}, readOnly.Scopes)
+
+	fullAccess, err := svc.CreateAPIKey(ctx, userID, models.CreateAPIKeyInput{Name: "full access"})
+	require.NoError(t, err)
+	require.Equal(t, auth.APIKeyScopes, fullAccess.Scopes)
+
+	t.Run("key is stored hashed", func(t *testing.T) {
+		var stored string
+		require.NoError(t, db.QueryRow("SELECT key_hash FROM api_keys WHERE id = ?", readOnly.ID).Scan(&stored))
+		require.NotEqual(t, readOnly.Key, stored)
+		require.Equal(t, auth.HashOneTimeToken(readOnly.Key), stored)
+	})
+
+	t.Run("authenticate resolves owner and scopes", func(t *testing.T) {
+		principal, err := svc.AuthenticateAPIKey(ctx, readOnly.Key)
+		require.NoError(t, err)
+		require.Equal(t, userID, principal.UserID)
+		require.Equal(t, readOnly.ID, principal.KeyID)
+		require.Equal(t, []string{auth.ScopeTransactionsRead}, principal.Scopes)
+
+		var lastUsed sql.NullTime
+		require.NoError(t, db.QueryRow("SELECT last_used_at FROM api_keys WHERE id = ?", readOnly.ID).Scan(&lastUsed))
+		require.True(t, lastUsed.Valid)
+		require.WithinDuration(t, time.Now(), lastUsed.Time, time.Minute)
+	})
+
+	t.Run("authenticate rejects bad keys", func(t *testing.T) {
+		for _, key := range []string{
+			"",
+			"not-a-key",
+			readOnly.Prefix + "_wrongsecret",
+			"uw_unknown0_" + strings.Split(readOnly.Key, "_")[2],
+		} {
+			_, err := svc.AuthenticateAPIKey(ctx, key)
+			require.ErrorIs(t, err, auth.ErrInvalidAPIKey, key)
+		}
+	})
+
+	t.Run("list returns active keys newest first", func(t *testing.T) {
+		keys, err := svc.ListAPIKeys(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		ids := []string{keys[0].ID, keys[1].ID}
+		require.ElementsMatch(t, []string{readOnly.ID, fullAccess.ID}, ids)
+		for _, key := range keys {
+			if key.ID == readOnly.ID {
+				require.NotNil(t, key.LastUsedAt)
+			}
+		}
+	})
+
+	t.Run("revoke is scoped to owner", func(t *testing.T) {
+		keyID := uuid.MustParse(readOnly.ID)
+		require.ErrorIs(t, svc.RevokeAPIKey(ctx, uuid.New(), keyID), auth.ErrAPIKeyNotFound)
+		require.NoError(t, svc.RevokeAPIKey(ctx, userID, keyID))
+		require.ErrorIs(t, svc.RevokeAPIKey(ctx, userID, keyID), auth.ErrAPIKeyNotFound)
+
+		_, err := svc.AuthenticateAPIKey(ctx, readOnly.Key)
+		require.ErrorIs(t, err, auth.ErrInvalidAPIKey)
+
+		keys, err := svc.ListAPIKeys(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, fullAccess.ID, keys[0].ID)
+	})
+}
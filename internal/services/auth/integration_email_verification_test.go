@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/services/user"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailVerificationIntegration(t *testing.T) {
+	env := testhelpers.SetupTestEnv(t)
+	defer env.Db.Close()
+	authSvc := env.Services.AuthService
+	ctx := context.Background()
+
+	require.NoError(t, env.Services.UserService.SignUp(ctx, user.SignUpInput{
+		Email:    "new@example.com",
+		Password: "Validpass1!",
+	}))
+	messages := env.Mailer.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "new@example.com", messages[0].To)
+	token := extractEmailToken(t, messages[0].Body)
+
+	req := httptest.NewRequest("POST", "/login", nil)
+	req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+	loginCtx := context.WithValue(req.Context(), constants.RequestKey, req)
+	input := models.LoginInput{Email: "new@example.com", Password: "Validpass1!"}
+
+	t.Run("resend is throttled right after signup", func(t *testing.T) {
+		err := authSvc.ResendVerificationEmail(ctx, "new@example.com")
+		require.ErrorIs(t, err, auth.ErrVerifyEmailThrottled)
+	})
+
+	t.Run("unverified login is refused", func(t *testing.T) {
+		authSvc.UnverifiedEmailPolicy = auth.UnverifiedEmailRefuse
+		_, err := authSvc.Login(loginCtx, input)
+		require.ErrorIs(t, err, auth.ErrEmailNotVerified)
+	})
+
+	t.Run("unverified login is restricted", func(t *testing.T) {
+		authSvc.UnverifiedEmailPolicy = auth.UnverifiedEmailRestrict
+		resp, err := authSvc.Login(loginCtx, input)
+		require.NoError(t, err)
+		require.True(t, resp.Restricted)
+		require.Empty(t, resp.RefreshToken)
+		claims, err := authSvc.TokenGen.ValidateJWT(resp.JWTToken)
+		require.NoError(t, err)
+		require.True(t, auth.IsRestricted(&claims.RegisteredClaims))
+	})
+
+	require.NoError(t, authSvc.VerifyEmail(ctx, token))
+
+	t.Run("verified login is unrestricted", func(t *testing.T) {
+		authSvc.UnverifiedEmailPolicy = auth.UnverifiedEmailRefuse
+		resp, err := authSvc.Login(loginCtx, input)
+		require.NoError(t, err)
+		require.False(t, resp.Restricted)
+		require.NotEmpty(t, resp.RefreshToken)
+		claims, err := authSvc.TokenGen.ValidateJWT(resp.JWTToken)
+		require.NoError(t, err)
+		require.False(t, auth.IsRestricted(&claims.RegisteredClaims))
+	})
+
+	t.Run("token is single use", func(t *testing.T) {
+		require.ErrorIs(t, authSvc.VerifyEmail(ctx, token), auth.ErrInvalidVerifyToken)
+	})
+
+	t.Run("verified accounts get no resend", func(t *testing.T) {
+		require.NoError(t, authSvc.ResendVerificationEmail(ctx, "new@example.com"))
+		require.Len(t, env.Mailer.Messages(), 1)
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -16,6 +18,7 @@ import (
 	"github.com/seanhuebl/unity-wealth/internal/services/auth"
 	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestLoginIntegration(t *testing.T) {
@@ -125,7 +128,7 @@ func TestLoginIntegration(t *testing.T) {
 				ctx = context.WithValue(req.Context(), constants.RequestKey, req)
 			}
 
-			svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, nil)
+			svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, nil, nil, nil)
 			response, err := svc.Login(ctx, tc.input)
 			if tc.hasErr {
 				require.Error(t, err)
@@ -154,8 +157,10 @@ func TestLoginIntegration(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotNil(t, getRefreshTokenEntry)
-			err = svc.PwdHasher.CheckPasswordHash(response.RefreshToken, getRefreshTokenEntry.TokenHash)
+			tokenID, secret, err := auth.ParseRefreshToken(response.RefreshToken)
 			require.NoError(t, err)
+			require.Equal(t, tokenID.String(), getRefreshTokenEntry.ID)
+			require.True(t, svc.RefreshHasher.Verify(secret, getRefreshTokenEntry.TokenHash))
 			_, err = svc.TokenGen.ValidateJWT(response.JWTToken)
 			require.NoError(t, err)
 
@@ -163,6 +168,85 @@ func TestLoginIntegration(t *testing.T) {
 	}
 }
 
+func TestLoginThrottleIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+	pwdHasher := auth.NewRealPwdHasher()
+	seedTestUserForAuth(t, pwdHasher, userQ)
+
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, nil, nil, zap.NewNop())
+	svc.Throttle = auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+	svc.Throttle.BaseDelay = 0
+
+	req := httptest.NewRequest("POST", "/login", nil)
+	req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+	ctx := context.WithValue(req.Context(), constants.RequestKey, req)
+	ctx = context.WithValue(ctx, constants.ClientIPKey, "203.0.113.7")
+
+	wrong := models.LoginInput{Email: "user@example.com", Password: "Wrongpass1!"}
+	for i := int64(1); i < svc.Throttle.MaxFailures; i++ {
+		_, err := svc.Login(ctx, wrong)
+		require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	}
+	_, err = svc.Login(ctx, wrong)
+	require.ErrorIs(t, err, auth.ErrTooManyAttempts)
+
+	// The right password is refused too until the lockout ends.
+	_, err = svc.Login(ctx, models.LoginInput{Email: "user@example.com", Password: "Validpass1!"})
+	var retryErr *auth.RetryAfterError
+	require.ErrorAs(t, err, &retryErr)
+	require.Greater(t, retryErr.RetryAfter, time.Duration(0))
+}
+
+func TestPasswordRehashIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+
+	// The user signed up while passwords were hashed with bcrypt.
+	userID := seedTestUserForAuth(t, auth.NewRealPwdHasher(), userQ)
+	storedHash := func() string {
+		var hash string
+		require.NoError(t, db.QueryRow("SELECT hashed_password FROM users WHERE id = ?", userID.String()).Scan(&hash))
+		return hash
+	}
+	legacyHash := storedHash()
+
+	hasher := auth.NewArgon2idHasher(auth.Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, hasher, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	_, err = svc.ValidateCredentials(ctx, models.LoginInput{Email: "user@example.com", Password: "Wrongpass1!"})
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	require.Equal(t, legacyHash, storedHash(), "failed login must not rehash")
+
+	gotID, err := svc.ValidateCredentials(ctx, models.LoginInput{Email: "user@example.com", Password: "Validpass1!"})
+	require.NoError(t, err)
+	require.Equal(t, userID, gotID)
+
+	upgraded := storedHash()
+	require.True(t, strings.HasPrefix(upgraded, "$argon2id$"), upgraded)
+	require.False(t, hasher.NeedsRehash(upgraded))
+
+	// The upgraded hash keeps working and is not rewritten again.
+	_, err = svc.ValidateCredentials(ctx, models.LoginInput{Email: "user@example.com", Password: "Validpass1!"})
+	require.NoError(t, err)
+	require.Equal(t, upgraded, storedHash())
+}
+
 // Helpers
 func seedTestUserForAuth(t *testing.T, hasher auth.PasswordHasher, userQ database.UserQuerier) uuid.UUID {
 	password := "Validpass1!"
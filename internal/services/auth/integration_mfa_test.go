@@ -0,0 +1,211 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMFAIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+	pwdHasher := auth.NewRealPwdHasher()
+	userID := seedTestUserForAuth(t, pwdHasher, userQ)
+
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, nil, nil, zap.NewNop())
+	ctx := context.Background()
+	credentials := models.LoginInput{Email: "user@example.com", Password: "Validpass1!"}
+
+	deviceCtx := func(header string) context.Context {
+		req := httptest.NewRequest("POST", "/login", nil)
+		req.Header.Set("X-Device-Info", header)
+		return context.WithValue(req.Context(), constants.RequestKey, req)
+	}
+	phone := deviceCtx("os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+	laptop := deviceCtx("os=Windows; os_version=11; device_type=Desktop; browser=Firefox; browser_version=120.0")
+
+	enrollment, err := svc.EnrollTOTP(ctx, userID)
+	require.NoError(t, err)
+	require.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+
+	t.Run("login unaffected before confirmation", func(t *testing.T) {
+		resp, err := svc.Login(phone, credentials)
+		require.NoError(t, err)
+		require.False(t, resp.MFARequired)
+		require.NotEmpty(t, resp.JWTToken)
+	})
+
+	t.Run("confirm rejects wrong code", func(t *testing.T) {
+		_, err := svc.ConfirmTOTP(ctx, userID, "000000")
+		require.ErrorIs(t, err, auth.ErrInvalidMFACode)
+	})
+
+	code, err := auth.TOTPCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	recoveryCodes, err := svc.ConfirmTOTP(ctx, userID, code)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, 10)
+
+	t.Run("recovery codes are stored as keyed hashes", func(t *testing.T) {
+		var stored int
+		hash := svc.RefreshHasher.Hash(strings.ReplaceAll(recoveryCodes[9], "-", ""))
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM mfa_recovery_codes WHERE code_hash = ?", hash).Scan(&stored))
+		require.Equal(t, 1, stored)
+	})
+
+	t.Run("enroll refused once enabled", func(t *testing.T) {
+		_, err := svc.EnrollTOTP(ctx, userID)
+		require.ErrorIs(t, err, auth.ErrMFAAlreadyEnabled)
+	})
+
+	t.Run("login returns challenge instead of tokens", func(t *testing.T) {
+		resp, err := svc.Login(phone, credentials)
+		require.NoError(t, err)
+		require.True(t, resp.MFARequired)
+		require.NotEmpty(t, resp.MFAToken)
+		require.Empty(t, resp.JWTToken)
+		require.Empty(t, resp.RefreshToken)
+	})
+
+	t.Run("TOTP code completes login and trusts device", func(t *testing.T) {
+		resp, err := svc.Login(phone, credentials)
+		require.NoError(t, err)
+
+		// The confirmation code's step is spent, so use the next one.
+		next, err := auth.TOTPCode(enrollment.Secret, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		loginResp, err := svc.CompleteMFALogin(phone, models.MFALoginInput{MFAToken: resp.MFAToken, Code: next, TrustDevice: true})
+		require.NoError(t, err)
+		require.Equal(t, userID, loginResp.UserID)
+		require.NotEmpty(t, loginResp.JWTToken)
+		require.NotEmpty(t, loginResp.RefreshToken)
+		require.NotEmpty(t, loginResp.TrustedDeviceToken)
+
+		_, err = svc.CompleteMFALogin(phone, models.MFALoginInput{MFAToken: resp.MFAToken, Code: next})
+		require.ErrorIs(t, err, auth.ErrInvalidMFAChallenge)
+
+		withToken := credentials
+		withToken.TrustedDeviceToken = loginResp.TrustedDeviceToken
+		trusted, err := svc.Login(phone, withToken)
+		require.NoError(t, err)
+		require.False(t, trusted.MFARequired)
+		require.NotEmpty(t, trusted.RefreshToken)
+
+		// The token is what is trusted, not the device fields, which anyone can send.
+		spoofed, err := svc.Login(phone, credentials)
+		require.NoError(t, err)
+		require.True(t, spoofed.MFARequired)
+
+		forged := credentials
+		forged.TrustedDeviceToken = "not-a-token"
+		spoofed, err = svc.Login(phone, forged)
+		require.NoError(t, err)
+		require.True(t, spoofed.MFARequired)
+	})
+
+	t.Run("replayed TOTP code rejected", func(t *testing.T) {
+		resp, err := svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		require.True(t, resp.MFARequired)
+
+		_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, Code: code})
+		require.ErrorIs(t, err, auth.ErrInvalidMFACode)
+	})
+
+	t.Run("challenge dies after too many attempts", func(t *testing.T) {
+		resp, err := svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: "wrong-wrong"})
+			require.ErrorIs(t, err, auth.ErrInvalidMFACode)
+		}
+		_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: recoveryCodes[0]})
+		require.ErrorIs(t, err, auth.ErrInvalidMFAChallenge)
+	})
+
+	t.Run("recovery code is single use", func(t *testing.T) {
+		resp, err := svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: recoveryCodes[0]})
+		require.NoError(t, err)
+
+		resp, err = svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: recoveryCodes[0]})
+		require.ErrorIs(t, err, auth.ErrInvalidMFACode)
+	})
+
+	t.Run("unverified user still needs the second factor", func(t *testing.T) {
+		svc.UnverifiedEmailPolicy = auth.UnverifiedEmailRestrict
+		defer func() { svc.UnverifiedEmailPolicy = auth.UnverifiedEmailAllow }()
+
+		resp, err := svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		require.True(t, resp.MFARequired)
+		require.Empty(t, resp.JWTToken)
+
+		loginResp, err := svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: recoveryCodes[3]})
+		require.NoError(t, err)
+		require.True(t, loginResp.Restricted)
+		require.Empty(t, loginResp.RefreshToken)
+		claims, err := tokenGen.ValidateJWT(loginResp.JWTToken)
+		require.NoError(t, err)
+		require.True(t, auth.IsRestricted(&claims.RegisteredClaims))
+	})
+
+	t.Run("wrong codes lock the user out across challenges", func(t *testing.T) {
+		svc.Throttle = auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+		defer func() { svc.Throttle = nil }()
+
+		for i := int64(1); i < svc.Throttle.MaxMFAFailures; i++ {
+			// Each guess comes from a fresh password login.
+			resp, err := svc.Login(laptop, credentials)
+			require.NoError(t, err)
+			_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: "wrong-wrong"})
+			require.ErrorIs(t, err, auth.ErrInvalidMFACode)
+		}
+		resp, err := svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		require.ErrorIs(t, svc.DisableTOTP(ctx, userID, "wrong-wrong"), auth.ErrTooManyAttempts)
+
+		_, err = svc.CompleteMFALogin(laptop, models.MFALoginInput{MFAToken: resp.MFAToken, RecoveryCode: recoveryCodes[1]})
+		require.ErrorIs(t, err, auth.ErrTooManyAttempts)
+		_, err = svc.Login(laptop, credentials)
+		require.ErrorIs(t, err, auth.ErrTooManyAttempts)
+		require.ErrorIs(t, svc.DisableTOTP(ctx, userID, recoveryCodes[1]), auth.ErrTooManyAttempts)
+	})
+
+	t.Run("disable with recovery code", func(t *testing.T) {
+		require.NoError(t, svc.DisableTOTP(ctx, userID, recoveryCodes[1]))
+		require.ErrorIs(t, svc.DisableTOTP(ctx, userID, recoveryCodes[2]), auth.ErrMFANotEnabled)
+
+		resp, err := svc.Login(laptop, credentials)
+		require.NoError(t, err)
+		require.False(t, resp.MFARequired)
+
+		var trusted int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM mfa_trusted_devices").Scan(&trusted))
+		require.Zero(t, trusted)
+	})
+}
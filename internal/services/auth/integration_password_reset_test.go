@@ -0,0 +1,124 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPasswordResetIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenQ := database.NewRealTokenQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+	pwdHasher := auth.NewRealPwdHasher()
+	memMailer := mailer.NewMemoryMailer()
+	denylist := auth.NewMemoryTokenDenylist(auth.AccessTokenTTL)
+	seedTestUserForAuth(t, pwdHasher, userQ)
+
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, denylist, memMailer, zap.NewNop())
+
+	req := httptest.NewRequest("POST", "/login", nil)
+	req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+	loginCtx := context.WithValue(req.Context(), constants.RequestKey, req)
+	loginResp, err := svc.Login(loginCtx, models.LoginInput{Email: "user@example.com", Password: "Validpass1!"})
+	require.NoError(t, err)
+	claims, err := tokenGen.ValidateJWT(loginResp.JWTToken)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("unknown email sends nothing", func(t *testing.T) {
+		require.NoError(t, svc.RequestPasswordReset(ctx, "nobody@example.com"))
+		svc.Wait()
+		require.Empty(t, memMailer.Messages())
+	})
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, "user@example.com"))
+	svc.Wait()
+	messages := memMailer.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "user@example.com", messages[0].To)
+	token := extractEmailToken(t, messages[0].Body)
+
+	// The stored value is a hash, never the token itself.
+	var storedHash string
+	require.NoError(t, db.QueryRow("SELECT token_hash FROM password_reset_tokens").Scan(&storedHash))
+	require.Equal(t, auth.HashOneTimeToken(token), storedHash)
+
+	// Access tokens issued in the same second as the reset would otherwise survive it.
+	time.Sleep(time.Second)
+	require.NoError(t, svc.ResetPassword(ctx, token, "Newpass1!"))
+
+	t.Run("password changed", func(t *testing.T) {
+		_, err := svc.ValidateCredentials(ctx, models.LoginInput{Email: "user@example.com", Password: "Validpass1!"})
+		require.Error(t, err)
+		_, err = svc.ValidateCredentials(ctx, models.LoginInput{Email: "user@example.com", Password: "Newpass1!"})
+		require.NoError(t, err)
+	})
+
+	t.Run("sessions revoked", func(t *testing.T) {
+		refreshID, _, err := auth.ParseRefreshToken(loginResp.RefreshToken)
+		require.NoError(t, err)
+		stored, err := tokenQ.GetRefreshTokenByID(ctx, refreshID.String())
+		require.NoError(t, err)
+		require.True(t, stored.RevokedAt.Valid)
+
+		denied, err := denylist.IsDenied(ctx, claims)
+		require.NoError(t, err)
+		require.True(t, denied)
+	})
+
+	t.Run("token is single use", func(t *testing.T) {
+		err := svc.ResetPassword(ctx, token, "Otherpass1!")
+		require.ErrorIs(t, err, auth.ErrInvalidResetToken)
+	})
+
+	t.Run("newer request invalidates older token", func(t *testing.T) {
+		require.NoError(t, svc.RequestPasswordReset(ctx, "user@example.com"))
+		svc.Wait()
+		require.NoError(t, svc.RequestPasswordReset(ctx, "user@example.com"))
+		svc.Wait()
+		messages := memMailer.Messages()
+		older := extractEmailToken(t, messages[len(messages)-2].Body)
+		newer := extractEmailToken(t, messages[len(messages)-1].Body)
+
+		require.ErrorIs(t, svc.ResetPassword(ctx, older, "Otherpass1!"), auth.ErrInvalidResetToken)
+		require.NoError(t, svc.ResetPassword(ctx, newer, "Otherpass1!"))
+	})
+}
+
+func extractEmailToken(t *testing.T, body string) string {
+	t.Helper()
+	for _, line := range strings.Split(body, "\n") {
+		if strings.Contains(line, "token=") {
+			u, err := url.Parse(strings.TrimSpace(line))
+			require.NoError(t, err)
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("no token link in body: %q", body)
+	return ""
+}
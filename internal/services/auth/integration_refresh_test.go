@@ -0,0 +1,85 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRefreshIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	tokenQ := database.NewRealTokenQuerier(transactionalQ)
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+	pwdHasher := auth.NewRealPwdHasher()
+	userID := seedTestUserForAuth(t, pwdHasher, userQ)
+
+	req := httptest.NewRequest("POST", "/login", nil)
+	req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+	ctx := context.WithValue(req.Context(), constants.RequestKey, req)
+
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, nil, nil, zap.NewNop())
+	loginResp, err := svc.Login(ctx, models.LoginInput{
+		Email:    "user@example.com",
+		Password: "Validpass1!",
+	})
+	require.NoError(t, err)
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		refreshResp, err := svc.Refresh(ctx, loginResp.RefreshToken)
+		require.NoError(t, err)
+		require.Equal(t, userID, refreshResp.UserID)
+		require.NotEqual(t, loginResp.RefreshToken, refreshResp.RefreshToken)
+		_, err = tokenGen.ValidateJWT(refreshResp.JWTToken)
+		require.NoError(t, err)
+
+		oldID, _, err := auth.ParseRefreshToken(loginResp.RefreshToken)
+		require.NoError(t, err)
+		newID, _, err := auth.ParseRefreshToken(refreshResp.RefreshToken)
+		require.NoError(t, err)
+
+		oldToken, err := tokenQ.GetRefreshTokenByID(ctx, oldID.String())
+		require.NoError(t, err)
+		require.True(t, oldToken.RevokedAt.Valid)
+
+		newToken, err := tokenQ.GetRefreshTokenByID(ctx, newID.String())
+		require.NoError(t, err)
+		require.False(t, newToken.RevokedAt.Valid)
+		require.Equal(t, oldToken.FamilyID, newToken.FamilyID)
+
+		t.Run("reusing the old token revokes the family", func(t *testing.T) {
+			_, err := svc.Refresh(ctx, loginResp.RefreshToken)
+			require.ErrorIs(t, err, auth.ErrRefreshTokenReused)
+
+			newToken, err := tokenQ.GetRefreshTokenByID(ctx, newID.String())
+			require.NoError(t, err)
+			require.True(t, newToken.RevokedAt.Valid)
+
+			_, err = svc.Refresh(ctx, refreshResp.RefreshToken)
+			require.ErrorIs(t, err, auth.ErrRefreshTokenReused)
+		})
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		_, err := svc.Refresh(ctx, "00000000-0000-0000-0000-000000000000.secret")
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+}
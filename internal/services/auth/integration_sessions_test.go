@@ -0,0 +1,101 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSessionsIntegration(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+
+	testhelpers.CreateTestingSchema(t, db)
+	transactionalQ := database.NewRealTransactionalQuerier(database.New(db))
+	sqlTxQ := database.NewRealSqlTxQuerier(transactionalQ)
+	userQ := database.NewRealUserQuerier(transactionalQ)
+	tokenGen := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth"))
+	pwdHasher := auth.NewRealPwdHasher()
+	userID := seedTestUserForAuth(t, pwdHasher, userQ)
+	denylist := auth.NewMemoryTokenDenylist(time.Hour)
+	svc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, nil, pwdHasher, denylist, nil, zap.NewNop())
+
+	login := func(deviceHeader string) models.LoginResponse {
+		req := httptest.NewRequest("POST", "/login", nil)
+		req.Header.Set("X-Device-Info", deviceHeader)
+		ctx := context.WithValue(req.Context(), constants.RequestKey, req)
+		resp, err := svc.Login(ctx, models.LoginInput{
+			Email:    "user@example.com",
+			Password: "Validpass1!",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	phone := login("os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0; app_info=unity-wealth/2.0.1")
+	laptop := login("os=Windows; os_version=10; device_type=Desktop; browser=Firefox; browser_version=120.0")
+
+	ctx := context.Background()
+	sessions, err := svc.ListSessions(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	var phoneSession models.Session
+	for _, s := range sessions {
+		require.True(t, s.Active)
+		require.False(t, s.LastUsedAt.IsZero())
+		if s.Os == "Android" {
+			phoneSession = s
+		}
+	}
+	require.Equal(t, "unity-wealth/2.0.1", phoneSession.AppInfo)
+	phoneID := uuid.MustParse(phoneSession.ID)
+
+	t.Run("other users cannot revoke the session", func(t *testing.T) {
+		err := svc.RevokeSession(ctx, uuid.New(), phoneID)
+		require.ErrorIs(t, err, auth.ErrSessionNotFound)
+	})
+
+	t.Run("revoking a session ends it", func(t *testing.T) {
+		require.NoError(t, svc.RevokeSession(ctx, userID, phoneID))
+
+		sessions, err := svc.ListSessions(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		for _, s := range sessions {
+			require.Equal(t, s.ID != phoneSession.ID, s.Active)
+		}
+
+		_, err = svc.Refresh(ctx, phone.RefreshToken)
+		require.ErrorIs(t, err, auth.ErrRefreshTokenReused)
+	})
+
+	t.Run("revoking a session denies its access tokens", func(t *testing.T) {
+		phoneClaims, err := tokenGen.ValidateJWT(phone.JWTToken)
+		require.NoError(t, err)
+		require.Equal(t, phoneSession.ID, phoneClaims.SessionID)
+		denied, err := denylist.IsDenied(ctx, phoneClaims)
+		require.NoError(t, err)
+		require.True(t, denied)
+
+		laptopClaims, err := tokenGen.ValidateJWT(laptop.JWTToken)
+		require.NoError(t, err)
+		denied, err = denylist.IsDenied(ctx, laptopClaims)
+		require.NoError(t, err)
+		require.False(t, denied)
+	})
+}
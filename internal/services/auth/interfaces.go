@@ -1,25 +1,71 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
 )
 
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
 	CheckPasswordHash(password, hash string) error
+	// NeedsRehash reports whether hash should be replaced with a fresh HashPassword result.
+	NeedsRehash(hash string) bool
+}
+
+type RefreshTokenHasher interface {
+	Hash(token string) string
+	Verify(token, hash string) bool
 }
 
 type TokenGenerator interface {
-	MakeJWT(userID uuid.UUID, expiresIn time.Duration) (string, error)
-	ValidateJWT(tokenString string) (*jwt.RegisteredClaims, error)
+	MakeJWT(userID, sessionID uuid.UUID, expiresIn time.Duration) (string, error)
+	MakeRestrictedJWT(userID uuid.UUID, expiresIn time.Duration) (string, error)
+	ValidateJWT(tokenString string) (*AccessClaims, error)
 	MakeRefreshToken() (string, error)
+	JWKS() models.JWKSet
 }
 
 type TokenExtractor interface {
 	GetAPIKey(headers http.Header) (string, error)
 	GetBearerToken(headers http.Header) (string, error)
 }
+
+type TokenDenylist interface {
+	DenyToken(ctx context.Context, claims *jwt.RegisteredClaims) error
+	DenyUser(ctx context.Context, userID string, issuedBefore time.Time) error
+	// DenyDevice denies the access tokens issued to the session of device deviceID
+	// up to issuedBefore.
+	DenyDevice(ctx context.Context, deviceID string, issuedBefore time.Time) error
+	IsDenied(ctx context.Context, claims *AccessClaims) (bool, error)
+}
+
+// AttemptStore keeps short-lived counters and blocks for rate limiting.
+type AttemptStore interface {
+	// Incr adds one to the counter for key and returns the new value. A new counter
+	// expires after window.
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Block refuses key for d.
+	Block(ctx context.Context, key string, d time.Duration) error
+	// BlockedFor returns how much longer key is blocked, or zero.
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Reset clears the counter and any block for key.
+	Reset(ctx context.Context, key string) error
+}
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (models.APIKeyPrincipal, error)
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg mailer.Message) error
+}
+
+type EmailVerifier interface {
+	SendVerificationEmail(ctx context.Context, userID, email string) error
+}
@@ -0,0 +1,311 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+const minRSAKeyBits = 2048
+
+// SigningKey is one key in a KeyRing. RSA keys sign with RS256 and Ed25519 keys with
+// EdDSA. Keys loaded from a public key PEM can verify tokens but not sign them.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// CanSign reports whether the key holds a private key.
+func (k *SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+// ParseSigningKey parses a PEM encoded private key (PKCS#8 or PKCS#1) or public key.
+func ParseSigningKey(kid string, data []byte) (*SigningKey, error) {
+	if kid == "" {
+		return nil, errors.New("key id must not be empty")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %q: no PEM data found", kid)
+	}
+
+	var private crypto.Signer
+	var public crypto.PublicKey
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("key %q: unsupported private key type %T", kid, parsed)
+		}
+		private, public = signer, signer.Public()
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		private, public = parsed, parsed.Public()
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		public = parsed
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		public = parsed
+	default:
+		return nil, fmt.Errorf("key %q: unsupported PEM block %q", kid, block.Type)
+	}
+
+	key := &SigningKey{ID: kid, private: private, public: public}
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("key %q: RSA keys must be at least %d bits", kid, minRSAKeyBits)
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T", kid, public)
+	}
+	return key, nil
+}
+
+// LoadSigningKeyFile reads a PEM key from path. The key id is the file name without
+// its extension.
+func LoadSigningKeyFile(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from server configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	base := filepath.Base(path)
+	return ParseSigningKey(strings.TrimSuffix(base, filepath.Ext(base)), data)
+}
+
+// KeyRingSource says where a KeyRing loads its keys from.
+type KeyRingSource struct {
+	// Dir holds one PEM file per key, named <kid>.pem.
+	Dir string
+	// ActiveFile is the signing key. PreviousFiles are kept for verification only.
+	ActiveFile    string
+	PreviousFiles []string
+	// ActiveKID picks the signing key when ActiveFile is empty. If it is also empty,
+	// the last kid in Dir in sort order signs, so date named files rotate by adding
+	// a newer file.
+	ActiveKID string
+}
+
+// KeyRing holds the active signing key and the previous keys that tokens may still be
+// signed with. A key is retired by removing it from the ring; tokens carrying its kid
+// no longer validate.
+type KeyRing struct {
+	source *KeyRingSource
+
+	mu     sync.RWMutex
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewKeyRing(active *SigningKey, previous ...*SigningKey) (*KeyRing, error) {
+	k := &KeyRing{}
+	if err := k.set(active, previous); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// LoadKeyRing builds a KeyRing from source. Call Reload, or Watch, to pick up changes.
+func LoadKeyRing(source KeyRingSource) (*KeyRing, error) {
+	k := &KeyRing{source: &source}
+	if err := k.Reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload rereads the key files. On error the current keys stay in use.
+func (k *KeyRing) Reload() error {
+	if k.source == nil {
+		return errors.New("key ring was not loaded from files")
+	}
+	var keys []*SigningKey
+	if k.source.Dir != "" {
+		entries, err := os.ReadDir(k.source.Dir)
+		if err != nil {
+			return fmt.Errorf("failed to read key directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" {
+				continue
+			}
+			key, err := LoadSigningKeyFile(filepath.Join(k.source.Dir, entry.Name()))
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+	}
+	activeKID := k.source.ActiveKID
+	if k.source.ActiveFile != "" {
+		key, err := LoadSigningKeyFile(k.source.ActiveFile)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		activeKID = key.ID
+	}
+	for _, path := range k.source.PreviousFiles {
+		key, err := LoadSigningKeyFile(path)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return errors.New("no signing keys found")
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	if activeKID == "" {
+		activeKID = keys[len(keys)-1].ID
+	}
+	var active *SigningKey
+	previous := make([]*SigningKey, 0, len(keys)-1)
+	for _, key := range keys {
+		if key.ID == activeKID && active == nil {
+			active = key
+			continue
+		}
+		previous = append(previous, key)
+	}
+	if active == nil {
+		return fmt.Errorf("active key %q not found", activeKID)
+	}
+	return k.set(active, previous)
+}
+
+// Watch reloads the keys every interval until ctx is done.
+func (k *KeyRing) Watch(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				before := k.Active().ID
+				if err := k.Reload(); err != nil {
+					logger.Warn("failed to reload signing keys", zap.Error(err))
+					continue
+				}
+				if after := k.Active().ID; after != before {
+					logger.Info("signing_key_rotated", zap.String("previous_kid", before), zap.String("kid", after))
+				}
+			}
+		}
+	}()
+}
+
+// Active returns the key new tokens are signed with.
+func (k *KeyRing) Active() *SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.active
+}
+
+// Lookup returns the key with the given kid if it is still in the ring.
+func (k *KeyRing) Lookup(kid string) (*SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// JWKS returns the public half of every key in the ring, active key first.
+func (k *KeyRing) JWKS() models.JWKSet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	set := models.JWKSet{Keys: make([]models.JWK, 0, len(k.keys))}
+	set.Keys = append(set.Keys, toJWK(k.active))
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		if id != k.active.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		set.Keys = append(set.Keys, toJWK(k.keys[id]))
+	}
+	return set
+}
+
+func (k *KeyRing) set(active *SigningKey, previous []*SigningKey) error {
+	if active == nil {
+		return errors.New("active key must not be nil")
+	}
+	if !active.CanSign() {
+		return fmt.Errorf("active key %q has no private key", active.ID)
+	}
+	keys := map[string]*SigningKey{active.ID: active}
+	for _, key := range previous {
+		if _, dup := keys[key.ID]; dup {
+			return fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		keys[key.ID] = key
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.active = active
+	k.keys = keys
+	return nil
+}
+
+func toJWK(key *SigningKey) models.JWK {
+	jwk := models.JWK{
+		Kid: key.ID,
+		Alg: key.Method.Alg(),
+		Use: "sig",
+	}
+	switch pub := key.public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+)
+
+// Logout ends the caller's session on the current device. The access token in
+// claims is denylisted and the device's refresh tokens are revoked.
+func (a *AuthService) Logout(ctx context.Context, claims *jwt.RegisteredClaims, refreshToken string) error {
+	if err := a.Denylist.DenyToken(ctx, claims); err != nil {
+		return fmt.Errorf("failed to denylist access token: %w", err)
+	}
+
+	// Without a refresh token there is no device to revoke.
+	tokenID, _, err := ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	tokenQ := database.NewRealTokenQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	stored, err := tokenQ.GetRefreshTokenByID(ctx, tokenID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch refresh token: %w", err)
+	}
+	// Never let one user revoke another user's device.
+	if stored.UserID != claims.Subject {
+		return nil
+	}
+
+	if err := tokenQ.RevokeToken(ctx, database.RevokeTokenParams{
+		RevokedAt:    sql.NullTime{Time: time.Now(), Valid: true},
+		UserID:       stored.UserID,
+		DeviceInfoID: stored.DeviceInfoID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// LogoutAll signs the user out everywhere: every refresh token is revoked and
+// every access token issued so far is denylisted.
+func (a *AuthService) LogoutAll(ctx context.Context, claims *jwt.RegisteredClaims) error {
+	now := time.Now()
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	tokenQ := database.NewRealTokenQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	if err := tokenQ.RevokeAllUserTokens(ctx, database.RevokeAllUserTokensParams{
+		RevokedAt: sql.NullTime{Time: now, Valid: true},
+		UserID:    claims.Subject,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := a.Denylist.DenyUser(ctx, claims.Subject, now); err != nil {
+		return fmt.Errorf("failed to denylist access tokens: %w", err)
+	}
+	return nil
+}
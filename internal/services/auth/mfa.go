@@ -0,0 +1,447 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// MFAChallengeTTL is how long the token returned by a password login can be
+	// exchanged at POST /login/mfa.
+	MFAChallengeTTL = 5 * time.Minute
+	// MFATrustTTL is how long a trusted device token skips the second factor.
+	MFATrustTTL       = 30 * 24 * time.Hour
+	mfaMaxAttempts    = 5
+	recoveryCodeCount = 10
+)
+
+const recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+// EnrollTOTP stores a new pending TOTP secret for the user. MFA stays off until the
+// secret is confirmed with a code from the authenticator app.
+func (a *AuthService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (models.TOTPEnrollment, error) {
+	user, err := a.UserQuerier.GetUserMFAByID(ctx, userID.String())
+	if err != nil {
+		return models.TOTPEnrollment{}, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if user.TotpEnabledAt.Valid {
+		return models.TOTPEnrollment{}, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return models.TOTPEnrollment{}, fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+	if err := a.UserQuerier.SetUserTOTPSecret(ctx, database.SetUserTOTPSecretParams{
+		TotpSecret: sql.NullString{String: secret, Valid: true},
+		UpdatedAt:  sql.NullTime{Time: time.Now(), Valid: true},
+		ID:         userID.String(),
+	}); err != nil {
+		return models.TOTPEnrollment{}, fmt.Errorf("failed to store TOTP secret: %w", err)
+	}
+
+	return models.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: TOTPProvisioningURI(user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns MFA on once code matches the pending secret and returns a fresh set
+// of recovery codes. The plain codes are only ever available in this response.
+func (a *AuthService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	userQ := database.NewRealUserQuerier(queriesTx)
+	mfaQ := database.NewRealMFAQuerier(queriesTx)
+
+	user, err := userQ.GetUserMFAByID(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if user.TotpEnabledAt.Valid {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if !user.TotpSecret.Valid {
+		return nil, ErrMFANotEnabled
+	}
+
+	now := time.Now()
+	step, ok := ValidateTOTP(user.TotpSecret.String, code, now, 0)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	rows, err := userQ.EnableUserTOTP(ctx, database.EnableUserTOTPParams{
+		TotpEnabledAt: sql.NullTime{Time: now, Valid: true},
+		TotpLastStep:  sql.NullInt64{Int64: step, Valid: true},
+		ID:            userID.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable TOTP: %w", err)
+	}
+	if rows == 0 {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	codes, err := a.replaceRecoveryCodes(ctx, mfaQ, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns MFA off after checking a current TOTP or recovery code. Recovery
+// codes and trusted devices are discarded with it. Wrong codes count against the user
+// as they do at login.
+func (a *AuthService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	userQ := database.NewRealUserQuerier(queriesTx)
+	mfaQ := database.NewRealMFAQuerier(queriesTx)
+
+	user, err := userQ.GetUserMFAByID(ctx, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if !user.TotpEnabledAt.Valid {
+		return ErrMFANotEnabled
+	}
+	if err := a.checkMFAAttempts(ctx, userID.String()); err != nil {
+		return err
+	}
+
+	var ok bool
+	if isTOTPCode(code) {
+		ok, err = a.verifyTOTPCode(ctx, userQ, userID.String(), user, code)
+	} else {
+		ok, err = a.verifyRecoveryCode(ctx, mfaQ, userID.String(), code)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return a.recordMFAFailure(ctx, userID.String())
+	}
+	a.recordMFASuccess(ctx, userID.String())
+
+	if err := userQ.DisableUserTOTP(ctx, database.DisableUserTOTPParams{
+		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:        userID.String(),
+	}); err != nil {
+		return fmt.Errorf("failed to disable TOTP: %w", err)
+	}
+	if err := mfaQ.DeleteUserMFARecoveryCodes(ctx, userID.String()); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if err := mfaQ.DeleteUserMFATrustedDevices(ctx, userID.String()); err != nil {
+		return fmt.Errorf("failed to clear trusted devices: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CompleteMFALogin exchanges an MFA challenge token and a TOTP or recovery code for a
+// full session. A wrong code counts against the challenge, which dies after
+// mfaMaxAttempts, and against the user, who is locked out by the Throttle.
+func (a *AuthService) CompleteMFALogin(ctx context.Context, input models.MFALoginInput) (models.LoginResponse, error) {
+	req, err := helpers.GetRequestFromContext(ctx)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	deviceInfo, err := GetDeviceInfoFromRequest(req)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	userQ := database.NewRealUserQuerier(queriesTx)
+	mfaQ := database.NewRealMFAQuerier(queriesTx)
+
+	challenge, err := mfaQ.GetMFAChallengeByHash(ctx, HashOneTimeToken(input.MFAToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LoginResponse{}, ErrInvalidMFAChallenge
+		}
+		return models.LoginResponse{}, fmt.Errorf("failed to fetch mfa challenge: %w", err)
+	}
+	now := time.Now()
+	if challenge.UsedAt.Valid || now.After(challenge.ExpiresAt) || challenge.Attempts >= mfaMaxAttempts {
+		return models.LoginResponse{}, ErrInvalidMFAChallenge
+	}
+	if err := a.checkMFAAttempts(ctx, challenge.UserID); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	user, err := userQ.GetUserMFAByID(ctx, challenge.UserID)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	var ok bool
+	if input.Code != "" {
+		ok, err = a.verifyTOTPCode(ctx, userQ, challenge.UserID, user, input.Code)
+	} else {
+		ok, err = a.verifyRecoveryCode(ctx, mfaQ, challenge.UserID, input.RecoveryCode)
+	}
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	if !ok {
+		if err := mfaQ.IncrementMFAChallengeAttempts(ctx, challenge.ID); err != nil {
+			return models.LoginResponse{}, fmt.Errorf("failed to record mfa attempt: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return models.LoginResponse{}, a.recordMFAFailure(ctx, challenge.UserID)
+	}
+
+	rows, err := mfaQ.MarkMFAChallengeUsed(ctx, database.MarkMFAChallengeUsedParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		ID:     challenge.ID,
+	})
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to consume mfa challenge: %w", err)
+	}
+	if rows == 0 {
+		return models.LoginResponse{}, ErrInvalidMFAChallenge
+	}
+	a.recordMFASuccess(ctx, challenge.UserID)
+
+	userID, err := uuid.Parse(challenge.UserID)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+	if !user.EmailVerifiedAt.Valid {
+		switch a.UnverifiedEmailPolicy {
+		case UnverifiedEmailRefuse:
+			return models.LoginResponse{}, ErrEmailNotVerified
+		case UnverifiedEmailRestrict:
+			if err := tx.Commit(); err != nil {
+				return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+			}
+			return a.restrictedLogin(userID)
+		}
+	}
+	response, err := a.createSession(ctx, queriesTx, userID, deviceInfo)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	if input.TrustDevice {
+		response.TrustedDeviceToken, err = a.trustDevice(ctx, mfaQ, userID, now)
+		if err != nil {
+			return models.LoginResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return response, nil
+}
+
+// Helpers
+
+// checkMFAAttempts refuses a user locked out for wrong second factor codes.
+func (a *AuthService) checkMFAAttempts(ctx context.Context, userID string) error {
+	if a.Throttle == nil {
+		return nil
+	}
+	return a.Throttle.CheckMFA(ctx, userID)
+}
+
+// recordMFAFailure counts a wrong second factor code and returns the error to report:
+// the lockout if the code triggered one, ErrInvalidMFACode otherwise.
+func (a *AuthService) recordMFAFailure(ctx context.Context, userID string) error {
+	if a.Throttle == nil {
+		return ErrInvalidMFACode
+	}
+	err := a.Throttle.RecordMFAFailure(ctx, userID)
+	if errors.Is(err, ErrTooManyAttempts) {
+		return err
+	}
+	if err != nil {
+		a.logger.Warn("unable to record failed mfa attempt", zap.Error(err))
+	}
+	return ErrInvalidMFACode
+}
+
+func (a *AuthService) recordMFASuccess(ctx context.Context, userID string) {
+	if a.Throttle == nil {
+		return
+	}
+	if err := a.Throttle.RecordMFASuccess(ctx, userID); err != nil {
+		a.logger.Warn("unable to reset mfa attempts", zap.Error(err))
+	}
+}
+
+// isTrustedDevice reports whether token is a live trusted device token of the user.
+// Only its hash is stored, like the other one-time tokens.
+func (a *AuthService) isTrustedDevice(ctx context.Context, mfaQ database.MFAQuerier, userID uuid.UUID, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	device, err := mfaQ.GetMFATrustedDeviceByHash(ctx, HashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch trusted device: %w", err)
+	}
+	return device.UserID == userID.String() && time.Now().Before(device.ExpiresAt), nil
+}
+
+// trustDevice stores a new trusted device token for the user and returns it.
+func (a *AuthService) trustDevice(ctx context.Context, mfaQ database.MFAQuerier, userID uuid.UUID, now time.Time) (string, error) {
+	token, err := a.TokenGen.MakeRefreshToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate trusted device token: %w", err)
+	}
+	if err := mfaQ.CreateMFATrustedDevice(ctx, database.CreateMFATrustedDeviceParams{
+		ID:        uuid.NewString(),
+		TokenHash: HashOneTimeToken(token),
+		UserID:    userID.String(),
+		ExpiresAt: now.Add(MFATrustTTL),
+	}); err != nil {
+		return "", fmt.Errorf("failed to trust device: %w", err)
+	}
+	return token, nil
+}
+
+func (a *AuthService) createMFAChallenge(ctx context.Context, mfaQ database.MFAQuerier, userID uuid.UUID) (string, error) {
+	token, err := a.TokenGen.MakeRefreshToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate mfa token: %w", err)
+	}
+	if err := mfaQ.CreateMFAChallenge(ctx, database.CreateMFAChallengeParams{
+		ID:        uuid.NewString(),
+		TokenHash: HashOneTimeToken(token),
+		UserID:    userID.String(),
+		ExpiresAt: time.Now().Add(MFAChallengeTTL),
+	}); err != nil {
+		return "", fmt.Errorf("failed to create mfa challenge: %w", err)
+	}
+	return token, nil
+}
+
+// verifyTOTPCode checks code and advances the user's last accepted step so the same
+// code cannot be used twice.
+func (a *AuthService) verifyTOTPCode(ctx context.Context, userQ database.UserQuerier, userID string, user database.GetUserMFAByIDRow, code string) (bool, error) {
+	if !user.TotpEnabledAt.Valid || !user.TotpSecret.Valid {
+		return false, nil
+	}
+	step, ok := ValidateTOTP(user.TotpSecret.String, code, time.Now(), user.TotpLastStep.Int64)
+	if !ok {
+		return false, nil
+	}
+	rows, err := userQ.UpdateUserTOTPLastStep(ctx, database.UpdateUserTOTPLastStepParams{
+		TotpLastStep: sql.NullInt64{Int64: step, Valid: true},
+		ID:           userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record TOTP step: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// verifyRecoveryCode consumes the matching unused recovery code, if any. Codes are
+// stored as keyed HMACs, so the lookup is a single indexed query.
+func (a *AuthService) verifyRecoveryCode(ctx context.Context, mfaQ database.MFAQuerier, userID, code string) (bool, error) {
+	code = normalizeRecoveryCode(code)
+	if code == "" {
+		return false, nil
+	}
+	rows, err := mfaQ.UseMFARecoveryCode(ctx, database.UseMFARecoveryCodeParams{
+		UsedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+		UserID:   userID,
+		CodeHash: a.RefreshHasher.Hash(code),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (a *AuthService) replaceRecoveryCodes(ctx context.Context, mfaQ database.MFAQuerier, userID string) ([]string, error) {
+	if err := mfaQ.DeleteUserMFARecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		if err := mfaQ.CreateMFARecoveryCode(ctx, database.CreateMFARecoveryCodeParams{
+			ID:       uuid.NewString(),
+			UserID:   userID,
+			CodeHash: a.RefreshHasher.Hash(normalizeRecoveryCode(code)),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create recovery code: %w", err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// generateRecoveryCode returns ten base32 characters formatted as xxxxx-xxxxx.
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := models.RandReader(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+	}
+	return string(buf[:5]) + "-" + string(buf[5:]), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func isTOTPCode(code string) bool {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
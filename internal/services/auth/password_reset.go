@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+const passwordResetTTL = time.Hour
+
+// RequestPasswordReset emails a single-use reset link to the account behind email.
+// The account lookup and the email happen in the background and failures are only
+// logged, so neither the response time nor the status tells whether the account
+// exists. Only a malformed email and a *RetryAfterError from PasswordResetLimiter,
+// which counts requests for any address, are reported.
+func (a *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	if !models.IsValidEmail(email) {
+		return ErrInvalidEmail
+	}
+	if a.PasswordResetLimiter != nil {
+		err := a.PasswordResetLimiter.Allow(ctx, strings.ToLower(strings.TrimSpace(email)))
+		var retryErr *RetryAfterError
+		if errors.As(err, &retryErr) {
+			return err
+		}
+		if err != nil {
+			// Let the request through if the limiter itself is failing.
+			a.logger.Warn("password reset rate limit unavailable", zap.Error(err))
+		}
+	}
+
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		if err := a.sendPasswordReset(context.WithoutCancel(ctx), email); err != nil {
+			a.logger.Error("password reset request failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// sendPasswordReset replaces the user's reset token and emails the new one. Unknown
+// addresses get nothing.
+func (a *AuthService) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := a.UserQuerier.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	token, err := a.TokenGen.MakeRefreshToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	resetQ := database.NewRealPasswordResetQuerier(a.SqlTxQuerier.WithTx(tx))
+
+	// Only the most recently requested link stays usable.
+	now := time.Now()
+	if err := resetQ.InvalidateUserPasswordResetTokens(ctx, database.InvalidateUserPasswordResetTokensParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		UserID: user.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+	if err := resetQ.CreatePasswordResetToken(ctx, database.CreatePasswordResetTokenParams{
+		ID:        uuid.NewString(),
+		TokenHash: HashOneTimeToken(token),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(passwordResetTTL),
+	}); err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := a.Mailer.Send(ctx, passwordResetMessage(email, token)); err != nil {
+		return fmt.Errorf("failed to send reset email to user %s: %w", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password using a token from RequestPasswordReset.
+// The token is consumed and every session the user has is signed out.
+func (a *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if err := models.ValidatePassword(newPassword); err != nil {
+		return fmt.Errorf("%w, %v", ErrInvalidPassword, err)
+	}
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	resetQ := database.NewRealPasswordResetQuerier(queriesTx)
+	userQ := database.NewRealUserQuerier(queriesTx)
+	tokenQ := database.NewRealTokenQuerier(queriesTx)
+
+	stored, err := resetQ.GetPasswordResetTokenByHash(ctx, HashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to fetch reset token: %w", err)
+	}
+	if stored.UsedAt.Valid {
+		return ErrInvalidResetToken
+	}
+	now := time.Now()
+	if now.After(stored.ExpiresAt) {
+		return ErrResetTokenExpired
+	}
+
+	// Guarded by used_at IS NULL so two concurrent resets cannot both succeed.
+	rows, err := resetQ.MarkPasswordResetTokenUsed(ctx, database.MarkPasswordResetTokenUsedParams{
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+		ID:     stored.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to consume reset token: %w", err)
+	}
+	if rows == 0 {
+		return ErrInvalidResetToken
+	}
+
+	hashedPW, err := a.PwdHasher.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := userQ.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+		HashedPassword: hashedPW,
+		UpdatedAt:      sql.NullTime{Time: now, Valid: true},
+		ID:             stored.UserID,
+	}); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := tokenQ.RevokeAllUserTokens(ctx, database.RevokeAllUserTokensParams{
+		RevokedAt: sql.NullTime{Time: now, Valid: true},
+		UserID:    stored.UserID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := a.Denylist.DenyUser(ctx, stored.UserID, now); err != nil {
+		return fmt.Errorf("failed to denylist access tokens: %w", err)
+	}
+	return nil
+}
+
+// HashOneTimeToken returns the digest stored in place of an emailed reset or verification
+// token. These tokens are high-entropy and short-lived, so a fast, deterministic hash
+// allows lookup by value.
+func HashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Helpers
+func passwordResetMessage(to, token string) mailer.Message {
+	baseURL := os.Getenv("APP_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	link := baseURL + "/password/reset?token=" + url.QueryEscape(token)
+
+	return mailer.Message{
+		To:      to,
+		Subject: "Reset your Unity Wealth password",
+		Body: "We received a request to reset your password.\n\n" +
+			"Use the link below within one hour to choose a new one:\n\n" +
+			link + "\n\n" +
+			"If you did not request this, you can ignore this email.\n",
+	}
+}
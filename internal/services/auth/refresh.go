@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+// Refresh exchanges a refresh token for a new JWT and rotates the refresh token.
+// Presenting a token that is no longer the device's active token is treated as
+// theft and revokes every token in its family.
+func (a *AuthService) Refresh(ctx context.Context, refreshToken string) (models.LoginResponse, error) {
+
+	// 1. Split the presented token into its record ID and secret.
+	tokenID, secret, err := ParseRefreshToken(refreshToken)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	// 2. Start a database transaction.
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	tokenQ := database.NewRealTokenQuerier(queriesTx)
+
+	// 3. Load the stored token and verify the secret.
+	stored, err := tokenQ.GetRefreshTokenByID(ctx, tokenID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LoginResponse{}, ErrInvalidRefreshToken
+		}
+		return models.LoginResponse{}, fmt.Errorf("failed to fetch refresh token: %w", err)
+	}
+	if !a.RefreshHasher.Verify(secret, stored.TokenHash) {
+		return models.LoginResponse{}, ErrInvalidRefreshToken
+	}
+
+	// 4. The token must still be the device's active token, otherwise it was reused.
+	active, err := tokenQ.GetRefreshByUserAndDevice(ctx, database.GetRefreshByUserAndDeviceParams{
+		UserID:       stored.UserID,
+		DeviceInfoID: stored.DeviceInfoID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.LoginResponse{}, fmt.Errorf("failed to fetch active refresh token: %w", err)
+	}
+	if stored.RevokedAt.Valid || errors.Is(err, sql.ErrNoRows) || active.ID != stored.ID {
+		if err := a.revokeFamily(ctx, tokenQ, stored); err != nil {
+			return models.LoginResponse{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return models.LoginResponse{}, ErrRefreshTokenReused
+	}
+
+	if !stored.ExpiresAt.Valid || stored.ExpiresAt.Time.Before(time.Now()) {
+		return models.LoginResponse{}, ErrRefreshTokenExpired
+	}
+
+	userID, err := uuid.Parse(stored.UserID)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+	deviceID, err := uuid.Parse(stored.DeviceInfoID)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to parse device ID: %w", err)
+	}
+
+	// 5. Revoke the presented token. A concurrent refresh with the same token may
+	// have revoked it since it was loaded; only one of them gets to rotate it.
+	revoked, err := tokenQ.RevokeTokenByID(ctx, database.RevokeTokenByIDParams{
+		RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:        stored.ID,
+	})
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if revoked == 0 {
+		if err := a.revokeFamily(ctx, tokenQ, stored); err != nil {
+			return models.LoginResponse{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return models.LoginResponse{}, ErrRefreshTokenReused
+	}
+
+	// 6. Generate JWT and the rotated refresh token.
+	jwtToken, newRefreshToken, err := a.GenerateTokens(userID, deviceID)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	refreshHash := a.RefreshHasher.Hash(newRefreshToken)
+
+	// 7. Store the rotated token in the same family.
+	familyID := stored.FamilyID
+	if !familyID.Valid {
+		familyID = sql.NullString{String: stored.ID, Valid: true}
+	}
+	newID := uuid.New()
+	if err := tokenQ.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		ID:           newID.String(),
+		TokenHash:    refreshHash,
+		ExpiresAt:    sql.NullTime{Time: time.Now().Add(refreshTokenTTL), Valid: true},
+		UserID:       stored.UserID,
+		DeviceInfoID: stored.DeviceInfoID,
+		FamilyID:     familyID,
+	}); err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to create refresh token entry: %w", err)
+	}
+
+	// 8. Record device activity.
+	deviceQ := database.NewRealDevicequerier(queriesTx)
+	if err := deviceQ.UpdateDeviceLastUsed(ctx, database.UpdateDeviceLastUsedParams{
+		LastUsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		ID:         stored.DeviceInfoID,
+	}); err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to update device last used: %w", err)
+	}
+
+	// 9. Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return models.LoginResponse{
+		UserID:       userID,
+		RefreshToken: FormatRefreshToken(newID, newRefreshToken),
+		JWTToken:     jwtToken,
+	}, nil
+}
+
+// FormatRefreshToken builds the value handed to the client: the token record ID and the secret.
+func FormatRefreshToken(id uuid.UUID, secret string) string {
+	return id.String() + "." + secret
+}
+
+// ParseRefreshToken splits a client refresh token into its record ID and secret.
+func ParseRefreshToken(token string) (uuid.UUID, string, error) {
+	idPart, secret, found := strings.Cut(token, ".")
+	if !found || secret == "" {
+		return uuid.Nil, "", ErrInvalidRefreshToken
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return uuid.Nil, "", ErrInvalidRefreshToken
+	}
+	return id, secret, nil
+}
+
+func (a *AuthService) revokeFamily(ctx context.Context, tokenQ database.TokenQuerier, stored models.RefreshToken) error {
+	familyID := stored.FamilyID
+	if !familyID.Valid {
+		familyID = sql.NullString{String: stored.ID, Valid: true}
+	}
+	a.logger.Warn("refresh token reuse detected",
+		zap.String("user_id", stored.UserID),
+		zap.String("device_info_id", stored.DeviceInfoID),
+		zap.String("family_id", familyID.String),
+	)
+	if err := tokenQ.RevokeTokenFamily(ctx, database.RevokeTokenFamilyParams{
+		RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		FamilyID:  familyID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+	return nil
+}
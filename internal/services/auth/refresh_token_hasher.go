@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HMACTokenHasher hashes refresh tokens with HMAC-SHA256. The tokens are 256-bit random
+// values, so a slow password hash adds cost without adding security; the key keeps a
+// leaked table from being checked against candidate tokens.
+type HMACTokenHasher struct {
+	key []byte
+}
+
+func NewHMACTokenHasher(key []byte) *HMACTokenHasher {
+	return &HMACTokenHasher{key: key}
+}
+
+func (h *HMACTokenHasher) Hash(token string) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify also accepts bcrypt hashes so tokens issued before the switch keep working
+// until they are rotated or expire.
+func (h *HMACTokenHasher) Verify(token, hash string) bool {
+	if isBcryptHash(hash) {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(token)) == nil
+	}
+	return hmac.Equal([]byte(h.Hash(token)), []byte(hash))
+}
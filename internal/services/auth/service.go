@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,26 +19,54 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 60 * 24 * time.Hour
+)
+
 type AuthService struct {
 	SqlTxQuerier database.SqlTxQuerier
 	UserQuerier  database.UserQuerier
 	TokenGen     TokenGenerator
 	TokenExtract TokenExtractor
 	PwdHasher    PasswordHasher
-	logger       *zap.Logger
+	// RefreshHasher hashes refresh tokens and MFA recovery codes. NewAuthService sets
+	// an unkeyed HMAC hasher; production should set one with a server key.
+	RefreshHasher RefreshTokenHasher
+	Denylist      TokenDenylist
+	Mailer        Mailer
+	// UnverifiedEmailPolicy decides whether Login admits users who have not verified
+	// their email. The zero value admits them.
+	UnverifiedEmailPolicy UnverifiedEmailPolicy
+	// Throttle limits failed logins per email and client IP. Nil disables it.
+	Throttle *LoginThrottle
+	// PasswordResetLimiter caps password reset requests per email. Nil disables it.
+	PasswordResetLimiter *RateLimiter
+	logger               *zap.Logger
+	// background tracks the password reset emails still being sent.
+	background sync.WaitGroup
 }
 
-func NewAuthService(SqlTxQuerier database.SqlTxQuerier, UserQuerier database.UserQuerier, TokenGen TokenGenerator, tokenExtract TokenExtractor, PwdHasher PasswordHasher, logger *zap.Logger) *AuthService {
+func NewAuthService(SqlTxQuerier database.SqlTxQuerier, UserQuerier database.UserQuerier, TokenGen TokenGenerator, tokenExtract TokenExtractor, PwdHasher PasswordHasher, denylist TokenDenylist, mailer Mailer, logger *zap.Logger) *AuthService {
 	return &AuthService{
-		SqlTxQuerier: SqlTxQuerier,
-		UserQuerier:  UserQuerier,
-		TokenGen:     TokenGen,
-		TokenExtract: tokenExtract,
-		PwdHasher:    PwdHasher,
-		logger:       logger,
+		SqlTxQuerier:  SqlTxQuerier,
+		UserQuerier:   UserQuerier,
+		TokenGen:      TokenGen,
+		TokenExtract:  tokenExtract,
+		PwdHasher:     PwdHasher,
+		RefreshHasher: NewHMACTokenHasher(nil),
+		Denylist:      denylist,
+		Mailer:        mailer,
+		logger:        logger,
 	}
 }
 
+// Wait blocks until the work RequestPasswordReset left running in the background is
+// done.
+func (a *AuthService) Wait() {
+	a.background.Wait()
+}
+
 // Login encapsulates the entire login process.
 func (a *AuthService) Login(ctx context.Context, input models.LoginInput) (models.LoginResponse, error) {
 
@@ -46,11 +75,41 @@ func (a *AuthService) Login(ctx context.Context, input models.LoginInput) (model
 		return models.LoginResponse{}, fmt.Errorf("invalid email format")
 	}
 
-	// 2. Validate credentials and fetch user.
-	userID, err := a.ValidateCredentials(ctx, input)
+	// 2. Refuse throttled callers, then validate credentials and fetch user.
+	clientIP := helpers.GetClientIP(ctx)
+	if a.Throttle != nil {
+		if err := a.Throttle.Check(ctx, input.Email, clientIP); err != nil {
+			return models.LoginResponse{}, err
+		}
+	}
+	user, err := a.checkCredentials(ctx, input)
 	if err != nil {
+		if a.Throttle != nil && errors.Is(err, ErrInvalidCredentials) {
+			throttleErr := a.Throttle.RecordFailure(ctx, input.Email, clientIP)
+			if errors.Is(throttleErr, ErrTooManyAttempts) {
+				return models.LoginResponse{}, throttleErr
+			}
+			if throttleErr != nil {
+				a.logger.Warn("unable to record failed login", zap.Error(throttleErr))
+			}
+		}
 		return models.LoginResponse{}, err
 	}
+	if a.Throttle != nil {
+		if err := a.Throttle.RecordSuccess(ctx, input.Email); err != nil {
+			a.logger.Warn("unable to reset login attempts", zap.Error(err))
+		}
+	}
+	userID, err := uuid.Parse(user.ID)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+
+	// 2a. Refuse unverified users outright if the policy says so. Restricted users
+	// still have to pass the second factor below.
+	if !user.EmailVerifiedAt.Valid && a.UnverifiedEmailPolicy == UnverifiedEmailRefuse {
+		return models.LoginResponse{}, ErrEmailNotVerified
+	}
 
 	req, err := helpers.GetRequestFromContext(ctx)
 	if err != nil {
@@ -69,71 +128,155 @@ func (a *AuthService) Login(ctx context.Context, input models.LoginInput) (model
 	}
 	defer tx.Rollback()
 	queriesTx := a.SqlTxQuerier.WithTx(tx)
+
+	// 5. Hold the session behind a second factor unless the caller holds a trusted
+	// device token.
+	if user.TotpEnabledAt.Valid {
+		mfaQ := database.NewRealMFAQuerier(queriesTx)
+		trusted, err := a.isTrustedDevice(ctx, mfaQ, userID, input.TrustedDeviceToken)
+		if err != nil {
+			return models.LoginResponse{}, err
+		}
+		if !trusted {
+			// A user locked out for wrong codes gets no new challenge to guess at.
+			if err := a.checkMFAAttempts(ctx, user.ID); err != nil {
+				return models.LoginResponse{}, err
+			}
+			mfaToken, err := a.createMFAChallenge(ctx, mfaQ, userID)
+			if err != nil {
+				return models.LoginResponse{}, err
+			}
+			if err := tx.Commit(); err != nil {
+				return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+			}
+			return models.LoginResponse{
+				UserID:      userID,
+				MFARequired: true,
+				MFAToken:    mfaToken,
+			}, nil
+		}
+	}
+
+	// 6. Unverified users get a restricted token and no session.
+	if !user.EmailVerifiedAt.Valid && a.UnverifiedEmailPolicy == UnverifiedEmailRestrict {
+		return a.restrictedLogin(userID)
+	}
+
+	// 7. Register the device and issue tokens.
+	response, err := a.createSession(ctx, queriesTx, userID, deviceInfo)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	// 8. Commit the transaction.
+	if err := tx.Commit(); err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return response, nil
+}
+
+// Helpers
+func (a *AuthService) ValidateCredentials(ctx context.Context, input models.LoginInput) (uuid.UUID, error) {
+	user, err := a.checkCredentials(ctx, input)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(user.ID)
+}
+
+func (a *AuthService) checkCredentials(ctx context.Context, input models.LoginInput) (database.GetUserByEmailRow, error) {
+	user, err := a.UserQuerier.GetUserByEmail(ctx, input.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.GetUserByEmailRow{}, ErrInvalidCredentials
+		}
+		return database.GetUserByEmailRow{}, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	if err := a.PwdHasher.CheckPasswordHash(input.Password, user.HashedPassword); err != nil {
+		return database.GetUserByEmailRow{}, ErrInvalidCredentials
+	}
+	if a.PwdHasher.NeedsRehash(user.HashedPassword) {
+		a.upgradePasswordHash(ctx, user, input.Password)
+	}
+	return user, nil
+}
+
+// upgradePasswordHash replaces a legacy or outdated hash after a successful login. The
+// update only applies if the stored hash is unchanged, so it cannot undo a concurrent
+// password change. Failures are logged; the old hash keeps working.
+func (a *AuthService) upgradePasswordHash(ctx context.Context, user database.GetUserByEmailRow, password string) {
+	newHash, err := a.PwdHasher.HashPassword(password)
+	if err != nil {
+		a.logger.Warn("failed to rehash password", zap.String("user_id", user.ID), zap.Error(err))
+		return
+	}
+	if _, err := a.UserQuerier.UpgradeUserPasswordHash(ctx, database.UpgradeUserPasswordHashParams{
+		HashedPassword:   newHash,
+		UpdatedAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		ID:               user.ID,
+		HashedPassword_2: user.HashedPassword,
+	}); err != nil {
+		a.logger.Warn("failed to store rehashed password", zap.String("user_id", user.ID), zap.Error(err))
+	}
+}
+
+// restrictedLogin issues the access token of a user who has not verified their email.
+// No device or refresh token is recorded, so the session ends when the token expires.
+func (a *AuthService) restrictedLogin(userID uuid.UUID) (models.LoginResponse, error) {
+	jwtToken, err := a.TokenGen.MakeRestrictedJWT(userID, AccessTokenTTL)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	return models.LoginResponse{
+		UserID:     userID,
+		JWTToken:   jwtToken,
+		Restricted: true,
+	}, nil
+}
+
+// createSession records the device, issues a JWT and starts a new refresh token family.
+func (a *AuthService) createSession(ctx context.Context, queriesTx database.SqlTransactionalQuerier, userID uuid.UUID, deviceInfo models.DeviceInfo) (models.LoginResponse, error) {
 	deviceQ := database.NewRealDevicequerier(queriesTx)
 	tokenQ := database.NewRealTokenQuerier(queriesTx)
 
-	// 5. Handle device information.
 	deviceID, err := a.HandleDeviceInfo(ctx, deviceQ, tokenQ, userID, deviceInfo)
 	if err != nil {
 		return models.LoginResponse{}, err
 	}
 
-	// 6. Generate JWT and refresh token.
-	jwtToken, refreshToken, err := a.GenerateTokens(userID)
+	jwtToken, refreshToken, err := a.GenerateTokens(userID, deviceID)
 	if err != nil {
 		return models.LoginResponse{}, err
 	}
 
-	// 7. Hash the refresh token.
-	refreshHash, err := a.PwdHasher.HashPassword(refreshToken)
-	if err != nil {
-		return models.LoginResponse{}, fmt.Errorf("failed to hash refresh token: %w", err)
-	}
+	refreshHash := a.RefreshHasher.Hash(refreshToken)
 
-	// 8. Create a refresh token record.
+	// The first token of a login starts a new family.
+	refreshID := uuid.New()
 	expiration := sql.NullTime{
-		Time:  time.Now().Add(60 * 24 * time.Hour),
+		Time:  time.Now().Add(refreshTokenTTL),
 		Valid: true,
 	}
 	err = queriesTx.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
-		ID:           uuid.NewString(),
+		ID:           refreshID.String(),
 		TokenHash:    refreshHash,
 		ExpiresAt:    expiration,
 		UserID:       userID.String(),
 		DeviceInfoID: deviceID.String(),
+		FamilyID:     sql.NullString{String: refreshID.String(), Valid: true},
 	})
 	if err != nil {
 		return models.LoginResponse{}, fmt.Errorf("failed to create refresh token entry: %w", err)
 	}
 
-	// 9. Commit the transaction.
-	if err := tx.Commit(); err != nil {
-		return models.LoginResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	// 10. Return a structured login response.
 	return models.LoginResponse{
 		UserID:       userID,
-		RefreshToken: refreshToken,
+		RefreshToken: FormatRefreshToken(refreshID, refreshToken),
 		JWTToken:     jwtToken,
 	}, nil
 }
 
-// Helpers
-func (a *AuthService) ValidateCredentials(ctx context.Context, input models.LoginInput) (uuid.UUID, error) {
-	user, err := a.UserQuerier.GetUserByEmail(ctx, input.Email)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return uuid.Nil, fmt.Errorf("invalid email / password")
-		}
-		return uuid.Nil, fmt.Errorf("failed to fetch user: %w", err)
-	}
-	if err := a.PwdHasher.CheckPasswordHash(input.Password, user.HashedPassword); err != nil {
-		return uuid.Nil, fmt.Errorf("invalid email / password")
-	}
-	return uuid.Parse(user.ID)
-}
-
 func (a *AuthService) HandleDeviceInfo(ctx context.Context, deviceQ database.DeviceQuerier, tokenQ database.TokenQuerier, userID uuid.UUID, info models.DeviceInfo) (uuid.UUID, error) {
 	foundDevice, err := deviceQ.GetDeviceInfoByUser(ctx, database.GetDeviceInfoByUserParams{
 		UserID:         userID.String(),
@@ -153,6 +296,7 @@ func (a *AuthService) HandleDeviceInfo(ctx context.Context, deviceQ database.Dev
 				BrowserVersion: info.BrowserVersion,
 				Os:             info.Os,
 				OsVersion:      info.OsVersion,
+				AppInfo:        toNullString(info.AppInfo),
 			})
 			if err != nil {
 				return uuid.Nil, fmt.Errorf("failed to create new device: %w", err)
@@ -175,11 +319,21 @@ func (a *AuthService) HandleDeviceInfo(ctx context.Context, deviceQ database.Dev
 		return uuid.Nil, fmt.Errorf("failed to revoke token: %w", err)
 	}
 
+	if err := deviceQ.UpdateDeviceLastUsed(ctx, database.UpdateDeviceLastUsedParams{
+		LastUsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		AppInfo:    toNullString(info.AppInfo),
+		ID:         deviceID.String(),
+	}); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to update device last used: %w", err)
+	}
+
 	return deviceID, nil
 }
 
-func (a *AuthService) GenerateTokens(userID uuid.UUID) (string, string, error) {
-	jwtToken, err := a.TokenGen.MakeJWT(userID, 15*time.Minute)
+// GenerateTokens makes an access token for the session of device sessionID and a
+// new refresh token.
+func (a *AuthService) GenerateTokens(userID, sessionID uuid.UUID) (string, string, error) {
+	jwtToken, err := a.TokenGen.MakeJWT(userID, sessionID, AccessTokenTTL)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate JWT: %w", err)
 	}
@@ -192,6 +346,11 @@ func (a *AuthService) GenerateTokens(userID uuid.UUID) (string, string, error) {
 	return jwtToken, refreshToken, nil
 }
 
+// JWKS returns the public keys other services can verify access tokens with.
+func (a *AuthService) JWKS() models.JWKSet {
+	return a.TokenGen.JWKS()
+}
+
 func GetDeviceInfoFromRequest(req *http.Request) (models.DeviceInfo, error) {
 	// Check for the X-Device-Info header first.
 	xDeviceInfo := req.Header.Get("X-Device-Info")
@@ -232,6 +391,8 @@ func ParseDeviceInfoFromHeader(header string) models.DeviceInfo {
 				info.Browser = value
 			case "browser_version":
 				info.BrowserVersion = value
+			case "app_info":
+				info.AppInfo = value
 			}
 		}
 	}
@@ -281,6 +442,10 @@ func isValidVersion(version string) bool {
 	return matched
 }
 
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 func sanitizeInput(input string) string {
 	input = strings.TrimSpace(input)
 	if len(input) > 100 {
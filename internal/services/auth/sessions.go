@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// ListSessions returns every device the user has logged in from, most recently
+// used first. A session is active while the device holds an unexpired refresh token.
+func (a *AuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	deviceQ := database.NewRealDevicequerier(a.SqlTxQuerier.WithTx(tx))
+
+	rows, err := deviceQ.ListUserSessions(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]models.Session, 0, len(rows))
+	// A device can briefly hold more than one unrevoked token; keep one entry per device.
+	index := make(map[string]int, len(rows))
+	for _, row := range rows {
+		active := row.ExpiresAt.Valid && row.ExpiresAt.Time.After(now)
+		if i, ok := index[row.ID]; ok {
+			sessions[i].Active = sessions[i].Active || active
+			continue
+		}
+		index[row.ID] = len(sessions)
+		sessions = append(sessions, models.Session{
+			ID:             row.ID,
+			DeviceType:     row.DeviceType,
+			Browser:        row.Browser,
+			BrowserVersion: row.BrowserVersion,
+			Os:             row.Os,
+			OsVersion:      row.OsVersion,
+			AppInfo:        row.AppInfo.String,
+			CreatedAt:      row.CreatedAt.Time,
+			LastUsedAt:     row.LastUsedAt.Time,
+			Active:         active,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes the refresh tokens held by one of the user's devices and
+// denylists the access tokens issued to it so far.
+func (a *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	now := time.Now()
+
+	tx, err := a.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queriesTx := a.SqlTxQuerier.WithTx(tx)
+	deviceQ := database.NewRealDevicequerier(queriesTx)
+	tokenQ := database.NewRealTokenQuerier(queriesTx)
+
+	if _, err := deviceQ.GetDeviceInfoByID(ctx, database.GetDeviceInfoByIDParams{
+		ID:     sessionID.String(),
+		UserID: userID.String(),
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to fetch device info: %w", err)
+	}
+
+	if err := tokenQ.RevokeToken(ctx, database.RevokeTokenParams{
+		RevokedAt:    sql.NullTime{Time: now, Valid: true},
+		UserID:       userID.String(),
+		DeviceInfoID: sessionID.String(),
+	}); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := a.Denylist.DenyDevice(ctx, sessionID.String(), now); err != nil {
+		return fmt.Errorf("failed to denylist access tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryAfterError is returned when a caller is being throttled. It matches
+// ErrTooManyAttempts with errors.Is.
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// LoginThrottle slows down password guessing. Every failed login adds to a counter for
+// the email and one for the client IP. An email is blocked for BaseDelay after its first
+// failure, doubling with each further failure, and locked out for LockoutDuration once it
+// reaches MaxFailures. IPs are only locked out, at the higher MaxIPFailures, because many
+// users can share one address. Wrong second factor codes count per user, so a new
+// challenge from another password login does not buy more guesses; the user is locked
+// out at MaxMFAFailures.
+type LoginThrottle struct {
+	store  AttemptStore
+	logger *zap.Logger
+
+	MaxFailures     int64
+	MaxIPFailures   int64
+	MaxMFAFailures  int64
+	BaseDelay       time.Duration
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered after the first one.
+	Window time.Duration
+}
+
+func NewLoginThrottle(store AttemptStore, logger *zap.Logger) *LoginThrottle {
+	return &LoginThrottle{
+		store:           store,
+		logger:          logger,
+		MaxFailures:     5,
+		MaxIPFailures:   50,
+		MaxMFAFailures:  5,
+		BaseDelay:       time.Second,
+		LockoutDuration: 15 * time.Minute,
+		Window:          15 * time.Minute,
+	}
+}
+
+// Check returns a *RetryAfterError if the email or IP may not attempt a login yet.
+func (t *LoginThrottle) Check(ctx context.Context, email, ip string) error {
+	var wait time.Duration
+	for _, key := range t.keys(email, ip) {
+		d, err := t.store.BlockedFor(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check login attempts: %w", err)
+		}
+		if d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 {
+		return &RetryAfterError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login and blocks the email and IP as needed. It
+// returns a *RetryAfterError when the failure triggered a lockout.
+func (t *LoginThrottle) RecordFailure(ctx context.Context, email, ip string) error {
+	emailKey := t.emailKey(email)
+	failures, err := t.store.Incr(ctx, emailKey, t.Window)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	var lockedOut error
+	if failures >= t.MaxFailures {
+		if err := t.lockOut(ctx, emailKey, failures); err != nil {
+			return err
+		}
+		lockedOut = &RetryAfterError{RetryAfter: t.LockoutDuration}
+	} else if delay := t.backoff(failures); delay > 0 {
+		if err := t.store.Block(ctx, emailKey, delay); err != nil {
+			return fmt.Errorf("failed to record login attempt: %w", err)
+		}
+	}
+
+	if ip == "" {
+		return lockedOut
+	}
+	ipKey := t.ipKey(ip)
+	ipFailures, err := t.store.Incr(ctx, ipKey, t.Window)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	if ipFailures >= t.MaxIPFailures {
+		if err := t.lockOut(ctx, ipKey, ipFailures); err != nil {
+			return err
+		}
+		lockedOut = &RetryAfterError{RetryAfter: t.LockoutDuration}
+	}
+	return lockedOut
+}
+
+// RecordSuccess clears the email's failures. The IP counter is left alone so a valid
+// account cannot be used to reset it.
+func (t *LoginThrottle) RecordSuccess(ctx context.Context, email string) error {
+	if err := t.store.Reset(ctx, t.emailKey(email)); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
+
+// CheckMFA returns a *RetryAfterError if the user may not try a second factor code yet.
+func (t *LoginThrottle) CheckMFA(ctx context.Context, userID string) error {
+	wait, err := t.store.BlockedFor(ctx, t.mfaKey(userID))
+	if err != nil {
+		return fmt.Errorf("failed to check mfa attempts: %w", err)
+	}
+	if wait > 0 {
+		return &RetryAfterError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// RecordMFAFailure counts a wrong second factor code for the user. It returns a
+// *RetryAfterError when the failure triggered a lockout.
+func (t *LoginThrottle) RecordMFAFailure(ctx context.Context, userID string) error {
+	key := t.mfaKey(userID)
+	failures, err := t.store.Incr(ctx, key, t.Window)
+	if err != nil {
+		return fmt.Errorf("failed to record mfa attempt: %w", err)
+	}
+	if failures < t.MaxMFAFailures {
+		return nil
+	}
+	if err := t.lockOut(ctx, key, failures); err != nil {
+		return err
+	}
+	return &RetryAfterError{RetryAfter: t.LockoutDuration}
+}
+
+// RecordMFASuccess clears the user's second factor failures.
+func (t *LoginThrottle) RecordMFASuccess(ctx context.Context, userID string) error {
+	if err := t.store.Reset(ctx, t.mfaKey(userID)); err != nil {
+		return fmt.Errorf("failed to reset mfa attempts: %w", err)
+	}
+	return nil
+}
+
+func (t *LoginThrottle) lockOut(ctx context.Context, key string, failures int64) error {
+	if err := t.store.Block(ctx, key, t.LockoutDuration); err != nil {
+		return fmt.Errorf("failed to lock out %s: %w", key, err)
+	}
+	t.logger.Warn("login_lockout",
+		zap.String("key", key),
+		zap.Int64("failures", failures),
+		zap.Duration("lockout", t.LockoutDuration),
+	)
+	return nil
+}
+
+func (t *LoginThrottle) backoff(failures int64) time.Duration {
+	if t.BaseDelay <= 0 || failures < 1 {
+		return 0
+	}
+	delay := t.BaseDelay << (failures - 1)
+	if delay <= 0 || delay > t.LockoutDuration {
+		return t.LockoutDuration
+	}
+	return delay
+}
+
+func (t *LoginThrottle) keys(email, ip string) []string {
+	keys := []string{t.emailKey(email)}
+	if ip != "" {
+		keys = append(keys, t.ipKey(ip))
+	}
+	return keys
+}
+
+func (t *LoginThrottle) emailKey(email string) string {
+	return "login:email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func (t *LoginThrottle) ipKey(ip string) string {
+	return "login:ip:" + ip
+}
+
+func (t *LoginThrottle) mfaKey(userID string) string {
+	return "login:mfa:" + userID
+}
+
+// RateLimiter allows Limit calls per key in each Window. Once a key goes over, it is
+// refused until the window ends.
+type RateLimiter struct {
+	store  AttemptStore
+	prefix string
+	Limit  int64
+	Window time.Duration
+}
+
+func NewRateLimiter(store AttemptStore, name string, limit int64, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		store:  store,
+		prefix: "ratelimit:" + name + ":",
+		Limit:  limit,
+		Window: window,
+	}
+}
+
+// Allow counts a call for key and returns a *RetryAfterError if it is over the limit.
+func (r *RateLimiter) Allow(ctx context.Context, key string) error {
+	key = r.prefix + key
+	wait, err := r.store.BlockedFor(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if wait > 0 {
+		return &RetryAfterError{RetryAfter: wait}
+	}
+
+	n, err := r.store.Incr(ctx, key, r.Window)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if n > r.Limit {
+		// The counter expires within one window, so it starts fresh once the block lifts.
+		if err := r.store.Block(ctx, key, r.Window); err != nil {
+			return fmt.Errorf("failed to check rate limit: %w", err)
+		}
+		return &RetryAfterError{RetryAfter: r.Window}
+	}
+	return nil
+}
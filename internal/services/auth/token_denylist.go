@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	denylistTokenPrefix  = "jwt_denylist:jti:"
+	denylistUserPrefix   = "jwt_denylist:user:"
+	denylistDevicePrefix = "jwt_denylist:device:"
+
+	// legacyDenialCutoff separates user denials stored in seconds, before they were
+	// stored in milliseconds, from those stored since.
+	legacyDenialCutoff = 1e12
+)
+
+// RedisTokenDenylist stores revoked access tokens in Redis. Entries expire on their
+// own once the tokens they cover can no longer pass ValidateJWT.
+type RedisTokenDenylist struct {
+	client   *redis.Client
+	tokenTTL time.Duration
+}
+
+// NewRedisTokenDenylist returns a denylist backed by client. tokenTTL is the
+// lifetime of the access tokens being issued.
+func NewRedisTokenDenylist(client *redis.Client, tokenTTL time.Duration) *RedisTokenDenylist {
+	return &RedisTokenDenylist{
+		client:   client,
+		tokenTTL: tokenTTL,
+	}
+}
+
+func (r *RedisTokenDenylist) DenyToken(ctx context.Context, claims *jwt.RegisteredClaims) error {
+	ttl, ok := remainingTTL(claims)
+	if !ok {
+		return nil
+	}
+	return r.client.Set(ctx, denylistTokenPrefix+claims.ID, 1, ttl).Err()
+}
+
+func (r *RedisTokenDenylist) DenyUser(ctx context.Context, userID string, issuedBefore time.Time) error {
+	return r.client.Set(ctx, denylistUserPrefix+userID, issuedBefore.UnixMilli(), r.tokenTTL).Err()
+}
+
+func (r *RedisTokenDenylist) DenyDevice(ctx context.Context, deviceID string, issuedBefore time.Time) error {
+	return r.client.Set(ctx, denylistDevicePrefix+deviceID, issuedBefore.UnixMilli(), r.tokenTTL).Err()
+}
+
+func (r *RedisTokenDenylist) IsDenied(ctx context.Context, claims *AccessClaims) (bool, error) {
+	if claims.ID != "" {
+		n, err := r.client.Exists(ctx, denylistTokenPrefix+claims.ID).Result()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	denied, err := r.deniedBefore(ctx, denylistUserPrefix+claims.Subject, claims)
+	if err != nil || denied || claims.SessionID == "" {
+		return denied, err
+	}
+	return r.deniedBefore(ctx, denylistDevicePrefix+claims.SessionID, claims)
+}
+
+// deniedBefore reports whether claims were issued at or before the denial stored at key.
+func (r *RedisTokenDenylist) deniedBefore(ctx context.Context, key string, claims *AccessClaims) (bool, error) {
+	before, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	beforeMilli, err := strconv.ParseInt(before, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	if beforeMilli < legacyDenialCutoff {
+		beforeMilli = beforeMilli*1000 + 999
+	}
+	return issuedAtOrBefore(claims, beforeMilli), nil
+}
+
+// MemoryTokenDenylist is an in-process denylist for tests and single-instance deployments.
+type MemoryTokenDenylist struct {
+	mu       sync.Mutex
+	tokenTTL time.Duration
+	tokens   map[string]time.Time
+	users    map[string]denial
+	devices  map[string]denial
+}
+
+type denial struct {
+	issuedBefore int64
+	expiresAt    time.Time
+}
+
+func NewMemoryTokenDenylist(tokenTTL time.Duration) *MemoryTokenDenylist {
+	return &MemoryTokenDenylist{
+		tokenTTL: tokenTTL,
+		tokens:   make(map[string]time.Time),
+		users:    make(map[string]denial),
+		devices:  make(map[string]denial),
+	}
+}
+
+func (m *MemoryTokenDenylist) DenyToken(ctx context.Context, claims *jwt.RegisteredClaims) error {
+	ttl, ok := remainingTTL(claims)
+	if !ok {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	m.tokens[claims.ID] = time.Now().Add(ttl)
+	return nil
+}
+
+func (m *MemoryTokenDenylist) DenyUser(ctx context.Context, userID string, issuedBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	m.users[userID] = m.newDenial(issuedBefore)
+	return nil
+}
+
+func (m *MemoryTokenDenylist) DenyDevice(ctx context.Context, deviceID string, issuedBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictExpired()
+	m.devices[deviceID] = m.newDenial(issuedBefore)
+	return nil
+}
+
+func (m *MemoryTokenDenylist) IsDenied(ctx context.Context, claims *AccessClaims) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if exp, ok := m.tokens[claims.ID]; ok && claims.ID != "" && now.Before(exp) {
+		return true, nil
+	}
+	if d, ok := m.users[claims.Subject]; ok && now.Before(d.expiresAt) && issuedAtOrBefore(claims, d.issuedBefore) {
+		return true, nil
+	}
+	if d, ok := m.devices[claims.SessionID]; ok && claims.SessionID != "" && now.Before(d.expiresAt) {
+		return issuedAtOrBefore(claims, d.issuedBefore), nil
+	}
+	return false, nil
+}
+
+func (m *MemoryTokenDenylist) newDenial(issuedBefore time.Time) denial {
+	return denial{
+		issuedBefore: issuedBefore.UnixMilli(),
+		expiresAt:    time.Now().Add(m.tokenTTL),
+	}
+}
+
+func (m *MemoryTokenDenylist) evictExpired() {
+	now := time.Now()
+	for jti, exp := range m.tokens {
+		if !now.Before(exp) {
+			delete(m.tokens, jti)
+		}
+	}
+	for userID, d := range m.users {
+		if !now.Before(d.expiresAt) {
+			delete(m.users, userID)
+		}
+	}
+	for deviceID, d := range m.devices {
+		if !now.Before(d.expiresAt) {
+			delete(m.devices, deviceID)
+		}
+	}
+}
+
+// Helpers
+func remainingTTL(claims *jwt.RegisteredClaims) (time.Duration, bool) {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return 0, false
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	return ttl, ttl > 0
+}
+
+// issuedAtOrBefore compares at millisecond precision, the precision of iat, so a
+// login in the same second as a denial is not caught by it.
+func issuedAtOrBefore(claims *AccessClaims, milli int64) bool {
+	if claims.IssuedAt == nil {
+		return true
+	}
+	return claims.IssuedAt.UnixMilli() <= milli
+}
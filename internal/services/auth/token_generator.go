@@ -4,18 +4,43 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
 )
 
+func init() {
+	// Access tokens carry iat in milliseconds, so the denylist can tell a token issued
+	// right after a logout-all or password reset from those the denial covers.
+	jwt.TimePrecision = time.Millisecond
+}
+
+// RestrictedAudience is the audience of access tokens issued to users who have not
+// verified their email. ClaimsAuthMiddleware refuses them.
+const RestrictedAudience = "unverified-email"
+
+// AccessClaims are the claims of an access token. SessionID is the device the token
+// was issued to, so the token can be denylisted along with the device's session.
+// Restricted tokens have no session.
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"sid,omitempty"`
+}
+
 type RealTokenGenerator struct {
 	tokenSecret     string
 	tokenTypeAccess models.TokenType
+	keys            *KeyRing
+	// legacyUntil ends HS256 validation once a key ring signs; see AcceptLegacySecret.
+	legacyUntil time.Time
+	logger      *zap.Logger
 }
 
+// NewRealTokenGenerator signs tokens with HS256 using tokenSecret.
 func NewRealTokenGenerator(tokenSecret string, tokenTypeAccess models.TokenType) *RealTokenGenerator {
 	return &RealTokenGenerator{
 		tokenSecret:     tokenSecret,
@@ -23,34 +48,70 @@ func NewRealTokenGenerator(tokenSecret string, tokenTypeAccess models.TokenType)
 	}
 }
 
-func (rtg *RealTokenGenerator) MakeJWT(userID uuid.UUID, expiresIn time.Duration) (string, error) {
-	if rtg.tokenSecret == "" {
+// NewKeyRingTokenGenerator signs tokens with the key ring's active key and puts its kid
+// in the header. HS256 tokens are refused unless AcceptLegacySecret allows them.
+func NewKeyRingTokenGenerator(keys *KeyRing, tokenTypeAccess models.TokenType) *RealTokenGenerator {
+	return &RealTokenGenerator{
+		tokenTypeAccess: tokenTypeAccess,
+		keys:            keys,
+		logger:          zap.NewNop(),
+	}
+}
+
+// AcceptLegacySecret lets HS256 tokens signed with secret validate until the cutover,
+// so switching to a key ring does not log anyone out. Each token accepted this way is
+// logged. The secret is never used to sign.
+func (rtg *RealTokenGenerator) AcceptLegacySecret(secret string, until time.Time, logger *zap.Logger) {
+	rtg.tokenSecret = secret
+	rtg.legacyUntil = until
+	rtg.logger = logger
+}
+
+// MakeJWT makes an access token for the session of the device with ID sessionID.
+func (rtg *RealTokenGenerator) MakeJWT(userID, sessionID uuid.UUID, expiresIn time.Duration) (string, error) {
+	return rtg.makeJWT(userID, sessionID.String(), expiresIn, nil)
+}
+
+// MakeRestrictedJWT makes an access token with RestrictedAudience.
+func (rtg *RealTokenGenerator) MakeRestrictedJWT(userID uuid.UUID, expiresIn time.Duration) (string, error) {
+	return rtg.makeJWT(userID, "", expiresIn, jwt.ClaimStrings{RestrictedAudience})
+}
+
+func (rtg *RealTokenGenerator) makeJWT(userID uuid.UUID, sessionID string, expiresIn time.Duration, audience jwt.ClaimStrings) (string, error) {
+	if rtg.keys == nil && rtg.tokenSecret == "" {
 		return "", errors.New("tokenSecret must not be empty")
 	}
 	if expiresIn <= 0 {
 		return "", errors.New("expiresIn must be positive")
 	}
+	claims := AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    string(rtg.tokenTypeAccess),
+			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(expiresIn)),
+			Subject:   userID.String(),
+			Audience:  audience,
+			ID:        uuid.NewString(),
+		},
+		SessionID: sessionID,
+	}
+	if rtg.keys != nil {
+		key := rtg.keys.Active()
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.ID
+		return token.SignedString(key.private)
+	}
 	signingKey := []byte(rtg.tokenSecret)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		Issuer:    string(rtg.tokenTypeAccess),
-		IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
-		ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(expiresIn)),
-		Subject:   userID.String(),
-	})
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(signingKey)
 }
 
-func (rtg *RealTokenGenerator) ValidateJWT(tokenString string) (*jwt.RegisteredClaims, error) {
-	// Create an instance of RegisteredClaims to hold the parsed token claims.
-	var claims jwt.RegisteredClaims
+func (rtg *RealTokenGenerator) ValidateJWT(tokenString string) (*AccessClaims, error) {
+	// Create an instance of AccessClaims to hold the parsed token claims.
+	var claims AccessClaims
 
 	// Parse the token using the claims instance.
-	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(rtg.tokenSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &claims, rtg.verificationKey)
 	if err != nil {
 		return nil, err
 	}
@@ -76,9 +137,53 @@ func (rtg *RealTokenGenerator) ValidateJWT(tokenString string) (*jwt.RegisteredC
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
+	if _, legacy := token.Method.(*jwt.SigningMethodHMAC); legacy && rtg.keys != nil {
+		rtg.logger.Warn("accepted token signed with legacy secret",
+			zap.String("user_id", claims.Subject),
+			zap.Time("cutover", rtg.legacyUntil))
+	}
+
 	return &claims, nil
 }
 
+// IsRestricted reports whether claims belong to a token made by MakeRestrictedJWT.
+func IsRestricted(claims *jwt.RegisteredClaims) bool {
+	return slices.Contains(claims.Audience, RestrictedAudience)
+}
+
+// JWKS returns the public keys tokens can be verified with. It is empty when tokens
+// are signed with a shared secret.
+func (rtg *RealTokenGenerator) JWKS() models.JWKSet {
+	if rtg.keys == nil {
+		return models.JWKSet{Keys: []models.JWK{}}
+	}
+	return rtg.keys.JWKS()
+}
+
+func (rtg *RealTokenGenerator) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if rtg.tokenSecret == "" {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		if rtg.keys != nil && !time.Now().Before(rtg.legacyUntil) {
+			return nil, errors.New("legacy signing secret is no longer accepted")
+		}
+		return []byte(rtg.tokenSecret), nil
+	}
+	if rtg.keys == nil {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := rtg.keys.Lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if key.Method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
 func (rtg *RealTokenGenerator) MakeRefreshToken() (string, error) {
 	token := make([]byte, 32)
 	_, err := models.RandReader(token)
@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	params := auth.Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	hasher := auth.NewArgon2idHasher(params)
+
+	hash, err := hasher.HashPassword("Validpass1!")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), hash)
+	require.Len(t, strings.Split(hash, "$"), 6)
+
+	other, err := hasher.HashPassword("Validpass1!")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other, "salts must differ")
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("Validpass1!"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		password    string
+		hash        string
+		wantErr     error
+		needsRehash bool
+	}{
+		{
+			name:     "argon2id match",
+			password: "Validpass1!",
+			hash:     hash,
+		},
+		{
+			name:        "argon2id mismatch",
+			password:    "Wrongpass1!",
+			hash:        hash,
+			wantErr:     auth.ErrPasswordMismatch,
+			needsRehash: false,
+		},
+		{
+			name:        "legacy bcrypt match",
+			password:    "Validpass1!",
+			hash:        string(bcryptHash),
+			needsRehash: true,
+		},
+		{
+			name:        "legacy bcrypt mismatch",
+			password:    "Wrongpass1!",
+			hash:        string(bcryptHash),
+			wantErr:     bcrypt.ErrMismatchedHashAndPassword,
+			needsRehash: true,
+		},
+		{
+			name:        "outdated parameters",
+			password:    "Validpass1!",
+			hash:        mustHash(t, auth.NewArgon2idHasher(auth.Argon2idParams{Memory: 512, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}), "Validpass1!"),
+			needsRehash: true,
+		},
+		{
+			name:        "malformed parameters",
+			password:    "Validpass1!",
+			hash:        "$argon2id$v=19$m=x,t=1,p=1$c2FsdA$aGFzaA",
+			wantErr:     auth.ErrMalformedHash,
+			needsRehash: true,
+		},
+		{
+			name:        "unknown scheme",
+			password:    "Validpass1!",
+			hash:        "plaintext",
+			wantErr:     auth.ErrMalformedHash,
+			needsRehash: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := hasher.CheckPasswordHash(tc.password, tc.hash)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.needsRehash, hasher.NeedsRehash(tc.hash))
+		})
+	}
+}
+
+func TestHMACTokenHasher(t *testing.T) {
+	hasher := auth.NewHMACTokenHasher([]byte("server-key"))
+
+	hash := hasher.Hash("secret")
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, hasher.Hash("secret"))
+	require.NotEqual(t, hash, auth.NewHMACTokenHasher([]byte("other-key")).Hash("secret"))
+
+	require.True(t, hasher.Verify("secret", hash))
+	require.False(t, hasher.Verify("wrong", hash))
+	require.False(t, auth.NewHMACTokenHasher([]byte("other-key")).Verify("secret", hash))
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.True(t, hasher.Verify("secret", string(legacy)))
+	require.False(t, hasher.Verify("wrong", string(legacy)))
+}
+
+func mustHash(t *testing.T, hasher auth.PasswordHasher, password string) string {
+	t.Helper()
+	hash, err := hasher.HashPassword(password)
+	require.NoError(t, err)
+	return hash
+}
@@ -0,0 +1,119 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemoryAttemptStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("counter resets after window", func(t *testing.T) {
+		store := auth.NewMemoryAttemptStore()
+		n, err := store.Incr(ctx, "key", 20*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+		n, err = store.Incr(ctx, "key", 20*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+
+		time.Sleep(30 * time.Millisecond)
+		n, err = store.Incr(ctx, "key", 20*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+	})
+
+	t.Run("block and reset", func(t *testing.T) {
+		store := auth.NewMemoryAttemptStore()
+		require.NoError(t, store.Block(ctx, "key", time.Minute))
+		d, err := store.BlockedFor(ctx, "key")
+		require.NoError(t, err)
+		require.Greater(t, d, 59*time.Second)
+
+		d, err = store.BlockedFor(ctx, "other")
+		require.NoError(t, err)
+		require.Zero(t, d)
+
+		require.NoError(t, store.Reset(ctx, "key"))
+		d, err = store.BlockedFor(ctx, "key")
+		require.NoError(t, err)
+		require.Zero(t, d)
+	})
+}
+
+func TestRedisAttemptStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("increment and expiry run as one script", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := auth.NewRedisAttemptStore(client)
+		keys := []string{"attempts:count:key"}
+		window := time.Minute.Milliseconds()
+		// The first call loads the script: EVALSHA misses and EVAL sends the source.
+		redisMock.Regexp().ExpectEvalSha(`^[0-9a-f]{40}$`, keys, window).SetErr(errors.New("NOSCRIPT No matching script"))
+		redisMock.Regexp().ExpectEval(`INCR[\s\S]*PEXPIRE`, keys, window).SetVal(int64(1))
+		redisMock.Regexp().ExpectEvalSha(`^[0-9a-f]{40}$`, keys, window).SetVal(int64(2))
+
+		n, err := store.Incr(ctx, "key", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+		n, err = store.Incr(ctx, "key", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("block", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := auth.NewRedisAttemptStore(client)
+		redisMock.ExpectSet("attempts:block:key", 1, time.Minute).SetVal("OK")
+		redisMock.ExpectPTTL("attempts:block:key").SetVal(30 * time.Second)
+		redisMock.ExpectPTTL("attempts:block:other").SetVal(-2)
+
+		require.NoError(t, store.Block(ctx, "key", time.Minute))
+		d, err := store.BlockedFor(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, 30*time.Second, d)
+		d, err = store.BlockedFor(ctx, "other")
+		require.NoError(t, err)
+		require.Zero(t, d)
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		store := auth.NewRedisAttemptStore(client)
+		redisMock.ExpectDel("attempts:count:key", "attempts:block:key").SetVal(2)
+
+		require.NoError(t, store.Reset(ctx, "key"))
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+}
+
+func TestFallbackAttemptStore(t *testing.T) {
+	ctx := context.Background()
+	client, redisMock := redismock.NewClientMock()
+	redisMock.Regexp().ExpectEvalSha(`^[0-9a-f]{40}$`, []string{"attempts:count:key"}, time.Minute.Milliseconds()).SetErr(errors.New("redis down"))
+	redisMock.ExpectSet("attempts:block:key", 1, time.Minute).SetErr(errors.New("redis down"))
+	redisMock.ExpectPTTL("attempts:block:key").SetVal(-2)
+
+	store := auth.NewFallbackAttemptStore(auth.NewRedisAttemptStore(client), auth.NewMemoryAttemptStore(), zap.NewNop())
+
+	n, err := store.Incr(ctx, "key", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// The block lands in memory and is still honoured once Redis answers again.
+	require.NoError(t, store.Block(ctx, "key", time.Minute))
+	d, err := store.BlockedFor(ctx, "key")
+	require.NoError(t, err)
+	require.Greater(t, d, time.Duration(0))
+	require.NoError(t, redisMock.ExpectationsWereMet())
+}
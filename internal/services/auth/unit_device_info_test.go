@@ -81,6 +81,18 @@ func TestParseDeviceInfoFromHeader(t *testing.T) {
 				BrowserVersion: "100.0",
 			},
 		},
+		{
+			name:   "app info provided",
+			header: "os=iOS; os_version=17.1; device_type=Mobile; browser=Safari; browser_version=17.0; app_info=unity-wealth/1.4.2",
+			expected: models.DeviceInfo{
+				Os:             "iOS",
+				OsVersion:      "17.1",
+				DeviceType:     "Mobile",
+				Browser:        "Safari",
+				BrowserVersion: "17.0",
+				AppInfo:        "unity-wealth/1.4.2",
+			},
+		},
 		{
 			name:   "extra whitespace and mixed case keys",
 			header: " OS = android ; Os_Version = 10 ; device_type = Tablet ; Browser = Firefox ; Browser_Version = 85.0 ",
@@ -0,0 +1,366 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSendVerificationEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	email := "user@example.com"
+
+	tests := []struct {
+		name                 string
+		createErr            error
+		sendErr              error
+		expectsCommit        bool
+		expectedErrSubstring string
+	}{
+		{
+			name:          "success",
+			expectsCommit: true,
+		},
+		{
+			name:                 "error creating token",
+			createErr:            errors.New("db error"),
+			expectedErrSubstring: "failed to create verification token",
+		},
+		{
+			name:                 "error sending mail",
+			sendErr:              errors.New("smtp down"),
+			expectsCommit:        true,
+			expectedErrSubstring: "failed to send verification email",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockTokenGen := authmocks.NewTokenGenerator(t)
+			mockMailer := authmocks.NewMailer(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsCommit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockTokenGen.On("MakeRefreshToken").Return("verifytoken", nil).Once()
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			dummyQueries.On("InvalidateUserEmailVerificationTokens", ctx, mock.MatchedBy(func(arg database.InvalidateUserEmailVerificationTokensParams) bool {
+				return arg.UserID == userID && arg.UsedAt.Valid
+			})).Return(nil).Once()
+			dummyQueries.On("CreateEmailVerificationToken", ctx, mock.MatchedBy(func(arg database.CreateEmailVerificationTokenParams) bool {
+				return arg.UserID == userID &&
+					arg.TokenHash == auth.HashOneTimeToken("verifytoken") &&
+					arg.ExpiresAt.After(time.Now())
+			})).Return(tc.createErr).Once()
+			if tc.expectsCommit {
+				mockMailer.On("Send", ctx, mock.MatchedBy(func(msg mailer.Message) bool {
+					return msg.To == email && strings.Contains(msg.Body, "/verify-email?token=verifytoken")
+				})).Return(tc.sendErr).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, mockTokenGen, nil, nil, nil, mockMailer, zap.NewNop())
+			err = svc.SendVerificationEmail(ctx, userID, email)
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	email := "user@example.com"
+
+	tests := []struct {
+		name                 string
+		email                string
+		user                 database.GetUserByEmailRow
+		getUserErr           error
+		latest               models.EmailVerificationToken
+		latestErr            error
+		expectsLookup        bool
+		expectsSend          bool
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:          "success",
+			email:         email,
+			user:          database.GetUserByEmailRow{ID: userID},
+			latest:        models.EmailVerificationToken{CreatedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}},
+			expectsLookup: true,
+			expectsSend:   true,
+		},
+		{
+			name:          "no earlier token",
+			email:         email,
+			user:          database.GetUserByEmailRow{ID: userID},
+			latestErr:     sql.ErrNoRows,
+			expectsLookup: true,
+			expectsSend:   true,
+		},
+		{
+			name:        "invalid email",
+			email:       "invalid",
+			expectedErr: auth.ErrInvalidEmail,
+		},
+		{
+			name:       "unknown email",
+			email:      email,
+			getUserErr: sql.ErrNoRows,
+		},
+		{
+			name:  "already verified",
+			email: email,
+			user: database.GetUserByEmailRow{
+				ID:              userID,
+				EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			},
+		},
+		{
+			name:          "throttled",
+			email:         email,
+			user:          database.GetUserByEmailRow{ID: userID},
+			latest:        models.EmailVerificationToken{CreatedAt: sql.NullTime{Time: time.Now().Add(-10 * time.Second), Valid: true}},
+			expectsLookup: true,
+			expectedErr:   auth.ErrVerifyEmailThrottled,
+		},
+		{
+			name:                 "error fetching latest token",
+			email:                email,
+			user:                 database.GetUserByEmailRow{ID: userID},
+			latestErr:            errors.New("db error"),
+			expectsLookup:        true,
+			expectedErrSubstring: "failed to fetch verification token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockUserQ := dbmocks.NewUserQuerier(t)
+			mockTokenGen := authmocks.NewTokenGenerator(t)
+			mockMailer := authmocks.NewMailer(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			// Both transactions are opened up front, so their expectations interleave.
+			sqlMock.MatchExpectationsInOrder(false)
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectRollback()
+			lookupTx, err := db.Begin()
+			require.NoError(t, err)
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectCommit()
+			sendTx, err := db.Begin()
+			require.NoError(t, err)
+
+			if tc.email == email {
+				mockUserQ.On("GetUserByEmail", ctx, email).Return(tc.user, tc.getUserErr).Once()
+			}
+			if tc.expectsLookup {
+				mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(lookupTx, nil).Once()
+				mockSqlTxQ.On("WithTx", lookupTx).Return(dummyQueries)
+				dummyQueries.On("GetLatestEmailVerificationToken", ctx, userID).Return(tc.latest, tc.latestErr).Once()
+			}
+			if tc.expectsSend {
+				mockTokenGen.On("MakeRefreshToken").Return("verifytoken", nil).Once()
+				mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(sendTx, nil).Once()
+				mockSqlTxQ.On("WithTx", sendTx).Return(dummyQueries)
+				dummyQueries.On("InvalidateUserEmailVerificationTokens", ctx, mock.Anything).Return(nil).Once()
+				dummyQueries.On("CreateEmailVerificationToken", ctx, mock.Anything).Return(nil).Once()
+				mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).Return(nil).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, mockUserQ, mockTokenGen, nil, nil, nil, mockMailer, zap.NewNop())
+			err = svc.ResendVerificationEmail(ctx, tc.email)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectsSend {
+				require.NoError(t, sqlMock.ExpectationsWereMet())
+			}
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	tokenID := uuid.NewString()
+	validToken := models.EmailVerificationToken{
+		ID:        tokenID,
+		TokenHash: auth.HashOneTimeToken("verifytoken"),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name                 string
+		storedToken          models.EmailVerificationToken
+		getErr               error
+		markedRows           int64
+		expectsMark          bool
+		markUserErr          error
+		expectsMarkUser      bool
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:            "success",
+			storedToken:     validToken,
+			markedRows:      1,
+			expectsMark:     true,
+			expectsMarkUser: true,
+		},
+		{
+			name:        "unknown token",
+			getErr:      sql.ErrNoRows,
+			expectedErr: auth.ErrInvalidVerifyToken,
+		},
+		{
+			name: "used token",
+			storedToken: func() models.EmailVerificationToken {
+				used := validToken
+				used.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				return used
+			}(),
+			expectedErr: auth.ErrInvalidVerifyToken,
+		},
+		{
+			name: "expired token",
+			storedToken: func() models.EmailVerificationToken {
+				expired := validToken
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				return expired
+			}(),
+			expectedErr: auth.ErrVerifyTokenExpired,
+		},
+		{
+			name:        "token consumed concurrently",
+			storedToken: validToken,
+			expectsMark: true,
+			expectedErr: auth.ErrInvalidVerifyToken,
+		},
+		{
+			name:                 "error marking user verified",
+			storedToken:          validToken,
+			markedRows:           1,
+			expectsMark:          true,
+			markUserErr:          errors.New("db error"),
+			expectsMarkUser:      true,
+			expectedErrSubstring: "failed to mark email verified",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsMarkUser && tc.markUserErr == nil {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			dummyQueries.On("GetEmailVerificationTokenByHash", ctx, auth.HashOneTimeToken("verifytoken")).Return(tc.storedToken, tc.getErr).Once()
+			if tc.expectsMark {
+				dummyQueries.On("MarkEmailVerificationTokenUsed", ctx, mock.MatchedBy(func(arg database.MarkEmailVerificationTokenUsedParams) bool {
+					return arg.ID == tokenID && arg.UsedAt.Valid
+				})).Return(tc.markedRows, nil).Once()
+			}
+			if tc.expectsMarkUser {
+				dummyQueries.On("MarkUserEmailVerified", ctx, mock.MatchedBy(func(arg database.MarkUserEmailVerifiedParams) bool {
+					return arg.ID == userID && arg.EmailVerifiedAt.Valid
+				})).Return(tc.markUserErr).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, nil, nil, nil, zap.NewNop())
+			err = svc.VerifyEmail(ctx, "verifytoken")
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestParseUnverifiedEmailPolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected auth.UnverifiedEmailPolicy
+		wantErr  bool
+	}{
+		{input: "", expected: auth.UnverifiedEmailAllow},
+		{input: "allow", expected: auth.UnverifiedEmailAllow},
+		{input: "Restrict", expected: auth.UnverifiedEmailRestrict},
+		{input: " refuse ", expected: auth.UnverifiedEmailRefuse},
+		{input: "block", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			policy, err := auth.ParseUnverifiedEmailPolicy(tc.input)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, policy)
+		})
+	}
+}
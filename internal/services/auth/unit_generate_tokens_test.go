@@ -15,6 +15,7 @@ import (
 
 func TestGenerateTokens(t *testing.T) {
 	userID := uuid.New()
+	deviceID := uuid.New()
 
 	tests := []struct {
 		name                   string
@@ -59,13 +60,13 @@ func TestGenerateTokens(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockTokenGen := authmocks.NewTokenGenerator(t)
 			nopLogger := zap.NewNop()
-			mockTokenGen.On("MakeJWT", userID, 15*time.Minute).Return(tc.jwtToken, tc.jwtError)
+			mockTokenGen.On("MakeJWT", userID, deviceID, 15*time.Minute).Return(tc.jwtToken, tc.jwtError)
 			if tc.jwtError == nil {
 				mockTokenGen.On("MakeRefreshToken").Return(tc.refreshToken, tc.refreshError)
 			}
 
-			svc := auth.NewAuthService(nil, nil, mockTokenGen, nil, nil, nopLogger)
-			jwtToken, refreshToken, err := svc.GenerateTokens(userID)
+			svc := auth.NewAuthService(nil, nil, mockTokenGen, nil, nil, nil, nil, nopLogger)
+			jwtToken, refreshToken, err := svc.GenerateTokens(userID, deviceID)
 			if tc.expectedErrorSubstring != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), tc.expectedErrorSubstring)
@@ -52,6 +52,10 @@ func TestHandleDeviceInfo(t *testing.T) {
 				tokenQ.On("RevokeToken", ctx, mock.MatchedBy(func(params database.RevokeTokenParams) bool {
 					return params.UserID == userID.String() && params.DeviceInfoID == validDeviceIDStr
 				})).Return(nil)
+
+				deviceQ.On("UpdateDeviceLastUsed", ctx, mock.MatchedBy(func(params database.UpdateDeviceLastUsedParams) bool {
+					return params.ID == validDeviceIDStr && params.LastUsedAt.Valid
+				})).Return(nil)
 			},
 			expectedErrorSubstring: "",
 			expectedDeviceID:       uuid.MustParse(validDeviceIDStr),
@@ -143,6 +147,16 @@ func TestHandleDeviceInfo(t *testing.T) {
 			expectedErrorSubstring: "failed to revoke token",
 			expectedDeviceID:       uuid.Nil,
 		},
+		{
+			name: "failed to update device last used",
+			setupMocks: func(deviceQ *dbmocks.DeviceQuerier, tokenQ *dbmocks.TokenQuerier) {
+				deviceQ.On("GetDeviceInfoByUser", ctx, mock.Anything).Return(validDeviceIDStr, nil)
+				tokenQ.On("RevokeToken", ctx, mock.Anything).Return(nil)
+				deviceQ.On("UpdateDeviceLastUsed", ctx, mock.Anything).Return(errors.New("update error"))
+			},
+			expectedErrorSubstring: "failed to update device last used",
+			expectedDeviceID:       uuid.Nil,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -154,7 +168,7 @@ func TestHandleDeviceInfo(t *testing.T) {
 				tc.setupMocks(mockDeviceQ, mockTokenQ)
 			}
 
-			deviceID, err := auth.NewAuthService(nil, nil, nil, nil, nil, nopLogger).HandleDeviceInfo(ctx, mockDeviceQ, mockTokenQ, userID, inputDeviceInfo)
+			deviceID, err := auth.NewAuthService(nil, nil, nil, nil, nil, nil, nil, nopLogger).HandleDeviceInfo(ctx, mockDeviceQ, mockTokenQ, userID, inputDeviceInfo)
 
 			if tc.expectedErrorSubstring != "" {
 				require.Error(t, err)
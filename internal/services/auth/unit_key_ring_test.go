@@ -0,0 +1,214 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func rsaKeyPEM(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), key
+}
+
+func ed25519KeyPEM(t *testing.T) ([]byte, ed25519.PrivateKey) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), key
+}
+
+func publicKeyPEM(t *testing.T, pub interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaPEM, _ := rsaKeyPEM(t)
+	edPEM, edKey := ed25519KeyPEM(t)
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantAlg string
+		canSign bool
+		wantErr string
+	}{
+		{name: "rsa private key", data: rsaPEM, wantAlg: "RS256", canSign: true},
+		{
+			name:    "pkcs1 rsa private key",
+			data:    pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(smallRSA)}),
+			wantErr: "at least 2048 bits",
+		},
+		{name: "ed25519 private key", data: edPEM, wantAlg: "EdDSA", canSign: true},
+		{name: "ed25519 public key", data: publicKeyPEM(t, edKey.Public()), wantAlg: "EdDSA"},
+		{name: "not pem", data: []byte("secret"), wantErr: "no PEM data"},
+		{name: "unsupported block", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}), wantErr: "unsupported PEM block"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := auth.ParseSigningKey("k1", tc.data)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "k1", key.ID)
+			require.Equal(t, tc.wantAlg, key.Method.Alg())
+			require.Equal(t, tc.canSign, key.CanSign())
+		})
+	}
+}
+
+func TestKeyRingTokenGenerator(t *testing.T) {
+	dir := t.TempDir()
+	oldPEM, _ := rsaKeyPEM(t)
+	newPEM, _ := ed25519KeyPEM(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-01.pem"), oldPEM, 0o600))
+
+	ring, err := auth.LoadKeyRing(auth.KeyRingSource{Dir: dir})
+	require.NoError(t, err)
+	require.Equal(t, "2026-01", ring.Active().ID)
+
+	tokenGen := auth.NewKeyRingTokenGenerator(ring, models.TokenType("unity-wealth"))
+	userID := uuid.New()
+	oldToken, err := tokenGen.MakeJWT(userID, uuid.New(), time.Hour)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(oldToken, &jwt.RegisteredClaims{})
+	require.NoError(t, err)
+	require.Equal(t, "2026-01", parsed.Header["kid"])
+	require.Equal(t, "RS256", parsed.Header["alg"])
+
+	t.Run("rotation keeps previous keys valid", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-02.pem"), newPEM, 0o600))
+		require.NoError(t, ring.Reload())
+		require.Equal(t, "2026-02", ring.Active().ID)
+
+		newToken, err := tokenGen.MakeJWT(userID, uuid.New(), time.Hour)
+		require.NoError(t, err)
+		parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &jwt.RegisteredClaims{})
+		require.NoError(t, err)
+		require.Equal(t, "2026-02", parsed.Header["kid"])
+		require.Equal(t, "EdDSA", parsed.Header["alg"])
+
+		for _, token := range []string{oldToken, newToken} {
+			claims, err := tokenGen.ValidateJWT(token)
+			require.NoError(t, err)
+			require.Equal(t, userID.String(), claims.Subject)
+		}
+	})
+
+	t.Run("jwks lists active key first", func(t *testing.T) {
+		set := tokenGen.JWKS()
+		require.Len(t, set.Keys, 2)
+		require.Equal(t, "2026-02", set.Keys[0].Kid)
+		require.Equal(t, "OKP", set.Keys[0].Kty)
+		require.Equal(t, "Ed25519", set.Keys[0].Crv)
+		require.NotEmpty(t, set.Keys[0].X)
+		require.Equal(t, "2026-01", set.Keys[1].Kid)
+		require.Equal(t, "RSA", set.Keys[1].Kty)
+		require.Equal(t, "RS256", set.Keys[1].Alg)
+		require.Equal(t, "sig", set.Keys[1].Use)
+
+		e, err := base64.RawURLEncoding.DecodeString(set.Keys[1].E)
+		require.NoError(t, err)
+		require.Equal(t, int64(65537), new(big.Int).SetBytes(e).Int64())
+	})
+
+	t.Run("retired key is rejected", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(dir, "2026-01.pem")))
+		require.NoError(t, ring.Reload())
+
+		_, err := tokenGen.ValidateJWT(oldToken)
+		require.ErrorContains(t, err, `unknown key id "2026-01"`)
+		require.Len(t, tokenGen.JWKS().Keys, 1)
+	})
+
+	t.Run("failed reload keeps current keys", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-03.pem"), []byte("garbage"), 0o600))
+		require.Error(t, ring.Reload())
+		require.Equal(t, "2026-02", ring.Active().ID)
+		require.NoError(t, os.Remove(filepath.Join(dir, "2026-03.pem")))
+	})
+
+	t.Run("hs256 accepted only until the legacy cutover", func(t *testing.T) {
+		hsToken, err := auth.NewRealTokenGenerator("tokensecret", models.TokenType("unity-wealth")).MakeJWT(userID, uuid.New(), time.Hour)
+		require.NoError(t, err)
+
+		_, err = tokenGen.ValidateJWT(hsToken)
+		require.ErrorContains(t, err, "unexpected signing method")
+
+		core, logs := observer.New(zap.WarnLevel)
+		legacy := auth.NewKeyRingTokenGenerator(ring, models.TokenType("unity-wealth"))
+		legacy.AcceptLegacySecret("tokensecret", time.Now().Add(time.Hour), zap.New(core))
+		claims, err := legacy.ValidateJWT(hsToken)
+		require.NoError(t, err)
+		require.Equal(t, userID.String(), claims.Subject)
+		require.Equal(t, 1, logs.FilterMessage("accepted token signed with legacy secret").Len())
+
+		// Tokens from the key ring are not logged.
+		ringToken, err := legacy.MakeJWT(userID, uuid.New(), time.Hour)
+		require.NoError(t, err)
+		_, err = legacy.ValidateJWT(ringToken)
+		require.NoError(t, err)
+		require.Equal(t, 1, logs.Len())
+
+		expired := auth.NewKeyRingTokenGenerator(ring, models.TokenType("unity-wealth"))
+		expired.AcceptLegacySecret("tokensecret", time.Now().Add(-time.Minute), zap.New(core))
+		_, err = expired.ValidateJWT(hsToken)
+		require.ErrorContains(t, err, "legacy signing secret is no longer accepted")
+	})
+}
+
+func TestKeyRingSourceFiles(t *testing.T) {
+	dir := t.TempDir()
+	activePEM, _ := ed25519KeyPEM(t)
+	_, previousKey := rsaKeyPEM(t)
+	activePath := filepath.Join(dir, "current.pem")
+	previousPath := filepath.Join(dir, "previous.pem")
+	require.NoError(t, os.WriteFile(activePath, activePEM, 0o600))
+	require.NoError(t, os.WriteFile(previousPath, publicKeyPEM(t, &previousKey.PublicKey), 0o600))
+
+	ring, err := auth.LoadKeyRing(auth.KeyRingSource{ActiveFile: activePath, PreviousFiles: []string{previousPath}})
+	require.NoError(t, err)
+	require.Equal(t, "current", ring.Active().ID)
+	_, ok := ring.Lookup("previous")
+	require.True(t, ok)
+
+	// A verify-only key cannot be made the signing key.
+	_, err = auth.LoadKeyRing(auth.KeyRingSource{ActiveFile: previousPath})
+	require.ErrorContains(t, err, "has no private key")
+
+	_, err = auth.LoadKeyRing(auth.KeyRingSource{Dir: dir, ActiveKID: "missing"})
+	require.ErrorContains(t, err, `active key "missing" not found`)
+
+	_, err = auth.LoadKeyRing(auth.KeyRingSource{Dir: t.TempDir()})
+	require.ErrorContains(t, err, "no signing keys found")
+}
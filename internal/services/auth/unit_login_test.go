@@ -11,6 +11,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/seanhuebl/unity-wealth/internal/constants"
 	"github.com/seanhuebl/unity-wealth/internal/database"
@@ -88,30 +89,34 @@ func TestLogin(t *testing.T) {
 			mockSqlTxQ.On("BeginTx", ctx.Request.Context(), (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
 			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
 
-			mockHasher.On("HashPassword", "refresh").Return("hashedrefresh", nil)
 			mockHasher.On("CheckPasswordHash", tc.input.Password, dummyUserRow.HashedPassword).Return(nil)
+			mockHasher.On("NeedsRehash", dummyUserRow.HashedPassword).Return(false)
 
-			mockTokenGen.On("MakeJWT", validUserID, 15*time.Minute).Return("JWT", nil)
+			mockTokenGen.On("MakeJWT", validUserID, validDeviceID, 15*time.Minute).Return("JWT", nil)
 			mockTokenGen.On("MakeRefreshToken").Return("refresh", nil)
 
 			if tc.deviceFound {
 				dummyQueries.On("GetDeviceInfoByUser", ctx.Request.Context(), mock.Anything).Return(validDeviceID.String(), nil)
 				dummyQueries.On("RevokeToken", ctx.Request.Context(), mock.Anything).Return(nil)
+				dummyQueries.On("UpdateDeviceLastUsed", ctx.Request.Context(), mock.Anything).Return(nil)
 			} else {
 				dummyQueries.On("CreateDeviceInfo", ctx.Request.Context(), mock.Anything).Return(validDeviceID.String(), nil)
 			}
 
 			dummyQueries.On("CreateRefreshToken", ctx.Request.Context(), mock.AnythingOfType("database.CreateRefreshTokenParams")).Return(nil)
-			svc := auth.NewAuthService(mockSqlTxQ, mockUserQ, mockTokenGen, mockExtractor, mockHasher, nopLogger)
+			svc := auth.NewAuthService(mockSqlTxQ, mockUserQ, mockTokenGen, mockExtractor, mockHasher, nil, nil, nopLogger)
 			response, err := svc.Login(ctx.Request.Context(), tc.input)
 			if tc.expectedErrorSubstring != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), tc.expectedErrorSubstring)
 			} else {
 				require.NoError(t, err)
-				if diff := cmp.Diff(tc.expectedResponse, response); diff != "" {
+				if diff := cmp.Diff(tc.expectedResponse, response, cmpopts.IgnoreFields(models.LoginResponse{}, "RefreshToken")); diff != "" {
 					t.Errorf("response mismatch (-want +got)\n%s", diff)
 				}
+				_, secret, err := auth.ParseRefreshToken(response.RefreshToken)
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedResponse.RefreshToken, secret)
 			}
 
 			mockSqlTxQ.AssertExpectations(t)
@@ -122,3 +127,90 @@ func TestLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginUnverifiedEmailPolicy(t *testing.T) {
+	userID := uuid.New()
+	input := models.LoginInput{Email: "user@example.com", Password: "Validpass1!"}
+	req := httptest.NewRequest("POST", "/login", nil)
+	req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+	ctx := context.WithValue(req.Context(), constants.RequestKey, req)
+
+	tests := []struct {
+		name             string
+		policy           auth.UnverifiedEmailPolicy
+		totpEnabled      bool
+		expectedErr      error
+		expectedResponse models.LoginResponse
+	}{
+		{
+			name:        "refuse",
+			policy:      auth.UnverifiedEmailRefuse,
+			expectedErr: auth.ErrEmailNotVerified,
+		},
+		{
+			name:   "restrict",
+			policy: auth.UnverifiedEmailRestrict,
+			expectedResponse: models.LoginResponse{
+				UserID:     userID,
+				JWTToken:   "JWT",
+				Restricted: true,
+			},
+		},
+		{
+			name:        "restrict still asks for the second factor",
+			policy:      auth.UnverifiedEmailRestrict,
+			totpEnabled: true,
+			expectedResponse: models.LoginResponse{
+				UserID:      userID,
+				MFARequired: true,
+				MFAToken:    "mfa",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockUserQ := dbmocks.NewUserQuerier(t)
+			mockTokenGen := authmocks.NewTokenGenerator(t)
+			mockHasher := authmocks.NewPasswordHasher(t)
+
+			mockUserQ.On("GetUserByEmail", ctx, input.Email).Return(database.GetUserByEmailRow{
+				ID:             userID.String(),
+				HashedPassword: "hashedpassword",
+				TotpEnabledAt:  sql.NullTime{Time: time.Now(), Valid: tc.totpEnabled},
+			}, nil).Once()
+			mockHasher.On("CheckPasswordHash", input.Password, "hashedpassword").Return(nil).Once()
+			mockHasher.On("NeedsRehash", "hashedpassword").Return(false).Once()
+			if tc.expectedErr == nil {
+				db, sqlMock, err := sqlmock.New()
+				require.NoError(t, err)
+				sqlMock.ExpectBegin()
+				dummyTx, err := db.Begin()
+				require.NoError(t, err)
+
+				// Neither path may open a session: no device, no refresh token.
+				dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+				mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+				mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries).Once()
+				if tc.totpEnabled {
+					sqlMock.ExpectCommit()
+					dummyQueries.On("CreateMFAChallenge", ctx, mock.AnythingOfType("database.CreateMFAChallengeParams")).Return(nil).Once()
+					mockTokenGen.On("MakeRefreshToken").Return("mfa", nil).Once()
+				} else {
+					sqlMock.ExpectRollback()
+					mockTokenGen.On("MakeRestrictedJWT", userID, auth.AccessTokenTTL).Return("JWT", nil).Once()
+				}
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, mockUserQ, mockTokenGen, nil, mockHasher, nil, nil, zap.NewNop())
+			svc.UnverifiedEmailPolicy = tc.policy
+			response, err := svc.Login(ctx, input)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLogout(t *testing.T) {
+	userID := uuid.New()
+	deviceID := uuid.New()
+	tokenID := uuid.New()
+	ctx := context.Background()
+	claims := &jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   userID.String(),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+
+	tests := []struct {
+		name                 string
+		refreshToken         string
+		denyErr              error
+		storedToken          models.RefreshToken
+		getTokenErr          error
+		revokeErr            error
+		expectsLookup        bool
+		expectsRevoke        bool
+		expectsCommit        bool
+		expectedErrSubstring string
+	}{
+		{
+			name:          "success",
+			refreshToken:  auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken:   models.RefreshToken{ID: tokenID.String(), UserID: userID.String(), DeviceInfoID: deviceID.String()},
+			expectsLookup: true,
+			expectsRevoke: true,
+			expectsCommit: true,
+		},
+		{
+			name:         "no refresh token",
+			refreshToken: "",
+		},
+		{
+			name:                 "denylist error",
+			refreshToken:         auth.FormatRefreshToken(tokenID, "secret"),
+			denyErr:              errors.New("redis down"),
+			expectedErrSubstring: "failed to denylist access token",
+		},
+		{
+			name:          "refresh token not found",
+			refreshToken:  auth.FormatRefreshToken(tokenID, "secret"),
+			getTokenErr:   sql.ErrNoRows,
+			expectsLookup: true,
+		},
+		{
+			name:                 "error fetching refresh token",
+			refreshToken:         auth.FormatRefreshToken(tokenID, "secret"),
+			getTokenErr:          errors.New("db error"),
+			expectsLookup:        true,
+			expectedErrSubstring: "failed to fetch refresh token",
+		},
+		{
+			name:          "refresh token belongs to another user",
+			refreshToken:  auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken:   models.RefreshToken{ID: tokenID.String(), UserID: uuid.NewString(), DeviceInfoID: deviceID.String()},
+			expectsLookup: true,
+		},
+		{
+			name:                 "error revoking token",
+			refreshToken:         auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken:          models.RefreshToken{ID: tokenID.String(), UserID: userID.String(), DeviceInfoID: deviceID.String()},
+			revokeErr:            errors.New("db error"),
+			expectsLookup:        true,
+			expectsRevoke:        true,
+			expectedErrSubstring: "failed to revoke token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockDenylist := authmocks.NewTokenDenylist(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsCommit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockDenylist.On("DenyToken", ctx, claims).Return(tc.denyErr).Once()
+			if tc.expectsLookup {
+				mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+				mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+				dummyQueries.On("GetRefreshTokenByID", ctx, tokenID.String()).Return(tc.storedToken, tc.getTokenErr)
+			}
+			if tc.expectsRevoke {
+				dummyQueries.On("RevokeToken", ctx, mock.MatchedBy(func(arg database.RevokeTokenParams) bool {
+					return arg.UserID == userID.String() &&
+						arg.DeviceInfoID == deviceID.String() &&
+						arg.RevokedAt.Valid
+				})).Return(tc.revokeErr).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, nil, mockDenylist, nil, zap.NewNop())
+			err = svc.Logout(ctx, claims, tc.refreshToken)
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectsLookup {
+				require.NoError(t, sqlMock.ExpectationsWereMet())
+			}
+		})
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.Background()
+	claims := &jwt.RegisteredClaims{Subject: userID.String()}
+
+	tests := []struct {
+		name                 string
+		revokeErr            error
+		denyErr              error
+		expectedErrSubstring string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:                 "error revoking tokens",
+			revokeErr:            errors.New("db error"),
+			expectedErrSubstring: "failed to revoke tokens",
+		},
+		{
+			name:                 "denylist error",
+			denyErr:              errors.New("redis down"),
+			expectedErrSubstring: "failed to denylist access tokens",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockDenylist := authmocks.NewTokenDenylist(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.revokeErr == nil {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			dummyQueries.On("RevokeAllUserTokens", ctx, mock.MatchedBy(func(arg database.RevokeAllUserTokensParams) bool {
+				return arg.UserID == userID.String() && arg.RevokedAt.Valid
+			})).Return(tc.revokeErr).Once()
+			if tc.revokeErr == nil {
+				mockDenylist.On("DenyUser", ctx, userID.String(), mock.AnythingOfType("time.Time")).Return(tc.denyErr).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, nil, mockDenylist, nil, zap.NewNop())
+			err = svc.LogoutAll(ctx, claims)
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
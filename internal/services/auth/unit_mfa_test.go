@@ -0,0 +1,176 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/constants"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEnrollTOTP(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		user                 database.GetUserMFAByIDRow
+		getErr               error
+		setErr               error
+		expectsSet           bool
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:       "success",
+			user:       database.GetUserMFAByIDRow{Email: "user@example.com"},
+			expectsSet: true,
+		},
+		{
+			name: "already enabled",
+			user: database.GetUserMFAByIDRow{
+				Email:         "user@example.com",
+				TotpEnabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+			},
+			expectedErr: auth.ErrMFAAlreadyEnabled,
+		},
+		{
+			name:                 "error fetching user",
+			getErr:               errors.New("db error"),
+			expectedErrSubstring: "failed to fetch user",
+		},
+		{
+			name:                 "error storing secret",
+			user:                 database.GetUserMFAByIDRow{Email: "user@example.com"},
+			setErr:               errors.New("db error"),
+			expectsSet:           true,
+			expectedErrSubstring: "failed to store TOTP secret",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserQ := dbmocks.NewUserQuerier(t)
+			mockUserQ.On("GetUserMFAByID", ctx, userID.String()).Return(tc.user, tc.getErr).Once()
+			if tc.expectsSet {
+				mockUserQ.On("SetUserTOTPSecret", ctx, mock.MatchedBy(func(arg database.SetUserTOTPSecretParams) bool {
+					return arg.ID == userID.String() && arg.TotpSecret.Valid && arg.TotpSecret.String != ""
+				})).Return(tc.setErr).Once()
+			}
+
+			svc := auth.NewAuthService(nil, mockUserQ, nil, nil, nil, nil, nil, zap.NewNop())
+			enrollment, err := svc.EnrollTOTP(ctx, userID)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, enrollment.Secret, 32)
+			require.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+		})
+	}
+}
+
+func TestCompleteMFALoginRejectsChallenge(t *testing.T) {
+	challengeID := uuid.NewString()
+	valid := models.MfaChallenge{
+		ID:        challengeID,
+		TokenHash: auth.HashOneTimeToken("mfatoken"),
+		UserID:    uuid.NewString(),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	tests := []struct {
+		name                 string
+		challenge            models.MfaChallenge
+		getErr               error
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:        "unknown token",
+			getErr:      sql.ErrNoRows,
+			expectedErr: auth.ErrInvalidMFAChallenge,
+		},
+		{
+			name: "used challenge",
+			challenge: func() models.MfaChallenge {
+				used := valid
+				used.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				return used
+			}(),
+			expectedErr: auth.ErrInvalidMFAChallenge,
+		},
+		{
+			name: "expired challenge",
+			challenge: func() models.MfaChallenge {
+				expired := valid
+				expired.ExpiresAt = time.Now().Add(-time.Second)
+				return expired
+			}(),
+			expectedErr: auth.ErrInvalidMFAChallenge,
+		},
+		{
+			name: "attempts exhausted",
+			challenge: func() models.MfaChallenge {
+				exhausted := valid
+				exhausted.Attempts = 5
+				return exhausted
+			}(),
+			expectedErr: auth.ErrInvalidMFAChallenge,
+		},
+		{
+			name:                 "error fetching challenge",
+			getErr:               errors.New("db error"),
+			expectedErrSubstring: "failed to fetch mfa challenge",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/login/mfa", nil)
+			req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+			ctx := context.WithValue(req.Context(), constants.RequestKey, req)
+
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectRollback()
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			dummyQueries.On("GetMFAChallengeByHash", ctx, auth.HashOneTimeToken("mfatoken")).Return(tc.challenge, tc.getErr).Once()
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, nil, nil, nil, zap.NewNop())
+			_, err = svc.CompleteMFALogin(ctx, models.MFALoginInput{MFAToken: "mfatoken", Code: "123456"})
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+			}
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	email := "user@example.com"
+
+	tests := []struct {
+		name          string
+		email         string
+		getUserErr    error
+		createErr     error
+		sendErr       error
+		expectsToken  bool
+		expectsCommit bool
+		expectsMail   bool
+		expectedErr   error
+		expectedLog   string
+	}{
+		{
+			name:          "success",
+			email:         email,
+			expectsToken:  true,
+			expectsCommit: true,
+			expectsMail:   true,
+		},
+		{
+			name:        "invalid email",
+			email:       "invalid",
+			expectedErr: auth.ErrInvalidEmail,
+		},
+		{
+			name:       "unknown email",
+			email:      email,
+			getUserErr: sql.ErrNoRows,
+		},
+		{
+			name:        "error fetching user",
+			email:       email,
+			getUserErr:  errors.New("db error"),
+			expectedLog: "failed to fetch user",
+		},
+		{
+			name:         "error creating token",
+			email:        email,
+			createErr:    errors.New("db error"),
+			expectsToken: true,
+			expectedLog:  "failed to create reset token",
+		},
+		{
+			name:          "error sending mail",
+			email:         email,
+			sendErr:       errors.New("smtp down"),
+			expectsToken:  true,
+			expectsCommit: true,
+			expectsMail:   true,
+			expectedLog:   "failed to send reset email",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockUserQ := dbmocks.NewUserQuerier(t)
+			mockTokenGen := authmocks.NewTokenGenerator(t)
+			mockMailer := authmocks.NewMailer(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsCommit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			// The work runs in the background, on a context the request cannot cancel.
+			if tc.email == email {
+				mockUserQ.On("GetUserByEmail", mock.Anything, email).Return(database.GetUserByEmailRow{ID: userID}, tc.getUserErr).Once()
+			}
+			if tc.expectsToken {
+				mockTokenGen.On("MakeRefreshToken").Return("resettoken", nil).Once()
+				mockSqlTxQ.On("BeginTx", mock.Anything, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+				mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+				dummyQueries.On("InvalidateUserPasswordResetTokens", mock.Anything, mock.MatchedBy(func(arg database.InvalidateUserPasswordResetTokensParams) bool {
+					return arg.UserID == userID && arg.UsedAt.Valid
+				})).Return(nil).Once()
+				dummyQueries.On("CreatePasswordResetToken", mock.Anything, mock.MatchedBy(func(arg database.CreatePasswordResetTokenParams) bool {
+					return arg.UserID == userID &&
+						arg.TokenHash == auth.HashOneTimeToken("resettoken") &&
+						arg.ExpiresAt.After(time.Now())
+				})).Return(tc.createErr).Once()
+			}
+			if tc.expectsMail {
+				mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(msg mailer.Message) bool {
+					return msg.To == email && strings.Contains(msg.Body, "token=resettoken")
+				})).Return(tc.sendErr).Once()
+			}
+
+			core, logs := observer.New(zapcore.ErrorLevel)
+			svc := auth.NewAuthService(mockSqlTxQ, mockUserQ, mockTokenGen, nil, nil, nil, mockMailer, zap.New(core))
+			err = svc.RequestPasswordReset(ctx, tc.email)
+			svc.Wait()
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			// Failures are logged, never returned, so they do not tell whether the
+			// account exists.
+			require.NoError(t, err)
+			if tc.expectedLog != "" {
+				require.Equal(t, 1, logs.Len())
+				require.Contains(t, logs.All()[0].ContextMap()["error"], tc.expectedLog)
+			} else {
+				require.Zero(t, logs.Len())
+			}
+			if tc.expectsToken {
+				require.NoError(t, sqlMock.ExpectationsWereMet())
+			}
+		})
+	}
+}
+
+func TestRequestPasswordResetRateLimit(t *testing.T) {
+	ctx := context.Background()
+	mockUserQ := dbmocks.NewUserQuerier(t)
+	svc := auth.NewAuthService(nil, mockUserQ, nil, nil, nil, nil, nil, zap.NewNop())
+	svc.PasswordResetLimiter = auth.NewRateLimiter(auth.NewMemoryAttemptStore(), "password_reset:email", 1, time.Hour)
+
+	mockUserQ.On("GetUserByEmail", mock.Anything, "user@example.com").Return(database.GetUserByEmailRow{}, sql.ErrNoRows).Once()
+	require.NoError(t, svc.RequestPasswordReset(ctx, "user@example.com"))
+	svc.Wait()
+
+	// The limit counts requests per address, whatever its case, and starts no lookup.
+	err := svc.RequestPasswordReset(ctx, "User@Example.com")
+	var retryErr *auth.RetryAfterError
+	require.ErrorAs(t, err, &retryErr)
+	require.Equal(t, time.Hour, retryErr.RetryAfter)
+	svc.Wait()
+
+	mockUserQ.On("GetUserByEmail", mock.Anything, "other@example.com").Return(database.GetUserByEmailRow{}, sql.ErrNoRows).Once()
+	require.NoError(t, svc.RequestPasswordReset(ctx, "other@example.com"))
+	svc.Wait()
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	tokenID := uuid.NewString()
+	validToken := models.PasswordResetToken{
+		ID:        tokenID,
+		TokenHash: auth.HashOneTimeToken("resettoken"),
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name                 string
+		password             string
+		storedToken          models.PasswordResetToken
+		getErr               error
+		markedRows           int64
+		expectsMark          bool
+		expectsUpdate        bool
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:          "success",
+			password:      "Newpass1!",
+			storedToken:   validToken,
+			markedRows:    1,
+			expectsMark:   true,
+			expectsUpdate: true,
+		},
+		{
+			name:        "weak password",
+			password:    "weak",
+			expectedErr: auth.ErrInvalidPassword,
+		},
+		{
+			name:        "unknown token",
+			password:    "Newpass1!",
+			getErr:      sql.ErrNoRows,
+			expectedErr: auth.ErrInvalidResetToken,
+		},
+		{
+			name:     "used token",
+			password: "Newpass1!",
+			storedToken: func() models.PasswordResetToken {
+				used := validToken
+				used.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				return used
+			}(),
+			expectedErr: auth.ErrInvalidResetToken,
+		},
+		{
+			name:     "expired token",
+			password: "Newpass1!",
+			storedToken: func() models.PasswordResetToken {
+				expired := validToken
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				return expired
+			}(),
+			expectedErr: auth.ErrResetTokenExpired,
+		},
+		{
+			name:        "token consumed concurrently",
+			password:    "Newpass1!",
+			storedToken: validToken,
+			expectsMark: true,
+			expectedErr: auth.ErrInvalidResetToken,
+		},
+		{
+			name:                 "error fetching token",
+			password:             "Newpass1!",
+			getErr:               errors.New("db error"),
+			expectedErrSubstring: "failed to fetch reset token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockHasher := authmocks.NewPasswordHasher(t)
+			mockDenylist := authmocks.NewTokenDenylist(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsUpdate {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			if tc.expectedErr != auth.ErrInvalidPassword {
+				mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+				mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+				dummyQueries.On("GetPasswordResetTokenByHash", ctx, auth.HashOneTimeToken("resettoken")).Return(tc.storedToken, tc.getErr).Once()
+			}
+			if tc.expectsMark {
+				dummyQueries.On("MarkPasswordResetTokenUsed", ctx, mock.MatchedBy(func(arg database.MarkPasswordResetTokenUsedParams) bool {
+					return arg.ID == tokenID && arg.UsedAt.Valid
+				})).Return(tc.markedRows, nil).Once()
+			}
+			if tc.expectsUpdate {
+				mockHasher.On("HashPassword", tc.password).Return("hashednewpass", nil).Once()
+				dummyQueries.On("UpdateUserPassword", ctx, mock.MatchedBy(func(arg database.UpdateUserPasswordParams) bool {
+					return arg.ID == userID && arg.HashedPassword == "hashednewpass"
+				})).Return(nil).Once()
+				dummyQueries.On("RevokeAllUserTokens", ctx, mock.MatchedBy(func(arg database.RevokeAllUserTokensParams) bool {
+					return arg.UserID == userID && arg.RevokedAt.Valid
+				})).Return(nil).Once()
+				mockDenylist.On("DenyUser", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, mockHasher, mockDenylist, nil, zap.NewNop())
+			err = svc.ResetPassword(ctx, "resettoken", tc.password)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRefresh(t *testing.T) {
+	userID := uuid.New()
+	deviceID := uuid.New()
+	tokenID := uuid.New()
+	familyID := uuid.New()
+	ctx := context.Background()
+
+	storedToken := models.RefreshToken{
+		ID:           tokenID.String(),
+		TokenHash:    "hashedsecret",
+		ExpiresAt:    sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		UserID:       userID.String(),
+		DeviceInfoID: deviceID.String(),
+		FamilyID:     sql.NullString{String: familyID.String(), Valid: true},
+	}
+
+	tests := []struct {
+		name                 string
+		refreshToken         string
+		storedToken          models.RefreshToken
+		getTokenErr          error
+		secretMismatch       bool
+		activeToken          models.RefreshToken
+		activeErr            error
+		expectsRotation      bool
+		revokedByOther       bool
+		expectsFamilyRevoke  bool
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:            "success",
+			refreshToken:    auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken:     storedToken,
+			activeToken:     storedToken,
+			expectsRotation: true,
+		},
+		{
+			name:         "malformed token",
+			refreshToken: "not-a-token",
+			expectedErr:  auth.ErrInvalidRefreshToken,
+		},
+		{
+			name:         "token not found",
+			refreshToken: auth.FormatRefreshToken(tokenID, "secret"),
+			getTokenErr:  sql.ErrNoRows,
+			expectedErr:  auth.ErrInvalidRefreshToken,
+		},
+		{
+			name:                 "error fetching token",
+			refreshToken:         auth.FormatRefreshToken(tokenID, "secret"),
+			getTokenErr:          errors.New("db error"),
+			expectedErrSubstring: "failed to fetch refresh token",
+		},
+		{
+			name:           "secret mismatch",
+			refreshToken:   auth.FormatRefreshToken(tokenID, "wrong"),
+			storedToken:    storedToken,
+			secretMismatch: true,
+			expectedErr:    auth.ErrInvalidRefreshToken,
+		},
+		{
+			name:         "reuse of a rotated token",
+			refreshToken: auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken: func() models.RefreshToken {
+				revoked := storedToken
+				revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				return revoked
+			}(),
+			activeToken:         models.RefreshToken{ID: uuid.NewString()},
+			expectsFamilyRevoke: true,
+			expectedErr:         auth.ErrRefreshTokenReused,
+		},
+		{
+			name:                "reuse after logout",
+			refreshToken:        auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken:         storedToken,
+			activeErr:           sql.ErrNoRows,
+			expectsFamilyRevoke: true,
+			expectedErr:         auth.ErrRefreshTokenReused,
+		},
+		{
+			name:                "concurrent refresh with the same token",
+			refreshToken:        auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken:         storedToken,
+			activeToken:         storedToken,
+			revokedByOther:      true,
+			expectsFamilyRevoke: true,
+			expectedErr:         auth.ErrRefreshTokenReused,
+		},
+		{
+			name:         "expired token",
+			refreshToken: auth.FormatRefreshToken(tokenID, "secret"),
+			storedToken: func() models.RefreshToken {
+				expired := storedToken
+				expired.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+				return expired
+			}(),
+			activeToken: func() models.RefreshToken {
+				expired := storedToken
+				expired.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+				return expired
+			}(),
+			expectedErr: auth.ErrRefreshTokenExpired,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			mockUserQ := dbmocks.NewUserQuerier(t)
+			mockTokenGen := authmocks.NewTokenGenerator(t)
+			mockHasher := authmocks.NewPasswordHasher(t)
+			mockRefreshHasher := authmocks.NewRefreshTokenHasher(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsRotation || tc.expectsFamilyRevoke {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			if tc.refreshToken != "not-a-token" {
+				mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+				mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+				dummyQueries.On("GetRefreshTokenByID", ctx, tokenID.String()).Return(tc.storedToken, tc.getTokenErr)
+			}
+			if tc.getTokenErr == nil && tc.refreshToken != "not-a-token" {
+				_, secret, _ := auth.ParseRefreshToken(tc.refreshToken)
+				mockRefreshHasher.On("Verify", secret, tc.storedToken.TokenHash).Return(!tc.secretMismatch)
+				if !tc.secretMismatch {
+					dummyQueries.On("GetRefreshByUserAndDevice", ctx, database.GetRefreshByUserAndDeviceParams{
+						UserID:       userID.String(),
+						DeviceInfoID: deviceID.String(),
+					}).Return(tc.activeToken, tc.activeErr)
+				}
+			}
+			if tc.revokedByOther {
+				dummyQueries.On("RevokeTokenByID", ctx, mock.MatchedBy(func(arg database.RevokeTokenByIDParams) bool {
+					return arg.ID == tokenID.String()
+				})).Return(int64(0), nil).Once()
+			}
+			if tc.expectsFamilyRevoke {
+				dummyQueries.On("RevokeTokenFamily", ctx, mock.MatchedBy(func(arg database.RevokeTokenFamilyParams) bool {
+					return arg.FamilyID.String == familyID.String() && arg.RevokedAt.Valid
+				})).Return(nil).Once()
+			}
+			if tc.expectsRotation {
+				dummyQueries.On("RevokeTokenByID", ctx, mock.MatchedBy(func(arg database.RevokeTokenByIDParams) bool {
+					return arg.ID == tokenID.String()
+				})).Return(int64(1), nil).Once()
+				mockTokenGen.On("MakeJWT", userID, deviceID, 15*time.Minute).Return("JWT", nil)
+				mockTokenGen.On("MakeRefreshToken").Return("newsecret", nil)
+				mockRefreshHasher.On("Hash", "newsecret").Return("hashednewsecret")
+				dummyQueries.On("CreateRefreshToken", ctx, mock.MatchedBy(func(arg database.CreateRefreshTokenParams) bool {
+					return arg.TokenHash == "hashednewsecret" &&
+						arg.UserID == userID.String() &&
+						arg.DeviceInfoID == deviceID.String() &&
+						arg.FamilyID.String == familyID.String()
+				})).Return(nil).Once()
+				dummyQueries.On("UpdateDeviceLastUsed", ctx, mock.MatchedBy(func(arg database.UpdateDeviceLastUsedParams) bool {
+					return arg.ID == deviceID.String() && arg.LastUsedAt.Valid
+				})).Return(nil).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, mockUserQ, mockTokenGen, nil, mockHasher, nil, nil, zap.NewNop())
+			svc.RefreshHasher = mockRefreshHasher
+			response, err := svc.Refresh(ctx, tc.refreshToken)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, userID, response.UserID)
+			require.Equal(t, "JWT", response.JWTToken)
+			newID, secret, err := auth.ParseRefreshToken(response.RefreshToken)
+			require.NoError(t, err)
+			require.NotEqual(t, tokenID, newID)
+			require.Equal(t, "newsecret", secret)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
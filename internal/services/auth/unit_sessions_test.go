@@ -0,0 +1,200 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestListSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	phoneID := uuid.NewString()
+	laptopID := uuid.NewString()
+	lastUsed := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name                 string
+		rows                 []database.ListUserSessionsRow
+		listErr              error
+		expected             []models.Session
+		expectedErrSubstring string
+	}{
+		{
+			name: "success",
+			rows: []database.ListUserSessionsRow{
+				{
+					ID:         phoneID,
+					DeviceType: "Mobile",
+					Os:         "Android",
+					AppInfo:    sql.NullString{String: "unity-wealth/2.0.1", Valid: true},
+					LastUsedAt: sql.NullTime{Time: lastUsed, Valid: true},
+					ExpiresAt:  sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+				},
+				{
+					ID:         laptopID,
+					DeviceType: "Desktop",
+					Os:         "Windows",
+					LastUsedAt: sql.NullTime{Time: lastUsed, Valid: true},
+				},
+			},
+			expected: []models.Session{
+				{ID: phoneID, DeviceType: "Mobile", Os: "Android", AppInfo: "unity-wealth/2.0.1", LastUsedAt: lastUsed, Active: true},
+				{ID: laptopID, DeviceType: "Desktop", Os: "Windows", LastUsedAt: lastUsed},
+			},
+		},
+		{
+			name: "expired token is inactive and duplicates collapse",
+			rows: []database.ListUserSessionsRow{
+				{ID: phoneID, ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}},
+				{ID: phoneID, ExpiresAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}},
+				{ID: laptopID, ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}},
+			},
+			expected: []models.Session{
+				{ID: phoneID, Active: true},
+				{ID: laptopID},
+			},
+		},
+		{
+			name:     "no sessions",
+			expected: []models.Session{},
+		},
+		{
+			name:                 "query error",
+			listErr:              errors.New("db error"),
+			expectedErrSubstring: "failed to list sessions",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectRollback()
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			dummyQueries.On("ListUserSessions", ctx, userID.String()).Return(tc.rows, tc.listErr).Once()
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, nil, nil, nil, zap.NewNop())
+			sessions, err := svc.ListSessions(ctx, userID)
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, sessions)
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		getErr               error
+		revokeErr            error
+		denyErr              error
+		expectsRevoke        bool
+		expectedErr          error
+		expectedErrSubstring string
+	}{
+		{
+			name:          "success",
+			expectsRevoke: true,
+		},
+		{
+			name:        "session not found",
+			getErr:      sql.ErrNoRows,
+			expectedErr: auth.ErrSessionNotFound,
+		},
+		{
+			name:                 "error fetching device",
+			getErr:               errors.New("db error"),
+			expectedErrSubstring: "failed to fetch device info",
+		},
+		{
+			name:                 "error revoking token",
+			revokeErr:            errors.New("db error"),
+			expectsRevoke:        true,
+			expectedErrSubstring: "failed to revoke token",
+		},
+		{
+			name:                 "error denylisting access tokens",
+			denyErr:              errors.New("redis down"),
+			expectsRevoke:        true,
+			expectedErrSubstring: "failed to denylist access tokens",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDenylist := authmocks.NewTokenDenylist(t)
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.expectsRevoke && tc.revokeErr == nil {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			dummyQueries.On("GetDeviceInfoByID", ctx, database.GetDeviceInfoByIDParams{
+				ID:     sessionID.String(),
+				UserID: userID.String(),
+			}).Return(models.DeviceInfoLog{ID: sessionID.String(), UserID: userID.String()}, tc.getErr).Once()
+			if tc.expectsRevoke {
+				dummyQueries.On("RevokeToken", ctx, mock.MatchedBy(func(arg database.RevokeTokenParams) bool {
+					return arg.UserID == userID.String() && arg.DeviceInfoID == sessionID.String() && arg.RevokedAt.Valid
+				})).Return(tc.revokeErr).Once()
+			}
+			if tc.expectsRevoke && tc.revokeErr == nil {
+				mockDenylist.On("DenyDevice", ctx, sessionID.String(), mock.AnythingOfType("time.Time")).Return(tc.denyErr).Once()
+			}
+
+			svc := auth.NewAuthService(mockSqlTxQ, nil, nil, nil, nil, mockDenylist, nil, zap.NewNop())
+			err = svc.RevokeSession(ctx, userID, sessionID)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrSubstring != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrSubstring)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authmocks "github.com/seanhuebl/unity-wealth/internal/mocks/auth"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	ctx := context.Background()
+	email := "user@example.com"
+	ip := "203.0.113.7"
+
+	t.Run("backoff doubles per failure", func(t *testing.T) {
+		throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+		throttle.BaseDelay = time.Minute
+		throttle.LockoutDuration = time.Hour
+
+		for i, expected := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+			require.NoError(t, throttle.RecordFailure(ctx, email, ip), "failure %d", i+1)
+			var retryErr *auth.RetryAfterError
+			require.ErrorAs(t, throttle.Check(ctx, email, ip), &retryErr)
+			require.InDelta(t, expected.Seconds(), retryErr.RetryAfter.Seconds(), 1)
+		}
+	})
+
+	t.Run("lockout after max failures is logged", func(t *testing.T) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.New(core))
+		throttle.BaseDelay = 0
+
+		for i := int64(1); i < throttle.MaxFailures; i++ {
+			require.NoError(t, throttle.RecordFailure(ctx, email, ip))
+			require.NoError(t, throttle.Check(ctx, email, ip))
+		}
+		err := throttle.RecordFailure(ctx, email, ip)
+		require.ErrorIs(t, err, auth.ErrTooManyAttempts)
+
+		var retryErr *auth.RetryAfterError
+		require.ErrorAs(t, throttle.Check(ctx, email, ip), &retryErr)
+		require.InDelta(t, throttle.LockoutDuration.Seconds(), retryErr.RetryAfter.Seconds(), 1)
+
+		entries := logs.FilterMessage("login_lockout").All()
+		require.Len(t, entries, 1)
+		require.Equal(t, "login:email:"+email, entries[0].ContextMap()["key"])
+
+		// Other accounts from the same IP are unaffected.
+		require.NoError(t, throttle.Check(ctx, "other@example.com", ip))
+	})
+
+	t.Run("mfa failures lock out the user", func(t *testing.T) {
+		throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+		userID := "user-1"
+
+		require.NoError(t, throttle.RecordMFAFailure(ctx, userID))
+		require.NoError(t, throttle.RecordMFASuccess(ctx, userID))
+		for i := int64(1); i < throttle.MaxMFAFailures; i++ {
+			require.NoError(t, throttle.RecordMFAFailure(ctx, userID))
+			require.NoError(t, throttle.CheckMFA(ctx, userID))
+		}
+		require.ErrorIs(t, throttle.RecordMFAFailure(ctx, userID), auth.ErrTooManyAttempts)
+
+		var retryErr *auth.RetryAfterError
+		require.ErrorAs(t, throttle.CheckMFA(ctx, userID), &retryErr)
+		require.InDelta(t, throttle.LockoutDuration.Seconds(), retryErr.RetryAfter.Seconds(), 1)
+		require.NoError(t, throttle.CheckMFA(ctx, "user-2"))
+		// Password logins are counted apart.
+		require.NoError(t, throttle.Check(ctx, email, ip))
+	})
+
+	t.Run("ip lockout covers every email", func(t *testing.T) {
+		throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+		throttle.BaseDelay = 0
+		throttle.MaxIPFailures = 3
+
+		require.NoError(t, throttle.RecordFailure(ctx, "a@example.com", ip))
+		require.NoError(t, throttle.RecordFailure(ctx, "b@example.com", ip))
+		require.ErrorIs(t, throttle.RecordFailure(ctx, "c@example.com", ip), auth.ErrTooManyAttempts)
+
+		require.ErrorIs(t, throttle.Check(ctx, "d@example.com", ip), auth.ErrTooManyAttempts)
+		require.NoError(t, throttle.Check(ctx, "d@example.com", "198.51.100.1"))
+	})
+
+	t.Run("success clears email failures", func(t *testing.T) {
+		throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+		require.NoError(t, throttle.RecordFailure(ctx, email, ip))
+		require.Error(t, throttle.Check(ctx, email, ""))
+
+		require.NoError(t, throttle.RecordSuccess(ctx, email))
+		require.NoError(t, throttle.Check(ctx, email, ""))
+	})
+
+	t.Run("email is case insensitive", func(t *testing.T) {
+		throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+		require.NoError(t, throttle.RecordFailure(ctx, "User@Example.com", ""))
+		require.ErrorIs(t, throttle.Check(ctx, email, ""), auth.ErrTooManyAttempts)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store := authmocks.NewAttemptStore(t)
+		store.On("BlockedFor", ctx, mock.Anything).Return(time.Duration(0), errors.New("redis down")).Once()
+		throttle := auth.NewLoginThrottle(store, zap.NewNop())
+
+		err := throttle.Check(ctx, email, ip)
+		require.ErrorContains(t, err, "failed to check login attempts")
+		require.NotErrorIs(t, err, auth.ErrTooManyAttempts)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter := auth.NewRateLimiter(auth.NewMemoryAttemptStore(), "signup", 2, time.Hour)
+
+	require.NoError(t, limiter.Allow(ctx, "203.0.113.7"))
+	require.NoError(t, limiter.Allow(ctx, "203.0.113.7"))
+
+	var retryErr *auth.RetryAfterError
+	require.ErrorAs(t, limiter.Allow(ctx, "203.0.113.7"), &retryErr)
+	require.Equal(t, time.Hour, retryErr.RetryAfter)
+	require.ErrorIs(t, limiter.Allow(ctx, "203.0.113.7"), auth.ErrTooManyAttempts)
+
+	require.NoError(t, limiter.Allow(ctx, "198.51.100.1"))
+}
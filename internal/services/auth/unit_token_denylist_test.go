@@ -0,0 +1,234 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTokenDenylist(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	deviceID := uuid.NewString()
+	issued := time.Now().Add(-time.Minute)
+	newClaims := func() *auth.AccessClaims {
+		return &auth.AccessClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        uuid.NewString(),
+				Subject:   userID,
+				IssuedAt:  jwt.NewNumericDate(issued),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			SessionID: deviceID,
+		}
+	}
+
+	t.Run("denied token", func(t *testing.T) {
+		denylist := auth.NewMemoryTokenDenylist(time.Hour)
+		denied, other := newClaims(), newClaims()
+		require.NoError(t, denylist.DenyToken(ctx, &denied.RegisteredClaims))
+
+		isDenied, err := denylist.IsDenied(ctx, denied)
+		require.NoError(t, err)
+		require.True(t, isDenied)
+
+		isDenied, err = denylist.IsDenied(ctx, other)
+		require.NoError(t, err)
+		require.False(t, isDenied)
+	})
+
+	t.Run("denied user", func(t *testing.T) {
+		denylist := auth.NewMemoryTokenDenylist(time.Hour)
+		before := newClaims()
+		require.NoError(t, denylist.DenyUser(ctx, userID, time.Now()))
+
+		isDenied, err := denylist.IsDenied(ctx, before)
+		require.NoError(t, err)
+		require.True(t, isDenied)
+
+		after := newClaims()
+		after.IssuedAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		isDenied, err = denylist.IsDenied(ctx, after)
+		require.NoError(t, err)
+		require.False(t, isDenied)
+	})
+
+	t.Run("denied device", func(t *testing.T) {
+		denylist := auth.NewMemoryTokenDenylist(time.Hour)
+		before := newClaims()
+		require.NoError(t, denylist.DenyDevice(ctx, deviceID, time.Now()))
+
+		isDenied, err := denylist.IsDenied(ctx, before)
+		require.NoError(t, err)
+		require.True(t, isDenied)
+
+		otherDevice := newClaims()
+		otherDevice.SessionID = uuid.NewString()
+		isDenied, err = denylist.IsDenied(ctx, otherDevice)
+		require.NoError(t, err)
+		require.False(t, isDenied)
+
+		after := newClaims()
+		after.IssuedAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		isDenied, err = denylist.IsDenied(ctx, after)
+		require.NoError(t, err)
+		require.False(t, isDenied)
+	})
+
+	t.Run("login in the same second as a denial", func(t *testing.T) {
+		denylist := auth.NewMemoryTokenDenylist(time.Hour)
+		deniedAt := time.Now().Truncate(time.Second).Add(100 * time.Millisecond)
+		require.NoError(t, denylist.DenyUser(ctx, userID, deniedAt))
+
+		after := newClaims()
+		after.IssuedAt = jwt.NewNumericDate(deniedAt.Add(200 * time.Millisecond))
+		isDenied, err := denylist.IsDenied(ctx, after)
+		require.NoError(t, err)
+		require.False(t, isDenied)
+	})
+
+	t.Run("expired token is not stored", func(t *testing.T) {
+		denylist := auth.NewMemoryTokenDenylist(time.Hour)
+		expired := newClaims()
+		expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		require.NoError(t, denylist.DenyToken(ctx, &expired.RegisteredClaims))
+
+		isDenied, err := denylist.IsDenied(ctx, expired)
+		require.NoError(t, err)
+		require.False(t, isDenied)
+	})
+}
+
+func TestRedisTokenDenylist(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	deviceID := uuid.NewString()
+	issued := time.Now().Add(-time.Minute)
+	claims := &auth.AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(issued),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		SessionID: deviceID,
+	}
+
+	t.Run("deny user", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		denylist := auth.NewRedisTokenDenylist(client, 15*time.Minute)
+		now := time.Now()
+		redisMock.ExpectSet("jwt_denylist:user:"+userID, now.UnixMilli(), 15*time.Minute).SetVal("OK")
+
+		require.NoError(t, denylist.DenyUser(ctx, userID, now))
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	t.Run("deny device", func(t *testing.T) {
+		client, redisMock := redismock.NewClientMock()
+		denylist := auth.NewRedisTokenDenylist(client, 15*time.Minute)
+		now := time.Now()
+		redisMock.ExpectSet("jwt_denylist:device:"+deviceID, now.UnixMilli(), 15*time.Minute).SetVal("OK")
+
+		require.NoError(t, denylist.DenyDevice(ctx, deviceID, now))
+		require.NoError(t, redisMock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name        string
+		setup       func(redisMock redismock.ClientMock)
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name: "token denied",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(1)
+			},
+			expected: true,
+		},
+		{
+			name: "user denied after token was issued",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(0)
+				redisMock.ExpectGet("jwt_denylist:user:" + userID).SetVal(strconv.FormatInt(time.Now().UnixMilli(), 10))
+			},
+			expected: true,
+		},
+		{
+			name: "user denied in seconds after token was issued",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(0)
+				redisMock.ExpectGet("jwt_denylist:user:" + userID).SetVal(strconv.FormatInt(time.Now().Unix(), 10))
+			},
+			expected: true,
+		},
+		{
+			name: "user denied before token was issued",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(0)
+				redisMock.ExpectGet("jwt_denylist:user:" + userID).SetVal(strconv.FormatInt(issued.Add(-time.Hour).UnixMilli(), 10))
+				redisMock.ExpectGet("jwt_denylist:device:" + deviceID).RedisNil()
+			},
+			expected: false,
+		},
+		{
+			name: "device denied after token was issued",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(0)
+				redisMock.ExpectGet("jwt_denylist:user:" + userID).RedisNil()
+				redisMock.ExpectGet("jwt_denylist:device:" + deviceID).SetVal(strconv.FormatInt(time.Now().UnixMilli(), 10))
+			},
+			expected: true,
+		},
+		{
+			name: "device denied before token was issued",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(0)
+				redisMock.ExpectGet("jwt_denylist:user:" + userID).RedisNil()
+				redisMock.ExpectGet("jwt_denylist:device:" + deviceID).SetVal(strconv.FormatInt(issued.Add(-time.Hour).UnixMilli(), 10))
+			},
+			expected: false,
+		},
+		{
+			name: "not denied",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetVal(0)
+				redisMock.ExpectGet("jwt_denylist:user:" + userID).RedisNil()
+				redisMock.ExpectGet("jwt_denylist:device:" + deviceID).RedisNil()
+			},
+			expected: false,
+		},
+		{
+			name: "redis error",
+			setup: func(redisMock redismock.ClientMock) {
+				redisMock.ExpectExists("jwt_denylist:jti:" + claims.ID).SetErr(errors.New("redis down"))
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, redisMock := redismock.NewClientMock()
+			tc.setup(redisMock)
+			denylist := auth.NewRedisTokenDenylist(client, 15*time.Minute)
+
+			isDenied, err := denylist.IsDenied(ctx, claims)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, isDenied)
+			require.NoError(t, redisMock.ExpectationsWereMet())
+		})
+	}
+}
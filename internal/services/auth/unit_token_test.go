@@ -55,7 +55,7 @@ func TestMakeJWT(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tokenGen := auth.NewRealTokenGenerator(tc.tokenSecret, "testaccess")
-			token, err := tokenGen.MakeJWT(tc.userID, tc.expiresIn)
+			token, err := tokenGen.MakeJWT(tc.userID, uuid.New(), tc.expiresIn)
 
 			if (err != nil) != tc.wantErr {
 				t.Errorf("MakeJWT() error = %v, wantErr %v", err, tc.wantErr)
@@ -102,7 +102,7 @@ func TestValidateJWT(t *testing.T) {
 			name: "Valid token",
 			tokenString: func() string {
 				tokenGen := auth.NewRealTokenGenerator("testsecret", "testaccess")
-				token, _ := tokenGen.MakeJWT(userID, time.Hour)
+				token, _ := tokenGen.MakeJWT(userID, uuid.New(), time.Hour)
 				return token
 			}(),
 			tokenSecret: "testsecret",
@@ -113,7 +113,7 @@ func TestValidateJWT(t *testing.T) {
 			name: "Invalid token secret",
 			tokenString: func() string {
 				tokenGen := auth.NewRealTokenGenerator("wrongsecret", "testaccess")
-				token, _ := tokenGen.MakeJWT(uuid.New(), time.Hour)
+				token, _ := tokenGen.MakeJWT(uuid.New(), uuid.New(), time.Hour)
 				return token
 			}(),
 			tokenSecret: "wrongsecret",
@@ -0,0 +1,76 @@
+package auth_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B test secret for HMAC-SHA1.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		unix     int64
+		expected string
+	}{
+		{name: "t=59", unix: 59, expected: "287082"},
+		{name: "t=1111111109", unix: 1111111109, expected: "081804"},
+		{name: "t=1234567890", unix: 1234567890, expected: "005924"},
+		{name: "t=2000000000", unix: 2000000000, expected: "279037"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := auth.TOTPCode(rfcSecret, time.Unix(tc.unix, 0))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, code)
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	currentStep := now.Unix() / 30
+	code, err := auth.TOTPCode(rfcSecret, now)
+	require.NoError(t, err)
+	previous, err := auth.TOTPCode(rfcSecret, now.Add(-30*time.Second))
+	require.NoError(t, err)
+	stale, err := auth.TOTPCode(rfcSecret, now.Add(-90*time.Second))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		secret       string
+		code         string
+		lastStep     int64
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{name: "current code", secret: rfcSecret, code: code, expectedStep: currentStep, expectedOK: true},
+		{name: "previous period within skew", secret: rfcSecret, code: previous, expectedStep: currentStep - 1, expectedOK: true},
+		{name: "outside skew", secret: rfcSecret, code: stale},
+		{name: "replayed step", secret: rfcSecret, code: code, lastStep: currentStep},
+		{name: "wrong code", secret: rfcSecret, code: "000000"},
+		{name: "wrong length", secret: rfcSecret, code: "12345"},
+		{name: "invalid secret", secret: "not base32!", code: code},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			step, ok := auth.ValidateTOTP(tc.secret, tc.code, now, tc.lastStep)
+			require.Equal(t, tc.expectedOK, ok)
+			require.Equal(t, tc.expectedStep, step)
+		})
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := auth.TOTPProvisioningURI("user@example.com", rfcSecret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Unity%20Wealth:user@example.com?"))
+	require.Contains(t, uri, "secret="+rfcSecret)
+	require.Contains(t, uri, "issuer=Unity+Wealth")
+}
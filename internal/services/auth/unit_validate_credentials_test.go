@@ -13,6 +13,7 @@ import (
 	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
 	"github.com/seanhuebl/unity-wealth/internal/models"
 	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -30,6 +31,8 @@ func TestValidateCredentials(t *testing.T) {
 		input                  models.LoginInput
 		getUserErr             error
 		pwdHasherErr           error
+		needsRehash            bool
+		rehashErr              error
 		expectedErrorSubstring string
 		expectedUserID         uuid.UUID
 	}{
@@ -44,6 +47,25 @@ func TestValidateCredentials(t *testing.T) {
 			expectedErrorSubstring: "",
 			expectedUserID:         uuid.MustParse(dummyUser.ID),
 		},
+		{
+			name: "legacy hash is upgraded",
+			input: models.LoginInput{
+				Email:    "user@example.com",
+				Password: "correctpassword",
+			},
+			needsRehash:    true,
+			expectedUserID: uuid.MustParse(dummyUser.ID),
+		},
+		{
+			name: "failed upgrade does not block login",
+			input: models.LoginInput{
+				Email:    "user@example.com",
+				Password: "correctpassword",
+			},
+			needsRehash:    true,
+			rehashErr:      errors.New("db error"),
+			expectedUserID: uuid.MustParse(dummyUser.ID),
+		},
 		{
 			name: "user not found",
 			input: models.LoginInput{
@@ -88,7 +110,19 @@ func TestValidateCredentials(t *testing.T) {
 			if tc.getUserErr == nil {
 				mockPwdHasher.On("CheckPasswordHash", tc.input.Password, dummyUser.HashedPassword).Return(tc.pwdHasherErr)
 			}
-			authSvc := auth.NewAuthService(nil, mockUserQ, nil, nil, mockPwdHasher, nopLogger)
+			if tc.getUserErr == nil && tc.pwdHasherErr == nil {
+				mockPwdHasher.On("NeedsRehash", dummyUser.HashedPassword).Return(tc.needsRehash)
+			}
+			if tc.needsRehash {
+				mockPwdHasher.On("HashPassword", tc.input.Password).Return("newhash", nil).Once()
+				mockUserQ.On("UpgradeUserPasswordHash", ctx, mock.MatchedBy(func(arg database.UpgradeUserPasswordHashParams) bool {
+					return arg.ID == dummyUser.ID &&
+						arg.HashedPassword == "newhash" &&
+						arg.HashedPassword_2 == dummyUser.HashedPassword &&
+						arg.UpdatedAt.Valid
+				})).Return(int64(1), tc.rehashErr).Once()
+			}
+			authSvc := auth.NewAuthService(nil, mockUserQ, nil, nil, mockPwdHasher, nil, nil, nopLogger)
 
 			userID, err := authSvc.ValidateCredentials(ctx, tc.input)
 			if tc.expectedErrorSubstring != "" {
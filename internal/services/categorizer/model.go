@@ -0,0 +1,180 @@
+package categorizer
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+const (
+	// docFeature counts the transactions a category was trained on.
+	docFeature     = "*"
+	merchantPrefix = "m:"
+	amountPrefix   = "a:"
+	maxSuggestions = 3
+)
+
+// stopWords are left out of merchant and description words; they say nothing about
+// the category.
+var stopWords = map[string]bool{
+	"and": true, "as": true, "at": true, "by": true, "for": true, "from": true,
+	"in": true, "including": true, "of": true, "on": true, "or": true, "other": true,
+	"such": true, "the": true, "to": true, "with": true,
+}
+
+// features returns what the model reads from a transaction: the words of the merchant
+// and a bucket for the direction and size of the amount.
+func features(merchant string, amount float64) []string {
+	feats := words(merchant)
+	for i, w := range feats {
+		feats[i] = merchantPrefix + w
+	}
+	return append(feats, amountBucket(amount))
+}
+
+// words splits text into lower case words, dropping numbers, single letters and stop
+// words. Plurals are made singular so "groceries" in a description matches "grocery"
+// in a merchant.
+func words(text string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 2 || stopWords[w] || strings.IndexFunc(w, unicode.IsLetter) < 0 {
+			continue
+		}
+		switch {
+		case len(w) > 4 && strings.HasSuffix(w, "ies"):
+			w = w[:len(w)-3] + "y"
+		case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+			w = w[:len(w)-1]
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// amountBucket names the amount's direction and its number of whole-dollar digits, so
+// $4.50 and $7 share a bucket and $1,200 is in another.
+func amountBucket(amount float64) string {
+	flow := "out"
+	if amount < 0 {
+		flow = "in"
+	}
+	digits := 0
+	if dollars := int64(math.Abs(amount)); dollars > 0 {
+		digits = min(len(strconv.FormatInt(dollars, 10)), 6)
+	}
+	return amountPrefix + flow + ":" + strconv.Itoa(digits)
+}
+
+// model is a multinomial naive Bayes model over transaction features.
+type model struct {
+	examples map[int64]int64            // transactions per category
+	counts   map[int64]map[string]int64 // feature counts per category
+	totals   map[int64]int64            // all feature counts per category
+	vocab    map[string]int64           // feature counts over all categories
+}
+
+func newModel() *model {
+	return &model{
+		examples: map[int64]int64{},
+		counts:   map[int64]map[string]int64{},
+		totals:   map[int64]int64{},
+		vocab:    map[string]int64{},
+	}
+}
+
+// add trains the model on n transactions of category with feats; a negative n untrains
+// it.
+func (m *model) add(category int64, feats []string, n int64) {
+	m.addCount(category, docFeature, n)
+	for _, f := range feats {
+		m.addCount(category, f, n)
+	}
+}
+
+// addCount adds n to one stored count, docFeature included.
+func (m *model) addCount(category int64, feature string, n int64) {
+	if feature == docFeature {
+		m.examples[category] += n
+		return
+	}
+	if m.counts[category] == nil {
+		m.counts[category] = map[string]int64{}
+	}
+	m.counts[category][feature] += n
+	m.totals[category] += n
+	m.vocab[feature] += n
+}
+
+// knows reports whether the model has seen any of the merchant words in feats. The
+// amount alone is too weak to suggest a category on.
+func (m *model) knows(feats []string) bool {
+	return slices.ContainsFunc(feats, func(f string) bool {
+		return strings.HasPrefix(f, merchantPrefix) && m.vocab[f] > 0
+	})
+}
+
+// suggest returns the limit most likely categories for feats with their probabilities.
+// Features the model has never seen are skipped rather than counted against every
+// category alike.
+func (m *model) suggest(feats []string, limit int) []models.CategorySuggestion {
+	var docs, categories int64
+	for _, n := range m.examples {
+		if n > 0 {
+			docs += n
+			categories++
+		}
+	}
+	vocab := int64(0)
+	for _, n := range m.vocab {
+		if n > 0 {
+			vocab++
+		}
+	}
+
+	type score struct {
+		category int64
+		logP     float64
+	}
+	scores := make([]score, 0, categories)
+	for category, n := range m.examples {
+		if n <= 0 {
+			continue
+		}
+		logP := math.Log(float64(n+1) / float64(docs+categories))
+		for _, f := range feats {
+			if m.vocab[f] <= 0 {
+				continue
+			}
+			logP += math.Log(float64(m.counts[category][f]+1) / float64(m.totals[category]+vocab))
+		}
+		scores = append(scores, score{category, logP})
+	}
+	slices.SortFunc(scores, func(a, b score) int {
+		if c := cmp.Compare(b.logP, a.logP); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.category, b.category)
+	})
+
+	// Softmax, shifted by the best score so the exponents cannot underflow to zero.
+	var sum float64
+	for _, s := range scores {
+		sum += math.Exp(s.logP - scores[0].logP)
+	}
+	suggestions := make([]models.CategorySuggestion, 0, limit)
+	for _, s := range scores[:min(limit, len(scores))] {
+		suggestions = append(suggestions, models.CategorySuggestion{
+			DetailedCategory: s.category,
+			Confidence:       math.Round(math.Exp(s.logP-scores[0].logP)/sum*1e4) / 1e4,
+		})
+	}
+	return suggestions
+}
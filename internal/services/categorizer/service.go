@@ -0,0 +1,259 @@
+// Package categorizer suggests categories for transactions with a naive Bayes model
+// over merchant words and amount buckets. Each user has a model trained on their own
+// transactions; a model trained on the category descriptions stands in until the
+// user's model knows a merchant.
+package categorizer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+// learnPageSize is how many untrained transactions catchUp trains at a time.
+const learnPageSize = 500
+
+type CategorizerService struct {
+	modelQueries database.CategoryModelQuerier
+	sqlTxQuerier database.SqlTxQuerier
+	txQueries    database.TransactionQuerier
+	logger       *zap.Logger
+
+	mu     sync.Mutex
+	global *model
+}
+
+func NewCategorizerService(modelQueries database.CategoryModelQuerier, sqlTxQuerier database.SqlTxQuerier, txQueries database.TransactionQuerier, logger *zap.Logger) *CategorizerService {
+	return &CategorizerService{
+		modelQueries: modelQueries,
+		sqlTxQuerier: sqlTxQuerier,
+		txQueries:    txQueries,
+		logger:       logger,
+	}
+}
+
+// Suggest returns up to three detailed categories for txn, most likely first. The
+// user's model is used when it knows a word of the merchant, and the global model
+// otherwise; when neither does there are no suggestions. If the user's model was
+// trained on txn itself, that is left out so its current category does not vote for
+// itself.
+func (s *CategorizerService) Suggest(ctx context.Context, userID string, txn models.Tx) ([]models.CategorySuggestion, error) {
+	if err := s.catchUp(ctx, userID); err != nil {
+		return nil, err
+	}
+	feats := features(txn.Merchant, txn.Amount)
+
+	user, err := s.userModel(ctx, userID, txn.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.knows(feats) {
+		return user.suggest(feats, maxSuggestions), nil
+	}
+
+	global, err := s.globalModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if global.knows(feats) {
+		return global.suggest(feats, maxSuggestions), nil
+	}
+	return []models.CategorySuggestion{}, nil
+}
+
+// Learn trains the user's model on txn, first taking back what an earlier version of
+// txn taught it. A correction to the category or merchant thus moves the counts
+// instead of adding to them, and learning an unchanged transaction does nothing.
+func (s *CategorizerService) Learn(ctx context.Context, userID string, txn models.Tx) error {
+	return s.inTx(ctx, func(q database.CategoryModelQuerier) error {
+		return learn(ctx, q, userID, txn.ID, txn.DetailedCategory, features(txn.Merchant, txn.Amount))
+	})
+}
+
+// Forget takes a deleted transaction back out of the user's model.
+func (s *CategorizerService) Forget(ctx context.Context, userID, txnID string) error {
+	return s.inTx(ctx, func(q database.CategoryModelQuerier) error {
+		ex, err := q.GetCategoryExample(ctx, database.GetCategoryExampleParams{TransactionID: txnID, UserID: userID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return fmt.Errorf("failed to load example: %w", err)
+		}
+		if err := unlearn(ctx, q, ex); err != nil {
+			return err
+		}
+		if err := q.DeleteCategoryExample(ctx, database.DeleteCategoryExampleParams{TransactionID: txnID, UserID: userID}); err != nil {
+			return fmt.Errorf("failed to delete example: %w", err)
+		}
+		return nil
+	})
+}
+
+// catchUp trains the user's model on transactions it has not seen, such as those
+// created before the categorizer existed or whose training failed.
+func (s *CategorizerService) catchUp(ctx context.Context, userID string) error {
+	for {
+		rows, err := s.modelQueries.ListUnlearnedTransactions(ctx, database.ListUnlearnedTransactionsParams{UserID: userID, Limit: learnPageSize})
+		if err != nil {
+			return fmt.Errorf("failed to list untrained transactions: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		err = s.inTx(ctx, func(q database.CategoryModelQuerier) error {
+			for _, row := range rows {
+				if err := learn(ctx, q, userID, row.ID, row.DetailedCategoryID, features(row.Merchant, float64(row.AmountCents)/100)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		s.logger.Debug("trained category model", zap.String("user_id", userID), zap.Int("transactions", len(rows)))
+		if len(rows) < learnPageSize {
+			return nil
+		}
+	}
+}
+
+// userModel loads the user's model, leaving out the transaction with ID skipID.
+func (s *CategorizerService) userModel(ctx context.Context, userID, skipID string) (*model, error) {
+	rows, err := s.modelQueries.ListCategoryFeatures(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category model: %w", err)
+	}
+	m := newModel()
+	for _, row := range rows {
+		m.addCount(row.DetailedCategoryID, row.Feature, row.Count)
+	}
+	if skipID == "" {
+		return m, nil
+	}
+	ex, err := s.modelQueries.GetCategoryExample(ctx, database.GetCategoryExampleParams{TransactionID: skipID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to load example: %w", err)
+	}
+	m.add(ex.DetailedCategoryID, strings.Fields(ex.Features), -1)
+	return m, nil
+}
+
+// globalModel returns the model trained on the names and descriptions of the
+// categories, building it on first use. Each category is one example.
+func (s *CategorizerService) globalModel(ctx context.Context) (*model, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.global != nil {
+		return s.global, nil
+	}
+
+	primaries, err := s.txQueries.GetPrimaryCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load primary categories: %w", err)
+	}
+	details, err := s.txQueries.GetDetailedCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load detailed categories: %w", err)
+	}
+	primaryNames := make(map[int64]string, len(primaries))
+	for _, p := range primaries {
+		primaryNames[p.ID] = p.Name
+	}
+	m := newModel()
+	for _, d := range details {
+		var feats []string
+		for _, w := range words(strings.Join([]string{primaryNames[d.PrimaryCategoryID], d.Name, d.Description}, " ")) {
+			if !slices.Contains(feats, merchantPrefix+w) {
+				feats = append(feats, merchantPrefix+w)
+			}
+		}
+		m.add(d.ID, feats, 1)
+	}
+	s.global = m
+	return m, nil
+}
+
+func (s *CategorizerService) inTx(ctx context.Context, fn func(q database.CategoryModelQuerier) error) error {
+	tx, err := s.sqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(database.NewRealCategoryModelQuerier(s.sqlTxQuerier.WithTx(tx))); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// learn makes the stored example of transaction txnID the given category and
+// features, moving the counts to match.
+func learn(ctx context.Context, q database.CategoryModelQuerier, userID, txnID string, category int64, feats []string) error {
+	encoded := strings.Join(feats, " ")
+	ex, err := q.GetCategoryExample(ctx, database.GetCategoryExampleParams{TransactionID: txnID, UserID: userID})
+	switch {
+	case err == nil:
+		if ex.DetailedCategoryID == category && ex.Features == encoded {
+			return nil
+		}
+		if err := unlearn(ctx, q, ex); err != nil {
+			return err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to load example: %w", err)
+	}
+
+	if err := addCounts(ctx, q, userID, category, feats, 1); err != nil {
+		return err
+	}
+	if err := q.SaveCategoryExample(ctx, database.SaveCategoryExampleParams{
+		TransactionID:      txnID,
+		UserID:             userID,
+		DetailedCategoryID: category,
+		Features:           encoded,
+	}); err != nil {
+		return fmt.Errorf("failed to save example: %w", err)
+	}
+	return nil
+}
+
+// unlearn takes a stored example out of the counts. It leaves the example itself,
+// which the caller replaces or deletes.
+func unlearn(ctx context.Context, q database.CategoryModelQuerier, ex models.CategoryModelExample) error {
+	if err := addCounts(ctx, q, ex.UserID, ex.DetailedCategoryID, strings.Fields(ex.Features), -1); err != nil {
+		return err
+	}
+	if err := q.DeleteEmptyCategoryFeatures(ctx, ex.UserID); err != nil {
+		return fmt.Errorf("failed to prune category model: %w", err)
+	}
+	return nil
+}
+
+func addCounts(ctx context.Context, q database.CategoryModelQuerier, userID string, category int64, feats []string, n int64) error {
+	for _, f := range append([]string{docFeature}, feats...) {
+		if err := q.AddCategoryFeature(ctx, database.AddCategoryFeatureParams{
+			UserID:             userID,
+			Feature:            f,
+			DetailedCategoryID: category,
+			Count:              n,
+		}); err != nil {
+			return fmt.Errorf("failed to update category model: %w", err)
+		}
+	}
+	return nil
+}
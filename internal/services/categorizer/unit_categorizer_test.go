@@ -0,0 +1,139 @@
+package categorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/categorizer"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	groceries = int64(40)
+	coffee    = int64(41)
+	gas       = int64(42)
+)
+
+func setupCategorizer(t *testing.T) (*testmodels.TestEnv, *categorizer.CategorizerService, uuid.UUID) {
+	t.Helper()
+	env := testhelpers.SetupTestEnv(t)
+	t.Cleanup(func() { env.Db.Close() })
+	userID := uuid.New()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	_, err := env.Db.Exec(`
+	INSERT INTO primary_categories (id, name) VALUES (8, 'TRANSPORTATION');
+	INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES
+		(41, 'COFFEE', 'Purchases at coffee shops or cafes', 7),
+		(42, 'GAS', 'Purchases at gas stations', 8);
+	`)
+	require.NoError(t, err)
+
+	q := database.NewRealTransactionalQuerier(database.New(env.Db))
+	svc := categorizer.NewCategorizerService(database.NewRealCategoryModelQuerier(q), database.NewRealSqlTxQuerier(q), env.TxQ, zap.NewNop())
+	return env, svc, userID
+}
+
+func seedTx(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, merchant string, amount float64, category int64) models.Tx {
+	t.Helper()
+	id := uuid.New()
+	testhelpers.SeedTestTransaction(t, env.TxQ, userID, id, &models.NewTxRequest{Date: "2025-03-01", Merchant: merchant, Amount: amount, DetailedCategory: category})
+	return models.Tx{ID: id.String(), Merchant: merchant, Amount: amount, DetailedCategory: category}
+}
+
+func categoriesOf(suggestions []models.CategorySuggestion) []int64 {
+	ids := make([]int64, len(suggestions))
+	for i, s := range suggestions {
+		ids[i] = s.DetailedCategory
+	}
+	return ids
+}
+
+func TestSuggestGlobalFallback(t *testing.T) {
+	ctx := context.Background()
+	_, svc, userID := setupCategorizer(t)
+
+	suggestions, err := svc.Suggest(ctx, userID.String(), models.Tx{Merchant: "SHELL GAS STATION 0042", Amount: 38.5})
+	require.NoError(t, err)
+	require.Len(t, suggestions, 3)
+	require.Equal(t, gas, suggestions[0].DetailedCategory)
+	var sum float64
+	for i, s := range suggestions {
+		if i > 0 {
+			require.LessOrEqual(t, s.Confidence, suggestions[i-1].Confidence)
+		}
+		sum += s.Confidence
+	}
+	require.InDelta(t, 1, sum, 0.001)
+
+	// A merchant no model knows gets no suggestions.
+	suggestions, err = svc.Suggest(ctx, userID.String(), models.Tx{Merchant: "ZXQV 1234", Amount: 10})
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+}
+
+func TestSuggestUserModel(t *testing.T) {
+	ctx := context.Background()
+	env, svc, userID := setupCategorizer(t)
+
+	// The user files their coffee under groceries, against the descriptions. Training
+	// catches up on these transactions the first time a suggestion is asked for.
+	var bought []models.Tx
+	for _, merchant := range []string{"BLUE BOTTLE COFFEE 12", "Blue Bottle Coffee", "SQ *BLUE BOTTLE"} {
+		bought = append(bought, seedTx(t, env, userID, merchant, 5.25, groceries))
+	}
+	seedTx(t, env, userID, "Chevron 0091", 41, gas)
+
+	suggestions, err := svc.Suggest(ctx, userID.String(), models.Tx{Merchant: "Blue Bottle", Amount: 4.75})
+	require.NoError(t, err)
+	require.Equal(t, []int64{groceries, gas}, categoriesOf(suggestions))
+	require.Greater(t, suggestions[0].Confidence, 0.9)
+
+	// Correcting the category moves the counts instead of adding to them.
+	for _, txn := range bought {
+		txn.DetailedCategory = coffee
+		require.NoError(t, svc.Learn(ctx, userID.String(), txn))
+		require.NoError(t, svc.Learn(ctx, userID.String(), txn))
+	}
+	suggestions, err = svc.Suggest(ctx, userID.String(), models.Tx{Merchant: "Blue Bottle", Amount: 4.75})
+	require.NoError(t, err)
+	require.Equal(t, []int64{coffee, gas}, categoriesOf(suggestions))
+
+	var stale int
+	require.NoError(t, env.Db.QueryRow("SELECT COUNT(*) FROM category_model_features WHERE detailed_category_id = ?", groceries).Scan(&stale))
+	require.Zero(t, stale)
+	var docs int
+	require.NoError(t, env.Db.QueryRow("SELECT count FROM category_model_features WHERE feature = '*' AND detailed_category_id = ?", coffee).Scan(&docs))
+	require.Equal(t, 3, docs)
+}
+
+func TestSuggestLeavesOutTheTransaction(t *testing.T) {
+	ctx := context.Background()
+	env, svc, userID := setupCategorizer(t)
+
+	only := seedTx(t, env, userID, "Shell", 30, groceries)
+	_, err := svc.Suggest(ctx, userID.String(), models.Tx{Merchant: "Shell", Amount: 30})
+	require.NoError(t, err)
+
+	// Without itself the user's model knows nothing, so the descriptions decide.
+	suggestions, err := svc.Suggest(ctx, userID.String(), only)
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+
+	other := seedTx(t, env, userID, "Shell", 30, gas)
+	suggestions, err = svc.Suggest(ctx, userID.String(), only)
+	require.NoError(t, err)
+	require.Equal(t, []int64{gas}, categoriesOf(suggestions))
+
+	require.NoError(t, svc.Forget(ctx, userID.String(), other.ID))
+	require.NoError(t, svc.Forget(ctx, userID.String(), other.ID))
+	var examples int
+	require.NoError(t, env.Db.QueryRow("SELECT COUNT(*) FROM category_model_examples").Scan(&examples))
+	require.Equal(t, 1, examples)
+}
@@ -0,0 +1,10 @@
+package category
+
+import "errors"
+
+var (
+	ErrCategoryExists   = errors.New("another category has this name")
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrInvalidCategory  = errors.New("invalid category")
+	ErrInvalidMerge     = errors.New("invalid merge")
+)
@@ -0,0 +1,415 @@
+// Package category layers each user's categories over the system taxonomy. Users add
+// detailed categories of their own under the system primary categories, rename or
+// hide system categories for themselves, and merge categories, which moves
+// everything filed under the merged ones.
+package category
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxMergeSources bounds the categories one merge folds in.
+	MaxMergeSources      = 100
+	maxNameLength        = 100
+	maxDescriptionLength = 500
+)
+
+type CategoryService struct {
+	categoryQueries database.UserCategoryQuerier
+	sqlTxQuerier    database.SqlTxQuerier
+	txQueries       database.TransactionQuerier
+	logger          *zap.Logger
+}
+
+func NewCategoryService(categoryQueries database.UserCategoryQuerier, sqlTxQuerier database.SqlTxQuerier, txQueries database.TransactionQuerier, logger *zap.Logger) *CategoryService {
+	return &CategoryService{
+		categoryQueries: categoryQueries,
+		sqlTxQuerier:    sqlTxQuerier,
+		txQueries:       txQueries,
+		logger:          logger,
+	}
+}
+
+// ListCategories returns the detailed categories the user sees, grouped by primary
+// category: the system categories under the user's names for them, and the user's
+// own. Hidden and archived categories are left out unless includeHidden is set.
+func (s *CategoryService) ListCategories(ctx context.Context, userID string, includeHidden bool) ([]models.Category, error) {
+	all, err := userCategories(ctx, s.categoryQueries, userID)
+	if err != nil {
+		return nil, err
+	}
+	if includeHidden {
+		return all, nil
+	}
+	return slices.DeleteFunc(all, func(c models.Category) bool { return c.Hidden || c.Archived }), nil
+}
+
+// CreateCategory adds a category of the user's own. Its name may not be that of
+// another category the user sees.
+func (s *CategoryService) CreateCategory(ctx context.Context, userID string, req models.NewCategoryRequest) (models.Category, error) {
+	name, err := validName(req.Name)
+	if err != nil {
+		return models.Category{}, err
+	}
+	description := strings.TrimSpace(req.Description)
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return models.Category{}, fmt.Errorf("%w: description must be at most %d characters", ErrInvalidCategory, maxDescriptionLength)
+	}
+	primaries, err := s.txQueries.GetPrimaryCategories(ctx)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("failed to load categories: %w", err)
+	}
+	if !slices.ContainsFunc(primaries, func(p models.PrimaryCategory) bool { return p.ID == req.PrimaryCategory }) {
+		return models.Category{}, fmt.Errorf("%w: unknown primary_category %d", ErrInvalidCategory, req.PrimaryCategory)
+	}
+
+	var id int64
+	err = s.inTx(ctx, func(q database.UserCategoryQuerier) error {
+		all, err := userCategories(ctx, q, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkNameFree(all, name, 0); err != nil {
+			return err
+		}
+		id, err = q.CreateDetailedCategory(ctx, database.CreateDetailedCategoryParams{
+			Name:              name,
+			Description:       description,
+			PrimaryCategoryID: req.PrimaryCategory,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create category: %w", err)
+		}
+		if err := q.CreateUserCategory(ctx, database.CreateUserCategoryParams{DetailedCategoryID: id, UserID: userID}); err != nil {
+			return fmt.Errorf("failed to create category: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+	return s.category(ctx, userID, id)
+}
+
+// UpdateCategory renames, hides or archives a category for the user. A system
+// category is renamed and hidden for this user only, and cannot be archived; a
+// category of the user's own is archived rather than hidden. Transactions already in
+// an archived category keep it, but no more can be put in it.
+func (s *CategoryService) UpdateCategory(ctx context.Context, userID string, categoryID int64, update models.CategoryUpdate) (models.Category, error) {
+	err := s.inTx(ctx, func(q database.UserCategoryQuerier) error {
+		all, err := userCategories(ctx, q, userID)
+		if err != nil {
+			return err
+		}
+		c, err := find(all, categoryID)
+		if err != nil {
+			return err
+		}
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+
+		if !c.Custom {
+			if update.Archived != nil {
+				return fmt.Errorf("%w: system categories are hidden, not archived", ErrInvalidCategory)
+			}
+			override, err := currentOverride(ctx, q, userID, categoryID)
+			if err != nil {
+				return err
+			}
+			if update.Name != nil {
+				override.Name = sql.NullString{}
+				if strings.TrimSpace(*update.Name) != "" {
+					name, err := validName(*update.Name)
+					if err != nil {
+						return err
+					}
+					if err := checkNameFree(all, name, categoryID); err != nil {
+						return err
+					}
+					override.Name = sql.NullString{String: name, Valid: true}
+				}
+			}
+			if update.Hidden != nil {
+				override.Hidden = *update.Hidden
+			}
+			override.UpdatedAt = now
+			if err := q.UpsertCategoryOverride(ctx, override); err != nil {
+				return fmt.Errorf("failed to update category: %w", err)
+			}
+			return nil
+		}
+
+		if update.Hidden != nil {
+			return fmt.Errorf("%w: categories of your own are archived, not hidden", ErrInvalidCategory)
+		}
+		archived := c.Archived
+		if update.Archived != nil {
+			archived = *update.Archived
+		}
+		if update.Name != nil {
+			name, err := validName(*update.Name)
+			if err != nil {
+				return err
+			}
+			if !archived {
+				if err := checkNameFree(all, name, categoryID); err != nil {
+					return err
+				}
+			}
+			if _, err := q.RenameUserCategory(ctx, database.RenameUserCategoryParams{Name: name, ID: categoryID, UserID: userID}); err != nil {
+				return fmt.Errorf("failed to update category: %w", err)
+			}
+			c.Name = name
+		}
+		if update.Archived == nil || archived == c.Archived {
+			return nil
+		}
+		params := database.SetUserCategoryArchivedParams{UpdatedAt: now, DetailedCategoryID: categoryID, UserID: userID}
+		if archived {
+			params.ArchivedAt = now
+		} else if err := checkNameFree(all, c.Name, categoryID); err != nil {
+			return err
+		}
+		if _, err := q.SetUserCategoryArchived(ctx, params); err != nil {
+			return fmt.Errorf("failed to update category: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+	return s.category(ctx, userID, categoryID)
+}
+
+// MergeCategories folds the source categories into the target. The user's
+// transactions, merchant default categories, rules and trained suggestions move from
+// the sources to the target; sources of the user's own are then archived and system
+// sources hidden.
+func (s *CategoryService) MergeCategories(ctx context.Context, userID string, merge models.CategoryMerge) (models.Category, error) {
+	if len(merge.SourceIDs) == 0 || len(merge.SourceIDs) > MaxMergeSources {
+		return models.Category{}, fmt.Errorf("%w: a merge takes 1 to %d source categories", ErrInvalidMerge, MaxMergeSources)
+	}
+	if slices.Contains(merge.SourceIDs, merge.TargetID) {
+		return models.Category{}, fmt.Errorf("%w: a category cannot be merged into itself", ErrInvalidMerge)
+	}
+	sources := slices.Clone(merge.SourceIDs)
+	slices.Sort(sources)
+
+	err := s.inTx(ctx, func(q database.UserCategoryQuerier) error {
+		all, err := userCategories(ctx, q, userID)
+		if err != nil {
+			return err
+		}
+		target, err := find(all, merge.TargetID)
+		if err != nil {
+			return err
+		}
+		if target.Hidden || target.Archived {
+			return fmt.Errorf("%w: cannot merge into a hidden or archived category", ErrInvalidMerge)
+		}
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		for _, sourceID := range slices.Compact(sources) {
+			source, err := find(all, sourceID)
+			if err != nil {
+				return err
+			}
+			moved, err := moveCategory(ctx, q, userID, sourceID, target.ID, now)
+			if err != nil {
+				return err
+			}
+			if source.Custom {
+				_, err = q.SetUserCategoryArchived(ctx, database.SetUserCategoryArchivedParams{
+					ArchivedAt:         now,
+					MergedIntoID:       sql.NullInt64{Int64: target.ID, Valid: true},
+					UpdatedAt:          now,
+					DetailedCategoryID: sourceID,
+					UserID:             userID,
+				})
+			} else {
+				var override database.UpsertCategoryOverrideParams
+				override, err = currentOverride(ctx, q, userID, sourceID)
+				if err == nil {
+					override.Hidden = true
+					override.UpdatedAt = now
+					err = q.UpsertCategoryOverride(ctx, override)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("failed to retire merged category: %w", err)
+			}
+			s.logger.Debug("merged category", zap.String("user_id", userID), zap.Int64("from", sourceID), zap.Int64("to", target.ID), zap.Int64("transactions", moved))
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+	return s.category(ctx, userID, merge.TargetID)
+}
+
+func (s *CategoryService) category(ctx context.Context, userID string, categoryID int64) (models.Category, error) {
+	all, err := userCategories(ctx, s.categoryQueries, userID)
+	if err != nil {
+		return models.Category{}, err
+	}
+	return find(all, categoryID)
+}
+
+func (s *CategoryService) inTx(ctx context.Context, fn func(q database.UserCategoryQuerier) error) error {
+	tx, err := s.sqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(database.NewRealUserCategoryQuerier(s.sqlTxQuerier.WithTx(tx))); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// moveCategory moves what the user filed under fromID to toID and returns how many
+// transactions moved.
+func moveCategory(ctx context.Context, q database.UserCategoryQuerier, userID string, fromID, toID int64, now sql.NullTime) (int64, error) {
+	// Split lines move first: a split transaction keeps the category of its first line.
+	if err := q.ReassignSplitCategory(ctx, database.ReassignSplitCategoryParams{
+		ToCategoryID:   toID,
+		FromCategoryID: fromID,
+		UserID:         userID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to move split lines: %w", err)
+	}
+	moved, err := q.ReassignTransactionCategory(ctx, database.ReassignTransactionCategoryParams{
+		ToCategoryID:   toID,
+		UpdatedAt:      now,
+		UserID:         userID,
+		FromCategoryID: fromID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to move transactions: %w", err)
+	}
+	if err := q.ReassignMerchantCategory(ctx, database.ReassignMerchantCategoryParams{
+		ToCategoryID:   sql.NullInt64{Int64: toID, Valid: true},
+		UpdatedAt:      now,
+		UserID:         userID,
+		FromCategoryID: sql.NullInt64{Int64: fromID, Valid: true},
+	}); err != nil {
+		return 0, fmt.Errorf("failed to move merchant defaults: %w", err)
+	}
+	if err := q.ReassignRuleCategory(ctx, database.ReassignRuleCategoryParams{
+		ToCategoryID:   toID,
+		UpdatedAt:      now,
+		UserID:         userID,
+		FromCategoryID: fromID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to move rules: %w", err)
+	}
+	if err := q.MoveCategoryModelFeatures(ctx, database.MoveCategoryModelFeaturesParams{
+		ToCategoryID:   toID,
+		UserID:         userID,
+		FromCategoryID: fromID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to move category model: %w", err)
+	}
+	if err := q.DeleteCategoryModelFeatures(ctx, database.DeleteCategoryModelFeaturesParams{UserID: userID, DetailedCategoryID: fromID}); err != nil {
+		return 0, fmt.Errorf("failed to move category model: %w", err)
+	}
+	if err := q.MoveCategoryModelExamples(ctx, database.MoveCategoryModelExamplesParams{
+		ToCategoryID:   toID,
+		UserID:         userID,
+		FromCategoryID: fromID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to move category model: %w", err)
+	}
+	return moved, nil
+}
+
+// currentOverride returns the user's override of a system category as it stands, so
+// that an update of one field keeps the other.
+func currentOverride(ctx context.Context, q database.UserCategoryQuerier, userID string, categoryID int64) (database.UpsertCategoryOverrideParams, error) {
+	rows, err := q.ListUserCategories(ctx, userID)
+	if err != nil {
+		return database.UpsertCategoryOverrideParams{}, fmt.Errorf("failed to list categories: %w", err)
+	}
+	override := database.UpsertCategoryOverrideParams{UserID: userID, DetailedCategoryID: categoryID}
+	for _, row := range rows {
+		if row.ID == categoryID {
+			override.Name = row.OverrideName
+			override.Hidden = row.Hidden
+		}
+	}
+	return override, nil
+}
+
+func userCategories(ctx context.Context, q database.UserCategoryQuerier, userID string) ([]models.Category, error) {
+	rows, err := q.ListUserCategories(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	out := make([]models.Category, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, convertRow(row))
+	}
+	return out, nil
+}
+
+// convertRow turns a row of the user's category list into the category as the user
+// sees it.
+func convertRow(row database.ListUserCategoriesRow) models.Category {
+	c := models.Category{
+		ID:                  row.ID,
+		Name:                row.Name,
+		Description:         row.Description,
+		PrimaryCategory:     row.PrimaryCategoryID,
+		PrimaryCategoryName: row.PrimaryCategoryName,
+		Custom:              row.UserID.Valid,
+		Hidden:              row.Hidden,
+		Archived:            row.ArchivedAt.Valid,
+	}
+	if row.OverrideName.Valid {
+		c.Name = row.OverrideName.String
+	}
+	if row.MergedIntoID.Valid {
+		c.MergedInto = &row.MergedIntoID.Int64
+	}
+	return c
+}
+
+func find(all []models.Category, categoryID int64) (models.Category, error) {
+	i := slices.IndexFunc(all, func(c models.Category) bool { return c.ID == categoryID })
+	if i < 0 {
+		return models.Category{}, fmt.Errorf("%w: %d", ErrCategoryNotFound, categoryID)
+	}
+	return all[i], nil
+}
+
+func validName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidCategory, maxNameLength)
+	}
+	return name, nil
+}
+
+// checkNameFree returns ErrCategoryExists if a category the user sees, other than
+// exceptID, already goes by name. Hidden and archived categories do not count.
+func checkNameFree(all []models.Category, name string, exceptID int64) error {
+	for _, c := range all {
+		if c.ID != exceptID && !c.Hidden && !c.Archived && strings.EqualFold(c.Name, name) {
+			return fmt.Errorf("%w: %q", ErrCategoryExists, c.Name)
+		}
+	}
+	return nil
+}
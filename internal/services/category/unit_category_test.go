@@ -0,0 +1,276 @@
+package category_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/category"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+const groceries = int64(40)
+
+func setupCategories(t *testing.T) (*testmodels.TestEnv, uuid.UUID) {
+	t.Helper()
+	env := testhelpers.SetupTestEnv(t)
+	t.Cleanup(func() { env.Db.Close() })
+	userID := uuid.New()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	_, err := env.Db.Exec(`
+	INSERT INTO primary_categories (id, name) VALUES (9, 'ENTERTAINMENT');
+	INSERT INTO detailed_categories (id, name, description, primary_category_id) VALUES
+		(41, 'COFFEE', 'Purchases at coffee shops or cafes', 7);
+	`)
+	require.NoError(t, err)
+	return env, userID
+}
+
+func createOtherUser(t *testing.T, env *testmodels.TestEnv) uuid.UUID {
+	t.Helper()
+	otherUser := uuid.New()
+	require.NoError(t, env.UserQ.CreateUser(context.Background(), database.CreateUserParams{
+		ID:             otherUser.String(),
+		Email:          "other@example.com",
+		HashedPassword: "hashedpwd",
+	}))
+	return otherUser
+}
+
+func names(categories []models.Category) []string {
+	out := make([]string, len(categories))
+	for i, c := range categories {
+		out[i] = c.Name
+	}
+	return out
+}
+
+func TestCreateCategory(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupCategories(t)
+	svc := env.Services.CategoryService
+
+	created, err := svc.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{
+		Name: "  Kids activities ", Description: "Classes and camps", PrimaryCategory: 9,
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.Category{
+		ID: created.ID, Name: "Kids activities", Description: "Classes and camps",
+		PrimaryCategory: 9, PrimaryCategoryName: "ENTERTAINMENT", Custom: true,
+	}, created)
+
+	tests := []struct {
+		name        string
+		req         models.NewCategoryRequest
+		expectedErr error
+	}{
+		{name: "blank name", req: models.NewCategoryRequest{Name: "  ", PrimaryCategory: 9}, expectedErr: category.ErrInvalidCategory},
+		{name: "unknown primary", req: models.NewCategoryRequest{Name: "Pets", PrimaryCategory: 99}, expectedErr: category.ErrInvalidCategory},
+		{name: "same as own", req: models.NewCategoryRequest{Name: "KIDS ACTIVITIES", PrimaryCategory: 7}, expectedErr: category.ErrCategoryExists},
+		{name: "same as system", req: models.NewCategoryRequest{Name: "groceries", PrimaryCategory: 7}, expectedErr: category.ErrCategoryExists},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.CreateCategory(ctx, userID.String(), tc.req)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+
+	// Categories belong to one user, who alone sees and uses them.
+	otherUser := createOtherUser(t, env)
+	theirs, err := svc.ListCategories(ctx, otherUser.String(), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Groceries", "COFFEE"}, names(theirs))
+	_, err = env.Services.TxService.CreateTransaction(ctx, otherUser.String(), models.NewTxRequest{
+		Date: "2025-03-01", Merchant: "Camp", Amount: 200, DetailedCategory: created.ID,
+	})
+	require.ErrorIs(t, err, transaction.ErrCategoryUnavailable)
+	_, err = svc.UpdateCategory(ctx, otherUser.String(), created.ID, models.CategoryUpdate{Archived: ptr(true)})
+	require.ErrorIs(t, err, category.ErrCategoryNotFound)
+
+	mine, err := svc.ListCategories(ctx, userID.String(), false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Groceries", "COFFEE", "Kids activities"}, names(mine))
+}
+
+func TestUpdateSystemCategory(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupCategories(t)
+	svc := env.Services.CategoryService
+
+	renamed, err := svc.UpdateCategory(ctx, userID.String(), groceries, models.CategoryUpdate{Name: ptr("Food shopping")})
+	require.NoError(t, err)
+	require.Equal(t, "Food shopping", renamed.Name)
+	require.False(t, renamed.Custom)
+
+	hidden, err := svc.UpdateCategory(ctx, userID.String(), groceries, models.CategoryUpdate{Hidden: ptr(true)})
+	require.NoError(t, err)
+	require.True(t, hidden.Hidden)
+	require.Equal(t, "Food shopping", hidden.Name, "hiding keeps the name")
+
+	visible, err := svc.ListCategories(ctx, userID.String(), false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"COFFEE"}, names(visible))
+	all, err := svc.ListCategories(ctx, userID.String(), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Food shopping", "COFFEE"}, names(all))
+
+	// An empty name gives the category its own name back.
+	reset, err := svc.UpdateCategory(ctx, userID.String(), groceries, models.CategoryUpdate{Name: ptr(""), Hidden: ptr(false)})
+	require.NoError(t, err)
+	require.Equal(t, "Groceries", reset.Name)
+	require.False(t, reset.Hidden)
+
+	// Other users keep the system names.
+	otherUser := createOtherUser(t, env)
+	_, err = svc.UpdateCategory(ctx, userID.String(), groceries, models.CategoryUpdate{Name: ptr("Food shopping")})
+	require.NoError(t, err)
+	theirs, err := svc.ListCategories(ctx, otherUser.String(), false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Groceries", "COFFEE"}, names(theirs))
+
+	_, err = svc.UpdateCategory(ctx, userID.String(), groceries, models.CategoryUpdate{Name: ptr("coffee")})
+	require.ErrorIs(t, err, category.ErrCategoryExists)
+	_, err = svc.UpdateCategory(ctx, userID.String(), groceries, models.CategoryUpdate{Archived: ptr(true)})
+	require.ErrorIs(t, err, category.ErrInvalidCategory)
+	_, err = svc.UpdateCategory(ctx, userID.String(), 999, models.CategoryUpdate{Hidden: ptr(true)})
+	require.ErrorIs(t, err, category.ErrCategoryNotFound)
+}
+
+func TestUpdateOwnCategory(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupCategories(t)
+	svc := env.Services.CategoryService
+
+	own, err := svc.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "Camps", PrimaryCategory: 9})
+	require.NoError(t, err)
+	txn, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-03-01", Merchant: "Camp Kanata", Amount: 200, DetailedCategory: own.ID,
+	})
+	require.NoError(t, err)
+
+	renamed, err := svc.UpdateCategory(ctx, userID.String(), own.ID, models.CategoryUpdate{Name: ptr("Summer camps")})
+	require.NoError(t, err)
+	require.Equal(t, "Summer camps", renamed.Name)
+	_, err = svc.UpdateCategory(ctx, userID.String(), own.ID, models.CategoryUpdate{Hidden: ptr(true)})
+	require.ErrorIs(t, err, category.ErrInvalidCategory)
+
+	archived, err := svc.UpdateCategory(ctx, userID.String(), own.ID, models.CategoryUpdate{Archived: ptr(true)})
+	require.NoError(t, err)
+	require.True(t, archived.Archived)
+
+	// Transactions already in an archived category keep it, but no more go in.
+	got, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txn.ID)
+	require.NoError(t, err)
+	require.Equal(t, own.ID, got.DetailedCategory)
+	_, err = env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+		Date: "2025-03-02", Merchant: "Camp Kanata", Amount: 200, DetailedCategory: own.ID,
+	})
+	require.ErrorIs(t, err, transaction.ErrCategoryUnavailable)
+
+	// The name of an archived category is free again, so unarchiving checks it.
+	_, err = svc.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "summer camps", PrimaryCategory: 9})
+	require.NoError(t, err)
+	_, err = svc.UpdateCategory(ctx, userID.String(), own.ID, models.CategoryUpdate{Archived: ptr(false)})
+	require.ErrorIs(t, err, category.ErrCategoryExists)
+	restored, err := svc.UpdateCategory(ctx, userID.String(), own.ID, models.CategoryUpdate{Name: ptr("Camps"), Archived: ptr(false)})
+	require.NoError(t, err)
+	require.Equal(t, "Camps", restored.Name)
+	require.False(t, restored.Archived)
+}
+
+func TestMergeCategories(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupCategories(t)
+	svc := env.Services.CategoryService
+
+	own, err := svc.CreateCategory(ctx, userID.String(), models.NewCategoryRequest{Name: "Cafes", PrimaryCategory: 7})
+	require.NoError(t, err)
+	var txnIDs []string
+	for _, c := range []int64{own.ID, own.ID, groceries} {
+		txn, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{
+			Date: "2025-03-01", Merchant: "Blue Bottle", Amount: 5.25, DetailedCategory: c,
+		})
+		require.NoError(t, err)
+		txnIDs = append(txnIDs, txn.ID)
+	}
+	merchantID := testhelpers.MerchantID(t, env.Db, userID, "Blue Bottle")
+	_, err = env.Services.MerchantService.UpdateMerchant(ctx, userID.String(), merchantID, models.MerchantUpdate{DefaultCategory: &own.ID})
+	require.NoError(t, err)
+	rule, err := env.Services.RuleService.CreateRule(ctx, userID.String(), models.Rule{
+		Name:       "Cafes",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "cafe"}},
+		Actions:    models.RuleActions{DetailedCategory: own.ID},
+	})
+	require.NoError(t, err)
+
+	// Another user's transactions in the system category stay put.
+	otherUser := createOtherUser(t, env)
+	theirs, err := env.Services.TxService.CreateTransaction(ctx, otherUser.String(), models.NewTxRequest{
+		Date: "2025-03-01", Merchant: "Safeway", Amount: 80, DetailedCategory: groceries,
+	})
+	require.NoError(t, err)
+
+	target, err := svc.MergeCategories(ctx, userID.String(), models.CategoryMerge{TargetID: 41, SourceIDs: []int64{own.ID, groceries, own.ID}})
+	require.NoError(t, err)
+	require.Equal(t, "COFFEE", target.Name)
+
+	for _, id := range txnIDs {
+		got, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), id)
+		require.NoError(t, err)
+		require.Equal(t, int64(41), got.DetailedCategory)
+	}
+	got, err := env.Services.TxService.GetTransactionByID(ctx, otherUser.String(), theirs.ID)
+	require.NoError(t, err)
+	require.Equal(t, groceries, got.DetailedCategory)
+
+	merchants, err := env.Services.MerchantService.ListMerchants(ctx, userID.String())
+	require.NoError(t, err)
+	require.Equal(t, int64(41), *merchants[0].DefaultCategory)
+	rules, err := env.Services.RuleService.ListRules(ctx, userID.String())
+	require.NoError(t, err)
+	require.Equal(t, rule.ID, rules[0].ID)
+	require.Equal(t, int64(41), rules[0].Actions.DetailedCategory)
+	var stale int
+	require.NoError(t, env.Db.QueryRow(
+		"SELECT COUNT(*) FROM category_model_features WHERE user_id = ? AND detailed_category_id IN (?, ?)",
+		userID.String(), own.ID, groceries,
+	).Scan(&stale))
+	require.Zero(t, stale)
+
+	// The own source is archived and the system source hidden.
+	all, err := svc.ListCategories(ctx, userID.String(), true)
+	require.NoError(t, err)
+	require.Equal(t, []models.Category{
+		{ID: groceries, Name: "Groceries", Description: "Purchases for fresh produce and groceries, including farmers' markets", PrimaryCategory: 7, PrimaryCategoryName: "Food", Hidden: true},
+		{ID: 41, Name: "COFFEE", Description: "Purchases at coffee shops or cafes", PrimaryCategory: 7, PrimaryCategoryName: "Food"},
+		{ID: own.ID, Name: "Cafes", PrimaryCategory: 7, PrimaryCategoryName: "Food", Custom: true, Archived: true, MergedInto: ptr(int64(41))},
+	}, all)
+
+	tests := []struct {
+		name        string
+		merge       models.CategoryMerge
+		expectedErr error
+	}{
+		{name: "into itself", merge: models.CategoryMerge{TargetID: 41, SourceIDs: []int64{41}}, expectedErr: category.ErrInvalidMerge},
+		{name: "into hidden", merge: models.CategoryMerge{TargetID: groceries, SourceIDs: []int64{41}}, expectedErr: category.ErrInvalidMerge},
+		{name: "unknown source", merge: models.CategoryMerge{TargetID: 41, SourceIDs: []int64{999}}, expectedErr: category.ErrCategoryNotFound},
+		{name: "no sources", merge: models.CategoryMerge{TargetID: 41}, expectedErr: category.ErrInvalidMerge},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.MergeCategories(ctx, userID.String(), tc.merge)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
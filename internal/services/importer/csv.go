@@ -0,0 +1,386 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// MaxImportRows bounds the data rows read from one import file.
+const MaxImportRows = 10000
+
+// sampleRows is how many rows detection looks at.
+const sampleRows = 50
+
+var delimiters = []string{",", ";", "\t", "|"}
+
+// dateFormats are the date formats a mapping can name, in the order detection tries
+// them. Month-first comes before day-first, so ambiguous US-style dates win.
+var dateFormats = []struct {
+	name   string
+	layout string
+}{
+	{"YYYY-MM-DD", "2006-01-02"},
+	{"MM/DD/YYYY", "1/2/2006"},
+	{"DD/MM/YYYY", "2/1/2006"},
+	{"MM/DD/YY", "1/2/06"},
+	{"DD/MM/YY", "2/1/06"},
+	{"MM-DD-YYYY", "1-2-2006"},
+	{"DD-MM-YYYY", "2-1-2006"},
+	{"DD.MM.YYYY", "2.1.2006"},
+	{"YYYY/MM/DD", "2006/1/2"},
+	{"YYYYMMDD", "20060102"},
+	{"DD MMM YYYY", "2 Jan 2006"},
+	{"MMM DD, YYYY", "Jan 2, 2006"},
+}
+
+// Header names detection looks for, best match first. Headers are compared after
+// lower-casing and turning punctuation into spaces.
+var (
+	dateHeaders     = []string{"date", "transaction date", "trans date", "posted date", "posting date", "post date", "booking date"}
+	merchantHeaders = []string{"merchant", "payee", "description", "transaction description", "name", "details"}
+	amountHeaders   = []string{"amount", "transaction amount", "amount usd"}
+	debitHeaders    = []string{"debit", "debit amount", "withdrawal", "withdrawals", "money out", "paid out"}
+	creditHeaders   = []string{"credit", "credit amount", "deposit", "deposits", "money in", "paid in"}
+	categoryHeaders = []string{"category", "plaid category", "detailed category"}
+	notesHeaders    = []string{"notes", "note", "memo", "reference"}
+	tagsHeaders     = []string{"tags", "labels"}
+)
+
+type csvRecord struct {
+	line   int
+	fields []string
+}
+
+type csvFile struct {
+	headers []string
+	records []csvRecord
+}
+
+// readCSV splits data into a header row and data records. Blank lines are skipped and
+// records may have a different number of fields than the header; the row conversion
+// reports those.
+func readCSV(data []byte, delimiter string) (csvFile, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = []rune(delimiter)[0]
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var file csvFile
+	for {
+		fields, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return csvFile{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		if file.headers == nil {
+			file.headers = trimFields(fields)
+			continue
+		}
+		if len(file.records) == MaxImportRows {
+			return csvFile{}, ErrTooManyRows
+		}
+		line, _ := r.FieldPos(0)
+		file.records = append(file.records, csvRecord{line: line, fields: trimFields(fields)})
+	}
+	if file.headers == nil {
+		return csvFile{}, fmt.Errorf("%w: the file is empty", ErrInvalidFile)
+	}
+	if len(file.records) == 0 {
+		return csvFile{}, fmt.Errorf("%w: the file has a header but no rows", ErrInvalidFile)
+	}
+	return file, nil
+}
+
+// detectDelimiter picks the delimiter that splits the header into the most columns,
+// counting only delimiters that give most of the first rows the same number.
+func detectDelimiter(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	best, bestColumns := delimiters[0], 0
+	for _, delimiter := range delimiters {
+		r := csv.NewReader(bytes.NewReader(data))
+		r.Comma = []rune(delimiter)[0]
+		r.FieldsPerRecord = -1
+		r.LazyQuotes = true
+		columns, rows, matching := 0, 0, 0
+		for ; rows < sampleRows; rows++ {
+			fields, err := r.Read()
+			if err != nil {
+				break
+			}
+			if rows == 0 {
+				columns = len(fields)
+			}
+			if len(fields) == columns {
+				matching++
+			}
+		}
+		if matching*2 > rows && columns > bestColumns {
+			best, bestColumns = delimiter, columns
+		}
+	}
+	return best
+}
+
+// detectMapping guesses a mapping for file from its headers and values. The result may
+// be incomplete; validateMapping says what is missing. Warnings describe guesses the
+// user should check.
+func detectMapping(file csvFile, delimiter string) (models.CSVMapping, []string) {
+	m := models.CSVMapping{
+		Delimiter:      delimiter,
+		DateColumn:     findHeader(file.headers, dateHeaders, "date"),
+		MerchantColumn: findHeader(file.headers, merchantHeaders, ""),
+		CategoryColumn: findHeader(file.headers, categoryHeaders, ""),
+		NotesColumn:    findHeader(file.headers, notesHeaders, ""),
+		TagsColumn:     findHeader(file.headers, tagsHeaders, ""),
+	}
+	var warnings []string
+
+	m.AmountColumn = findHeader(file.headers, amountHeaders, "")
+	if m.AmountColumn == "" {
+		m.DebitColumn = findHeader(file.headers, debitHeaders, "")
+		m.CreditColumn = findHeader(file.headers, creditHeaders, "")
+	}
+
+	if m.DateColumn != "" {
+		formats := matchingDateFormats(columnValues(file, m.DateColumn))
+		if len(formats) > 0 {
+			m.DateFormat = formats[0]
+		}
+		if len(formats) > 1 {
+			warnings = append(warnings, fmt.Sprintf("dates match %s; assumed %s", strings.Join(formats, " and "), formats[0]))
+		}
+	}
+
+	if m.AmountColumn != "" {
+		var negative, positive int
+		for _, v := range columnValues(file, m.AmountColumn) {
+			if amount, err := parseAmount(v); err == nil {
+				switch {
+				case amount < 0:
+					negative++
+				case amount > 0:
+					positive++
+				}
+			}
+		}
+		// Most rows in a bank export are spending, so the more common sign is money out.
+		if negative > positive {
+			m.NegateAmounts = true
+			warnings = append(warnings, "most amounts are negative; treating negative amounts as money leaving the account")
+		}
+	}
+	return m, warnings
+}
+
+// validateMapping checks m on its own; columnIndexes checks it against a file.
+func validateMapping(m models.CSVMapping) error {
+	if !slices.Contains(delimiters, m.Delimiter) {
+		return fmt.Errorf("%w: delimiter must be one of , ; | or a tab", ErrInvalidMapping)
+	}
+	if m.DateColumn == "" {
+		return fmt.Errorf("%w: no date column", ErrInvalidMapping)
+	}
+	if _, ok := dateLayout(m.DateFormat); !ok {
+		return fmt.Errorf("%w: unknown date format %q", ErrInvalidMapping, m.DateFormat)
+	}
+	if m.MerchantColumn == "" {
+		return fmt.Errorf("%w: no merchant column", ErrInvalidMapping)
+	}
+	split := m.DebitColumn != "" || m.CreditColumn != ""
+	if m.AmountColumn == "" && !split {
+		return fmt.Errorf("%w: no amount column, or debit and credit columns", ErrInvalidMapping)
+	}
+	if m.AmountColumn != "" && split {
+		return fmt.Errorf("%w: use either an amount column or debit and credit columns", ErrInvalidMapping)
+	}
+	return nil
+}
+
+// columnIndexes finds each mapped column in headers.
+func columnIndexes(m models.CSVMapping, headers []string) (map[string]int, error) {
+	indexes := make(map[string]int)
+	for _, column := range []string{m.DateColumn, m.MerchantColumn, m.AmountColumn, m.DebitColumn, m.CreditColumn, m.CategoryColumn, m.NotesColumn, m.TagsColumn} {
+		if column == "" {
+			continue
+		}
+		i := headerIndex(headers, column)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: column %q not found", ErrInvalidMapping, column)
+		}
+		indexes[column] = i
+	}
+	return indexes, nil
+}
+
+func dateLayout(name string) (string, bool) {
+	for _, f := range dateFormats {
+		if f.name == name {
+			return f.layout, true
+		}
+	}
+	return "", false
+}
+
+// parseDate reads value with layout. A time of day after the date is ignored.
+func parseDate(value, layout string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(layout, " ") {
+		if i := strings.IndexAny(value, " T"); i > 0 {
+			value = value[:i]
+		}
+	}
+	return time.Parse(layout, value)
+}
+
+// matchingDateFormats returns the names of the formats that parse the most values,
+// so a few malformed dates do not hide the format of the rest.
+func matchingDateFormats(values []string) []string {
+	var names []string
+	best := 0
+	for _, f := range dateFormats {
+		parsed := 0
+		for _, v := range values {
+			if _, err := parseDate(v, f.layout); err == nil {
+				parsed++
+			}
+		}
+		switch {
+		case parsed == 0 || parsed < best:
+		case parsed > best:
+			names, best = []string{f.name}, parsed
+		default:
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// parseAmount reads a money amount as banks write it: with or without a currency
+// symbol or code, thousands separators, a decimal point or comma, and a leading or
+// trailing minus or parentheses for negatives.
+func parseAmount(value string) (float64, error) {
+	s := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	if strings.HasSuffix(s, "-") {
+		negative = !negative
+		s = strings.TrimSuffix(s, "-")
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r), r == '.', r == ',':
+			b.WriteRune(r)
+		case r == '-':
+			negative = !negative
+		case r == '+', unicode.IsSpace(r), unicode.Is(unicode.Sc, r), unicode.IsUpper(r):
+		default:
+			return 0, fmt.Errorf("invalid amount %q", value)
+		}
+	}
+	num := b.String()
+	if strings.IndexFunc(num, unicode.IsDigit) < 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	lastDot, lastComma := strings.LastIndex(num, "."), strings.LastIndex(num, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0 && lastComma > lastDot:
+		num = strings.ReplaceAll(num, ".", "")
+		num = strings.Replace(num, ",", ".", 1)
+	case lastDot >= 0 && lastComma >= 0:
+		num = strings.ReplaceAll(num, ",", "")
+	case lastComma >= 0 && strings.Count(num, ",") == 1 && len(num)-lastComma-1 != 3:
+		// A single comma followed by other than three digits is a decimal comma.
+		num = strings.Replace(num, ",", ".", 1)
+	case lastComma >= 0:
+		num = strings.ReplaceAll(num, ",", "")
+	case strings.Count(num, ".") > 1:
+		num = strings.ReplaceAll(num, ".", "")
+	}
+
+	amount, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func columnValues(file csvFile, column string) []string {
+	i := headerIndex(file.headers, column)
+	if i < 0 {
+		return nil
+	}
+	var values []string
+	for _, rec := range file.records {
+		if i < len(rec.fields) && rec.fields[i] != "" {
+			values = append(values, rec.fields[i])
+			if len(values) == sampleRows {
+				break
+			}
+		}
+	}
+	return values
+}
+
+// findHeader returns the first header matching one of names, or else the first that
+// contains fallback, if set.
+func findHeader(headers, names []string, fallback string) string {
+	for _, name := range names {
+		for _, h := range headers {
+			if normalizeHeader(h) == name {
+				return h
+			}
+		}
+	}
+	if fallback != "" {
+		for _, h := range headers {
+			if strings.Contains(normalizeHeader(h), fallback) {
+				return h
+			}
+		}
+	}
+	return ""
+}
+
+func headerIndex(headers []string, column string) int {
+	for i, h := range headers {
+		if strings.EqualFold(h, strings.TrimSpace(column)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeHeader(h string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(h), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func trimFields(fields []string) []string {
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+	return fields
+}
@@ -0,0 +1,15 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidBank     = errors.New("bank must be 1 to 64 characters")
+	ErrInvalidFile     = errors.New("invalid import file")
+	ErrInvalidMapping  = errors.New("invalid column mapping")
+	ErrMappingNotFound = errors.New("no mapping saved for this bank")
+	ErrTooManyRows     = fmt.Errorf("an import takes at most %d rows", MaxImportRows)
+	ErrUnknownCategory = errors.New("unknown default category")
+)
@@ -0,0 +1,13 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// TransactionBatcher writes imported rows. The transaction service implements it, so
+// imported rows are validated and stored like any other transaction.
+type TransactionBatcher interface {
+	BatchTransactions(ctx context.Context, userID string, batch models.TxBatchRequest) (models.TxBatchResponse, error)
+}
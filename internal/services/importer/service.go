@@ -0,0 +1,446 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"go.uber.org/zap"
+)
+
+const maxBankLength = 64
+
+type ImportService struct {
+	importQueries database.ImportQuerier
+	txQueries     database.TransactionQuerier
+	txns          TransactionBatcher
+	logger        *zap.Logger
+}
+
+func NewImportService(importQueries database.ImportQuerier, txQueries database.TransactionQuerier, txns TransactionBatcher, logger *zap.Logger) *ImportService {
+	return &ImportService{
+		importQueries: importQueries,
+		txQueries:     txQueries,
+		txns:          txns,
+		logger:        logger,
+	}
+}
+
+// PreviewCSV parses data without writing anything. The mapping comes from mapping if
+// set, else from the one saved for bank, else from the file itself. A file whose
+// columns cannot be detected gives a preview with its headers and no rows, so the
+// caller can supply a mapping.
+func (s *ImportService) PreviewCSV(ctx context.Context, userID, bank string, data []byte, mapping *models.CSVMapping) (models.ImportPreview, error) {
+	preview, file, err := s.resolve(ctx, userID, bank, data, mapping)
+	if err != nil {
+		return models.ImportPreview{}, err
+	}
+	if err := validateMapping(preview.Mapping); err != nil {
+		if preview.MappingSource != models.MappingSourceDetected {
+			return models.ImportPreview{}, err
+		}
+		preview.Warnings = append(preview.Warnings, strings.TrimPrefix(err.Error(), ErrInvalidMapping.Error()+": ")+" detected; send a mapping")
+		return preview, nil
+	}
+	rows, err := s.parseRows(ctx, userID, file, preview.Mapping)
+	if err != nil {
+		return models.ImportPreview{}, err
+	}
+	preview.Rows = rows
+	for _, row := range rows {
+		if len(row.Errors) == 0 {
+			preview.ValidRows++
+		} else {
+			preview.ErrorRows++
+		}
+	}
+	return preview, nil
+}
+
+// ImportCSV stores the rows of data that parse, resolving the mapping as PreviewCSV
+// does. Rows are written independently: one bad row does not stop the others.
+func (s *ImportService) ImportCSV(ctx context.Context, userID, bank string, data []byte, mapping *models.CSVMapping) (models.ImportResult, error) {
+	preview, file, err := s.resolve(ctx, userID, bank, data, mapping)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	if err := validateMapping(preview.Mapping); err != nil {
+		return models.ImportResult{}, err
+	}
+	rows, err := s.parseRows(ctx, userID, file, preview.Mapping)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	return s.createRows(ctx, userID, rows)
+}
+
+func (s *ImportService) ListMappings(ctx context.Context, userID string) ([]models.SavedCSVMapping, error) {
+	rows, err := s.importQueries.ListImportMappings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mappings: %w", err)
+	}
+	mappings := make([]models.SavedCSVMapping, 0, len(rows))
+	for _, row := range rows {
+		saved, err := savedMapping(row)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, saved)
+	}
+	return mappings, nil
+}
+
+// SaveMapping stores mapping as the one to use for bank, replacing any earlier one.
+func (s *ImportService) SaveMapping(ctx context.Context, userID, bank string, mapping models.CSVMapping) (models.SavedCSVMapping, error) {
+	bank, err := normalizeBank(bank)
+	if err != nil {
+		return models.SavedCSVMapping{}, err
+	}
+	if bank == "" {
+		return models.SavedCSVMapping{}, ErrInvalidBank
+	}
+	if err := validateMapping(mapping); err != nil {
+		return models.SavedCSVMapping{}, err
+	}
+	encoded, err := json.Marshal(mapping)
+	if err != nil {
+		return models.SavedCSVMapping{}, fmt.Errorf("failed to encode mapping: %w", err)
+	}
+	now := time.Now().UTC()
+	if err := s.importQueries.UpsertImportMapping(ctx, database.UpsertImportMappingParams{
+		UserID:    userID,
+		Bank:      bank,
+		Mapping:   string(encoded),
+		UpdatedAt: sql.NullTime{Time: now, Valid: true},
+	}); err != nil {
+		return models.SavedCSVMapping{}, fmt.Errorf("failed to save mapping: %w", err)
+	}
+	return models.SavedCSVMapping{Bank: bank, Mapping: mapping, UpdatedAt: &now}, nil
+}
+
+func (s *ImportService) DeleteMapping(ctx context.Context, userID, bank string) error {
+	bank, err := normalizeBank(bank)
+	if err != nil {
+		return err
+	}
+	if bank == "" {
+		return ErrInvalidBank
+	}
+	n, err := s.importQueries.DeleteImportMapping(ctx, database.DeleteImportMappingParams{UserID: userID, Bank: bank})
+	if err != nil {
+		return fmt.Errorf("failed to delete mapping: %w", err)
+	}
+	if n == 0 {
+		return ErrMappingNotFound
+	}
+	return nil
+}
+
+// resolve reads data and picks its mapping. Blank delimiter and date format fields of
+// a supplied mapping are filled in by detection.
+func (s *ImportService) resolve(ctx context.Context, userID, bank string, data []byte, mapping *models.CSVMapping) (models.ImportPreview, csvFile, error) {
+	bank, err := normalizeBank(bank)
+	if err != nil {
+		return models.ImportPreview{}, csvFile{}, err
+	}
+	preview := models.ImportPreview{Bank: bank, MappingSource: models.MappingSourceRequest}
+	if mapping == nil && bank != "" {
+		row, err := s.importQueries.GetImportMapping(ctx, database.GetImportMappingParams{UserID: userID, Bank: bank})
+		switch {
+		case err == nil:
+			saved, err := savedMapping(row)
+			if err != nil {
+				return models.ImportPreview{}, csvFile{}, err
+			}
+			mapping = &saved.Mapping
+			preview.MappingSource = models.MappingSourceSaved
+		case !errors.Is(err, sql.ErrNoRows):
+			return models.ImportPreview{}, csvFile{}, fmt.Errorf("failed to get mapping: %w", err)
+		}
+	}
+
+	delimiter := detectDelimiter(data)
+	if mapping != nil && mapping.Delimiter != "" {
+		delimiter = mapping.Delimiter
+	}
+	if !slices.Contains(delimiters, delimiter) {
+		return models.ImportPreview{}, csvFile{}, fmt.Errorf("%w: delimiter must be one of , ; | or a tab", ErrInvalidMapping)
+	}
+	file, err := readCSV(data, delimiter)
+	if err != nil {
+		return models.ImportPreview{}, csvFile{}, err
+	}
+	preview.Headers = file.headers
+
+	detected, warnings := detectMapping(file, delimiter)
+	if mapping == nil {
+		preview.Mapping = detected
+		preview.MappingSource = models.MappingSourceDetected
+		preview.Warnings = warnings
+		return preview, file, nil
+	}
+	preview.Mapping = *mapping
+	preview.Mapping.Delimiter = delimiter
+	if preview.Mapping.DateFormat == "" {
+		formats := matchingDateFormats(columnValues(file, preview.Mapping.DateColumn))
+		if len(formats) == 0 {
+			return models.ImportPreview{}, csvFile{}, fmt.Errorf("%w: could not detect the date format", ErrInvalidMapping)
+		}
+		preview.Mapping.DateFormat = formats[0]
+	}
+	return preview, file, nil
+}
+
+// parseRows turns each record of file into a transaction request, or the reasons it
+// cannot be one. Only an unknown default category and database failures are
+// returned as errors.
+func (s *ImportService) parseRows(ctx context.Context, userID string, file csvFile, m models.CSVMapping) ([]models.ImportRow, error) {
+	indexes, err := columnIndexes(m, file.headers)
+	if err != nil {
+		return nil, err
+	}
+	layout, _ := dateLayout(m.DateFormat)
+	categories := newCategoryCache(s.txQueries, userID)
+	defaultCategory, err := categories.defaultID(ctx, m.DefaultCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.ImportRow, 0, len(file.records))
+	for _, rec := range file.records {
+		row := models.ImportRow{Line: rec.line}
+		if len(rec.fields) != len(file.headers) {
+			row.Errors = append(row.Errors, fmt.Sprintf("expected %d columns, got %d", len(file.headers), len(rec.fields)))
+			rows = append(rows, row)
+			continue
+		}
+		field := func(column string) string {
+			if column == "" {
+				return ""
+			}
+			return rec.fields[indexes[column]]
+		}
+		req := models.NewTxRequest{Merchant: field(m.MerchantColumn), Notes: field(m.NotesColumn)}
+		if tags := field(m.TagsColumn); tags != "" {
+			req.Tags = strings.Split(tags, ",")
+		}
+
+		if date, err := parseDate(field(m.DateColumn), layout); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid date %q, expected %s", field(m.DateColumn), m.DateFormat))
+		} else {
+			req.Date = date.Format("2006-01-02")
+		}
+		if req.Merchant == "" {
+			row.Errors = append(row.Errors, "merchant is empty")
+		}
+		if amount, err := rowAmount(m, field); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		} else {
+			req.Amount = amount
+		}
+
+		switch name := field(m.CategoryColumn); {
+		case name != "":
+			id, err := categories.lookup(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if id == 0 {
+				row.Errors = append(row.Errors, fmt.Sprintf("unknown category %q", name))
+			}
+			req.DetailedCategory = id
+		case defaultCategory != 0:
+			req.DetailedCategory = defaultCategory
+		default:
+			row.Errors = append(row.Errors, "no category and no default_category")
+		}
+
+		if len(row.Errors) == 0 {
+			row.Transaction = &req
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// createRows stores the rows without errors, in best-effort batches, and reports the
+// rest. Rows whose external ID was already imported are skipped.
+func (s *ImportService) createRows(ctx context.Context, userID string, rows []models.ImportRow) (models.ImportResult, error) {
+	var result models.ImportResult
+	var valid []models.ImportRow
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			result.Errors = append(result.Errors, row)
+			continue
+		}
+		valid = append(valid, row)
+	}
+	for start := 0; start < len(valid); start += models.MaxBatchOperations {
+		chunk := valid[start:min(start+models.MaxBatchOperations, len(valid))]
+		ops := make([]models.TxBatchOp, len(chunk))
+		for i, row := range chunk {
+			ops[i] = models.TxBatchOp{Op: models.BatchOpCreate, Transaction: row.Transaction}
+		}
+		resp, err := s.txns.BatchTransactions(ctx, userID, models.TxBatchRequest{Mode: models.BatchModeBestEffort, Operations: ops})
+		if err != nil {
+			return models.ImportResult{}, fmt.Errorf("failed to import transactions: %w", err)
+		}
+		for _, res := range resp.Results {
+			switch {
+			case res.Err == nil:
+				result.Imported++
+			case errors.Is(res.Err, transaction.ErrDuplicateExternalID):
+				result.Skipped++
+			default:
+				row := chunk[res.Index]
+				s.logger.Warn("failed to import row", zap.Int("line", row.Line), zap.Error(res.Err))
+				row.Errors = []string{"failed to create transaction"}
+				result.Errors = append(result.Errors, row)
+			}
+		}
+	}
+	result.Failed = len(result.Errors)
+	return result, nil
+}
+
+// categoryCache resolves category names as written in a file against the categories
+// the user can file under, which it loads on first use. Names are compared by
+// categoryKey, the user's renames over the original names, so that a file exported
+// with the user's names reads back the same.
+type categoryCache struct {
+	q      database.TransactionQuerier
+	userID string
+	ids    map[string]int64
+}
+
+func newCategoryCache(q database.TransactionQuerier, userID string) *categoryCache {
+	return &categoryCache{q: q, userID: userID}
+}
+
+// lookup returns the ID of the named category, or 0 if there is none.
+func (c *categoryCache) lookup(ctx context.Context, name string) (int64, error) {
+	if c.ids == nil {
+		if err := c.load(ctx); err != nil {
+			return 0, err
+		}
+	}
+	return c.ids[categoryKey(name)], nil
+}
+
+func (c *categoryCache) load(ctx context.Context) error {
+	categories, err := c.q.ListUserCategories(ctx, c.userID)
+	if err != nil {
+		return fmt.Errorf("failed to look up category: %w", err)
+	}
+	c.ids = make(map[string]int64, len(categories))
+	var renamed []database.ListUserCategoriesRow
+	for _, category := range categories {
+		if category.ArchivedAt.Valid {
+			continue
+		}
+		key := categoryKey(category.PrimaryCategoryName + " " + category.Name)
+		if _, ok := c.ids[key]; !ok {
+			c.ids[key] = category.ID
+		}
+		if category.OverrideName.Valid {
+			renamed = append(renamed, category)
+		}
+	}
+	for _, category := range renamed {
+		c.ids[categoryKey(category.PrimaryCategoryName+" "+category.OverrideName.String)] = category.ID
+	}
+	return nil
+}
+
+// defaultID looks up the category for rows without one. An empty name gives 0.
+func (c *categoryCache) defaultID(ctx context.Context, name string) (int64, error) {
+	if name == "" {
+		return 0, nil
+	}
+	id, err := c.lookup(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCategory, name)
+	}
+	return id, nil
+}
+
+// rowAmount returns the amount with money leaving the account as positive.
+func rowAmount(m models.CSVMapping, field func(string) string) (float64, error) {
+	var amount float64
+	if m.AmountColumn != "" {
+		v, err := parseAmount(field(m.AmountColumn))
+		if err != nil {
+			return 0, err
+		}
+		amount = v
+		if m.NegateAmounts {
+			amount = -amount
+		}
+	} else {
+		debit, credit := field(m.DebitColumn), field(m.CreditColumn)
+		if debit == "" && credit == "" {
+			return 0, errors.New("debit and credit are both empty")
+		}
+		for i, value := range []string{debit, credit} {
+			if value == "" {
+				continue
+			}
+			v, err := parseAmount(value)
+			if err != nil {
+				return 0, err
+			}
+			v = math.Abs(v)
+			if i == 1 {
+				v = -v
+			}
+			amount += v
+		}
+	}
+	if amount == 0 {
+		return 0, errors.New("amount is zero")
+	}
+	return amount, nil
+}
+
+// categoryKey turns a category name, such as "Food and Drink Groceries" or
+// "FOOD_AND_DRINK_GROCERIES", into the PRIMARY_DETAILED form categoryCache compares.
+func categoryKey(name string) string {
+	return strings.ToUpper(strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "_"))
+}
+
+func normalizeBank(bank string) (string, error) {
+	bank = strings.ToLower(strings.TrimSpace(bank))
+	if len(bank) > maxBankLength || strings.ContainsFunc(bank, unicode.IsControl) || strings.Contains(bank, "/") {
+		return "", ErrInvalidBank
+	}
+	return bank, nil
+}
+
+func savedMapping(row models.ImportMapping) (models.SavedCSVMapping, error) {
+	saved := models.SavedCSVMapping{Bank: row.Bank}
+	if err := json.Unmarshal([]byte(row.Mapping), &saved.Mapping); err != nil {
+		return models.SavedCSVMapping{}, fmt.Errorf("failed to decode mapping for %s: %w", strconv.Quote(row.Bank), err)
+	}
+	if row.UpdatedAt.Valid {
+		updated := row.UpdatedAt.Time
+		saved.UpdatedAt = &updated
+	}
+	return saved, nil
+}
@@ -0,0 +1,287 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// statementTxn is one transaction read from an OFX or QIF file, before its values
+// are parsed.
+type statementTxn struct {
+	line     int
+	id       string
+	date     string
+	amount   string
+	payee    string
+	memo     string
+	number   string
+	category string
+}
+
+// qifTypes are the QIF sections that hold bank-style transactions. Investment and
+// list sections are skipped.
+var qifTypes = []string{"bank", "cash", "ccard", "oth a", "oth l"}
+
+// ImportOFX stores the statement transactions of an OFX or QFX file. Rows whose FITID
+// was imported before are skipped, so a re-downloaded statement can be imported
+// again. defaultCategory, a PRIMARY_DETAILED name, is required as OFX has no
+// categories.
+func (s *ImportService) ImportOFX(ctx context.Context, userID string, data []byte, defaultCategory string) (models.ImportResult, error) {
+	txns, err := parseOFX(data)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	rows, err := s.statementRows(ctx, userID, txns, "20060102", defaultCategory)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	return s.createRows(ctx, userID, rows)
+}
+
+// ImportQIF stores the bank, cash and credit card transactions of a QIF file. A
+// transaction's L category is used when it names a known category, and
+// defaultCategory otherwise.
+func (s *ImportService) ImportQIF(ctx context.Context, userID string, data []byte, defaultCategory string) (models.ImportResult, error) {
+	txns, err := parseQIF(data)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	dates := make([]string, len(txns))
+	for i := range txns {
+		txns[i].date = qifDate(txns[i].date)
+		dates[i] = txns[i].date
+	}
+	formats := matchingDateFormats(dates)
+	if len(formats) == 0 {
+		return models.ImportResult{}, fmt.Errorf("%w: could not detect the date format", ErrInvalidFile)
+	}
+	layout, _ := dateLayout(formats[0])
+	rows, err := s.statementRows(ctx, userID, txns, layout, defaultCategory)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+	return s.createRows(ctx, userID, rows)
+}
+
+// statementRows turns statement transactions into transaction requests. Statements
+// show money leaving the account as negative, so amounts are negated.
+func (s *ImportService) statementRows(ctx context.Context, userID string, txns []statementTxn, layout, defaultCategory string) ([]models.ImportRow, error) {
+	if len(txns) > MaxImportRows {
+		return nil, ErrTooManyRows
+	}
+	categories := newCategoryCache(s.txQueries, userID)
+	defaultID, err := categories.defaultID(ctx, defaultCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.ImportRow, 0, len(txns))
+	for _, txn := range txns {
+		row := models.ImportRow{Line: txn.line}
+		req := models.NewTxRequest{Merchant: txn.payee, Notes: txn.memo, ExternalID: txn.id}
+		if req.Merchant == "" {
+			req.Merchant, req.Notes = txn.memo, ""
+		}
+		if req.ExternalID == "" {
+			row.Errors = append(row.Errors, "missing FITID")
+		}
+		if date, err := parseDate(txn.date, layout); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid date %q", txn.date))
+		} else {
+			req.Date = date.Format("2006-01-02")
+		}
+		if req.Merchant == "" {
+			row.Errors = append(row.Errors, "payee is empty")
+		}
+		if amount, err := parseAmount(txn.amount); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		} else if amount == 0 {
+			row.Errors = append(row.Errors, "amount is zero")
+		} else {
+			req.Amount = -amount
+		}
+
+		if txn.category != "" {
+			if req.DetailedCategory, err = categories.lookup(ctx, txn.category); err != nil {
+				return nil, err
+			}
+		}
+		if req.DetailedCategory == 0 {
+			req.DetailedCategory = defaultID
+		}
+		if req.DetailedCategory == 0 {
+			row.Errors = append(row.Errors, "no category and no default_category")
+		}
+
+		if len(row.Errors) == 0 {
+			row.Transaction = &req
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseOFX reads the statement transactions of an OFX file. OFX 1.x is SGML, where
+// elements holding a value are not closed, and OFX 2.x is XML; both are read as a
+// stream of tags, each followed by its value. QFX files are OFX with extra tags.
+func parseOFX(data []byte) ([]statementTxn, error) {
+	text := string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: not an OFX file", ErrInvalidFile)
+	}
+	line := 1 + strings.Count(text[:start], "\n")
+	text = text[start:]
+
+	var (
+		txns    []statementTxn
+		cur     *statementTxn
+		account string
+	)
+	for {
+		open := strings.IndexByte(text, '<')
+		if open < 0 {
+			break
+		}
+		line += strings.Count(text[:open], "\n")
+		text = text[open:]
+		end := strings.IndexByte(text, '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated tag on line %d", ErrInvalidFile, line)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(text[1:end]))
+		text = text[end+1:]
+		next := strings.IndexByte(text, '<')
+		if next < 0 {
+			next = len(text)
+		}
+		value := html.UnescapeString(strings.TrimSpace(text[:next]))
+
+		switch {
+		case tag == "STMTTRN":
+			cur = &statementTxn{line: line}
+		case tag == "/STMTTRN":
+			if cur != nil {
+				if cur.id != "" {
+					// FITIDs are only unique within an account.
+					cur.id = "ofx:" + account + ":" + cur.id
+				}
+				txns = append(txns, *cur)
+				cur = nil
+			}
+		case cur == nil:
+			if tag == "ACCTID" {
+				account = value
+			}
+		case tag == "FITID":
+			cur.id = value
+		case tag == "DTPOSTED":
+			// Dates are YYYYMMDD, optionally followed by a time and time zone.
+			cur.date = value
+			if len(value) > 8 && strings.IndexFunc(value[:8], func(r rune) bool { return r < '0' || r > '9' }) < 0 {
+				cur.date = value[:8]
+			}
+		case tag == "TRNAMT":
+			cur.amount = value
+		case tag == "NAME":
+			cur.payee = value
+		case tag == "MEMO":
+			cur.memo = value
+		case tag == "CHECKNUM":
+			cur.number = value
+		}
+	}
+	if len(txns) == 0 {
+		return nil, fmt.Errorf("%w: the file has no statement transactions", ErrInvalidFile)
+	}
+	return txns, nil
+}
+
+// parseQIF reads the transactions of a QIF file. QIF has no transaction IDs, so each
+// gets one from its date, amount, payee and check number, numbered to tell apart
+// identical transactions in the same file.
+func parseQIF(data []byte) ([]statementTxn, error) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))), "\r\n", "\n")
+
+	var (
+		txns     []statementTxn
+		cur      statementTxn
+		sawType  bool
+		inBank   bool
+		occurred = make(map[string]int)
+	)
+	for i, l := range strings.Split(text, "\n") {
+		l = strings.TrimRight(l, " \t\r")
+		if l == "" {
+			continue
+		}
+		if l[0] == '!' {
+			header := strings.ToLower(l)
+			if t, ok := strings.CutPrefix(header, "!type:"); ok {
+				sawType = true
+				inBank = slices.Contains(qifTypes, strings.TrimSpace(t))
+			} else if header == "!account" {
+				inBank = false
+			}
+			cur = statementTxn{}
+			continue
+		}
+		if !inBank {
+			continue
+		}
+		if cur.line == 0 {
+			cur.line = i + 1
+		}
+		value := strings.TrimSpace(l[1:])
+		switch l[0] {
+		case 'D':
+			cur.date = value
+		case 'T':
+			cur.amount = value
+		case 'U':
+			if cur.amount == "" {
+				cur.amount = value
+			}
+		case 'P':
+			cur.payee = value
+		case 'M':
+			cur.memo = value
+		case 'N':
+			cur.number = value
+		case 'L':
+			// [Account] marks a transfer rather than a category; a class follows a slash.
+			if !strings.HasPrefix(value, "[") {
+				cur.category, _, _ = strings.Cut(value, "/")
+			}
+		case '^':
+			key := strings.Join([]string{cur.date, cur.amount, cur.payee, cur.number}, "\x00")
+			sum := sha256.Sum256([]byte(key))
+			cur.id = fmt.Sprintf("qif:%s:%d", hex.EncodeToString(sum[:12]), occurred[key])
+			occurred[key]++
+			txns = append(txns, cur)
+			cur = statementTxn{}
+		}
+	}
+	if !sawType {
+		return nil, fmt.Errorf("%w: not a QIF file", ErrInvalidFile)
+	}
+	if len(txns) == 0 {
+		return nil, fmt.Errorf("%w: the file has no bank transactions", ErrInvalidFile)
+	}
+	return txns, nil
+}
+
+// qifDate rewrites Quicken's dates, such as 3/ 5'25 for 3/5/2025, into forms the
+// date formats can read.
+func qifDate(value string) string {
+	value = strings.ReplaceAll(value, " ", "")
+	return strings.ReplaceAll(value, "'", "/")
+}
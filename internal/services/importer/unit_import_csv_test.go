@@ -0,0 +1,118 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	importermocks "github.com/seanhuebl/unity-wealth/internal/mocks/importer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestImportCSV(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	data := []byte("Date,Merchant,Amount,Category\n" +
+		"2025-03-05,Costco,125.98,FOOD_AND_DRINK_GROCERIES\n" +
+		"2025-03-06,,1,FOOD_AND_DRINK_GROCERIES\n" +
+		"2025-03-07,Target,10.50,FOOD_AND_DRINK_GROCERIES\n")
+
+	txQ := dbmocks.NewTransactionQuerier(t)
+	txQ.On("ListUserCategories", ctx, userID).Return([]database.ListUserCategoriesRow{{ID: 40, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "GROCERIES"}}, nil).Once()
+	batcher := importermocks.NewTransactionBatcher(t)
+	batcher.On("BatchTransactions", ctx, userID, mock.MatchedBy(func(batch models.TxBatchRequest) bool {
+		return batch.Mode == models.BatchModeBestEffort &&
+			len(batch.Operations) == 2 &&
+			batch.Operations[0].Transaction.Merchant == "Costco" &&
+			batch.Operations[1].Transaction.Merchant == "Target"
+	})).Return(models.TxBatchResponse{
+		Mode:      models.BatchModeBestEffort,
+		Committed: true,
+		Results: []models.TxBatchResult{
+			{Index: 0, Op: models.BatchOpCreate},
+			{Index: 1, Op: models.BatchOpCreate, Err: errors.New("unable to create transaction: constraint failed")},
+		},
+	}, nil).Once()
+
+	svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, batcher, zap.NewNop())
+	result, err := svc.ImportCSV(ctx, userID, "", data, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Imported)
+	require.Equal(t, 2, result.Failed)
+	require.Len(t, result.Errors, 2)
+	require.Equal(t, 3, result.Errors[0].Line)
+	require.Equal(t, []string{"merchant is empty"}, result.Errors[0].Errors)
+	require.Equal(t, 4, result.Errors[1].Line)
+	require.Equal(t, []string{"failed to create transaction"}, result.Errors[1].Errors)
+}
+
+func TestImportCSVErrors(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	t.Run("undetected columns", func(t *testing.T) {
+		svc := importer.NewImportService(dbmocks.NewImportQuerier(t), dbmocks.NewTransactionQuerier(t), importermocks.NewTransactionBatcher(t), zap.NewNop())
+		_, err := svc.ImportCSV(ctx, userID, "", []byte("When,Who,HowMuch\n2025-03-05,Costco,1\n"), nil)
+		require.ErrorIs(t, err, importer.ErrInvalidMapping)
+	})
+
+	t.Run("batch unavailable", func(t *testing.T) {
+		txQ := dbmocks.NewTransactionQuerier(t)
+		txQ.On("ListUserCategories", ctx, userID).Return([]database.ListUserCategoriesRow{{ID: 40, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "GROCERIES"}}, nil).Once()
+		batcher := importermocks.NewTransactionBatcher(t)
+		batcher.On("BatchTransactions", ctx, userID, mock.Anything).Return(models.TxBatchResponse{}, errors.New("batches are not available")).Once()
+
+		svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, batcher, zap.NewNop())
+		_, err := svc.ImportCSV(ctx, userID, "", []byte("Date,Merchant,Amount,Category\n2025-03-05,Costco,1,FOOD_AND_DRINK_GROCERIES\n"), nil)
+		require.ErrorContains(t, err, "failed to import transactions")
+	})
+}
+
+func TestPreviewCSVAmounts(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	tests := map[string]float64{
+		"12.34":       12.34,
+		"-12.34":      -12.34,
+		"(12.34)":     -12.34,
+		"12.34-":      -12.34,
+		"$1,234.56":   1234.56,
+		"1.234,56 €":  1234.56,
+		"12,5":        12.5,
+		"USD 1,000":   1000,
+		"+7":          7,
+		"1 234,56 kr": 0, // lower-case currency names are not amounts
+	}
+	for value, expected := range tests {
+		t.Run(value, func(t *testing.T) {
+			txQ := dbmocks.NewTransactionQuerier(t)
+			txQ.On("ListUserCategories", ctx, userID).Return([]database.ListUserCategoriesRow{{ID: 40, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "GROCERIES"}}, nil).Once()
+			svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, importermocks.NewTransactionBatcher(t), zap.NewNop())
+
+			mapping := &models.CSVMapping{
+				Delimiter:       "|",
+				DateColumn:      "date",
+				DateFormat:      "YYYY-MM-DD",
+				MerchantColumn:  "merchant",
+				AmountColumn:    "amount",
+				DefaultCategory: "food and drink groceries",
+			}
+			preview, err := svc.PreviewCSV(ctx, userID, "", []byte("date|merchant|amount\n2025-03-05|Costco|"+value+"\n"), mapping)
+			require.NoError(t, err)
+			require.Len(t, preview.Rows, 1)
+			if expected == 0 {
+				require.Equal(t, []string{`invalid amount "` + value + `"`}, preview.Rows[0].Errors)
+				return
+			}
+			require.Empty(t, preview.Rows[0].Errors)
+			require.InDelta(t, expected, preview.Rows[0].Transaction.Amount, 0.001)
+		})
+	}
+}
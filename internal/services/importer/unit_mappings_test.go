@@ -0,0 +1,149 @@
+package importer_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	importermocks "github.com/seanhuebl/unity-wealth/internal/mocks/importer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSaveMapping(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	valid := models.CSVMapping{
+		Delimiter:      ",",
+		DateColumn:     "Date",
+		DateFormat:     "MM/DD/YYYY",
+		MerchantColumn: "Description",
+		DebitColumn:    "Debit",
+		CreditColumn:   "Credit",
+	}
+
+	tests := []struct {
+		name        string
+		bank        string
+		mapping     models.CSVMapping
+		dbErr       error
+		expectsSave bool
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			bank:        "Chase",
+			mapping:     valid,
+			expectsSave: true,
+		},
+		{
+			name:        "empty bank",
+			bank:        "  ",
+			mapping:     valid,
+			expectedErr: importer.ErrInvalidBank,
+		},
+		{
+			name:        "unknown date format",
+			bank:        "chase",
+			mapping:     models.CSVMapping{Delimiter: ",", DateColumn: "Date", DateFormat: "whenever", MerchantColumn: "Description", AmountColumn: "Amount"},
+			expectedErr: importer.ErrInvalidMapping,
+		},
+		{
+			name:        "no amount",
+			bank:        "chase",
+			mapping:     models.CSVMapping{Delimiter: ",", DateColumn: "Date", DateFormat: "YYYY-MM-DD", MerchantColumn: "Description"},
+			expectedErr: importer.ErrInvalidMapping,
+		},
+		{
+			name:        "db error",
+			bank:        "chase",
+			mapping:     valid,
+			dbErr:       errors.New("db error"),
+			expectsSave: true,
+			expectedErr: errors.New("failed to save mapping"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			importQ := dbmocks.NewImportQuerier(t)
+			if tc.expectsSave {
+				importQ.On("UpsertImportMapping", ctx, mock.MatchedBy(func(p database.UpsertImportMappingParams) bool {
+					return p.UserID == userID && p.Bank == "chase" && p.Mapping == encodeMapping(t, tc.mapping) && p.UpdatedAt.Valid
+				})).Return(tc.dbErr).Once()
+			}
+
+			svc := importer.NewImportService(importQ, dbmocks.NewTransactionQuerier(t), importermocks.NewTransactionBatcher(t), zap.NewNop())
+			saved, err := svc.SaveMapping(ctx, userID, tc.bank, tc.mapping)
+			if tc.expectedErr != nil {
+				if errors.Is(err, tc.expectedErr) {
+					return
+				}
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "chase", saved.Bank)
+			require.Equal(t, tc.mapping, saved.Mapping)
+			require.NotNil(t, saved.UpdatedAt)
+		})
+	}
+}
+
+func TestListMappings(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	mapping := models.CSVMapping{Delimiter: ";", DateColumn: "Date", DateFormat: "DD.MM.YYYY", MerchantColumn: "Payee", AmountColumn: "Amount"}
+	updated := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	importQ := dbmocks.NewImportQuerier(t)
+	importQ.On("ListImportMappings", ctx, userID).Return([]models.ImportMapping{
+		{UserID: userID, Bank: "n26", Mapping: encodeMapping(t, mapping), UpdatedAt: sql.NullTime{Time: updated, Valid: true}},
+	}, nil).Once()
+
+	svc := importer.NewImportService(importQ, dbmocks.NewTransactionQuerier(t), importermocks.NewTransactionBatcher(t), zap.NewNop())
+	mappings, err := svc.ListMappings(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []models.SavedCSVMapping{{Bank: "n26", Mapping: mapping, UpdatedAt: &updated}}, mappings)
+}
+
+func TestDeleteMapping(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	tests := []struct {
+		name        string
+		rows        int64
+		dbErr       error
+		expectedErr error
+	}{
+		{name: "success", rows: 1},
+		{name: "not found", expectedErr: importer.ErrMappingNotFound},
+		{name: "db error", dbErr: errors.New("db error"), expectedErr: errors.New("failed to delete mapping")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			importQ := dbmocks.NewImportQuerier(t)
+			importQ.On("DeleteImportMapping", ctx, database.DeleteImportMappingParams{UserID: userID, Bank: "chase"}).Return(tc.rows, tc.dbErr).Once()
+
+			svc := importer.NewImportService(importQ, dbmocks.NewTransactionQuerier(t), importermocks.NewTransactionBatcher(t), zap.NewNop())
+			err := svc.DeleteMapping(ctx, userID, "CHASE")
+			switch {
+			case tc.expectedErr == nil:
+				require.NoError(t, err)
+			case errors.Is(err, tc.expectedErr):
+			default:
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package importer_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	importermocks "github.com/seanhuebl/unity-wealth/internal/mocks/importer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPreviewCSV(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	groceries := &models.NewTxRequest{Date: "2025-03-05", Merchant: "Costco", Amount: 125.98, DetailedCategory: 40}
+	groceriesCategory := database.ListUserCategoriesRow{ID: 40, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "GROCERIES"}
+
+	tests := []struct {
+		name            string
+		bank            string
+		data            string
+		mapping         *models.CSVMapping
+		saved           *models.CSVMapping
+		categories      []database.ListUserCategoriesRow
+		expectedMapping models.CSVMapping
+		expectedSource  string
+		expectedRows    []models.ImportRow
+		expectWarning   bool
+		expectedErr     error
+	}{
+		{
+			name: "detects comma and negative spending",
+			data: "Date,Description,Amount,Category,Tags\n" +
+				"2025-03-05,Costco,-125.98,Food and Drink Groceries,\n" +
+				"2025-03-06,Payroll,\"2,000.00\",INCOME_WAGES,\n" +
+				"2025-03-07,Target,-10.50,FOOD_AND_DRINK_GROCERIES,\"home,kids\"\n",
+			categories: []database.ListUserCategoriesRow{groceriesCategory, {ID: 2, PrimaryCategoryName: "INCOME", Name: "WAGES"}},
+			expectedMapping: models.CSVMapping{
+				Delimiter:      ",",
+				DateColumn:     "Date",
+				DateFormat:     "YYYY-MM-DD",
+				MerchantColumn: "Description",
+				AmountColumn:   "Amount",
+				NegateAmounts:  true,
+				CategoryColumn: "Category",
+				TagsColumn:     "Tags",
+			},
+			expectedSource: models.MappingSourceDetected,
+			expectedRows: []models.ImportRow{
+				{Line: 2, Transaction: groceries},
+				{Line: 3, Transaction: &models.NewTxRequest{Date: "2025-03-06", Merchant: "Payroll", Amount: -2000, DetailedCategory: 2}},
+				{Line: 4, Transaction: &models.NewTxRequest{Date: "2025-03-07", Merchant: "Target", Amount: 10.5, DetailedCategory: 40, Tags: []string{"home", "kids"}}},
+			},
+			expectWarning: true,
+		},
+		{
+			name: "detects semicolons, day-first dates and debit and credit columns",
+			data: "Booking Date;Payee;Debit;Credit;Memo\n" +
+				"05.03.2025;Costco;125,98;;weekly shop\n" +
+				"31.03.2025;Refund;;1.250,00;\n",
+			expectedMapping: models.CSVMapping{
+				Delimiter:      ";",
+				DateColumn:     "Booking Date",
+				DateFormat:     "DD.MM.YYYY",
+				MerchantColumn: "Payee",
+				DebitColumn:    "Debit",
+				CreditColumn:   "Credit",
+				NotesColumn:    "Memo",
+			},
+			expectedSource: models.MappingSourceDetected,
+			expectedRows: []models.ImportRow{
+				{Line: 2, Errors: []string{"no category and no default_category"}},
+				{Line: 3, Errors: []string{"no category and no default_category"}},
+			},
+		},
+		{
+			name: "uses the saved mapping for the bank",
+			bank: " Chase ",
+			data: "Posted,Who,Value\n03/05/2025,Costco,125.98\n",
+			saved: &models.CSVMapping{
+				Delimiter:       ",",
+				DateColumn:      "Posted",
+				DateFormat:      "MM/DD/YYYY",
+				MerchantColumn:  "Who",
+				AmountColumn:    "Value",
+				DefaultCategory: "FOOD_AND_DRINK_GROCERIES",
+			},
+			categories: []database.ListUserCategoriesRow{groceriesCategory},
+			expectedMapping: models.CSVMapping{
+				Delimiter:       ",",
+				DateColumn:      "Posted",
+				DateFormat:      "MM/DD/YYYY",
+				MerchantColumn:  "Who",
+				AmountColumn:    "Value",
+				DefaultCategory: "FOOD_AND_DRINK_GROCERIES",
+			},
+			expectedSource: models.MappingSourceSaved,
+			expectedRows:   []models.ImportRow{{Line: 2, Transaction: groceries}},
+		},
+		{
+			name: "reports each bad row",
+			data: "Date\tMerchant\tAmount\tCategory\n" +
+				"2025-03-05\tCostco\t125.98\tFOOD_AND_DRINK_GROCERIES\n" +
+				"2025-13-05\t\tabc\tFOOD_AND_DRINK_GROCERIES\n" +
+				"2025-03-05\tCostco\t0\tNOT_A_CATEGORY\n" +
+				"2025-03-05\tCostco\n",
+			mapping: &models.CSVMapping{
+				DateColumn:     "date",
+				MerchantColumn: "merchant",
+				AmountColumn:   "amount",
+				CategoryColumn: "category",
+			},
+			categories: []database.ListUserCategoriesRow{groceriesCategory},
+			expectedMapping: models.CSVMapping{
+				Delimiter:      "\t",
+				DateColumn:     "date",
+				DateFormat:     "YYYY-MM-DD",
+				MerchantColumn: "merchant",
+				AmountColumn:   "amount",
+				CategoryColumn: "category",
+			},
+			expectedSource: models.MappingSourceRequest,
+			expectedRows: []models.ImportRow{
+				{Line: 2, Transaction: groceries},
+				{Line: 3, Errors: []string{`invalid date "2025-13-05", expected YYYY-MM-DD`, "merchant is empty", `invalid amount "abc"`}},
+				{Line: 4, Errors: []string{"amount is zero", `unknown category "NOT_A_CATEGORY"`}},
+				{Line: 5, Errors: []string{"expected 4 columns, got 2"}},
+			},
+		},
+		{
+			name:        "mapping names a missing column",
+			data:        "Date,Merchant,Amount\n2025-03-05,Costco,1\n",
+			mapping:     &models.CSVMapping{DateColumn: "Date", MerchantColumn: "Merchant", AmountColumn: "Total"},
+			expectedErr: importer.ErrInvalidMapping,
+		},
+		{
+			name:        "mapping has both amount and debit",
+			data:        "Date,Merchant,Amount,Debit\n2025-03-05,Costco,1,1\n",
+			mapping:     &models.CSVMapping{DateColumn: "Date", MerchantColumn: "Merchant", AmountColumn: "Amount", DebitColumn: "Debit"},
+			expectedErr: importer.ErrInvalidMapping,
+		},
+		{
+			name:        "header only",
+			data:        "Date,Merchant,Amount\n",
+			expectedErr: importer.ErrInvalidFile,
+		},
+		{
+			name:        "bank too long",
+			bank:        string(make([]byte, 65)),
+			data:        "Date,Merchant,Amount\n2025-03-05,Costco,1\n",
+			expectedErr: importer.ErrInvalidBank,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			importQ := dbmocks.NewImportQuerier(t)
+			txQ := dbmocks.NewTransactionQuerier(t)
+			if tc.saved != nil {
+				importQ.On("GetImportMapping", ctx, database.GetImportMappingParams{UserID: userID, Bank: "chase"}).
+					Return(models.ImportMapping{UserID: userID, Bank: "chase", Mapping: encodeMapping(t, *tc.saved)}, nil)
+			} else if tc.bank != "" && tc.expectedErr == nil {
+				importQ.On("GetImportMapping", ctx, mock.Anything).Return(models.ImportMapping{}, sql.ErrNoRows)
+			}
+			txQ.On("ListUserCategories", ctx, userID).Return(tc.categories, nil).Maybe()
+
+			svc := importer.NewImportService(importQ, txQ, importermocks.NewTransactionBatcher(t), zap.NewNop())
+			preview, err := svc.PreviewCSV(ctx, userID, tc.bank, []byte(tc.data), tc.mapping)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSource, preview.MappingSource)
+			if diff := cmp.Diff(tc.expectedMapping, preview.Mapping); diff != "" {
+				t.Errorf("mapping mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedRows, preview.Rows); diff != "" {
+				t.Errorf("rows mismatch (-want +got):\n%s", diff)
+			}
+			require.Equal(t, tc.expectWarning, len(preview.Warnings) > 0, preview.Warnings)
+		})
+	}
+}
+
+func TestPreviewCSVUndetectedColumns(t *testing.T) {
+	svc := importer.NewImportService(dbmocks.NewImportQuerier(t), dbmocks.NewTransactionQuerier(t), importermocks.NewTransactionBatcher(t), zap.NewNop())
+	preview, err := svc.PreviewCSV(context.Background(), uuid.NewString(), "", []byte("When,Who,HowMuch\n2025-03-05,Costco,1\n"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"When", "Who", "HowMuch"}, preview.Headers)
+	require.Empty(t, preview.Rows)
+	require.Equal(t, []string{"no date column detected; send a mapping"}, preview.Warnings)
+}
+
+func TestPreviewCSVCategoryLookupFails(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	txQ := dbmocks.NewTransactionQuerier(t)
+	txQ.On("ListUserCategories", ctx, userID).Return(nil, errors.New("db error")).Once()
+
+	svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, importermocks.NewTransactionBatcher(t), zap.NewNop())
+	_, err := svc.PreviewCSV(ctx, userID, "", []byte("Date,Merchant,Amount,Category\n2025-03-05,Costco,1,FOOD_AND_DRINK_GROCERIES\n"), nil)
+	require.ErrorContains(t, err, "failed to look up category")
+}
+
+func TestPreviewCSVUserCategoryNames(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	txQ := dbmocks.NewTransactionQuerier(t)
+	txQ.On("ListUserCategories", ctx, userID).Return([]database.ListUserCategoriesRow{
+		{ID: 40, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "GROCERIES", OverrideName: sql.NullString{String: "Weekly shop", Valid: true}},
+		{ID: 41, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "RESTAURANT"},
+		{ID: 90, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "Weekly shop", UserID: sql.NullString{String: userID, Valid: true}},
+		{ID: 91, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "Farmers' markets", UserID: sql.NullString{String: userID, Valid: true}},
+		{ID: 92, PrimaryCategoryName: "FOOD_AND_DRINK", Name: "Old favourites", UserID: sql.NullString{String: userID, Valid: true}, ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+	}, nil).Once()
+
+	svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, importermocks.NewTransactionBatcher(t), zap.NewNop())
+	data := "Date,Merchant,Amount,Category\n" +
+		"2025-03-05,Costco,1,FOOD_AND_DRINK_Weekly shop\n" +
+		"2025-03-05,Costco,1,Food and Drink Groceries\n" +
+		"2025-03-05,Ferry Plaza,1,FOOD_AND_DRINK_Farmers' markets\n" +
+		"2025-03-05,Ferry Plaza,1,food and drink farmers markets\n" +
+		"2025-03-05,Diner,1,FOOD_AND_DRINK_Old favourites\n"
+	preview, err := svc.PreviewCSV(ctx, userID, "", []byte(data), nil)
+	require.NoError(t, err)
+	require.Len(t, preview.Rows, 5)
+
+	// The user's rename wins over a custom category of the same name.
+	require.Equal(t, int64(40), preview.Rows[0].Transaction.DetailedCategory)
+	require.Equal(t, int64(40), preview.Rows[1].Transaction.DetailedCategory)
+	require.Equal(t, int64(91), preview.Rows[2].Transaction.DetailedCategory)
+	require.Equal(t, int64(91), preview.Rows[3].Transaction.DetailedCategory)
+	require.Equal(t, []string{`unknown category "FOOD_AND_DRINK_Old favourites"`}, preview.Rows[4].Errors)
+}
+
+func encodeMapping(t *testing.T, m models.CSVMapping) string {
+	t.Helper()
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	return string(b)
+}
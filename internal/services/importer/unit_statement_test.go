@@ -0,0 +1,180 @@
+package importer_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	importermocks "github.com/seanhuebl/unity-wealth/internal/mocks/importer"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/seanhuebl/unity-wealth/internal/testfixtures"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestImportStatements(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	tests := []struct {
+		name            string
+		file            string
+		qif             bool
+		expectedCreated []models.NewTxRequest
+		expectedErrors  []models.ImportRow
+	}{
+		{
+			name: "OFX 1.x",
+			file: "checking_v1.ofx",
+			expectedCreated: []models.NewTxRequest{
+				{Date: "2025-03-05", Merchant: "COSTCO WHSE #0123", Amount: 125.98, DetailedCategory: 40, Notes: "POS PURCHASE", ExternalID: "ofx:1234567890:202503050001"},
+				{Date: "2025-03-14", Merchant: "ACME CORP PAYROLL", Amount: -2000, DetailedCategory: 40, ExternalID: "ofx:1234567890:202503140001"},
+				{Date: "2025-03-20", Merchant: "CHECK 1042", Amount: 45, DetailedCategory: 40, ExternalID: "ofx:1234567890:202503200001"},
+			},
+			expectedErrors: []models.ImportRow{{Line: 62, Errors: []string{`invalid date "2025-03-31"`}}},
+		},
+		{
+			name: "OFX 2.x",
+			file: "credit_card_v2.qfx",
+			expectedCreated: []models.NewTxRequest{
+				{Date: "2025-03-06", Merchant: "TARGET 00012345", Amount: 10.5, DetailedCategory: 40, Notes: "Household", ExternalID: "ofx:4111111111111111:2025030624692160"},
+				{Date: "2025-03-10", Merchant: "TARGET RETURN", Amount: -25, DetailedCategory: 40, ExternalID: "ofx:4111111111111111:2025031024692161"},
+			},
+			expectedErrors: []models.ImportRow{{Line: 37, Errors: []string{"amount is zero"}}},
+		},
+		{
+			name: "QIF",
+			file: "checking.qif",
+			qif:  true,
+			expectedCreated: []models.NewTxRequest{
+				{Date: "2025-03-05", Merchant: "Costco", Amount: 125.98, DetailedCategory: 41, Notes: "Weekly shop"},
+				{Date: "2025-03-14", Merchant: "Acme Corp", Amount: -2000, DetailedCategory: 40},
+				{Date: "2025-03-20", Merchant: "City Water", Amount: 45, DetailedCategory: 40},
+				{Date: "2025-03-21", Merchant: "Blue Bottle", Amount: 4.5, DetailedCategory: 40},
+				{Date: "2025-03-21", Merchant: "Blue Bottle", Amount: 4.5, DetailedCategory: 40},
+			},
+			expectedErrors: []models.ImportRow{{Line: 33, Errors: []string{`invalid date "3/40/25"`}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := testfixtures.ImportFiles.ReadFile("imports/" + tc.file)
+			require.NoError(t, err)
+
+			txQ := dbmocks.NewTransactionQuerier(t)
+			txQ.On("ListUserCategories", ctx, userID).Return([]database.ListUserCategoriesRow{
+				{ID: 40, PrimaryCategoryName: "GENERAL_MERCHANDISE", Name: "OTHER"},
+				{ID: 41, PrimaryCategoryName: "FOOD", Name: "GROCERIES"},
+			}, nil).Once()
+
+			var created []models.NewTxRequest
+			batcher := importermocks.NewTransactionBatcher(t)
+			batcher.On("BatchTransactions", ctx, userID, mock.Anything).Run(func(args mock.Arguments) {
+				for _, op := range args.Get(2).(models.TxBatchRequest).Operations {
+					created = append(created, *op.Transaction)
+				}
+			}).Return(func(_ context.Context, _ string, batch models.TxBatchRequest) models.TxBatchResponse {
+				resp := models.TxBatchResponse{Mode: batch.Mode, Committed: true}
+				for i := range batch.Operations {
+					resp.Results = append(resp.Results, models.TxBatchResult{Index: i, Op: models.BatchOpCreate})
+				}
+				return resp
+			}, nil).Once()
+
+			svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, batcher, zap.NewNop())
+			importFile := svc.ImportOFX
+			if tc.qif {
+				importFile = svc.ImportQIF
+			}
+			result, err := importFile(ctx, userID, data, "General Merchandise Other")
+			require.NoError(t, err)
+			require.Equal(t, len(tc.expectedCreated), result.Imported)
+			require.Equal(t, len(tc.expectedErrors), result.Failed)
+
+			if tc.qif {
+				// QIF IDs are derived from the transaction, so only check they tell
+				// apart the two identical coffees.
+				require.NotEqual(t, created[3].ExternalID, created[4].ExternalID)
+				for i := range created {
+					require.True(t, strings.HasPrefix(created[i].ExternalID, "qif:"), created[i].ExternalID)
+					created[i].ExternalID = ""
+				}
+			}
+			if diff := cmp.Diff(tc.expectedCreated, created); diff != "" {
+				t.Errorf("created mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedErrors, result.Errors); diff != "" {
+				t.Errorf("errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportOFXSkipsImportedRows(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	data, err := testfixtures.ImportFiles.ReadFile("imports/credit_card_v2.qfx")
+	require.NoError(t, err)
+
+	txQ := dbmocks.NewTransactionQuerier(t)
+	txQ.On("ListUserCategories", ctx, userID).Return([]database.ListUserCategoriesRow{{ID: 40, PrimaryCategoryName: "FOOD", Name: "GROCERIES"}}, nil).Once()
+	batcher := importermocks.NewTransactionBatcher(t)
+	batcher.On("BatchTransactions", ctx, userID, mock.Anything).Return(models.TxBatchResponse{
+		Mode:      models.BatchModeBestEffort,
+		Committed: true,
+		Results: []models.TxBatchResult{
+			{Index: 0, Op: models.BatchOpCreate, Err: transaction.ErrDuplicateExternalID},
+			{Index: 1, Op: models.BatchOpCreate},
+		},
+	}, nil).Once()
+
+	svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, batcher, zap.NewNop())
+	result, err := svc.ImportOFX(ctx, userID, data, "FOOD_GROCERIES")
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Imported)
+	require.Equal(t, 1, result.Skipped)
+	require.Equal(t, 1, result.Failed)
+}
+
+func TestImportStatementErrors(t *testing.T) {
+	ctx := context.Background()
+	ofx, err := testfixtures.ImportFiles.ReadFile("imports/checking_v1.ofx")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		data        string
+		qif         bool
+		category    string
+		expectedErr error
+	}{
+		{name: "not OFX", data: "Date,Merchant,Amount\n", expectedErr: importer.ErrInvalidFile},
+		{name: "OFX without transactions", data: "<OFX><BANKMSGSRSV1></BANKMSGSRSV1></OFX>", expectedErr: importer.ErrInvalidFile},
+		{name: "unterminated tag", data: "<OFX><STMTTRN", expectedErr: importer.ErrInvalidFile},
+		{name: "not QIF", data: string(ofx), qif: true, expectedErr: importer.ErrInvalidFile},
+		{name: "QIF without bank transactions", data: "!Type:Invst\nD3/22'25\nT50.00\n^\n", qif: true, expectedErr: importer.ErrInvalidFile},
+		{name: "unknown default category", data: string(ofx), category: "NOPE", expectedErr: importer.ErrUnknownCategory},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			txQ := dbmocks.NewTransactionQuerier(t)
+			txQ.On("ListUserCategories", ctx, mock.Anything).Return(nil, nil).Maybe()
+			svc := importer.NewImportService(dbmocks.NewImportQuerier(t), txQ, importermocks.NewTransactionBatcher(t), zap.NewNop())
+			importFile := svc.ImportOFX
+			if tc.qif {
+				importFile = svc.ImportQIF
+			}
+			_, err := importFile(ctx, uuid.NewString(), []byte(tc.data), tc.category)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
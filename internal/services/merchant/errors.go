@@ -0,0 +1,10 @@
+package merchant
+
+import "errors"
+
+var (
+	ErrInvalidMerchant  = errors.New("invalid merchant")
+	ErrInvalidMerge     = errors.New("invalid merge")
+	ErrMerchantExists   = errors.New("another merchant has this name; merge them instead")
+	ErrMerchantNotFound = errors.New("merchant not found")
+)
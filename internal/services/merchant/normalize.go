@@ -0,0 +1,115 @@
+package merchant
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// processorPrefix matches the tag a payment processor puts before the merchant
+	// name, such as "SQ *" for Square or "TST*" for Toast.
+	processorPrefix = regexp.MustCompile(`(?i)^(SQ|SQU|TST|PAYPAL|PP|SP|IN|CKE|DD|GOOGLE)\s*\*\s*`)
+	domain          = regexp.MustCompile(`(?i)^www\.|\.(com|net|org)\b`)
+	// storeNumber matches a store, terminal or phone number such as "#0423", "00042"
+	// or "866-579-7172".
+	storeNumber = regexp.MustCompile(`^#?\d[\d-]*$|^#\S+$`)
+)
+
+// locations are the trailing country and US state codes that card statements add
+// after the merchant's city.
+var locations = map[string]bool{
+	"US": true, "USA": true,
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true,
+	"DC": true, "DE": true, "FL": true, "GA": true, "HI": true, "ID": true, "IL": true,
+	"IN": true, "IA": true, "KS": true, "KY": true, "LA": true, "ME": true, "MD": true,
+	"MA": true, "MI": true, "MN": true, "MS": true, "MO": true, "MT": true, "NE": true,
+	"NV": true, "NH": true, "NJ": true, "NM": true, "NY": true, "NC": true, "ND": true,
+	"OH": true, "OK": true, "OR": true, "PA": true, "RI": true, "SC": true, "SD": true,
+	"TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true, "WV": true,
+	"WI": true, "WY": true,
+}
+
+// knownNames maps the keys of abbreviations that no stripping turns into the brand.
+var knownNames = map[string]string{
+	"amzn":               "Amazon",
+	"amzn mktp":          "Amazon",
+	"amazon mktp":        "Amazon",
+	"amazon mktplace":    "Amazon",
+	"amazon marketplace": "Amazon",
+	"wal mart":           "Walmart",
+	"wm supercenter":     "Walmart",
+}
+
+// Normalize turns a merchant string from a card statement into a display name. It
+// strips processor prefixes, the reference after an asterisk, domains, and the store
+// number and location that follow the name, and fixes the case of names sent in all
+// capitals. "SQ *BLUE BOTTLE 0423 SAN FRANCISCO CA" becomes "Blue Bottle". A string
+// with nothing left after stripping is returned as sent, trimmed.
+func Normalize(raw string) string {
+	s := strings.Join(strings.Fields(raw), " ")
+	for processorPrefix.MatchString(s) {
+		s = processorPrefix.ReplaceAllString(s, "")
+	}
+	if i := strings.IndexByte(s, '*'); i > 0 {
+		s = s[:i]
+	}
+	s = domain.ReplaceAllString(s, "")
+
+	words := strings.Fields(s)
+	for i, w := range words {
+		if i > 0 && storeNumber.MatchString(w) {
+			words = words[:i]
+			break
+		}
+	}
+	for len(words) > 1 && locations[strings.ToUpper(words[len(words)-1])] && words[len(words)-1] == strings.ToUpper(words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+	name := strings.Join(words, " ")
+
+	if known, ok := knownNames[Key(name)]; ok {
+		return known
+	}
+	if name == "" {
+		return strings.Join(strings.Fields(raw), " ")
+	}
+	return fixCase(name)
+}
+
+// Key is the form of a name that aliases are stored under: lower case letters and
+// digits, with everything else collapsed to single spaces.
+func Key(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// fixCase title-cases a name sent in one case. Each run of letters is cased on its
+// own, so "7-ELEVEN" becomes "7-Eleven". Runs without vowels, such as "CVS", are
+// taken for initials and kept in capitals. Names in mixed case are left alone.
+func fixCase(name string) string {
+	if strings.ToUpper(name) != name && strings.ToLower(name) != name {
+		return name
+	}
+	runes := []rune(strings.ToLower(name))
+	for start := 0; start < len(runes); {
+		if !unicode.IsLetter(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || runes[end] == '\'') {
+			end++
+		}
+		if strings.ContainsAny(string(runes[start:end]), "aeiouy") {
+			runes[start] = unicode.ToUpper(runes[start])
+		} else {
+			for i := start; i < end; i++ {
+				runes[i] = unicode.ToUpper(runes[i])
+			}
+		}
+		start = end
+	}
+	return string(runes)
+}
@@ -0,0 +1,346 @@
+// Package merchant keeps each user's directory of canonical merchants. Transactions
+// are linked to a merchant through the normalized form of the merchant string they
+// were sent with, so "SQ *BLUE BOTTLE 0423" and "Blue Bottle Coffee" can be merged
+// into one merchant and counted as one.
+package merchant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxMergeSources bounds the merchants one merge folds in.
+	MaxMergeSources = 100
+	maxNameLength   = 200
+	// linkPageSize is how many unlinked transactions linkTransactions links at a time.
+	linkPageSize = 500
+)
+
+type MerchantService struct {
+	merchantQueries database.MerchantQuerier
+	sqlTxQuerier    database.SqlTxQuerier
+	txQueries       database.TransactionQuerier
+	logger          *zap.Logger
+}
+
+func NewMerchantService(merchantQueries database.MerchantQuerier, sqlTxQuerier database.SqlTxQuerier, txQueries database.TransactionQuerier, logger *zap.Logger) *MerchantService {
+	return &MerchantService{
+		merchantQueries: merchantQueries,
+		sqlTxQuerier:    sqlTxQuerier,
+		txQueries:       txQueries,
+		logger:          logger,
+	}
+}
+
+// Resolve returns the user's merchant for a merchant string, creating it the first
+// time the string's normalized form is seen.
+func (s *MerchantService) Resolve(ctx context.Context, userID, raw string) (models.Merchant, error) {
+	var m models.Merchant
+	err := s.inTx(ctx, func(q database.MerchantQuerier) error {
+		var err error
+		m, err = resolve(ctx, q, userID, raw)
+		return err
+	})
+	return m, err
+}
+
+// ListMerchants returns the user's merchant directory by name. Transactions written
+// before they were linked to merchants are linked first.
+func (s *MerchantService) ListMerchants(ctx context.Context, userID string) ([]models.MerchantSummary, error) {
+	if err := s.linkTransactions(ctx, userID); err != nil {
+		return nil, err
+	}
+	return summaries(ctx, s.merchantQueries, userID)
+}
+
+// UpdateMerchant renames a merchant and sets its default category. A rename renames
+// the merchant's transactions too; the old name stays an alias. Renaming to a name
+// that resolves to another merchant fails with ErrMerchantExists, since that is a
+// merge.
+func (s *MerchantService) UpdateMerchant(ctx context.Context, userID, merchantID string, update models.MerchantUpdate) (models.MerchantSummary, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+			return models.MerchantSummary{}, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidMerchant, maxNameLength)
+		}
+		if Key(name) == "" {
+			return models.MerchantSummary{}, fmt.Errorf("%w: name must contain a letter or digit", ErrInvalidMerchant)
+		}
+		update.Name = &name
+	}
+	if update.DefaultCategory != nil && *update.DefaultCategory != 0 {
+		if err := s.checkCategory(ctx, userID, *update.DefaultCategory); err != nil {
+			return models.MerchantSummary{}, err
+		}
+	}
+
+	err := s.inTx(ctx, func(q database.MerchantQuerier) error {
+		m, err := getMerchant(ctx, q, userID, merchantID)
+		if err != nil {
+			return err
+		}
+		params := database.UpdateMerchantParams{
+			Name:              m.Name,
+			DefaultCategoryID: m.DefaultCategoryID,
+			UpdatedAt:         sql.NullTime{Time: time.Now(), Valid: true},
+			ID:                m.ID,
+			UserID:            userID,
+		}
+		if update.DefaultCategory != nil {
+			params.DefaultCategoryID = sql.NullInt64{Int64: *update.DefaultCategory, Valid: *update.DefaultCategory != 0}
+		}
+		if update.Name != nil && *update.Name != m.Name {
+			params.Name = *update.Name
+			if err := addAlias(ctx, q, userID, m.ID, Key(params.Name)); err != nil {
+				return err
+			}
+		}
+		if _, err := q.UpdateMerchant(ctx, params); err != nil {
+			return fmt.Errorf("failed to update merchant: %w", err)
+		}
+		return moveTransactions(ctx, q, userID, m.ID, m.ID, params.Name)
+	})
+	if err != nil {
+		return models.MerchantSummary{}, err
+	}
+	return s.summary(ctx, userID, merchantID)
+}
+
+// MergeMerchants folds the source merchants into the target. Their transactions take
+// the target's name, their aliases resolve to the target from then on, and they are
+// deleted. The target keeps its own default category.
+func (s *MerchantService) MergeMerchants(ctx context.Context, userID string, merge models.MerchantMerge) (models.MerchantSummary, error) {
+	if len(merge.SourceIDs) == 0 || len(merge.SourceIDs) > MaxMergeSources {
+		return models.MerchantSummary{}, fmt.Errorf("%w: a merge takes 1 to %d source merchants", ErrInvalidMerge, MaxMergeSources)
+	}
+	if slices.Contains(merge.SourceIDs, merge.TargetID) {
+		return models.MerchantSummary{}, fmt.Errorf("%w: a merchant cannot be merged into itself", ErrInvalidMerge)
+	}
+	sources := slices.Clone(merge.SourceIDs)
+	slices.Sort(sources)
+
+	err := s.inTx(ctx, func(q database.MerchantQuerier) error {
+		target, err := getMerchant(ctx, q, userID, merge.TargetID)
+		if err != nil {
+			return err
+		}
+		for _, sourceID := range slices.Compact(sources) {
+			if _, err := getMerchant(ctx, q, userID, sourceID); err != nil {
+				return err
+			}
+			if err := q.MoveMerchantAliases(ctx, database.MoveMerchantAliasesParams{
+				ToMerchantID:   target.ID,
+				UserID:         userID,
+				FromMerchantID: sourceID,
+			}); err != nil {
+				return fmt.Errorf("failed to move aliases: %w", err)
+			}
+			if err := moveTransactions(ctx, q, userID, sourceID, target.ID, target.Name); err != nil {
+				return err
+			}
+			if _, err := q.DeleteMerchant(ctx, database.DeleteMerchantParams{ID: sourceID, UserID: userID}); err != nil {
+				return fmt.Errorf("failed to delete merchant: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.MerchantSummary{}, err
+	}
+	return s.summary(ctx, userID, merge.TargetID)
+}
+
+// linkTransactions links the user's transactions that have no merchant, such as those
+// written before the directory existed.
+func (s *MerchantService) linkTransactions(ctx context.Context, userID string) error {
+	for {
+		rows, err := s.merchantQueries.ListUnlinkedTransactions(ctx, database.ListUnlinkedTransactionsParams{UserID: userID, Limit: linkPageSize})
+		if err != nil {
+			return fmt.Errorf("failed to list unlinked transactions: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		err = s.inTx(ctx, func(q database.MerchantQuerier) error {
+			now := sql.NullTime{Time: time.Now(), Valid: true}
+			for _, row := range rows {
+				m, err := resolve(ctx, q, userID, row.RawMerchant)
+				if err != nil {
+					return err
+				}
+				if err := q.LinkTransactionMerchant(ctx, database.LinkTransactionMerchantParams{
+					MerchantID: sql.NullString{String: m.ID, Valid: true},
+					Merchant:   m.Name,
+					UpdatedAt:  now,
+					ID:         row.ID,
+					UserID:     userID,
+				}); err != nil {
+					return fmt.Errorf("failed to link transaction: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		s.logger.Debug("linked transactions to merchants", zap.String("user_id", userID), zap.Int("transactions", len(rows)))
+		if len(rows) < linkPageSize {
+			return nil
+		}
+	}
+}
+
+func (s *MerchantService) summary(ctx context.Context, userID, merchantID string) (models.MerchantSummary, error) {
+	all, err := summaries(ctx, s.merchantQueries, userID)
+	if err != nil {
+		return models.MerchantSummary{}, err
+	}
+	i := slices.IndexFunc(all, func(m models.MerchantSummary) bool { return m.ID == merchantID })
+	if i < 0 {
+		return models.MerchantSummary{}, ErrMerchantNotFound
+	}
+	return all[i], nil
+}
+
+func (s *MerchantService) checkCategory(ctx context.Context, userID string, categoryID int64) error {
+	categories, err := s.txQueries.ListUserCategories(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load categories: %w", err)
+	}
+	if !slices.ContainsFunc(categories, func(c database.ListUserCategoriesRow) bool {
+		return c.ID == categoryID && !c.ArchivedAt.Valid
+	}) {
+		return fmt.Errorf("%w: unknown default_category %d", ErrInvalidMerchant, categoryID)
+	}
+	return nil
+}
+
+func (s *MerchantService) inTx(ctx context.Context, fn func(q database.MerchantQuerier) error) error {
+	tx, err := s.sqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(database.NewRealMerchantQuerier(s.sqlTxQuerier.WithTx(tx))); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// resolve finds the merchant raw normalizes to, or creates it.
+func resolve(ctx context.Context, q database.MerchantQuerier, userID, raw string) (models.Merchant, error) {
+	name := Normalize(raw)
+	alias := Key(name)
+	if alias == "" {
+		alias = strings.ToLower(name)
+	}
+	m, err := q.GetMerchantByAlias(ctx, database.GetMerchantByAliasParams{UserID: userID, Alias: alias})
+	if err == nil {
+		return m, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.Merchant{}, fmt.Errorf("failed to look up merchant: %w", err)
+	}
+
+	m = models.Merchant{ID: uuid.NewString(), UserID: userID, Name: name}
+	if err := q.CreateMerchant(ctx, database.CreateMerchantParams{ID: m.ID, UserID: userID, Name: name}); err != nil {
+		return models.Merchant{}, fmt.Errorf("failed to create merchant: %w", err)
+	}
+	if err := addAlias(ctx, q, userID, m.ID, alias); err != nil {
+		return models.Merchant{}, err
+	}
+	return m, nil
+}
+
+// addAlias makes alias resolve to the merchant. An alias of another merchant is
+// ErrMerchantExists.
+func addAlias(ctx context.Context, q database.MerchantQuerier, userID, merchantID, alias string) error {
+	n, err := q.CreateMerchantAlias(ctx, database.CreateMerchantAliasParams{UserID: userID, Alias: alias, MerchantID: merchantID})
+	if err != nil {
+		return fmt.Errorf("failed to add alias: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+	owner, err := q.GetMerchantByAlias(ctx, database.GetMerchantByAliasParams{UserID: userID, Alias: alias})
+	if err != nil {
+		return fmt.Errorf("failed to look up merchant: %w", err)
+	}
+	if owner.ID != merchantID {
+		return fmt.Errorf("%w: %q is already %s", ErrMerchantExists, alias, owner.Name)
+	}
+	return nil
+}
+
+// moveTransactions links the transactions of merchant fromID to merchant toID under
+// name. Only transactions that change get a new version.
+func moveTransactions(ctx context.Context, q database.MerchantQuerier, userID, fromID, toID, name string) error {
+	if _, err := q.UpdateMerchantTransactions(ctx, database.UpdateMerchantTransactionsParams{
+		MerchantID:     sql.NullString{String: toID, Valid: true},
+		Merchant:       name,
+		UpdatedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+		UserID:         userID,
+		FromMerchantID: sql.NullString{String: fromID, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to update transactions: %w", err)
+	}
+	return nil
+}
+
+func getMerchant(ctx context.Context, q database.MerchantQuerier, userID, merchantID string) (models.Merchant, error) {
+	m, err := q.GetMerchant(ctx, database.GetMerchantParams{ID: merchantID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Merchant{}, fmt.Errorf("%w: %s", ErrMerchantNotFound, merchantID)
+		}
+		return models.Merchant{}, fmt.Errorf("failed to load merchant: %w", err)
+	}
+	return m, nil
+}
+
+func summaries(ctx context.Context, q database.MerchantQuerier, userID string) ([]models.MerchantSummary, error) {
+	rows, err := q.ListUserMerchants(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merchants: %w", err)
+	}
+	aliases, err := q.ListMerchantAliases(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	byMerchant := make(map[string][]string, len(rows))
+	for _, a := range aliases {
+		byMerchant[a.MerchantID] = append(byMerchant[a.MerchantID], a.Alias)
+	}
+	out := make([]models.MerchantSummary, 0, len(rows))
+	for _, row := range rows {
+		m := models.MerchantSummary{
+			ID:               row.ID,
+			Name:             row.Name,
+			Aliases:          byMerchant[row.ID],
+			TransactionCount: row.TransactionCount,
+		}
+		if row.DefaultCategoryID.Valid {
+			m.DefaultCategory = &row.DefaultCategoryID.Int64
+		}
+		if m.Aliases == nil {
+			m.Aliases = []string{}
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
@@ -0,0 +1,194 @@
+package merchant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/merchant"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/internal/testmodels"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMerchants(t *testing.T) (*testmodels.TestEnv, uuid.UUID) {
+	t.Helper()
+	env := testhelpers.SetupTestEnv(t)
+	t.Cleanup(func() { env.Db.Close() })
+	userID := uuid.New()
+	testhelpers.SeedTestUser(t, env.UserQ, userID, false)
+	testhelpers.SeedTestCategories(t, env.Db)
+	return env, userID
+}
+
+func createTx(t *testing.T, env *testmodels.TestEnv, userID uuid.UUID, merchantName string) *models.Tx {
+	t.Helper()
+	txn, err := env.Services.TxService.CreateTransaction(context.Background(), userID.String(), models.NewTxRequest{
+		Date: "2025-03-01", Merchant: merchantName, Amount: 5.25, DetailedCategory: 40,
+	})
+	require.NoError(t, err)
+	return txn
+}
+
+func byName(t *testing.T, merchants []models.MerchantSummary, name string) models.MerchantSummary {
+	t.Helper()
+	for _, m := range merchants {
+		if m.Name == name {
+			return m
+		}
+	}
+	t.Fatalf("no merchant named %q in %v", name, merchants)
+	return models.MerchantSummary{}
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupMerchants(t)
+	svc := env.Services.MerchantService
+
+	first := createTx(t, env, userID, "SQ *BLUE BOTTLE 0423 SAN FRANCISCO CA")
+	second := createTx(t, env, userID, "Blue Bottle")
+	require.Equal(t, "Blue Bottle", first.Merchant)
+	require.Equal(t, "SQ *BLUE BOTTLE 0423 SAN FRANCISCO CA", first.RawMerchant)
+	require.NotEmpty(t, first.MerchantID)
+	require.Equal(t, first.MerchantID, second.MerchantID)
+
+	// Merchants belong to one user.
+	otherUser := uuid.New()
+	require.NoError(t, env.UserQ.CreateUser(ctx, database.CreateUserParams{
+		ID:             otherUser.String(),
+		Email:          "other@example.com",
+		HashedPassword: "hashedpwd",
+	}))
+	other, err := svc.Resolve(ctx, otherUser.String(), "Blue Bottle")
+	require.NoError(t, err)
+	require.NotEqual(t, first.MerchantID, other.ID)
+
+	merchants, err := svc.ListMerchants(ctx, userID.String())
+	require.NoError(t, err)
+	require.Equal(t, []models.MerchantSummary{{
+		ID: first.MerchantID, Name: "Blue Bottle", Aliases: []string{"blue bottle"}, TransactionCount: 2,
+	}}, merchants)
+}
+
+func TestListMerchantsLinksOldTransactions(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupMerchants(t)
+
+	// Transactions written straight to the table have no merchant yet.
+	for _, name := range []string{"AMZN Mktp US*2K3LM91Z0", "Amazon.com", "TST* SWEETGREEN 1234"} {
+		testhelpers.SeedTestTransaction(t, env.TxQ, userID, uuid.New(), &models.NewTxRequest{Date: "2025-03-01", Merchant: name, Amount: 12, DetailedCategory: 40})
+	}
+	_, err := env.Db.Exec("UPDATE transactions SET raw_merchant = merchant")
+	require.NoError(t, err)
+
+	merchants, err := env.Services.MerchantService.ListMerchants(ctx, userID.String())
+	require.NoError(t, err)
+	require.Len(t, merchants, 2)
+	require.Equal(t, int64(2), byName(t, merchants, "Amazon").TransactionCount)
+	require.Equal(t, int64(1), byName(t, merchants, "Sweetgreen").TransactionCount)
+
+	var unlinked int
+	require.NoError(t, env.Db.QueryRow("SELECT COUNT(*) FROM transactions WHERE merchant_id IS NULL").Scan(&unlinked))
+	require.Zero(t, unlinked)
+}
+
+func TestUpdateMerchant(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupMerchants(t)
+	svc := env.Services.MerchantService
+
+	txn := createTx(t, env, userID, "COSTCO WHSE #0042")
+	createTx(t, env, userID, "Trader Joe's")
+	name := "Costco"
+	category := int64(40)
+
+	updated, err := svc.UpdateMerchant(ctx, userID.String(), txn.MerchantID, models.MerchantUpdate{Name: &name, DefaultCategory: &category})
+	require.NoError(t, err)
+	require.Equal(t, models.MerchantSummary{
+		ID: txn.MerchantID, Name: "Costco", DefaultCategory: &category, Aliases: []string{"costco", "costco whse"}, TransactionCount: 1,
+	}, updated)
+
+	// The transaction takes the new name, and both names resolve to the merchant.
+	got, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), txn.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Costco", got.Merchant)
+	require.Equal(t, "COSTCO WHSE #0042", got.RawMerchant)
+	again := createTx(t, env, userID, "COSTCO WHSE #0117")
+	require.Equal(t, txn.MerchantID, again.MerchantID)
+
+	// A merchant's default category fills in for a transaction sent without one.
+	noCategory, err := env.Services.TxService.CreateTransaction(ctx, userID.String(), models.NewTxRequest{Date: "2025-03-02", Merchant: "costco", Amount: 80})
+	require.NoError(t, err)
+	require.Equal(t, int64(40), noCategory.DetailedCategory)
+
+	clear := int64(0)
+	updated, err = svc.UpdateMerchant(ctx, userID.String(), txn.MerchantID, models.MerchantUpdate{DefaultCategory: &clear})
+	require.NoError(t, err)
+	require.Nil(t, updated.DefaultCategory)
+
+	t.Run("name of another merchant", func(t *testing.T) {
+		taken := "TRADER JOE'S"
+		_, err := svc.UpdateMerchant(ctx, userID.String(), txn.MerchantID, models.MerchantUpdate{Name: &taken})
+		require.ErrorIs(t, err, merchant.ErrMerchantExists)
+	})
+	t.Run("blank name", func(t *testing.T) {
+		blank := " ** "
+		_, err := svc.UpdateMerchant(ctx, userID.String(), txn.MerchantID, models.MerchantUpdate{Name: &blank})
+		require.ErrorIs(t, err, merchant.ErrInvalidMerchant)
+	})
+	t.Run("unknown category", func(t *testing.T) {
+		unknown := int64(999)
+		_, err := svc.UpdateMerchant(ctx, userID.String(), txn.MerchantID, models.MerchantUpdate{DefaultCategory: &unknown})
+		require.ErrorIs(t, err, merchant.ErrInvalidMerchant)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := svc.UpdateMerchant(ctx, userID.String(), uuid.NewString(), models.MerchantUpdate{Name: &name})
+		require.ErrorIs(t, err, merchant.ErrMerchantNotFound)
+	})
+}
+
+func TestMergeMerchants(t *testing.T) {
+	ctx := context.Background()
+	env, userID := setupMerchants(t)
+	svc := env.Services.MerchantService
+
+	target := createTx(t, env, userID, "Blue Bottle")
+	source := createTx(t, env, userID, "BLUE BOTTLE COFFEE 12")
+	require.NotEqual(t, target.MerchantID, source.MerchantID)
+
+	merged, err := svc.MergeMerchants(ctx, userID.String(), models.MerchantMerge{TargetID: target.MerchantID, SourceIDs: []string{source.MerchantID}})
+	require.NoError(t, err)
+	require.Equal(t, models.MerchantSummary{
+		ID: target.MerchantID, Name: "Blue Bottle", Aliases: []string{"blue bottle", "blue bottle coffee"}, TransactionCount: 2,
+	}, merged)
+
+	got, err := env.Services.TxService.GetTransactionByID(ctx, userID.String(), source.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Blue Bottle", got.Merchant)
+	require.Equal(t, target.MerchantID, got.MerchantID)
+	require.Equal(t, "BLUE BOTTLE COFFEE 12", got.RawMerchant)
+
+	// The source's strings now resolve to the target.
+	again := createTx(t, env, userID, "Blue Bottle Coffee")
+	require.Equal(t, target.MerchantID, again.MerchantID)
+
+	t.Run("into itself", func(t *testing.T) {
+		_, err := svc.MergeMerchants(ctx, userID.String(), models.MerchantMerge{TargetID: target.MerchantID, SourceIDs: []string{target.MerchantID}})
+		require.ErrorIs(t, err, merchant.ErrInvalidMerge)
+	})
+	t.Run("deleted source", func(t *testing.T) {
+		_, err := svc.MergeMerchants(ctx, userID.String(), models.MerchantMerge{TargetID: target.MerchantID, SourceIDs: []string{source.MerchantID}})
+		require.ErrorIs(t, err, merchant.ErrMerchantNotFound)
+	})
+	t.Run("too many sources", func(t *testing.T) {
+		sources := make([]string, merchant.MaxMergeSources+1)
+		for i := range sources {
+			sources[i] = uuid.NewString()
+		}
+		_, err := svc.MergeMerchants(ctx, userID.String(), models.MerchantMerge{TargetID: target.MerchantID, SourceIDs: sources})
+		require.ErrorIs(t, err, merchant.ErrInvalidMerge)
+	})
+}
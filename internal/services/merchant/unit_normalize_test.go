@@ -0,0 +1,40 @@
+package merchant_test
+
+import (
+	"testing"
+
+	"github.com/seanhuebl/unity-wealth/internal/services/merchant"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{raw: "SQ *BLUE BOTTLE 0423 SAN FRANCISCO CA", expected: "Blue Bottle"},
+		{raw: "TST* SWEETGREEN 1234", expected: "Sweetgreen"},
+		{raw: "PAYPAL *SPOTIFY", expected: "Spotify"},
+		{raw: "AMZN Mktp US*2K3LM91Z0", expected: "Amazon"},
+		{raw: "Amazon.com", expected: "Amazon"},
+		{raw: "WAL-MART #1234 SPRINGFIELD MO", expected: "Walmart"},
+		{raw: "CVS/PHARMACY #04521", expected: "CVS/Pharmacy"},
+		{raw: "COSTCO WHSE #0042", expected: "Costco Whse"},
+		{raw: "Trader Joe's", expected: "Trader Joe's"},
+		{raw: "costco", expected: "Costco"},
+		{raw: "  7-ELEVEN   ", expected: "7-Eleven"},
+		{raw: "#1234", expected: "#1234"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.raw, func(t *testing.T) {
+			require.Equal(t, tc.expected, merchant.Normalize(tc.raw))
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	require.Equal(t, "blue bottle", merchant.Key("Blue  Bottle"))
+	require.Equal(t, "trader joe s", merchant.Key("TRADER JOE'S"))
+	require.Equal(t, "7 eleven", merchant.Key("7-Eleven"))
+	require.Equal(t, "", merchant.Key("***"))
+}
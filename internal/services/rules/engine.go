@@ -0,0 +1,232 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+const (
+	maxNameLength  = 100
+	maxValueLength = 200
+	maxTags        = 20
+)
+
+// compiledRule is a validated rule with its merchant regex compiled.
+type compiledRule struct {
+	rule     models.Rule
+	merchant *regexp.Regexp
+}
+
+// ruleSet is a user's rules in the order they run, with the transfer categories
+// MarkTransfer sets.
+type ruleSet struct {
+	rules       []compiledRule
+	transferOut int64
+	transferIn  int64
+}
+
+// validateRule checks r and normalizes its text and tags in place.
+func validateRule(r *models.Rule) error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" || utf8.RuneCountInString(r.Name) > maxNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidRule, maxNameLength)
+	}
+
+	c := &r.Conditions
+	if c.Merchant == nil && c.MinAmount == 0 && c.MaxAmount == 0 && c.Flow == "" && c.DayOfMonthFrom == 0 && c.DayOfMonthTo == 0 {
+		return fmt.Errorf("%w: at least one condition is required", ErrInvalidRule)
+	}
+	if c.Merchant != nil {
+		if c.Merchant.Value == "" || utf8.RuneCountInString(c.Merchant.Value) > maxValueLength {
+			return fmt.Errorf("%w: merchant value must be 1 to %d characters", ErrInvalidRule, maxValueLength)
+		}
+		switch c.Merchant.Match {
+		case models.MerchantContains, models.MerchantEquals:
+		case models.MerchantRegex:
+			if _, err := regexp.Compile(c.Merchant.Value); err != nil {
+				return fmt.Errorf("%w: invalid merchant regex: %v", ErrInvalidRule, err)
+			}
+		default:
+			return fmt.Errorf("%w: merchant match must be %s, %s or %s", ErrInvalidRule, models.MerchantContains, models.MerchantEquals, models.MerchantRegex)
+		}
+	}
+	if c.MinAmount < 0 || c.MaxAmount < 0 {
+		return fmt.Errorf("%w: amounts must not be negative; use flow for the sign", ErrInvalidRule)
+	}
+	if c.MinAmount != 0 && c.MaxAmount != 0 && c.MinAmount > c.MaxAmount {
+		return fmt.Errorf("%w: min_amount must not be greater than max_amount", ErrInvalidRule)
+	}
+	if c.Flow != "" && c.Flow != models.FlowInflow && c.Flow != models.FlowOutflow {
+		return fmt.Errorf("%w: flow must be %s or %s", ErrInvalidRule, models.FlowInflow, models.FlowOutflow)
+	}
+	if c.DayOfMonthFrom < 0 || c.DayOfMonthFrom > 31 || c.DayOfMonthTo < 0 || c.DayOfMonthTo > 31 {
+		return fmt.Errorf("%w: days of the month must be 1 to 31", ErrInvalidRule)
+	}
+
+	a := &r.Actions
+	a.Merchant = strings.TrimSpace(a.Merchant)
+	if utf8.RuneCountInString(a.Merchant) > maxValueLength {
+		return fmt.Errorf("%w: merchant must be at most %d characters", ErrInvalidRule, maxValueLength)
+	}
+	a.AddTags = normalizeTags(a.AddTags)
+	if len(a.AddTags) > maxTags {
+		return fmt.Errorf("%w: at most %d tags can be added", ErrInvalidRule, maxTags)
+	}
+	if a.DetailedCategory < 0 {
+		return fmt.Errorf("%w: invalid detailed_category", ErrInvalidRule)
+	}
+	if a.DetailedCategory != 0 && a.MarkTransfer {
+		return fmt.Errorf("%w: mark_transfer sets the category, so it cannot be combined with detailed_category", ErrInvalidRule)
+	}
+	if a.DetailedCategory == 0 && a.Merchant == "" && len(a.AddTags) == 0 && !a.MarkTransfer {
+		return fmt.Errorf("%w: at least one action is required", ErrInvalidRule)
+	}
+	return nil
+}
+
+// compileRule validates r and prepares it to run.
+func compileRule(r models.Rule) (compiledRule, error) {
+	if err := validateRule(&r); err != nil {
+		return compiledRule{}, err
+	}
+	c := compiledRule{rule: r}
+	if m := r.Conditions.Merchant; m != nil && m.Match == models.MerchantRegex {
+		c.merchant = regexp.MustCompile(m.Value)
+	}
+	return c, nil
+}
+
+// matches reports whether txn meets every condition of the rule.
+func (c compiledRule) matches(txn models.NewTxRequest) bool {
+	cond := c.rule.Conditions
+	if m := cond.Merchant; m != nil {
+		var ok bool
+		switch m.Match {
+		case models.MerchantContains:
+			ok = strings.Contains(strings.ToLower(txn.Merchant), strings.ToLower(m.Value))
+		case models.MerchantEquals:
+			ok = strings.EqualFold(strings.TrimSpace(txn.Merchant), strings.TrimSpace(m.Value))
+		case models.MerchantRegex:
+			ok = c.merchant.MatchString(txn.Merchant)
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	cents := helpers.ConvertToCents(math.Abs(txn.Amount))
+	if cond.MinAmount != 0 && cents < helpers.ConvertToCents(cond.MinAmount) {
+		return false
+	}
+	if cond.MaxAmount != 0 && cents > helpers.ConvertToCents(cond.MaxAmount) {
+		return false
+	}
+	// Money leaving the account is positive.
+	if cond.Flow == models.FlowOutflow && txn.Amount <= 0 || cond.Flow == models.FlowInflow && txn.Amount >= 0 {
+		return false
+	}
+
+	if cond.DayOfMonthFrom != 0 || cond.DayOfMonthTo != 0 {
+		date, err := time.Parse("2006-01-02", txn.Date)
+		if err != nil {
+			return false
+		}
+		from, to, day := max(cond.DayOfMonthFrom, 1), cond.DayOfMonthTo, date.Day()
+		if to == 0 {
+			to = 31
+		}
+		if from <= to && (day < from || day > to) || from > to && day < from && day > to {
+			return false
+		}
+	}
+	return true
+}
+
+// apply runs the rules on req and returns the IDs of those that matched. Conditions
+// see the transaction as it came in, not as earlier rules changed it.
+func (rs *ruleSet) apply(req *models.NewTxRequest) []string {
+	original := *req
+	var matched []string
+	var setCategory, setMerchant bool
+	for _, c := range rs.rules {
+		if !c.matches(original) {
+			continue
+		}
+		matched = append(matched, c.rule.ID)
+		a := c.rule.Actions
+		if !setCategory {
+			switch {
+			case a.DetailedCategory != 0:
+				req.DetailedCategory, setCategory = a.DetailedCategory, true
+			case a.MarkTransfer:
+				id := rs.transferOut
+				if original.Amount < 0 {
+					id = rs.transferIn
+				}
+				if id != 0 {
+					req.DetailedCategory, setCategory = id, true
+				}
+			}
+		}
+		if !setMerchant && a.Merchant != "" {
+			req.Merchant, setMerchant = a.Merchant, true
+		}
+		if len(a.AddTags) > 0 {
+			req.Tags = normalizeTags(append(slices.Clone(req.Tags), a.AddTags...))
+		}
+	}
+	return matched
+}
+
+// change returns what the rules change on a stored transaction, if anything.
+func (rs *ruleSet) change(txn models.Tx) (models.RuleChange, bool) {
+	req := models.NewTxRequest{
+		Date:             txn.Date,
+		Merchant:         txn.Merchant,
+		Amount:           txn.Amount,
+		DetailedCategory: txn.DetailedCategory,
+		Notes:            txn.Notes,
+		Tags:             slices.Clone(txn.Tags),
+	}
+	matched := rs.apply(&req)
+	if len(matched) == 0 {
+		return models.RuleChange{}, false
+	}
+
+	change := models.RuleChange{TransactionID: txn.ID, Date: txn.Date, Merchant: txn.Merchant, RuleIDs: matched}
+	changed := false
+	if req.DetailedCategory != txn.DetailedCategory {
+		change.Set.DetailedCategory = &req.DetailedCategory
+		changed = true
+	}
+	if req.Merchant != txn.Merchant {
+		change.Set.Merchant = &req.Merchant
+		changed = true
+	}
+	if !slices.Equal(req.Tags, normalizeTags(txn.Tags)) {
+		change.Set.Tags = req.Tags
+		changed = true
+	}
+	return change, changed
+}
+
+// normalizeTags stores tags the way transactions do: trimmed, lower case, without
+// commas and without repeats.
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, ",", " ")))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
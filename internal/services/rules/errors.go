@@ -0,0 +1,13 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidRule  = errors.New("invalid rule")
+	ErrInvalidRun   = errors.New("invalid rule run")
+	ErrRuleNotFound = errors.New("rule not found")
+	ErrTooManyRules = fmt.Errorf("a user can have at most %d rules", MaxRulesPerUser)
+)
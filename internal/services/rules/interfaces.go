@@ -0,0 +1,14 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// TransactionStore reads and updates past transactions for runs over history.
+type TransactionStore interface {
+	ExportTransactions(ctx context.Context, userID uuid.UUID, filter models.TxFilter, fn func(models.TxExport) error) error
+	PatchTransactions(ctx context.Context, userID string, ops []models.TxPatchOp) ([]error, error)
+}
@@ -0,0 +1,366 @@
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"go.uber.org/zap"
+)
+
+// MaxRulesPerUser bounds the rules a user can have; every new transaction is checked
+// against all of them.
+const MaxRulesPerUser = 200
+
+// maxReportedChanges bounds the changes listed in a RuleRunResult.
+const maxReportedChanges = 100
+
+// The categories MarkTransfer sets, as PRIMARY_DETAILED names.
+const (
+	transferOutCategory = "TRANSFER_OUT_ACCOUNT_TRANSFER"
+	transferInCategory  = "TRANSFER_IN_ACCOUNT_TRANSFER"
+)
+
+type RuleService struct {
+	ruleQueries database.RuleQuerier
+	txQueries   database.TransactionQuerier
+	txns        TransactionStore
+	logger      *zap.Logger
+}
+
+func NewRuleService(ruleQueries database.RuleQuerier, txQueries database.TransactionQuerier, txns TransactionStore, logger *zap.Logger) *RuleService {
+	return &RuleService{
+		ruleQueries: ruleQueries,
+		txQueries:   txQueries,
+		txns:        txns,
+		logger:      logger,
+	}
+}
+
+// ListRules returns the user's rules in the order they run.
+func (s *RuleService) ListRules(ctx context.Context, userID string) ([]models.Rule, error) {
+	rows, err := s.ruleQueries.ListUserRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	rules := make([]models.Rule, 0, len(rows))
+	for _, row := range rows {
+		rule, err := convertRule(row)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *RuleService) CreateRule(ctx context.Context, userID string, rule models.Rule) (models.Rule, error) {
+	if err := s.validate(ctx, userID, &rule); err != nil {
+		return models.Rule{}, err
+	}
+	count, err := s.ruleQueries.CountUserRules(ctx, userID)
+	if err != nil {
+		return models.Rule{}, fmt.Errorf("failed to count rules: %w", err)
+	}
+	if count >= MaxRulesPerUser {
+		return models.Rule{}, ErrTooManyRules
+	}
+	conditions, actions, err := encodeRule(rule)
+	if err != nil {
+		return models.Rule{}, err
+	}
+
+	rule.ID = uuid.NewString()
+	if err := s.ruleQueries.CreateRule(ctx, database.CreateRuleParams{
+		ID:         rule.ID,
+		UserID:     userID,
+		Name:       rule.Name,
+		Priority:   rule.Priority,
+		Conditions: conditions,
+		Actions:    actions,
+	}); err != nil {
+		return models.Rule{}, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return s.getRule(ctx, userID, rule.ID)
+}
+
+// UpdateRule replaces one of the user's rules.
+func (s *RuleService) UpdateRule(ctx context.Context, userID, ruleID string, rule models.Rule) (models.Rule, error) {
+	if err := s.validate(ctx, userID, &rule); err != nil {
+		return models.Rule{}, err
+	}
+	conditions, actions, err := encodeRule(rule)
+	if err != nil {
+		return models.Rule{}, err
+	}
+
+	n, err := s.ruleQueries.UpdateRule(ctx, database.UpdateRuleParams{
+		ID:         ruleID,
+		UserID:     userID,
+		Name:       rule.Name,
+		Priority:   rule.Priority,
+		Conditions: conditions,
+		Actions:    actions,
+		UpdatedAt:  sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return models.Rule{}, fmt.Errorf("failed to update rule: %w", err)
+	}
+	if n == 0 {
+		return models.Rule{}, ErrRuleNotFound
+	}
+	return s.getRule(ctx, userID, ruleID)
+}
+
+func (s *RuleService) DeleteRule(ctx context.Context, userID, ruleID string) error {
+	n, err := s.ruleQueries.DeleteRule(ctx, database.DeleteRuleParams{ID: ruleID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	if n == 0 {
+		return ErrRuleNotFound
+	}
+	return nil
+}
+
+// UserRules loads the user's rules for TransactionService, which runs them on each
+// new transaction.
+func (s *RuleService) UserRules(ctx context.Context, userID string) (func(*models.NewTxRequest), error) {
+	rules, err := s.ListRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := s.compile(ctx, userID, rules)
+	if err != nil {
+		return nil, err
+	}
+	return func(req *models.NewTxRequest) { rs.apply(req) }, nil
+}
+
+// DryRun reports what the rules would change on the user's past transactions
+// without changing them. With run.Rule set only that rule is tried, so a rule can
+// be checked before it is saved.
+func (s *RuleService) DryRun(ctx context.Context, userID string, run models.RuleRunRequest) (models.RuleRunResult, error) {
+	var rules []models.Rule
+	if run.Rule != nil {
+		rule := *run.Rule
+		if err := s.validate(ctx, userID, &rule); err != nil {
+			return models.RuleRunResult{}, err
+		}
+		rules = []models.Rule{rule}
+	} else {
+		var err error
+		if rules, err = s.ListRules(ctx, userID); err != nil {
+			return models.RuleRunResult{}, err
+		}
+	}
+	return s.runHistory(ctx, userID, run, rules, false)
+}
+
+// ApplyRules runs the user's saved rules over their past transactions and saves the
+// changes.
+func (s *RuleService) ApplyRules(ctx context.Context, userID string, run models.RuleRunRequest) (models.RuleRunResult, error) {
+	if run.Rule != nil {
+		return models.RuleRunResult{}, fmt.Errorf("%w: only saved rules can be applied; save the rule first", ErrInvalidRun)
+	}
+	rules, err := s.ListRules(ctx, userID)
+	if err != nil {
+		return models.RuleRunResult{}, err
+	}
+	return s.runHistory(ctx, userID, run, rules, true)
+}
+
+// runHistory goes over the user's transactions in run's date range, oldest first,
+// and collects what rules change. With write set the changes are saved together once
+// they are all found, each only if the transaction has not changed since it was read;
+// the others are counted as skipped.
+func (s *RuleService) runHistory(ctx context.Context, userID string, run models.RuleRunRequest, rules []models.Rule, write bool) (models.RuleRunResult, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return models.RuleRunResult{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	filter := models.TxFilter{StartDate: run.StartDate, EndDate: run.EndDate, Sort: models.SortOldestFirst}
+	for _, date := range []string{filter.StartDate, filter.EndDate} {
+		if _, err := time.Parse("2006-01-02", date); date != "" && err != nil {
+			return models.RuleRunResult{}, fmt.Errorf("%w: dates must be YYYY-MM-DD", ErrInvalidRun)
+		}
+	}
+	if filter.StartDate != "" && filter.EndDate != "" && filter.StartDate > filter.EndDate {
+		return models.RuleRunResult{}, fmt.Errorf("%w: start_date must not be after end_date", ErrInvalidRun)
+	}
+
+	result := models.RuleRunResult{Changes: []models.RuleChange{}}
+	if len(rules) == 0 {
+		return result, nil
+	}
+	rs, err := s.compile(ctx, userID, rules)
+	if err != nil {
+		return models.RuleRunResult{}, err
+	}
+
+	var ops []models.TxPatchOp
+	err = s.txns.ExportTransactions(ctx, uid, filter, func(txn models.TxExport) error {
+		result.Checked++
+		change, ok := rs.change(txn.Tx)
+		if !ok {
+			return nil
+		}
+		split, err := s.isSplit(ctx, txn.ID)
+		if err != nil {
+			return err
+		}
+		if split {
+			result.Skipped++
+			return nil
+		}
+		if write {
+			patch := models.TxPatch{DetailedCategory: change.Set.DetailedCategory, Merchant: change.Set.Merchant}
+			if change.Set.Tags != nil {
+				patch.Tags = &change.Set.Tags
+			}
+			ops = append(ops, models.TxPatchOp{ID: txn.ID, Version: txn.Version, Patch: patch})
+		}
+		result.Changed++
+		if len(result.Changes) < maxReportedChanges {
+			result.Changes = append(result.Changes, change)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.RuleRunResult{}, err
+	}
+	if len(ops) > 0 {
+		errs, err := s.txns.PatchTransactions(ctx, userID, ops)
+		if err != nil {
+			return models.RuleRunResult{}, err
+		}
+		// Transactions that changed while the rules ran are skipped, not failed.
+		for i, err := range errs {
+			if err == nil {
+				continue
+			}
+			result.Changed--
+			result.Skipped++
+			result.Changes = slices.DeleteFunc(result.Changes, func(c models.RuleChange) bool {
+				return c.TransactionID == ops[i].ID
+			})
+		}
+	}
+	if write {
+		s.logger.Info("applied rules to history", zap.String("user_id", userID), zap.Int("checked", result.Checked), zap.Int("changed", result.Changed), zap.Int("skipped", result.Skipped))
+	}
+	return result, nil
+}
+
+// isSplit reports whether a transaction has lines. Rules do not change those, as a
+// patch cannot move the category of a split transaction away from its lines.
+func (s *RuleService) isSplit(ctx context.Context, txnID string) (bool, error) {
+	splits, err := s.txQueries.ListTransactionSplits(ctx, txnID)
+	if err != nil {
+		return false, fmt.Errorf("error loading splits: %w", err)
+	}
+	return len(splits) > 0, nil
+}
+
+// compile prepares rules to run. The transfer categories are only looked up when a
+// rule needs them.
+func (s *RuleService) compile(ctx context.Context, userID string, rules []models.Rule) (*ruleSet, error) {
+	rs := &ruleSet{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		c, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+		rs.rules = append(rs.rules, c)
+	}
+	if slices.ContainsFunc(rules, func(r models.Rule) bool { return r.Actions.MarkTransfer }) {
+		var err error
+		if rs.transferOut, err = s.categoryID(ctx, userID, transferOutCategory); err != nil {
+			return nil, err
+		}
+		if rs.transferIn, err = s.categoryID(ctx, userID, transferInCategory); err != nil {
+			return nil, err
+		}
+	}
+	return rs, nil
+}
+
+// categoryID returns the ID of the named category the user can file under, or 0 if
+// there is none.
+func (s *RuleService) categoryID(ctx context.Context, userID, name string) (int64, error) {
+	id, err := s.txQueries.GetDetailedCategoryID(ctx, database.GetDetailedCategoryIDParams{Name: name, UserID: userID})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to look up category: %w", err)
+	}
+	return id, nil
+}
+
+// validate checks rule, including that the category it sets is one the user can
+// file transactions under.
+func (s *RuleService) validate(ctx context.Context, userID string, rule *models.Rule) error {
+	if err := validateRule(rule); err != nil {
+		return err
+	}
+	if rule.Actions.DetailedCategory == 0 {
+		return nil
+	}
+	categories, err := s.txQueries.ListUserCategories(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load categories: %w", err)
+	}
+	if !slices.ContainsFunc(categories, func(c database.ListUserCategoriesRow) bool {
+		return c.ID == rule.Actions.DetailedCategory && !c.ArchivedAt.Valid
+	}) {
+		return fmt.Errorf("%w: unknown detailed_category %d", ErrInvalidRule, rule.Actions.DetailedCategory)
+	}
+	return nil
+}
+
+func (s *RuleService) getRule(ctx context.Context, userID, ruleID string) (models.Rule, error) {
+	row, err := s.ruleQueries.GetRule(ctx, database.GetRuleParams{ID: ruleID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Rule{}, ErrRuleNotFound
+		}
+		return models.Rule{}, fmt.Errorf("failed to load rule: %w", err)
+	}
+	return convertRule(row)
+}
+
+func encodeRule(rule models.Rule) (string, string, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode conditions: %w", err)
+	}
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode actions: %w", err)
+	}
+	return string(conditions), string(actions), nil
+}
+
+func convertRule(row models.CategorizationRule) (models.Rule, error) {
+	rule := models.Rule{ID: row.ID, Name: row.Name, Priority: row.Priority}
+	if err := json.Unmarshal([]byte(row.Conditions), &rule.Conditions); err != nil {
+		return models.Rule{}, fmt.Errorf("failed to decode conditions of rule %s: %w", row.ID, err)
+	}
+	if err := json.Unmarshal([]byte(row.Actions), &rule.Actions); err != nil {
+		return models.Rule{}, fmt.Errorf("failed to decode actions of rule %s: %w", row.ID, err)
+	}
+	if row.CreatedAt.Valid {
+		created := row.CreatedAt.Time
+		rule.CreatedAt = &created
+	}
+	if row.UpdatedAt.Valid {
+		updated := row.UpdatedAt.Time
+		rule.UpdatedAt = &updated
+	}
+	return rule, nil
+}
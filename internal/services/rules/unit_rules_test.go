@@ -0,0 +1,218 @@
+package rules_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateRule(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	merchant := &models.MerchantCondition{Match: models.MerchantContains, Value: "costco"}
+	valid := models.Rule{
+		Name:       "  Costco  ",
+		Priority:   2,
+		Conditions: models.RuleConditions{Merchant: merchant},
+		Actions:    models.RuleActions{DetailedCategory: 40, AddTags: []string{" Bulk ", "bulk"}},
+	}
+	categories := []database.ListUserCategoriesRow{{ID: 40, Name: "Groceries", PrimaryCategoryID: 7}}
+
+	tests := []struct {
+		name          string
+		rule          models.Rule
+		count         int64
+		expectsCount  bool
+		expectsCreate bool
+		expectedErr   error
+	}{
+		{
+			name:          "success",
+			rule:          valid,
+			expectsCount:  true,
+			expectsCreate: true,
+		},
+		{
+			name:        "blank name",
+			rule:        models.Rule{Name: " ", Conditions: valid.Conditions, Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "no conditions",
+			rule:        models.Rule{Name: "Costco", Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "no actions",
+			rule:        models.Rule{Name: "Costco", Conditions: valid.Conditions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "unknown match",
+			rule:        models.Rule{Name: "Costco", Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: "like", Value: "costco"}}, Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "bad regex",
+			rule:        models.Rule{Name: "Costco", Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantRegex, Value: "costco("}}, Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "min above max",
+			rule:        models.Rule{Name: "Big", Conditions: models.RuleConditions{MinAmount: 100, MaxAmount: 50}, Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "bad flow",
+			rule:        models.Rule{Name: "Big", Conditions: models.RuleConditions{Flow: "sideways"}, Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "bad day",
+			rule:        models.Rule{Name: "Late", Conditions: models.RuleConditions{DayOfMonthFrom: 32}, Actions: valid.Actions},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "category and transfer",
+			rule:        models.Rule{Name: "Costco", Conditions: valid.Conditions, Actions: models.RuleActions{DetailedCategory: 40, MarkTransfer: true}},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "too many tags",
+			rule:        models.Rule{Name: "Costco", Conditions: valid.Conditions, Actions: models.RuleActions{AddTags: strings.Split("a,b,c,d,e,f,g,h,i,j,k,l,m,n,o,p,q,r,s,t,u", ",")}},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:        "unknown category",
+			rule:        models.Rule{Name: "Costco", Conditions: valid.Conditions, Actions: models.RuleActions{DetailedCategory: 41}},
+			expectedErr: rules.ErrInvalidRule,
+		},
+		{
+			name:         "too many rules",
+			rule:         valid,
+			count:        rules.MaxRulesPerUser,
+			expectsCount: true,
+			expectedErr:  rules.ErrTooManyRules,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRuleQ := dbmocks.NewRuleQuerier(t)
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+			mockTxQ.On("ListUserCategories", ctx, userID).Return(categories, nil).Maybe()
+
+			var created database.CreateRuleParams
+			if tc.expectsCount {
+				mockRuleQ.On("CountUserRules", ctx, userID).Return(tc.count, nil).Once()
+			}
+			if tc.expectsCreate {
+				mockRuleQ.On("CreateRule", ctx, mock.AnythingOfType("database.CreateRuleParams")).
+					Run(func(args mock.Arguments) { created = args.Get(1).(database.CreateRuleParams) }).
+					Return(nil).Once()
+				mockRuleQ.On("GetRule", ctx, mock.AnythingOfType("database.GetRuleParams")).
+					Return(func(_ context.Context, p database.GetRuleParams) (models.CategorizationRule, error) {
+						require.Equal(t, created.ID, p.ID)
+						return models.CategorizationRule{ID: created.ID, UserID: created.UserID, Name: created.Name, Priority: created.Priority, Conditions: created.Conditions, Actions: created.Actions}, nil
+					}).Once()
+			}
+
+			svc := rules.NewRuleService(mockRuleQ, mockTxQ, nil, zap.NewNop())
+			rule, err := svc.CreateRule(ctx, userID, tc.rule)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, userID, created.UserID)
+			require.NotEmpty(t, rule.ID)
+			require.Equal(t, "Costco", rule.Name)
+			require.Equal(t, int64(2), rule.Priority)
+			require.Equal(t, merchant, rule.Conditions.Merchant)
+			require.Equal(t, []string{"bulk"}, rule.Actions.AddTags)
+		})
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	ruleID := uuid.NewString()
+	rule := models.Rule{
+		Name:       "Payroll",
+		Conditions: models.RuleConditions{Flow: models.FlowInflow, MinAmount: 1000},
+		Actions:    models.RuleActions{AddTags: []string{"income"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockRuleQ.On("UpdateRule", ctx, mock.MatchedBy(func(p database.UpdateRuleParams) bool {
+			return p.ID == ruleID && p.UserID == userID && p.Name == "Payroll" && p.UpdatedAt.Valid
+		})).Return(int64(1), nil).Once()
+		mockRuleQ.On("GetRule", ctx, database.GetRuleParams{ID: ruleID, UserID: userID}).
+			Return(ruleRow(t, userID, models.Rule{ID: ruleID, Name: rule.Name, Conditions: rule.Conditions, Actions: rule.Actions}), nil).Once()
+
+		svc := rules.NewRuleService(mockRuleQ, dbmocks.NewTransactionQuerier(t), nil, zap.NewNop())
+		updated, err := svc.UpdateRule(ctx, userID, ruleID, rule)
+		require.NoError(t, err)
+		require.Equal(t, ruleID, updated.ID)
+		require.Equal(t, rule.Conditions, updated.Conditions)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockRuleQ.On("UpdateRule", ctx, mock.AnythingOfType("database.UpdateRuleParams")).Return(int64(0), nil).Once()
+
+		svc := rules.NewRuleService(mockRuleQ, dbmocks.NewTransactionQuerier(t), nil, zap.NewNop())
+		_, err := svc.UpdateRule(ctx, userID, ruleID, rule)
+		require.ErrorIs(t, err, rules.ErrRuleNotFound)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		svc := rules.NewRuleService(dbmocks.NewRuleQuerier(t), dbmocks.NewTransactionQuerier(t), nil, zap.NewNop())
+		_, err := svc.UpdateRule(ctx, userID, ruleID, models.Rule{Name: "Payroll"})
+		require.ErrorIs(t, err, rules.ErrInvalidRule)
+	})
+}
+
+func TestDeleteRule(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	ruleID := uuid.NewString()
+	params := database.DeleteRuleParams{ID: ruleID, UserID: userID}
+
+	tests := []struct {
+		name        string
+		rows        int64
+		dbErr       error
+		expectedErr error
+	}{
+		{name: "success", rows: 1},
+		{name: "not found", expectedErr: rules.ErrRuleNotFound},
+		{name: "db error", dbErr: sql.ErrConnDone, expectedErr: sql.ErrConnDone},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRuleQ := dbmocks.NewRuleQuerier(t)
+			mockRuleQ.On("DeleteRule", ctx, params).Return(tc.rows, tc.dbErr).Once()
+
+			svc := rules.NewRuleService(mockRuleQ, dbmocks.NewTransactionQuerier(t), nil, zap.NewNop())
+			err := svc.DeleteRule(ctx, userID, ruleID)
+			if tc.expectedErr != nil {
+				require.True(t, errors.Is(err, tc.expectedErr))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	rulesmocks "github.com/seanhuebl/unity-wealth/internal/mocks/rules"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRunRules(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	coffee := models.Rule{
+		ID:         "coffee",
+		Name:       "Coffee",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "coffee"}},
+		Actions:    models.RuleActions{DetailedCategory: 41, AddTags: []string{"coffee"}},
+	}
+	history := []models.Tx{
+		{ID: "t1", Date: "2025-01-02", Merchant: "Joe's Coffee", Amount: 4.5, DetailedCategory: 40, Version: 3},
+		{ID: "t2", Date: "2025-01-03", Merchant: "Costco", Amount: 120, DetailedCategory: 40},
+		{ID: "t3", Date: "2025-01-04", Merchant: "Coffee Bar", Amount: 3, DetailedCategory: 41, Tags: []string{"coffee"}},
+	}
+	categories := []database.ListUserCategoriesRow{{ID: 40, Name: "Groceries"}, {ID: 41, Name: "Coffee"}}
+
+	expectHistory := func(store *rulesmocks.TransactionStore, filter models.TxFilter) {
+		store.On("ExportTransactions", ctx, userID, filter, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(3).(func(models.TxExport) error)
+				for _, txn := range history {
+					require.NoError(t, fn(models.TxExport{Tx: txn}))
+				}
+			}).
+			Return(nil).Once()
+	}
+	category := int64(41)
+	expectedChange := models.RuleChange{
+		TransactionID: "t1",
+		Date:          "2025-01-02",
+		Merchant:      "Joe's Coffee",
+		RuleIDs:       []string{"coffee"},
+		Set:           models.RuleUpdate{DetailedCategory: &category, Tags: []string{"coffee"}},
+	}
+
+	t.Run("dry run saved rules", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockStore := rulesmocks.NewTransactionStore(t)
+		mockRuleQ.On("ListUserRules", ctx, userID.String()).Return([]models.CategorizationRule{ruleRow(t, userID.String(), coffee)}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{}, nil).Once()
+		expectHistory(mockStore, models.TxFilter{StartDate: "2025-01-01", Sort: models.SortOldestFirst})
+
+		svc := rules.NewRuleService(mockRuleQ, mockTxQ, mockStore, zap.NewNop())
+		result, err := svc.DryRun(ctx, userID.String(), models.RuleRunRequest{StartDate: "2025-01-01"})
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Checked: 3, Changed: 1, Changes: []models.RuleChange{expectedChange}}, result)
+	})
+
+	t.Run("dry run unsaved rule", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockStore := rulesmocks.NewTransactionStore(t)
+		mockTxQ.On("ListUserCategories", ctx, userID.String()).Return(categories, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{}, nil).Once()
+		expectHistory(mockStore, models.TxFilter{Sort: models.SortOldestFirst})
+
+		unsaved := coffee
+		unsaved.ID = ""
+		svc := rules.NewRuleService(dbmocks.NewRuleQuerier(t), mockTxQ, mockStore, zap.NewNop())
+		result, err := svc.DryRun(ctx, userID.String(), models.RuleRunRequest{Rule: &unsaved})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Changed)
+		require.Empty(t, result.Changes[0].RuleIDs[0])
+	})
+
+	t.Run("dry run invalid rule", func(t *testing.T) {
+		svc := rules.NewRuleService(dbmocks.NewRuleQuerier(t), dbmocks.NewTransactionQuerier(t), rulesmocks.NewTransactionStore(t), zap.NewNop())
+		_, err := svc.DryRun(ctx, userID.String(), models.RuleRunRequest{Rule: &models.Rule{Name: "Empty"}})
+		require.ErrorIs(t, err, rules.ErrInvalidRule)
+	})
+
+	t.Run("bad dates", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockRuleQ.On("ListUserRules", ctx, userID.String()).Return([]models.CategorizationRule{}, nil).Twice()
+
+		svc := rules.NewRuleService(mockRuleQ, dbmocks.NewTransactionQuerier(t), rulesmocks.NewTransactionStore(t), zap.NewNop())
+		_, err := svc.DryRun(ctx, userID.String(), models.RuleRunRequest{StartDate: "01/02/2025"})
+		require.ErrorIs(t, err, rules.ErrInvalidRun)
+		_, err = svc.DryRun(ctx, userID.String(), models.RuleRunRequest{StartDate: "2025-02-01", EndDate: "2025-01-01"})
+		require.ErrorIs(t, err, rules.ErrInvalidRun)
+	})
+
+	t.Run("apply saves changes", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockStore := rulesmocks.NewTransactionStore(t)
+		mockRuleQ.On("ListUserRules", ctx, userID.String()).Return([]models.CategorizationRule{ruleRow(t, userID.String(), coffee)}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{}, nil).Once()
+		expectHistory(mockStore, models.TxFilter{Sort: models.SortOldestFirst})
+		tags := []string{"coffee"}
+		mockStore.On("PatchTransactions", ctx, userID.String(), []models.TxPatchOp{
+			{ID: "t1", Version: 3, Patch: models.TxPatch{DetailedCategory: &category, Tags: &tags}},
+		}).Return([]error{nil}, nil).Once()
+
+		svc := rules.NewRuleService(mockRuleQ, mockTxQ, mockStore, zap.NewNop())
+		result, err := svc.ApplyRules(ctx, userID.String(), models.RuleRunRequest{})
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Checked)
+		require.Equal(t, 1, result.Changed)
+	})
+
+	t.Run("apply skips split transactions", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockStore := rulesmocks.NewTransactionStore(t)
+		mockRuleQ.On("ListUserRules", ctx, userID.String()).Return([]models.CategorizationRule{ruleRow(t, userID.String(), coffee)}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{
+			{TransactionID: "t1", Line: 1, AmountCents: 250, DetailedCategoryID: 40},
+			{TransactionID: "t1", Line: 2, AmountCents: 200, DetailedCategoryID: 41},
+		}, nil).Once()
+		expectHistory(mockStore, models.TxFilter{Sort: models.SortOldestFirst})
+
+		svc := rules.NewRuleService(mockRuleQ, mockTxQ, mockStore, zap.NewNop())
+		result, err := svc.ApplyRules(ctx, userID.String(), models.RuleRunRequest{})
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Checked: 3, Skipped: 1, Changes: []models.RuleChange{}}, result)
+	})
+
+	t.Run("apply skips transactions changed since they were read", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockStore := rulesmocks.NewTransactionStore(t)
+		mockRuleQ.On("ListUserRules", ctx, userID.String()).Return([]models.CategorizationRule{ruleRow(t, userID.String(), coffee)}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{}, nil).Once()
+		expectHistory(mockStore, models.TxFilter{Sort: models.SortOldestFirst})
+		mockStore.On("PatchTransactions", ctx, userID.String(), mock.Anything).Return([]error{transaction.ErrVersionMismatch}, nil).Once()
+
+		svc := rules.NewRuleService(mockRuleQ, mockTxQ, mockStore, zap.NewNop())
+		result, err := svc.ApplyRules(ctx, userID.String(), models.RuleRunRequest{})
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Checked: 3, Skipped: 1, Changes: []models.RuleChange{}}, result)
+	})
+
+	t.Run("apply fails as a whole", func(t *testing.T) {
+		mockRuleQ := dbmocks.NewRuleQuerier(t)
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockStore := rulesmocks.NewTransactionStore(t)
+		mockRuleQ.On("ListUserRules", ctx, userID.String()).Return([]models.CategorizationRule{ruleRow(t, userID.String(), coffee)}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{}, nil).Once()
+		expectHistory(mockStore, models.TxFilter{Sort: models.SortOldestFirst})
+		mockStore.On("PatchTransactions", ctx, userID.String(), mock.Anything).Return(nil, errors.New("failed to commit transaction")).Once()
+
+		svc := rules.NewRuleService(mockRuleQ, mockTxQ, mockStore, zap.NewNop())
+		_, err := svc.ApplyRules(ctx, userID.String(), models.RuleRunRequest{})
+		require.ErrorContains(t, err, "failed to commit transaction")
+	})
+
+	t.Run("apply unsaved rule", func(t *testing.T) {
+		svc := rules.NewRuleService(dbmocks.NewRuleQuerier(t), dbmocks.NewTransactionQuerier(t), rulesmocks.NewTransactionStore(t), zap.NewNop())
+		_, err := svc.ApplyRules(ctx, userID.String(), models.RuleRunRequest{Rule: &coffee})
+		require.ErrorIs(t, err, rules.ErrInvalidRun)
+	})
+}
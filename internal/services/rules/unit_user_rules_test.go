@@ -0,0 +1,196 @@
+package rules_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// ruleRow stores rule the way CreateRule does.
+func ruleRow(t *testing.T, userID string, rule models.Rule) models.CategorizationRule {
+	t.Helper()
+	conditions, err := json.Marshal(rule.Conditions)
+	require.NoError(t, err)
+	actions, err := json.Marshal(rule.Actions)
+	require.NoError(t, err)
+	return models.CategorizationRule{
+		ID:         rule.ID,
+		UserID:     userID,
+		Name:       rule.Name,
+		Priority:   rule.Priority,
+		Conditions: string(conditions),
+		Actions:    string(actions),
+	}
+}
+
+func TestUserRules(t *testing.T) {
+	userID := uuid.NewString()
+	coffee := models.Rule{
+		ID:         "coffee",
+		Name:       "Coffee",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "blue bottle"}},
+		Actions:    models.RuleActions{DetailedCategory: 41, Merchant: "Blue Bottle", AddTags: []string{"Coffee"}},
+	}
+	rent := models.Rule{
+		ID:   "rent",
+		Name: "Rent",
+		Conditions: models.RuleConditions{
+			Merchant:       &models.MerchantCondition{Match: models.MerchantEquals, Value: "ACME PROPERTIES"},
+			MinAmount:      1000,
+			MaxAmount:      2500,
+			Flow:           models.FlowOutflow,
+			DayOfMonthFrom: 28,
+			DayOfMonthTo:   3,
+		},
+		Actions: models.RuleActions{DetailedCategory: 50},
+	}
+	payroll := models.Rule{
+		ID:         "payroll",
+		Name:       "Payroll",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantRegex, Value: `(?i)^payroll\s+\d+$`}},
+		Actions:    models.RuleActions{AddTags: []string{"income"}},
+	}
+	transfer := models.Rule{
+		ID:         "transfer",
+		Name:       "Savings",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "savings"}},
+		Actions:    models.RuleActions{MarkTransfer: true},
+	}
+	anyCoffee := models.Rule{
+		ID:         "any-coffee",
+		Name:       "Any coffee",
+		Priority:   1,
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "bottle"}},
+		Actions:    models.RuleActions{DetailedCategory: 99, Merchant: "Cafe", AddTags: []string{"treat", "coffee"}},
+	}
+
+	tests := []struct {
+		name     string
+		rules    []models.Rule
+		req      models.NewTxRequest
+		expected models.NewTxRequest
+	}{
+		{
+			name:     "merchant contains ignores case",
+			rules:    []models.Rule{coffee},
+			req:      models.NewTxRequest{Date: "2025-03-04", Merchant: "SQ *BLUE BOTTLE 123", Amount: 5.25, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-04", Merchant: "Blue Bottle", Amount: 5.25, DetailedCategory: 41, Tags: []string{"coffee"}},
+		},
+		{
+			name:     "no match",
+			rules:    []models.Rule{coffee},
+			req:      models.NewTxRequest{Date: "2025-03-04", Merchant: "Costco", Amount: 5.25, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-04", Merchant: "Costco", Amount: 5.25, DetailedCategory: 40},
+		},
+		{
+			name:     "all conditions met, day wraps the month end",
+			rules:    []models.Rule{rent},
+			req:      models.NewTxRequest{Date: "2025-03-01", Merchant: "acme properties", Amount: 1800, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-01", Merchant: "acme properties", Amount: 1800, DetailedCategory: 50},
+		},
+		{
+			name:     "day outside the range",
+			rules:    []models.Rule{rent},
+			req:      models.NewTxRequest{Date: "2025-03-15", Merchant: "ACME PROPERTIES", Amount: 1800, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-15", Merchant: "ACME PROPERTIES", Amount: 1800, DetailedCategory: 40},
+		},
+		{
+			name:     "amount above the range",
+			rules:    []models.Rule{rent},
+			req:      models.NewTxRequest{Date: "2025-03-01", Merchant: "ACME PROPERTIES", Amount: 2500.01, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-01", Merchant: "ACME PROPERTIES", Amount: 2500.01, DetailedCategory: 40},
+		},
+		{
+			name:     "wrong flow",
+			rules:    []models.Rule{rent},
+			req:      models.NewTxRequest{Date: "2025-03-01", Merchant: "ACME PROPERTIES", Amount: -1800, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-01", Merchant: "ACME PROPERTIES", Amount: -1800, DetailedCategory: 40},
+		},
+		{
+			name:     "regex",
+			rules:    []models.Rule{payroll},
+			req:      models.NewTxRequest{Date: "2025-03-01", Merchant: "PAYROLL 4411", Amount: -2000, DetailedCategory: 1, Tags: []string{"work"}},
+			expected: models.NewTxRequest{Date: "2025-03-01", Merchant: "PAYROLL 4411", Amount: -2000, DetailedCategory: 1, Tags: []string{"work", "income"}},
+		},
+		{
+			name:     "transfer out",
+			rules:    []models.Rule{transfer},
+			req:      models.NewTxRequest{Date: "2025-03-01", Merchant: "To Savings", Amount: 100, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-01", Merchant: "To Savings", Amount: 100, DetailedCategory: 91},
+		},
+		{
+			name:     "transfer in",
+			rules:    []models.Rule{transfer},
+			req:      models.NewTxRequest{Date: "2025-03-01", Merchant: "From Savings", Amount: -100, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-01", Merchant: "From Savings", Amount: -100, DetailedCategory: 90},
+		},
+		{
+			name:     "earlier rule wins, tags add up",
+			rules:    []models.Rule{coffee, anyCoffee},
+			req:      models.NewTxRequest{Date: "2025-03-04", Merchant: "Blue Bottle", Amount: 5.25, DetailedCategory: 40},
+			expected: models.NewTxRequest{Date: "2025-03-04", Merchant: "Blue Bottle", Amount: 5.25, DetailedCategory: 41, Tags: []string{"coffee", "treat"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockRuleQ := dbmocks.NewRuleQuerier(t)
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+
+			rows := make([]models.CategorizationRule, len(tc.rules))
+			for i, rule := range tc.rules {
+				rows[i] = ruleRow(t, userID, rule)
+			}
+			mockRuleQ.On("ListUserRules", ctx, userID).Return(rows, nil).Once()
+			if tc.rules[0].Actions.MarkTransfer {
+				mockTxQ.On("GetDetailedCategoryID", ctx, database.GetDetailedCategoryIDParams{Name: "TRANSFER_OUT_ACCOUNT_TRANSFER", UserID: userID}).Return(int64(91), nil).Once()
+				mockTxQ.On("GetDetailedCategoryID", ctx, database.GetDetailedCategoryIDParams{Name: "TRANSFER_IN_ACCOUNT_TRANSFER", UserID: userID}).Return(int64(90), nil).Once()
+			}
+
+			svc := rules.NewRuleService(mockRuleQ, mockTxQ, nil, zap.NewNop())
+			apply, err := svc.UserRules(ctx, userID)
+			require.NoError(t, err)
+
+			req := tc.req
+			apply(&req)
+			if diff := cmp.Diff(tc.expected, req); diff != "" {
+				t.Errorf("request mismatch (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserRulesNoTransferCategory(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	mockRuleQ := dbmocks.NewRuleQuerier(t)
+	mockTxQ := dbmocks.NewTransactionQuerier(t)
+
+	rule := models.Rule{
+		ID:         "transfer",
+		Name:       "Savings",
+		Conditions: models.RuleConditions{Merchant: &models.MerchantCondition{Match: models.MerchantContains, Value: "savings"}},
+		Actions:    models.RuleActions{MarkTransfer: true},
+	}
+	mockRuleQ.On("ListUserRules", ctx, userID).Return([]models.CategorizationRule{ruleRow(t, userID, rule)}, nil).Once()
+	mockTxQ.On("GetDetailedCategoryID", ctx, database.GetDetailedCategoryIDParams{Name: "TRANSFER_OUT_ACCOUNT_TRANSFER", UserID: userID}).Return(int64(0), sql.ErrNoRows).Once()
+	mockTxQ.On("GetDetailedCategoryID", ctx, database.GetDetailedCategoryIDParams{Name: "TRANSFER_IN_ACCOUNT_TRANSFER", UserID: userID}).Return(int64(0), sql.ErrNoRows).Once()
+
+	svc := rules.NewRuleService(mockRuleQ, mockTxQ, nil, zap.NewNop())
+	apply, err := svc.UserRules(ctx, userID)
+	require.NoError(t, err)
+
+	req := models.NewTxRequest{Date: "2025-03-01", Merchant: "To Savings", Amount: 100, DetailedCategory: 40}
+	apply(&req)
+	require.Equal(t, int64(40), req.DetailedCategory)
+}
@@ -0,0 +1,192 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// BatchTransactions runs up to MaxBatchOperations creates, updates and deletes for the
+// user in one database transaction. Each operation goes through the same path as its
+// single-transaction counterpart. In atomic mode the first failure rolls the batch
+// back and every other operation reports ErrBatchAborted; in best-effort mode each
+// operation runs in its own savepoint, the failures are rolled back and reported and
+// the rest is committed. The returned error is only set when the batch could not run
+// at all.
+func (s *TransactionService) BatchTransactions(ctx context.Context, userID string, batch models.TxBatchRequest) (models.TxBatchResponse, error) {
+	if s.SqlTxQuerier == nil {
+		return models.TxBatchResponse{}, ErrBatchUnavailable
+	}
+	if batch.Mode != models.BatchModeAtomic && batch.Mode != models.BatchModeBestEffort {
+		return models.TxBatchResponse{}, fmt.Errorf("%w: unknown mode %q", ErrInvalidBatchOp, batch.Mode)
+	}
+	if len(batch.Operations) == 0 || len(batch.Operations) > models.MaxBatchOperations {
+		return models.TxBatchResponse{}, fmt.Errorf("%w: a batch takes 1 to %d operations", ErrInvalidBatchOp, models.MaxBatchOperations)
+	}
+
+	// Rules are loaded and merchants resolved before the batch holds a connection.
+	applyRules, err := s.userRules(ctx, userID)
+	if err != nil {
+		return models.TxBatchResponse{}, err
+	}
+	merchants, err := s.batchMerchants(ctx, userID, batch.Operations, applyRules)
+	if err != nil {
+		return models.TxBatchResponse{}, err
+	}
+
+	tx, err := s.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TxBatchResponse{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	txQ := database.NewRealTransactionQuerier(s.SqlTxQuerier.WithTx(tx))
+
+	resp := models.TxBatchResponse{
+		Mode:    batch.Mode,
+		Results: make([]models.TxBatchResult, len(batch.Operations)),
+	}
+	failed := -1
+	for i, op := range batch.Operations {
+		if batch.Mode == models.BatchModeBestEffort {
+			resp.Results[i], err = s.applyBatchOpSavepoint(ctx, tx, txQ, userID, i, op, applyRules, merchants)
+			if err != nil {
+				return models.TxBatchResponse{}, err
+			}
+			continue
+		}
+		resp.Results[i] = s.applyBatchOp(ctx, txQ, userID, i, op, applyRules, merchants)
+		if resp.Results[i].Err != nil {
+			failed = i
+			break
+		}
+	}
+
+	if failed >= 0 {
+		for i, op := range batch.Operations {
+			if i == failed {
+				continue
+			}
+			resp.Results[i] = models.TxBatchResult{Index: i, Op: op.Op, ID: op.ID, Err: ErrBatchAborted}
+		}
+		return resp, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.TxBatchResponse{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	resp.Committed = true
+
+	// The categorizer learns once the batch no longer holds a connection.
+	for _, result := range resp.Results {
+		switch {
+		case result.Err != nil:
+		case result.Op == models.BatchOpDelete:
+			s.forget(ctx, userID, result.ID)
+		default:
+			s.learn(ctx, userID, result.Transaction)
+		}
+	}
+	return resp, nil
+}
+
+// batchMerchants resolves the merchants that the valid creates and updates of a batch
+// are written with, keyed by the merchant string.
+func (s *TransactionService) batchMerchants(ctx context.Context, userID string, ops []models.TxBatchOp, applyRules func(*models.NewTxRequest)) (map[string]models.Merchant, error) {
+	merchants := make(map[string]models.Merchant)
+	for _, op := range ops {
+		if op.Op == models.BatchOpDelete || validateBatchOp(op) != nil {
+			continue
+		}
+		req := *op.Transaction
+		if op.Op == models.BatchOpCreate {
+			applyRules(&req)
+		}
+		if _, ok := merchants[req.Merchant]; ok {
+			continue
+		}
+		m, err := s.merchant(ctx, userID, req.Merchant)
+		if err != nil {
+			return nil, err
+		}
+		merchants[req.Merchant] = m
+	}
+	return merchants, nil
+}
+
+func (s *TransactionService) applyBatchOp(ctx context.Context, txQ database.TransactionQuerier, userID string, index int, op models.TxBatchOp, applyRules func(*models.NewTxRequest), merchants map[string]models.Merchant) models.TxBatchResult {
+	result := models.TxBatchResult{Index: index, Op: op.Op, ID: op.ID}
+	if err := validateBatchOp(op); err != nil {
+		result.Err = err
+		return result
+	}
+
+	switch op.Op {
+	case models.BatchOpCreate:
+		req := *op.Transaction
+		applyRules(&req)
+		result.Transaction, result.Err = s.createTransaction(ctx, txQ, userID, req, merchants[req.Merchant])
+		if result.Transaction != nil {
+			result.ID = result.Transaction.ID
+		}
+	case models.BatchOpUpdate:
+		result.Transaction, result.Err = s.updateTransaction(ctx, txQ, op.ID, userID, *op.Transaction, op.Version, merchants[op.Transaction.Merchant])
+	case models.BatchOpDelete:
+		result.Err = s.deleteTransaction(ctx, txQ, op.ID, userID, op.Version)
+	}
+	return result
+}
+
+// applyBatchOpSavepoint runs a best-effort operation inside a savepoint, so that a
+// failure halfway through, such as a stale version after the old split lines are
+// deleted, leaves nothing of it in the batch.
+func (s *TransactionService) applyBatchOpSavepoint(ctx context.Context, tx *sql.Tx, txQ database.TransactionQuerier, userID string, index int, op models.TxBatchOp, applyRules func(*models.NewTxRequest), merchants map[string]models.Merchant) (models.TxBatchResult, error) {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_op"); err != nil {
+		return models.TxBatchResult{}, fmt.Errorf("failed to set savepoint: %w", err)
+	}
+	result := s.applyBatchOp(ctx, txQ, userID, index, op, applyRules, merchants)
+	if result.Err != nil {
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO batch_op"); err != nil {
+			return models.TxBatchResult{}, fmt.Errorf("failed to roll back to savepoint: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE batch_op"); err != nil {
+		return models.TxBatchResult{}, fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return result, nil
+}
+
+// validateBatchOp makes the checks that binding tags and path parameters make on the
+// single-transaction routes.
+func validateBatchOp(op models.TxBatchOp) error {
+	switch op.Op {
+	case models.BatchOpCreate:
+		if op.ID != "" {
+			return fmt.Errorf("%w: create does not take an id", ErrInvalidBatchOp)
+		}
+	case models.BatchOpUpdate, models.BatchOpDelete:
+		if _, err := uuid.Parse(op.ID); err != nil {
+			return fmt.Errorf("%w: %s needs a valid id", ErrInvalidBatchOp, op.Op)
+		}
+	default:
+		return fmt.Errorf("%w: op must be create, update or delete", ErrInvalidBatchOp)
+	}
+
+	if op.Op == models.BatchOpDelete {
+		if op.Transaction != nil {
+			return fmt.Errorf("%w: delete does not take a transaction", ErrInvalidBatchOp)
+		}
+		return nil
+	}
+	req := op.Transaction
+	if req == nil {
+		return fmt.Errorf("%w: %s needs a transaction", ErrInvalidBatchOp, op.Op)
+	}
+	if req.Date == "" || req.Merchant == "" || req.Amount == 0 || (req.DetailedCategory == 0 && len(req.Splits) == 0) {
+		return fmt.Errorf("%w: date, merchant, amount and detailed_category are required", ErrInvalidBatchOp)
+	}
+	return nil
+}
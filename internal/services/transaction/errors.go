@@ -0,0 +1,21 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBatchAborted           = errors.New("not applied because another operation in the batch failed")
+	ErrBatchUnavailable       = errors.New("batch operations are not configured")
+	ErrCategoryRequired       = errors.New("detailed_category is required")
+	ErrCategoryUnavailable    = errors.New("detailed_category is not available")
+	ErrCategorizerUnavailable = errors.New("category suggestions are not configured")
+	ErrInvalidBatchOp         = errors.New("invalid batch operation")
+	ErrInvalidSplit           = errors.New("invalid split")
+	ErrCursorFilterMismatch   = errors.New("cursor does not match filters")
+	ErrDuplicateExternalID    = errors.New("a transaction with this external ID already exists")
+	ErrEmptySearchQuery       = errors.New("search query has no words")
+	ErrSearchQueryTooLong     = fmt.Errorf("search query must be at most %d characters", MaxSearchQueryLength)
+	ErrVersionMismatch        = errors.New("transaction version does not match")
+)
@@ -0,0 +1,66 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// exportPageSize is how many transactions ExportTransactions reads at a time.
+const exportPageSize = 500
+
+// ExportTransactions calls fn with each of the user's transactions that match filter,
+// in the filter's sort order, with its category names filled in. Transactions are read
+// a page at a time, so a long history is never held in memory at once. An error from
+// fn stops the export and is returned as is.
+func (s *TransactionService) ExportTransactions(ctx context.Context, userID uuid.UUID, filter models.TxFilter, fn func(models.TxExport) error) error {
+	if filter.Sort == "" {
+		filter.Sort = models.SortNewestFirst
+	}
+	if filter.Sort != models.SortNewestFirst && filter.Sort != models.SortOldestFirst {
+		return fmt.Errorf("invalid sort direction %q", filter.Sort)
+	}
+	names, err := s.categoryNames(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+
+	var pos *models.TxCursor
+	for {
+		transactions, err := s.listTransactions(ctx, listParams(userID, filter, pos, exportPageSize), filter.Sort == models.SortNewestFirst)
+		if err != nil {
+			return err
+		}
+		for _, txn := range transactions {
+			names := names[txn.DetailedCategory]
+			if err := fn(models.TxExport{Tx: txn, PrimaryCategoryName: names[0], DetailedCategoryName: names[1]}); err != nil {
+				return err
+			}
+		}
+		if len(transactions) < exportPageSize {
+			return nil
+		}
+		last := transactions[len(transactions)-1]
+		pos = &models.TxCursor{Date: last.Date, ID: last.ID}
+	}
+}
+
+// categoryNames maps each detailed category ID the user can see to its primary and
+// detailed names, with the user's renames applied.
+func (s *TransactionService) categoryNames(ctx context.Context, userID string) (map[int64][2]string, error) {
+	categories, err := s.txQueries.ListUserCategories(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading categories: %w", err)
+	}
+	names := make(map[int64][2]string, len(categories))
+	for _, c := range categories {
+		name := c.Name
+		if c.OverrideName.Valid {
+			name = c.OverrideName.String
+		}
+		names[c.ID] = [2]string{c.PrimaryCategoryName, name}
+	}
+	return names, nil
+}
@@ -0,0 +1,25 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+// RuleLoader loads a user's categorization rules. The returned function applies them
+// to a new transaction in place.
+type RuleLoader interface {
+	UserRules(ctx context.Context, userID string) (func(*models.NewTxRequest), error)
+}
+
+// Categorizer suggests categories and learns them from the user's transactions.
+type Categorizer interface {
+	Suggest(ctx context.Context, userID string, txn models.Tx) ([]models.CategorySuggestion, error)
+	Learn(ctx context.Context, userID string, txn models.Tx) error
+	Forget(ctx context.Context, userID, txnID string) error
+}
+
+// MerchantResolver links merchant strings to the user's canonical merchants.
+type MerchantResolver interface {
+	Resolve(ctx context.Context, userID, raw string) (models.Merchant, error)
+}
@@ -5,84 +5,457 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"html"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/seanhuebl/unity-wealth/internal/database"
 	"github.com/seanhuebl/unity-wealth/internal/helpers"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
 	"go.uber.org/zap"
 )
 
+const (
+	// MaxSearchQueryLength bounds the free text accepted by SearchTransactions.
+	MaxSearchQueryLength = 200
+	maxSearchTerms       = 10
+)
+
 type TransactionService struct {
 	txQueries database.TransactionQuerier
 	logger    *zap.Logger
+	// Cursors signs page cursors. The default uses a random key, so cursors do not
+	// survive a restart or work across instances.
+	Cursors *pagination.CursorCodec
+	// SqlTxQuerier runs batches and the writes of single transactions in a database
+	// transaction. Nil disables BatchTransactions, and single writes then run without
+	// one.
+	SqlTxQuerier database.SqlTxQuerier
+	// Rules runs the user's categorization rules on new transactions. Nil leaves them
+	// as sent.
+	Rules RuleLoader
+	// Categorizer picks the category of new transactions sent without one and learns
+	// from every write. Nil makes the category required.
+	Categorizer Categorizer
+	// Merchants links transactions to the user's canonical merchants as they are
+	// written. Nil stores the merchant as sent.
+	Merchants MerchantResolver
 }
 
 func NewTransactionService(txQueries database.TransactionQuerier, logger *zap.Logger) *TransactionService {
-	return &TransactionService{txQueries: txQueries, logger: logger}
+	return &TransactionService{txQueries: txQueries, logger: logger, Cursors: pagination.NewCursorCodec(nil)}
 }
 
 func (s *TransactionService) CreateTransaction(ctx context.Context, userID string, req models.NewTxRequest) (*models.Tx, error) {
+	applyRules, err := s.userRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	applyRules(&req)
+	merchant, err := s.merchant(ctx, userID, req.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	// A split transaction takes the category of its first line.
+	split := len(req.Splits) > 0
+	if req.DetailedCategory == 0 && !split && merchant.DefaultCategoryID.Valid {
+		req.DetailedCategory = merchant.DefaultCategoryID.Int64
+	}
+
+	var suggestions []models.CategorySuggestion
+	if req.DetailedCategory == 0 && !split {
+		if s.Categorizer == nil {
+			return nil, ErrCategoryRequired
+		}
+		suggestions, err = s.Categorizer.Suggest(ctx, userID, models.Tx{Merchant: merchantName(merchant, req.Merchant), Amount: req.Amount})
+		if err != nil {
+			return nil, fmt.Errorf("unable to suggest a category: %w", err)
+		}
+		if len(suggestions) == 0 {
+			return nil, fmt.Errorf("%w: no category could be suggested for this merchant", ErrCategoryRequired)
+		}
+		req.DetailedCategory = suggestions[0].DetailedCategory
+	}
+
+	var txn *models.Tx
+	err = s.inTx(ctx, func(q database.TransactionQuerier) error {
+		txn, err = s.createTransaction(ctx, q, userID, req, merchant)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	txn.Suggestions = suggestions
+	s.learn(ctx, userID, txn)
+	return txn, nil
+}
+
+// SuggestCategories returns the categories the categorizer suggests for one of the
+// user's transactions, most likely first.
+func (s *TransactionService) SuggestCategories(ctx context.Context, userID, txnID string) ([]models.CategorySuggestion, error) {
+	if s.Categorizer == nil {
+		return nil, ErrCategorizerUnavailable
+	}
+	txn, err := s.GetTransactionByID(ctx, userID, txnID)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := s.Categorizer.Suggest(ctx, userID, *txn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to suggest a category: %w", err)
+	}
+	return suggestions, nil
+}
+
+// learn trains the categorizer on a transaction that was just written. The write has
+// succeeded by then, so a failure is only logged; the categorizer catches up on
+// transactions it missed.
+func (s *TransactionService) learn(ctx context.Context, userID string, txn *models.Tx) {
+	if s.Categorizer == nil {
+		return
+	}
+	if err := s.Categorizer.Learn(ctx, userID, *txn); err != nil {
+		s.logger.Warn("failed to train category model", zap.String("transaction_id", txn.ID), zap.Error(err))
+	}
+}
+
+// forget takes a deleted transaction out of the categorizer, logging a failure like
+// learn.
+func (s *TransactionService) forget(ctx context.Context, userID, txnID string) {
+	if s.Categorizer == nil {
+		return
+	}
+	if err := s.Categorizer.Forget(ctx, userID, txnID); err != nil {
+		s.logger.Warn("failed to untrain category model", zap.String("transaction_id", txnID), zap.Error(err))
+	}
+}
+
+// merchant resolves raw to the user's canonical merchant. Without a resolver it
+// returns the zero Merchant, and the transaction keeps raw as its merchant.
+func (s *TransactionService) merchant(ctx context.Context, userID, raw string) (models.Merchant, error) {
+	if s.Merchants == nil {
+		return models.Merchant{}, nil
+	}
+	m, err := s.Merchants.Resolve(ctx, userID, raw)
+	if err != nil {
+		return models.Merchant{}, fmt.Errorf("unable to resolve merchant: %w", err)
+	}
+	return m, nil
+}
+
+// merchantName is the name a transaction sent as raw is stored under.
+func merchantName(m models.Merchant, raw string) string {
+	if m.ID == "" {
+		return raw
+	}
+	return m.Name
+}
+
+// userRules loads the function that runs the user's rules on a new transaction.
+func (s *TransactionService) userRules(ctx context.Context, userID string) (func(*models.NewTxRequest), error) {
+	if s.Rules == nil {
+		return func(*models.NewTxRequest) {}, nil
+	}
+	applyRules, err := s.Rules.UserRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load rules: %w", err)
+	}
+	return applyRules, nil
+}
+
+func (s *TransactionService) createTransaction(ctx context.Context, q database.TransactionQuerier, userID string, req models.NewTxRequest, merchant models.Merchant) (*models.Tx, error) {
 	_, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return nil, fmt.Errorf("invalid date format: %w", err)
 	}
-	tx := models.NewTransaction(uuid.NewString(), userID, req.Date, req.Merchant, req.Amount, req.DetailedCategory)
-	if err := s.txQueries.CreateTransaction(ctx, database.CreateTransactionParams{
+	if err := validateSplits(&req); err != nil {
+		return nil, err
+	}
+	externalID := sql.NullString{String: req.ExternalID, Valid: req.ExternalID != ""}
+	if externalID.Valid {
+		_, err := q.GetTransactionIDByExternalID(ctx, database.GetTransactionIDByExternalIDParams{UserID: userID, ExternalID: externalID})
+		if err == nil {
+			return nil, ErrDuplicateExternalID
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("unable to check external ID: %w", err)
+		}
+	}
+	tx := models.NewTransaction(uuid.NewString(), userID, req.Date, merchantName(merchant, req.Merchant), req.Amount, req.DetailedCategory)
+	tx.MerchantID = merchant.ID
+	tx.RawMerchant = req.Merchant
+	tx.Notes = req.Notes
+	tx.Tags = splitTags(joinTags(req.Tags))
+	tx.Splits = req.Splits
+	if err := q.CreateTransaction(ctx, database.CreateTransactionParams{
 		ID:                 tx.ID,
 		UserID:             tx.UserID,
 		TransactionDate:    tx.Date,
 		Merchant:           tx.Merchant,
 		AmountCents:        helpers.ConvertToCents(req.Amount),
 		DetailedCategoryID: tx.DetailedCategory,
+		Notes:              tx.Notes,
+		Tags:               joinTags(tx.Tags),
+		ExternalID:         externalID,
+		MerchantID:         nullString(merchant.ID),
+		RawMerchant:        req.Merchant,
 	}); err != nil {
+		if err = firstLineError(writeError(err), req.Splits); errors.Is(err, ErrInvalidSplit) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("unable to create transaction: %w", err)
 	}
+	if err := createSplits(ctx, q, tx.ID, req.Splits); err != nil {
+		return nil, err
+	}
 	return tx, nil
 }
 
-func (s *TransactionService) UpdateTransaction(ctx context.Context, txnID, userID string, req models.NewTxRequest) (*models.Tx, error) {
+// UpdateTransaction replaces one of the user's transactions. A non-zero
+// expectedVersion makes the write conditional: ErrVersionMismatch is returned when the
+// stored version differs.
+func (s *TransactionService) UpdateTransaction(ctx context.Context, txnID, userID string, req models.NewTxRequest, expectedVersion int64) (*models.Tx, error) {
+	merchant, err := s.merchant(ctx, userID, req.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	var txn *models.Tx
+	err = s.inTx(ctx, func(q database.TransactionQuerier) error {
+		txn, err = s.updateTransaction(ctx, q, txnID, userID, req, expectedVersion, merchant)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.learn(ctx, userID, txn)
+	return txn, nil
+}
 
+// updateTransaction stores req as the transaction, linked to merchant. A client that
+// sends back the canonical name it read keeps the raw merchant string stored before.
+// The splits of the transaction are replaced by those of req.
+func (s *TransactionService) updateTransaction(ctx context.Context, q database.TransactionQuerier, txnID, userID string, req models.NewTxRequest, expectedVersion int64, merchant models.Merchant) (*models.Tx, error) {
 	_, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return nil, fmt.Errorf("invalid date format: %w", err)
 	}
+	if err := validateSplits(&req); err != nil {
+		return nil, err
+	}
+	if req.DetailedCategory == 0 {
+		return nil, ErrCategoryRequired
+	}
 
-	txRow, err := s.txQueries.UpdateTransactionByID(ctx, database.UpdateTransactionByIDParams{
+	// The old lines go first, so the schema does not hold the new amount and
+	// category against them.
+	if err := q.DeleteTransactionSplits(ctx, database.DeleteTransactionSplitsParams{TransactionID: txnID, UserID: userID}); err != nil {
+		return nil, fmt.Errorf("error deleting splits: %w", err)
+	}
+	txRow, err := q.UpdateTransactionByID(ctx, database.UpdateTransactionByIDParams{
 		TransactionDate:    req.Date,
-		Merchant:           req.Merchant,
+		Merchant:           merchantName(merchant, req.Merchant),
+		MerchantID:         nullString(merchant.ID),
+		RawMerchant:        req.Merchant,
 		AmountCents:        helpers.ConvertToCents(req.Amount),
 		DetailedCategoryID: req.DetailedCategory,
+		Notes:              req.Notes,
+		Tags:               joinTags(req.Tags),
 		UpdatedAt:          sql.NullTime{Time: time.Now(), Valid: true},
 		ID:                 txnID,
+		UserID:             userID,
+		ExpectedVersion:    nullInt64(expectedVersion),
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("transaction not found: %w", err)
+			return nil, noRowsError(ctx, q, txnID, userID, expectedVersion, err)
+		}
+		if err = firstLineError(writeError(err), req.Splits); errors.Is(err, ErrInvalidSplit) {
+			return nil, err
 		}
 		return nil, fmt.Errorf("error updating transaction: %w", err)
 	}
+	if err := createSplits(ctx, q, txnID, req.Splits); err != nil {
+		return nil, err
+	}
 	txn := models.Tx{
 		ID:               txRow.ID,
 		UserID:           userID,
 		Date:             txRow.TransactionDate,
 		Merchant:         txRow.Merchant,
+		MerchantID:       txRow.MerchantID.String,
+		RawMerchant:      txRow.RawMerchant,
 		Amount:           helpers.CentsToDollars(txRow.AmountCents),
 		DetailedCategory: txRow.DetailedCategoryID,
+		Notes:            txRow.Notes,
+		Tags:             splitTags(txRow.Tags),
+		UpdatedAt:        timePtr(txRow.UpdatedAt),
+		Version:          txRow.Version,
+		Splits:           req.Splits,
 	}
 
 	return &txn, err
 }
 
-func (s *TransactionService) DeleteTransaction(ctx context.Context, txnID, userID string) error {
-	_, err := s.txQueries.DeleteTransactionByID(ctx, database.DeleteTransactionByIDParams{
-		ID:     txnID,
-		UserID: userID,
+// PatchTransaction applies a merge patch to one of the user's transactions and returns
+// the whole transaction, splits included, as stored afterwards. Only the fields set in
+// patch change. expectedVersion works as in UpdateTransaction.
+func (s *TransactionService) PatchTransaction(ctx context.Context, txnID, userID string, patch models.TxPatch, expectedVersion int64) (*models.Tx, error) {
+	params, err := s.patchParams(ctx, txnID, userID, patch, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	var txn *models.Tx
+	err = s.inTx(ctx, func(q database.TransactionQuerier) error {
+		txn, err = patchTransaction(ctx, q, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.learn(ctx, userID, txn)
+	return txn, nil
+}
+
+// PatchTransactions applies ops to the user's transactions in one database
+// transaction. The Version of each op works as expectedVersion does in
+// PatchTransaction. An op the database refuses, because its transaction changed or
+// went away or its category is no longer available, is left out and its error put at
+// its index in the returned slice. Any other failure saves nothing and is returned.
+func (s *TransactionService) PatchTransactions(ctx context.Context, userID string, ops []models.TxPatchOp) ([]error, error) {
+	params := make([]database.PatchTransactionByIDParams, 0, len(ops))
+	for _, op := range ops {
+		p, err := s.patchParams(ctx, op.ID, userID, op.Patch, op.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update transaction %s: %w", op.ID, err)
+		}
+		params = append(params, p)
+	}
+	errs := make([]error, len(ops))
+	txns := make([]*models.Tx, 0, len(ops))
+	err := s.inTx(ctx, func(q database.TransactionQuerier) error {
+		for i, p := range params {
+			txn, err := patchTransaction(ctx, q, p)
+			if refusedWrite(err) {
+				errs[i] = err
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to update transaction %s: %w", p.ID, err)
+			}
+			txns = append(txns, txn)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, txn := range txns {
+		s.learn(ctx, userID, txn)
+	}
+	return errs, nil
+}
+
+// refusedWrite reports whether the database turned a single-row write down without
+// writing anything: the row changed or went away, or its category is not available.
+func refusedWrite(err error) bool {
+	return errors.Is(err, ErrVersionMismatch) || errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrCategoryUnavailable)
+}
+
+// patchParams turns patch into the write PatchTransactionByID makes.
+func (s *TransactionService) patchParams(ctx context.Context, txnID, userID string, patch models.TxPatch, expectedVersion int64) (database.PatchTransactionByIDParams, error) {
+	params := database.PatchTransactionByIDParams{
+		UpdatedAt:       sql.NullTime{Time: time.Now(), Valid: true},
+		ID:              txnID,
+		UserID:          userID,
+		ExpectedVersion: nullInt64(expectedVersion),
+	}
+	if patch.Date != nil {
+		if _, err := time.Parse("2006-01-02", *patch.Date); err != nil {
+			return params, fmt.Errorf("invalid date format: %w", err)
+		}
+		params.TransactionDate = sql.NullString{String: *patch.Date, Valid: true}
+	}
+	if patch.Merchant != nil {
+		merchant, err := s.merchant(ctx, userID, *patch.Merchant)
+		if err != nil {
+			return params, err
+		}
+		params.Merchant = sql.NullString{String: merchantName(merchant, *patch.Merchant), Valid: true}
+		params.MerchantID = nullString(merchant.ID)
+		params.RawMerchant = sql.NullString{String: *patch.Merchant, Valid: true}
+	}
+	if patch.Amount != nil {
+		params.AmountCents = sql.NullInt64{Int64: helpers.ConvertToCents(*patch.Amount), Valid: true}
+	}
+	if patch.DetailedCategory != nil {
+		params.DetailedCategoryID = sql.NullInt64{Int64: *patch.DetailedCategory, Valid: true}
+	}
+	if patch.Notes != nil {
+		params.Notes = sql.NullString{String: *patch.Notes, Valid: true}
+	}
+	if patch.Tags != nil {
+		params.Tags = sql.NullString{String: joinTags(*patch.Tags), Valid: true}
+	}
+	return params, nil
+}
+
+// patchTransaction makes the write in params and returns the transaction, splits
+// included, as stored afterwards.
+func patchTransaction(ctx context.Context, q database.TransactionQuerier, params database.PatchTransactionByIDParams) (*models.Tx, error) {
+	row, err := q.PatchTransactionByID(ctx, params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, noRowsError(ctx, q, params.ID, params.UserID, params.ExpectedVersion.Int64, err)
+		}
+		if err = writeError(err); errors.Is(err, ErrInvalidSplit) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error patching transaction: %w", err)
+	}
+	splits, err := listSplits(ctx, q, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Tx{
+		ID:               row.ID,
+		UserID:           row.UserID,
+		Date:             row.TransactionDate,
+		Merchant:         row.Merchant,
+		MerchantID:       row.MerchantID.String,
+		RawMerchant:      row.RawMerchant,
+		Amount:           helpers.CentsToDollars(row.AmountCents),
+		DetailedCategory: row.DetailedCategoryID,
+		Notes:            row.Notes,
+		Tags:             splitTags(row.Tags),
+		UpdatedAt:        timePtr(row.UpdatedAt),
+		Version:          row.Version,
+		Splits:           splits,
+	}, nil
+}
+
+// DeleteTransaction deletes one of the user's transactions. expectedVersion works as
+// in UpdateTransaction.
+func (s *TransactionService) DeleteTransaction(ctx context.Context, txnID, userID string, expectedVersion int64) error {
+	if err := s.deleteTransaction(ctx, s.txQueries, txnID, userID, expectedVersion); err != nil {
+		return err
+	}
+	s.forget(ctx, userID, txnID)
+	return nil
+}
+
+func (s *TransactionService) deleteTransaction(ctx context.Context, q database.TransactionQuerier, txnID, userID string, expectedVersion int64) error {
+	_, err := q.DeleteTransactionByID(ctx, database.DeleteTransactionByIDParams{
+		ID:              txnID,
+		UserID:          userID,
+		ExpectedVersion: nullInt64(expectedVersion),
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("transaction not found: %w", err)
+			return noRowsError(ctx, q, txnID, userID, expectedVersion, err)
 		}
 		return fmt.Errorf("error deleting transaction: %w", err)
 	}
@@ -102,89 +475,335 @@ func (s *TransactionService) GetTransactionByID(ctx context.Context, userID, txn
 		UserID:           row.UserID,
 		Date:             row.TransactionDate,
 		Merchant:         row.Merchant,
+		MerchantID:       row.MerchantID.String,
+		RawMerchant:      row.RawMerchant,
 		Amount:           helpers.CentsToDollars(row.AmountCents),
 		DetailedCategory: row.DetailedCategoryID,
+		Notes:            row.Notes,
+		Tags:             splitTags(row.Tags),
+		Version:          row.Version,
+	}
+	if txn.Splits, err = listSplits(ctx, s.txQueries, txnID); err != nil {
+		return nil, err
 	}
 	return &txn, nil
 }
 
+// ListUserTransactions returns one page of the user's transactions that match filter,
+// ordered by date and then ID in the filter's sort direction. cursor is a NextCursor or
+// PrevCursor from an earlier page, or empty for the first page. A cursor carries the
+// filter it was made with; a different non-empty filter is rejected.
 func (s *TransactionService) ListUserTransactions(
 	ctx context.Context,
 	userID uuid.UUID,
-	cursorDate *string,
-	cursorID *string,
+	filter models.TxFilter,
+	cursor string,
 	pageSize int64,
-) (transactions []models.Tx, nextCursorDate, nextCursorID string, hasMoreData bool, err error) {
+) (models.TxPage, error) {
 	if pageSize <= 0 {
 		err := errors.New("pageSize <= 0")
-		return nil, "", "", false, fmt.Errorf("pageSize must be a positive integer: %w", err)
+		return models.TxPage{}, fmt.Errorf("pageSize must be a positive integer: %w", err)
 	}
-	transactions = make([]models.Tx, 0, pageSize)
-	fetchSize := pageSize + 1
-	if cursorDate == nil || cursorID == nil {
-		firstPageRows, err := s.txQueries.GetUserTransactionsFirstPage(ctx, database.GetUserTransactionsFirstPageParams{UserID: userID.String(), Limit: fetchSize})
-		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, "", "", false, fmt.Errorf("no transactions found: %w", err)
+	if filter != (models.TxFilter{}) && filter.Sort == "" {
+		filter.Sort = models.SortNewestFirst
+	}
+
+	var pos *models.TxCursor
+	if cursor != "" {
+		var c models.TxCursor
+		if err := s.Cursors.Decode(cursor, &c); err != nil {
+			return models.TxPage{}, fmt.Errorf("failed to decode cursor: %w", err)
+		}
+		if filter != (models.TxFilter{}) && filter != c.Filter {
+			return models.TxPage{}, ErrCursorFilterMismatch
+		}
+		filter = c.Filter
+		pos = &c
+	}
+	if filter.Sort == "" {
+		filter.Sort = models.SortNewestFirst
+	}
+	if filter.Sort != models.SortNewestFirst && filter.Sort != models.SortOldestFirst {
+		return models.TxPage{}, fmt.Errorf("invalid sort direction %q", filter.Sort)
+	}
+
+	// A backward page is read in the opposite order, starting at the cursor, and then
+	// flipped.
+	backward := pos != nil && pos.Backward
+	newestFirst := (filter.Sort == models.SortNewestFirst) != backward
+	transactions, err := s.listTransactions(ctx, listParams(userID, filter, pos, pageSize+1), newestFirst)
+	if err != nil {
+		return models.TxPage{}, err
+	}
+
+	hasMoreData := int64(len(transactions)) > pageSize
+	if hasMoreData {
+		transactions = transactions[:pageSize]
+	}
+	if backward {
+		slices.Reverse(transactions)
+	}
+
+	page := models.TxPage{Transactions: transactions}
+	if len(transactions) == 0 {
+		return page, nil
+	}
+	first, last := transactions[0], transactions[len(transactions)-1]
+	if backward {
+		// Going back from a cursor always leaves the cursor's own page ahead.
+		page.HasMoreData = true
+		if page.NextCursor, err = s.encodeCursor(last, false, filter); err != nil {
+			return models.TxPage{}, err
+		}
+		if hasMoreData {
+			if page.PrevCursor, err = s.encodeCursor(first, true, filter); err != nil {
+				return models.TxPage{}, err
 			}
-			return nil, "", "", false, fmt.Errorf("error loading first page of transactions: %w", err)
 		}
-		for _, txn := range firstPageRows {
-			transactions = append(transactions, ConvertFirstPageRow(txn))
+		return page, nil
+	}
+
+	page.HasMoreData = hasMoreData
+	if hasMoreData {
+		if page.NextCursor, err = s.encodeCursor(last, false, filter); err != nil {
+			return models.TxPage{}, err
+		}
+	}
+	if pos != nil {
+		if page.PrevCursor, err = s.encodeCursor(first, true, filter); err != nil {
+			return models.TxPage{}, err
 		}
-	} else {
-		nextRows, err := s.txQueries.GetUserTransactionsPaginated(ctx, database.GetUserTransactionsPaginatedParams{
-			UserID:          userID.String(),
-			TransactionDate: *cursorDate,
-			ID:              *cursorID,
-			Limit:           fetchSize,
+	}
+	return page, nil
+}
+
+// SearchTransactions runs a full-text search over the merchant, notes and tags of the
+// user's transactions and returns at most limit results, best match first. Every word
+// in query must match, and each is matched as a prefix, so "blu bot" finds
+// "SQ *BLUE BOTTLE 0423 SF".
+func (s *TransactionService) SearchTransactions(ctx context.Context, userID uuid.UUID, query string, limit int64) ([]models.TxSearchResult, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be a positive integer")
+	}
+	match, err := buildMatchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.txQueries.SearchUserTransactions(ctx, database.SearchUserTransactionsParams{
+		Query:     match,
+		UserID:    userID.String(),
+		PageLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching transactions: %w", err)
+	}
+	results := make([]models.TxSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, models.TxSearchResult{
+			Tx: models.Tx{
+				ID:               row.ID,
+				UserID:           row.UserID,
+				Date:             row.TransactionDate,
+				Merchant:         row.Merchant,
+				Amount:           helpers.CentsToDollars(row.AmountCents),
+				DetailedCategory: row.DetailedCategoryID,
+				Notes:            row.Notes,
+				Tags:             splitTags(row.Tags),
+			},
+			Snippet: highlightSnippet(row.Snippet),
 		})
+	}
+	return results, nil
+}
+
+// noRowsError explains a conditional write that matched no row. The write itself
+// checked the version, so this lookup only picks the error to report.
+func noRowsError(ctx context.Context, q database.TransactionQuerier, txnID, userID string, expectedVersion int64, err error) error {
+	if expectedVersion != 0 {
+		if _, getErr := q.GetUserTransactionByID(ctx, database.GetUserTransactionByIDParams{UserID: userID, ID: txnID}); getErr == nil {
+			return ErrVersionMismatch
+		}
+	}
+	return fmt.Errorf("transaction not found: %w", err)
+}
 
+func (s *TransactionService) listTransactions(ctx context.Context, params database.ListUserTransactionsNewestFirstParams, newestFirst bool) ([]models.Tx, error) {
+	transactions := make([]models.Tx, 0, params.PageLimit)
+	if newestFirst {
+		rows, err := s.txQueries.ListUserTransactionsNewestFirst(ctx, params)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				return nil, "", "", false, fmt.Errorf("no transactions found: %w", err)
+				return nil, fmt.Errorf("no transactions found: %w", err)
 			}
-			return nil, "", "", false, fmt.Errorf("error loading next page: %w", err)
+			return nil, fmt.Errorf("error loading transactions: %w", err)
 		}
-		for _, txn := range nextRows {
-			transactions = append(transactions, ConvertPaginatedRow(txn))
+		for _, txn := range rows {
+			transactions = append(transactions, ConvertNewestFirstRow(txn))
 		}
-
+		return transactions, nil
 	}
 
-	if int64(len(transactions)) > pageSize {
-		hasMoreData = true
-		lastTxn := transactions[pageSize-1]
-		nextCursorDate = lastTxn.Date
-		nextCursorID = lastTxn.ID
-		transactions = transactions[:pageSize]
-
-	} else {
-		hasMoreData = false
+	rows, err := s.txQueries.ListUserTransactionsOldestFirst(ctx, database.ListUserTransactionsOldestFirstParams(params))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no transactions found: %w", err)
+		}
+		return nil, fmt.Errorf("error loading transactions: %w", err)
 	}
+	for _, txn := range rows {
+		transactions = append(transactions, ConvertOldestFirstRow(txn))
+	}
+	return transactions, nil
+}
 
-	return transactions, nextCursorDate, nextCursorID, hasMoreData, nil
+func (s *TransactionService) encodeCursor(txn models.Tx, backward bool, filter models.TxFilter) (string, error) {
+	return s.Cursors.Encode(models.TxCursor{
+		Date:     txn.Date,
+		ID:       txn.ID,
+		Backward: backward,
+		Filter:   filter,
+	})
 }
 
 // Helpers
-func ConvertFirstPageRow(row database.GetUserTransactionsFirstPageRow) models.Tx {
+func ConvertNewestFirstRow(row database.ListUserTransactionsNewestFirstRow) models.Tx {
 	return models.Tx{
 		ID:               row.ID,
 		UserID:           row.UserID,
 		Date:             row.TransactionDate,
 		Merchant:         row.Merchant,
+		MerchantID:       row.MerchantID.String,
+		RawMerchant:      row.RawMerchant,
 		Amount:           helpers.CentsToDollars(row.AmountCents),
 		DetailedCategory: row.DetailedCategoryID,
+		Notes:            row.Notes,
+		Tags:             splitTags(row.Tags),
+		Version:          row.Version,
 	}
 }
 
-func ConvertPaginatedRow(row database.GetUserTransactionsPaginatedRow) models.Tx {
+func ConvertOldestFirstRow(row database.ListUserTransactionsOldestFirstRow) models.Tx {
 	return models.Tx{
 		ID:               row.ID,
 		UserID:           row.UserID,
 		Date:             row.TransactionDate,
 		Merchant:         row.Merchant,
+		MerchantID:       row.MerchantID.String,
+		RawMerchant:      row.RawMerchant,
 		Amount:           helpers.CentsToDollars(row.AmountCents),
 		DetailedCategory: row.DetailedCategoryID,
+		Notes:            row.Notes,
+		Tags:             splitTags(row.Tags),
+		Version:          row.Version,
+	}
+}
+
+// buildMatchQuery turns free text into an FTS5 query that matches every word as a
+// prefix. Only letters and digits are kept, so the user cannot inject FTS5 syntax.
+func buildMatchQuery(query string) (string, error) {
+	if len(query) > MaxSearchQueryLength {
+		return "", ErrSearchQueryTooLong
 	}
+	terms := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) == 0 {
+		return "", ErrEmptySearchQuery
+	}
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	for i, term := range terms {
+		terms[i] = `"` + term + `"*`
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// snippetMarks swaps the control characters SearchUserTransactions puts around each
+// hit for <mark> tags.
+var snippetMarks = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+// highlightSnippet escapes the text of a snippet before marking its hits, so a
+// merchant or note can never turn into markup.
+func highlightSnippet(snippet string) string {
+	return snippetMarks.Replace(html.EscapeString(snippet))
+}
+
+// joinTags normalizes tags to trimmed, lower case, unique values and joins them with
+// commas for storage.
+func joinTags(tags []string) string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, ",", " ")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return strings.Join(normalized, ",")
+}
+
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	return strings.Split(tags, ",")
+}
+
+func listParams(userID uuid.UUID, filter models.TxFilter, pos *models.TxCursor, limit int64) database.ListUserTransactionsNewestFirstParams {
+	params := database.ListUserTransactionsNewestFirstParams{
+		UserID:             userID.String(),
+		StartDate:          nullString(filter.StartDate),
+		EndDate:            nullString(filter.EndDate),
+		Merchant:           nullString(escapeLike(filter.Merchant)),
+		PrimaryCategoryID:  nullInt64(filter.PrimaryCategoryID),
+		DetailedCategoryID: nullInt64(filter.DetailedCategoryID),
+		MinAmountCents:     nullInt64(filter.MinAmountCents),
+		MaxAmountCents:     nullInt64(filter.MaxAmountCents),
+		Flow:               nullString(filter.Flow),
+		PageLimit:          limit,
+	}
+	if pos != nil {
+		params.CursorDate = sql.NullString{String: pos.Date, Valid: true}
+		params.CursorID = sql.NullString{String: pos.ID, Valid: true}
+	}
+	return params
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// writeError turns the database refusing a write into the service's errors. The
+// schema only lets a transaction into a system category or an active category of its
+// own user, and keeps the amount and category of a split transaction in line with its
+// splits.
+func writeError(err error) error {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, ErrCategoryUnavailable.Error()):
+		return fmt.Errorf("%w: it is archived or belongs to another user", ErrCategoryUnavailable)
+	case strings.Contains(msg, "splits must sum to amount_cents"):
+		return fmt.Errorf("%w: the amount of a split transaction changes with its lines", ErrInvalidSplit)
+	case strings.Contains(msg, "a split transaction has the category of its first line"):
+		return fmt.Errorf("%w: the category of a split transaction changes with its lines", ErrInvalidSplit)
+	}
+	return err
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt64(n int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: n, Valid: n != 0}
+}
+
+// escapeLike escapes LIKE wildcards so the merchant filter matches them literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
@@ -0,0 +1,125 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/helpers"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
+
+const (
+	// MaxSplitLines bounds the lines of a split transaction.
+	MaxSplitLines      = 50
+	maxSplitMemoLength = 200
+)
+
+// validateSplits checks the lines of a split transaction and gives it the category of
+// its first line. Errors name the offending line, counting from 1, and are returned
+// unwrapped so handlers can show them as they are.
+func validateSplits(req *models.NewTxRequest) error {
+	if len(req.Splits) == 0 {
+		return nil
+	}
+	if len(req.Splits) < 2 || len(req.Splits) > MaxSplitLines {
+		return fmt.Errorf("%w: a split takes 2 to %d lines", ErrInvalidSplit, MaxSplitLines)
+	}
+	var sum int64
+	for i, line := range req.Splits {
+		cents := helpers.ConvertToCents(line.Amount)
+		switch {
+		case cents == 0:
+			return fmt.Errorf("%w: line %d: amount is required", ErrInvalidSplit, i+1)
+		case line.DetailedCategory == 0:
+			return fmt.Errorf("%w: line %d: detailed_category is required", ErrInvalidSplit, i+1)
+		case len([]rune(line.Memo)) > maxSplitMemoLength:
+			return fmt.Errorf("%w: line %d: memo must be at most %d characters", ErrInvalidSplit, i+1, maxSplitMemoLength)
+		}
+		sum += cents
+	}
+	if amount := helpers.ConvertToCents(req.Amount); sum != amount {
+		return fmt.Errorf("%w: lines sum to %.2f but amount is %.2f", ErrInvalidSplit, helpers.CentsToDollars(sum), helpers.CentsToDollars(amount))
+	}
+	req.DetailedCategory = req.Splits[0].DetailedCategory
+	return nil
+}
+
+// createSplits stores the lines of a transaction that has none.
+func createSplits(ctx context.Context, q database.TransactionQuerier, txnID string, splits []models.TxSplit) error {
+	for i, line := range splits {
+		if err := q.CreateTransactionSplit(ctx, database.CreateTransactionSplitParams{
+			TransactionID:      txnID,
+			Line:               int64(i + 1),
+			AmountCents:        helpers.ConvertToCents(line.Amount),
+			DetailedCategoryID: line.DetailedCategory,
+			Memo:               line.Memo,
+		}); err != nil {
+			if err = splitCategoryError(writeError(err), i+1); errors.Is(err, ErrInvalidSplit) {
+				return err
+			}
+			return fmt.Errorf("unable to create split: %w", err)
+		}
+	}
+	return nil
+}
+
+// listSplits loads the lines of a transaction. It returns nil for a transaction that
+// is not split.
+func listSplits(ctx context.Context, q database.TransactionQuerier, txnID string) ([]models.TxSplit, error) {
+	rows, err := q.ListTransactionSplits(ctx, txnID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading splits: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	splits := make([]models.TxSplit, 0, len(rows))
+	for _, row := range rows {
+		splits = append(splits, models.TxSplit{
+			Amount:           helpers.CentsToDollars(row.AmountCents),
+			DetailedCategory: row.DetailedCategoryID,
+			Memo:             row.Memo,
+		})
+	}
+	return splits, nil
+}
+
+// splitCategoryError names the line whose category the database refused.
+func splitCategoryError(err error, line int) error {
+	if errors.Is(err, ErrCategoryUnavailable) {
+		return fmt.Errorf("%w: line %d: %s", ErrInvalidSplit, line, ErrCategoryUnavailable)
+	}
+	return err
+}
+
+// firstLineError blames the first line of a split transaction for the category the
+// database refused, since the transaction takes its category from that line.
+func firstLineError(err error, splits []models.TxSplit) error {
+	if len(splits) == 0 {
+		return err
+	}
+	return splitCategoryError(err, 1)
+}
+
+// inTx runs fn in a database transaction, so a write that fails halfway leaves the
+// transaction and its splits as they were. Without SqlTxQuerier fn runs on the
+// service's own queries.
+func (s *TransactionService) inTx(ctx context.Context, fn func(q database.TransactionQuerier) error) error {
+	if s.SqlTxQuerier == nil {
+		return fn(s.txQueries)
+	}
+	tx, err := s.SqlTxQuerier.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(database.NewRealTransactionQuerier(s.SqlTxQuerier.WithTx(tx))); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBatchTransactions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	txID := uuid.NewString()
+	create := models.TxBatchOp{
+		Op: models.BatchOpCreate,
+		Transaction: &models.NewTxRequest{
+			Date:             "2025-03-05",
+			Merchant:         "costco",
+			Amount:           125.98,
+			DetailedCategory: 40,
+		},
+	}
+	del := models.TxBatchOp{Op: models.BatchOpDelete, ID: txID}
+
+	tests := []struct {
+		name              string
+		mode              string
+		ops               []models.TxBatchOp
+		setup             func(q *dbmocks.SqlTransactionalQuerier)
+		expectsCommit     bool
+		expectedCommitted bool
+		expectedErrs      []error
+	}{
+		{
+			name: "atomic commits",
+			mode: models.BatchModeAtomic,
+			ops:  []models.TxBatchOp{create, del},
+			setup: func(q *dbmocks.SqlTransactionalQuerier) {
+				q.On("CreateTransaction", ctx, mock.MatchedBy(func(p database.CreateTransactionParams) bool {
+					return p.UserID == userID && p.AmountCents == 12598
+				})).Return(nil).Once()
+				q.On("DeleteTransactionByID", ctx, database.DeleteTransactionByIDParams{ID: txID, UserID: userID}).Return(txID, nil).Once()
+			},
+			expectsCommit:     true,
+			expectedCommitted: true,
+			expectedErrs:      []error{nil, nil},
+		},
+		{
+			name: "atomic rolls back on the first failure",
+			mode: models.BatchModeAtomic,
+			ops:  []models.TxBatchOp{create, del, create},
+			setup: func(q *dbmocks.SqlTransactionalQuerier) {
+				q.On("CreateTransaction", ctx, mock.Anything).Return(nil).Once()
+				q.On("DeleteTransactionByID", ctx, mock.Anything).Return("", sql.ErrNoRows).Once()
+			},
+			expectedErrs: []error{transaction.ErrBatchAborted, sql.ErrNoRows, transaction.ErrBatchAborted},
+		},
+		{
+			name: "best effort commits the rest",
+			mode: models.BatchModeBestEffort,
+			ops:  []models.TxBatchOp{{Op: models.BatchOpUpdate, ID: "not-a-uuid"}, del, create},
+			setup: func(q *dbmocks.SqlTransactionalQuerier) {
+				q.On("DeleteTransactionByID", ctx, mock.Anything).Return("", errors.New("db error")).Once()
+				q.On("CreateTransaction", ctx, mock.Anything).Return(nil).Once()
+			},
+			expectsCommit:     true,
+			expectedCommitted: true,
+			expectedErrs:      []error{transaction.ErrInvalidBatchOp, errors.New("error deleting transaction"), nil},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSqlTxQ := dbmocks.NewSqlTxQuerier(t)
+			dummyQueries := dbmocks.NewSqlTransactionalQuerier(t)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			sqlMock.ExpectBegin()
+			if tc.mode == models.BatchModeBestEffort {
+				for _, expected := range tc.expectedErrs {
+					sqlMock.ExpectExec("SAVEPOINT batch_op").WillReturnResult(sqlmock.NewResult(0, 0))
+					if expected != nil {
+						sqlMock.ExpectExec("ROLLBACK TO batch_op").WillReturnResult(sqlmock.NewResult(0, 0))
+					}
+					sqlMock.ExpectExec("RELEASE batch_op").WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			}
+			if tc.expectsCommit {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+			dummyTx, err := db.Begin()
+			require.NoError(t, err)
+
+			mockSqlTxQ.On("BeginTx", ctx, (*sql.TxOptions)(nil)).Return(dummyTx, nil).Once()
+			mockSqlTxQ.On("WithTx", dummyTx).Return(dummyQueries)
+			tc.setup(dummyQueries)
+
+			svc := transaction.NewTransactionService(dbmocks.NewTransactionQuerier(t), zap.NewNop())
+			svc.SqlTxQuerier = mockSqlTxQ
+			resp, err := svc.BatchTransactions(ctx, userID, models.TxBatchRequest{Mode: tc.mode, Operations: tc.ops})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCommitted, resp.Committed)
+			require.Len(t, resp.Results, len(tc.expectedErrs))
+			for i, expected := range tc.expectedErrs {
+				got := resp.Results[i].Err
+				switch {
+				case expected == nil:
+					require.NoError(t, got, i)
+				case errors.Is(got, expected):
+				default:
+					require.ErrorContains(t, got, expected.Error(), i)
+				}
+			}
+			if tc.expectedCommitted && tc.ops[len(tc.ops)-1].Op == models.BatchOpCreate {
+				created := resp.Results[len(tc.ops)-1]
+				require.NotEmpty(t, created.ID)
+				require.Equal(t, created.ID, created.Transaction.ID)
+			}
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBatchTransactionsRejectsBatch(t *testing.T) {
+	ctx := context.Background()
+	op := models.TxBatchOp{Op: models.BatchOpDelete, ID: uuid.NewString()}
+
+	svc := transaction.NewTransactionService(dbmocks.NewTransactionQuerier(t), zap.NewNop())
+	_, err := svc.BatchTransactions(ctx, uuid.NewString(), models.TxBatchRequest{Mode: models.BatchModeAtomic, Operations: []models.TxBatchOp{op}})
+	require.ErrorIs(t, err, transaction.ErrBatchUnavailable)
+
+	svc.SqlTxQuerier = dbmocks.NewSqlTxQuerier(t)
+	for name, batch := range map[string]models.TxBatchRequest{
+		"unknown mode": {Mode: "sometimes", Operations: []models.TxBatchOp{op}},
+		"empty":        {Mode: models.BatchModeAtomic},
+		"too large":    {Mode: models.BatchModeAtomic, Operations: make([]models.TxBatchOp, models.MaxBatchOperations+1)},
+	} {
+		_, err := svc.BatchTransactions(ctx, uuid.NewString(), batch)
+		require.ErrorIs(t, err, transaction.ErrInvalidBatchOp, name)
+	}
+}
@@ -0,0 +1,109 @@
+package transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	txID := uuid.NewString()
+	userID := uuid.NewString()
+	merchant := "costco"
+	getParams := database.GetUserTransactionByIDParams{UserID: userID, ID: txID}
+
+	writes := map[string]func(svc *transaction.TransactionService, mockTxQ *dbmocks.TransactionQuerier, version int64) error{
+		"update": func(svc *transaction.TransactionService, mockTxQ *dbmocks.TransactionQuerier, version int64) error {
+			mockTxQ.On("DeleteTransactionSplits", ctx, database.DeleteTransactionSplitsParams{TransactionID: txID, UserID: userID}).Return(nil)
+			mockTxQ.On("UpdateTransactionByID", ctx, mock.MatchedBy(func(p database.UpdateTransactionByIDParams) bool {
+				return p.ExpectedVersion == sql.NullInt64{Int64: version, Valid: version != 0}
+			})).Return(database.UpdateTransactionByIDRow{}, sql.ErrNoRows)
+			_, err := svc.UpdateTransaction(ctx, txID, userID, models.NewTxRequest{
+				Date:             "2025-03-05",
+				Merchant:         merchant,
+				Amount:           1,
+				DetailedCategory: 40,
+			}, version)
+			return err
+		},
+		"patch": func(svc *transaction.TransactionService, mockTxQ *dbmocks.TransactionQuerier, version int64) error {
+			mockTxQ.On("PatchTransactionByID", ctx, mock.MatchedBy(func(p database.PatchTransactionByIDParams) bool {
+				return p.ExpectedVersion == sql.NullInt64{Int64: version, Valid: version != 0}
+			})).Return(database.PatchTransactionByIDRow{}, sql.ErrNoRows)
+			_, err := svc.PatchTransaction(ctx, txID, userID, models.TxPatch{Merchant: &merchant}, version)
+			return err
+		},
+		"delete": func(svc *transaction.TransactionService, mockTxQ *dbmocks.TransactionQuerier, version int64) error {
+			mockTxQ.On("DeleteTransactionByID", ctx, database.DeleteTransactionByIDParams{
+				ID:              txID,
+				UserID:          userID,
+				ExpectedVersion: sql.NullInt64{Int64: version, Valid: version != 0},
+			}).Return("", sql.ErrNoRows)
+			return svc.DeleteTransaction(ctx, txID, userID, version)
+		},
+	}
+
+	tests := []struct {
+		name            string
+		expectedVersion int64
+		getErr          error
+		expectedErr     error
+		expectedErrText string
+	}{
+		{
+			name:            "stale version",
+			expectedVersion: 2,
+			expectedErr:     transaction.ErrVersionMismatch,
+		},
+		{
+			name:            "missing transaction with If-Match",
+			expectedVersion: 2,
+			getErr:          sql.ErrNoRows,
+			expectedErrText: "transaction not found",
+		},
+		{
+			name:            "lookup fails",
+			expectedVersion: 2,
+			getErr:          errors.New("db error"),
+			expectedErrText: "transaction not found",
+		},
+		{
+			name:            "missing transaction without If-Match",
+			expectedErrText: "transaction not found",
+		},
+	}
+
+	for write, run := range writes {
+		for _, tc := range tests {
+			t.Run(write+": "+tc.name, func(t *testing.T) {
+				mockTxQ := dbmocks.NewTransactionQuerier(t)
+				if tc.expectedVersion != 0 {
+					mockTxQ.On("GetUserTransactionByID", ctx, getParams).
+						Return(database.GetUserTransactionByIDRow{ID: txID, UserID: userID, Version: 3}, tc.getErr)
+				}
+				svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+
+				err := run(svc, mockTxQ, tc.expectedVersion)
+				if tc.expectedErr != nil {
+					require.ErrorIs(t, err, tc.expectedErr)
+				} else {
+					require.Error(t, err)
+					require.NotErrorIs(t, err, transaction.ErrVersionMismatch)
+					require.Contains(t, err.Error(), tc.expectedErrText)
+				}
+				mockTxQ.AssertExpectations(t)
+			})
+		}
+	}
+}
@@ -48,7 +48,7 @@ func TestDeleteTransaction(t *testing.T) {
 
 			svc := transaction.NewTransactionService(mockTxQ, nopLogger)
 
-			err := svc.DeleteTransaction(ctx, txnID.String(), userID.String())
+			err := svc.DeleteTransaction(ctx, txnID.String(), userID.String(), 0)
 
 			if tc.deleteErr != nil {
 				require.Error(t, err)
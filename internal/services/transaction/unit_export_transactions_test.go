@@ -0,0 +1,99 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExportTransactions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	stopErr := errors.New("client went away")
+
+	// The first page is full, so the export asks for a second one after its last row.
+	firstPage := make([]database.ListUserTransactionsOldestFirstRow, 500)
+	for i := range firstPage {
+		firstPage[i] = database.ListUserTransactionsOldestFirstRow{
+			ID:                 fmt.Sprintf("txn-%03d", i),
+			UserID:             userID.String(),
+			TransactionDate:    "2025-03-05",
+			Merchant:           "Costco",
+			AmountCents:        12598,
+			DetailedCategoryID: 40,
+		}
+	}
+	secondPage := []database.ListUserTransactionsOldestFirstRow{
+		{ID: "txn-500", UserID: userID.String(), TransactionDate: "2025-03-06", Merchant: "Refund", AmountCents: -1000, DetailedCategoryID: 99, Tags: "returns"},
+	}
+
+	tests := []struct {
+		name          string
+		stopAt        int
+		expectedCount int
+		expectedErr   error
+	}{
+		{name: "all pages", expectedCount: 501},
+		{name: "writer error stops the export", stopAt: 3, expectedCount: 3, expectedErr: stopErr},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			txQ := dbmocks.NewTransactionQuerier(t)
+			txQ.On("ListUserCategories", ctx, userID.String()).Return([]database.ListUserCategoriesRow{
+				{ID: 40, Name: "GROCERIES", PrimaryCategoryID: 7, PrimaryCategoryName: "FOOD_AND_DRINK"},
+			}, nil).Once()
+			txQ.On("ListUserTransactionsOldestFirst", ctx, mock.MatchedBy(func(p database.ListUserTransactionsOldestFirstParams) bool {
+				return !p.CursorID.Valid && p.PageLimit == 500 && p.Merchant.String == "co"
+			})).Return(firstPage, nil).Once()
+			if tc.stopAt == 0 {
+				txQ.On("ListUserTransactionsOldestFirst", ctx, mock.MatchedBy(func(p database.ListUserTransactionsOldestFirstParams) bool {
+					return p.CursorID.String == "txn-499" && p.CursorDate.String == "2025-03-05"
+				})).Return(secondPage, nil).Once()
+			}
+
+			svc := transaction.NewTransactionService(txQ, zap.NewNop())
+			var exported []models.TxExport
+			err := svc.ExportTransactions(ctx, userID, models.TxFilter{Merchant: "co", Sort: models.SortOldestFirst}, func(txn models.TxExport) error {
+				if tc.stopAt != 0 && len(exported) == tc.stopAt {
+					return stopErr
+				}
+				exported = append(exported, txn)
+				return nil
+			})
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Len(t, exported, tc.expectedCount)
+			require.Equal(t, "FOOD_AND_DRINK", exported[0].PrimaryCategoryName)
+			require.Equal(t, "GROCERIES", exported[0].DetailedCategoryName)
+			require.Equal(t, 125.98, exported[0].Amount)
+			if tc.stopAt == 0 {
+				last := exported[len(exported)-1]
+				require.Equal(t, []string{"returns"}, last.Tags)
+				require.Empty(t, last.PrimaryCategoryName)
+			}
+		})
+	}
+}
+
+func TestExportTransactionsCategoryError(t *testing.T) {
+	ctx := context.Background()
+	txQ := dbmocks.NewTransactionQuerier(t)
+	txQ.On("ListUserCategories", ctx, mock.AnythingOfType("string")).Return(nil, errors.New("database is locked")).Once()
+
+	svc := transaction.NewTransactionService(txQ, zap.NewNop())
+	err := svc.ExportTransactions(ctx, uuid.New(), models.TxFilter{}, func(models.TxExport) error {
+		t.Fatal("no transaction should be exported")
+		return nil
+	})
+	require.ErrorContains(t, err, "error loading categories")
+}
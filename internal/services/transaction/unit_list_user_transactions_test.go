@@ -2,8 +2,10 @@ package transaction_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -11,8 +13,8 @@ import (
 	"github.com/seanhuebl/unity-wealth/internal/database"
 	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
 	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
-	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -20,221 +22,297 @@ import (
 
 func TestListUserTransactions(t *testing.T) {
 	ctx := context.Background()
+	userID := uuid.New()
+	cursorID := uuid.NewString()
 	tests := []struct {
-		name                         string
-		userID                       uuid.UUID
-		cursorDate                   *string
-		expectedCursorDate           string
-		cursorID                     *string
-		expectedHasMoreData          bool
-		pageSize                     int64
-		expectedPageSizeErrSubStr    string
-		txSliceLength                int
-		getFirstPageErr              error
-		expectedFirstPageErrSubStr   string
-		getTxPaginatedErr            error
-		expectedTxPaginatedErrSubStr string
+		name             string
+		filter           models.TxFilter
+		cursor           *models.TxCursor
+		rawCursor        string
+		query            string
+		rowCount         int
+		queryErr         error
+		pageSize         int64
+		expectedErr      error
+		expectedErrStr   string
+		expectedHasMore  bool
+		expectedNext     bool
+		expectedPrev     bool
+		expectedReversed bool
 	}{
 		{
-			name:                       "first page, more data, success",
-			userID:                     uuid.New(),
-			cursorDate:                 nil,
-			expectedCursorDate:         "2025-02-05",
-			cursorID:                   nil,
-			expectedHasMoreData:        true,
-			pageSize:                   5,
-			txSliceLength:              10,
-			getFirstPageErr:            nil,
-			expectedFirstPageErrSubStr: "",
-		},
-		{
-			name:                       "first page, no extra data, success",
-			userID:                     uuid.New(),
-			cursorDate:                 nil,
-			expectedCursorDate:         "",
-			cursorID:                   nil,
-			expectedHasMoreData:        false,
-			pageSize:                   5,
-			txSliceLength:              2,
-			getFirstPageErr:            nil,
-			expectedFirstPageErrSubStr: "",
-		},
-		{
-			name:                         "paginated, more data, success",
-			userID:                       uuid.New(),
-			cursorDate:                   testhelpers.StrPtr("2025-02-01"),
-			expectedCursorDate:           "2025-02-10",
-			cursorID:                     testhelpers.StrPtr(uuid.NewString()),
-			expectedHasMoreData:          true,
-			pageSize:                     10,
-			txSliceLength:                15,
-			getTxPaginatedErr:            nil,
-			expectedTxPaginatedErrSubStr: "",
-		},
-		{
-			name:                         "paginated, no extra data, success",
-			userID:                       uuid.New(),
-			cursorDate:                   testhelpers.StrPtr("2025-02-01"),
-			expectedCursorDate:           "",
-			cursorID:                     testhelpers.StrPtr(uuid.NewString()),
-			expectedHasMoreData:          false,
-			pageSize:                     10,
-			txSliceLength:                7,
-			getTxPaginatedErr:            nil,
-			expectedTxPaginatedErrSubStr: "",
-		},
-		{
-			name:                       "first page, db error",
-			userID:                     uuid.New(),
-			cursorDate:                 nil,
-			expectedCursorDate:         "",
-			cursorID:                   nil,
-			pageSize:                   1,
-			getFirstPageErr:            errors.New("db error"),
-			expectedFirstPageErrSubStr: "error loading first page of transactions",
-		},
-		{
-			name:                       "first page, no transactions found",
-			userID:                     uuid.New(),
-			cursorDate:                 nil,
-			expectedCursorDate:         "",
-			cursorID:                   nil,
-			pageSize:                   1,
-			getFirstPageErr:            nil,
-			expectedFirstPageErrSubStr: "",
-		},
-		{
-			name:                         "paginated, db error",
-			userID:                       uuid.New(),
-			cursorDate:                   testhelpers.StrPtr("2025-02-01"),
-			expectedCursorDate:           "",
-			cursorID:                     testhelpers.StrPtr(uuid.NewString()),
-			pageSize:                     1,
-			getTxPaginatedErr:            errors.New("db error"),
-			expectedTxPaginatedErrSubStr: "error loading next page",
-		},
-		{
-			name:                         "paginated, no transactions found",
-			userID:                       uuid.New(),
-			cursorDate:                   testhelpers.StrPtr("2025-02-01"),
-			expectedCursorDate:           "",
-			cursorID:                     testhelpers.StrPtr(uuid.NewString()),
-			pageSize:                     1,
-			getTxPaginatedErr:            nil,
-			expectedTxPaginatedErrSubStr: "",
-		},
-		{
-			name:                      "page size <= 0",
-			userID:                    uuid.New(),
-			pageSize:                  0,
-			expectedPageSizeErrSubStr: "pageSize must be a positive integer",
+			name:            "newest first, first page, more data, success",
+			query:           "ListUserTransactionsNewestFirst",
+			rowCount:        6,
+			pageSize:        5,
+			expectedHasMore: true,
+			expectedNext:    true,
+		},
+		{
+			name:     "newest first, first page, no extra data, success",
+			query:    "ListUserTransactionsNewestFirst",
+			rowCount: 2,
+			pageSize: 5,
+		},
+		{
+			name:            "newest first, next page, more data, success",
+			cursor:          &models.TxCursor{Date: "2025-02-01", ID: cursorID, Filter: models.TxFilter{Sort: models.SortNewestFirst}},
+			query:           "ListUserTransactionsNewestFirst",
+			rowCount:        11,
+			pageSize:        10,
+			expectedHasMore: true,
+			expectedNext:    true,
+			expectedPrev:    true,
+		},
+		{
+			name:         "newest first, next page, last page, success",
+			cursor:       &models.TxCursor{Date: "2025-02-01", ID: cursorID, Filter: models.TxFilter{Sort: models.SortNewestFirst}},
+			query:        "ListUserTransactionsNewestFirst",
+			rowCount:     7,
+			pageSize:     10,
+			expectedPrev: true,
+		},
+		{
+			name:             "newest first, previous page, more data, success",
+			cursor:           &models.TxCursor{Date: "2025-02-01", ID: cursorID, Backward: true, Filter: models.TxFilter{Sort: models.SortNewestFirst}},
+			query:            "ListUserTransactionsOldestFirst",
+			rowCount:         4,
+			pageSize:         3,
+			expectedHasMore:  true,
+			expectedNext:     true,
+			expectedPrev:     true,
+			expectedReversed: true,
+		},
+		{
+			name:             "newest first, previous page, first page, success",
+			cursor:           &models.TxCursor{Date: "2025-02-01", ID: cursorID, Backward: true, Filter: models.TxFilter{Sort: models.SortNewestFirst}},
+			query:            "ListUserTransactionsOldestFirst",
+			rowCount:         2,
+			pageSize:         3,
+			expectedHasMore:  true,
+			expectedNext:     true,
+			expectedReversed: true,
+		},
+		{
+			name:            "oldest first, first page, more data, success",
+			filter:          models.TxFilter{Sort: models.SortOldestFirst},
+			query:           "ListUserTransactionsOldestFirst",
+			rowCount:        6,
+			pageSize:        5,
+			expectedHasMore: true,
+			expectedNext:    true,
+		},
+		{
+			name:             "oldest first, previous page, success",
+			cursor:           &models.TxCursor{Date: "2025-02-01", ID: cursorID, Backward: true, Filter: models.TxFilter{Sort: models.SortOldestFirst}},
+			query:            "ListUserTransactionsNewestFirst",
+			rowCount:         2,
+			pageSize:         3,
+			expectedHasMore:  true,
+			expectedNext:     true,
+			expectedReversed: true,
+		},
+		{
+			name:         "cursor with the same filter, success",
+			filter:       models.TxFilter{Merchant: "costco"},
+			cursor:       &models.TxCursor{Date: "2025-02-01", ID: cursorID, Filter: models.TxFilter{Merchant: "costco", Sort: models.SortNewestFirst}},
+			query:        "ListUserTransactionsNewestFirst",
+			rowCount:     1,
+			pageSize:     3,
+			expectedPrev: true,
+		},
+		{
+			name:        "cursor with a different filter",
+			filter:      models.TxFilter{Merchant: "target"},
+			cursor:      &models.TxCursor{Date: "2025-02-01", ID: cursorID, Filter: models.TxFilter{Merchant: "costco", Sort: models.SortNewestFirst}},
+			pageSize:    3,
+			expectedErr: transaction.ErrCursorFilterMismatch,
+		},
+		{
+			name:        "tampered cursor",
+			rawCursor:   "not-a-cursor",
+			pageSize:    3,
+			expectedErr: pagination.ErrInvalidCursor,
+		},
+		{
+			name:           "db error",
+			query:          "ListUserTransactionsNewestFirst",
+			queryErr:       errors.New("db error"),
+			pageSize:       1,
+			expectedErrStr: "error loading transactions",
+		},
+		{
+			name:     "no transactions found",
+			query:    "ListUserTransactionsNewestFirst",
+			pageSize: 1,
+		},
+		{
+			name:           "invalid sort direction",
+			filter:         models.TxFilter{Sort: "sideways"},
+			pageSize:       1,
+			expectedErrStr: "invalid sort direction",
+		},
+		{
+			name:           "page size <= 0",
+			pageSize:       0,
+			expectedErrStr: "pageSize must be a positive integer",
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockTxQ := dbmocks.NewTransactionQuerier(t)
-			fetchSize := tc.pageSize + 1
-			expectedTxs := make([]models.Tx, 0)
-			nopLogger := zap.NewNop()
-			svc := transaction.NewTransactionService(mockTxQ, nopLogger)
+			svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
 
-			firstPageRows := generateFirstPageRows(tc.userID, tc.txSliceLength)
-
-			if len(firstPageRows) > int(fetchSize) {
-				firstPageRows = firstPageRows[:fetchSize]
+			cursor := tc.rawCursor
+			if tc.cursor != nil {
+				var err error
+				cursor, err = svc.Cursors.Encode(tc.cursor)
+				require.NoError(t, err)
 			}
-			if tc.cursorDate == nil || tc.cursorID == nil {
-				mockTxQ.On("GetUserTransactionsFirstPage", ctx, mock.AnythingOfType("database.GetUserTransactionsFirstPageParams")).Return(firstPageRows, tc.getFirstPageErr).Maybe()
-				transactions, nextCursorDate, nextCursorID, hasMoreData, err := svc.ListUserTransactions(ctx, tc.userID, tc.cursorDate, tc.cursorID, tc.pageSize)
-				if tc.expectedPageSizeErrSubStr != "" {
-					require.Error(t, err)
-					require.Contains(t, err.Error(), tc.expectedPageSizeErrSubStr)
-
-				} else if tc.getFirstPageErr != nil {
-					require.Error(t, err)
-					require.Contains(t, err.Error(), tc.expectedFirstPageErrSubStr)
-					mockTxQ.AssertExpectations(t)
-				} else {
-					require.NoError(t, err)
-					if len(firstPageRows) > int(tc.pageSize) {
-						firstPageRows = firstPageRows[:tc.pageSize]
-					}
-					for _, row := range firstPageRows {
-						expectedTxs = append(expectedTxs, transaction.ConvertFirstPageRow(row))
-					}
-					if hasMoreData == true {
-						require.NotEmpty(t, nextCursorID)
-					}
-					require.Equal(t, tc.expectedCursorDate, nextCursorDate)
-					require.Equal(t, tc.expectedHasMoreData, hasMoreData)
 
-					if diff := cmp.Diff(expectedTxs, transactions); diff != "" {
-						t.Errorf("transaction slice mismatch (-want +got)\n%s", diff)
-					}
-					mockTxQ.AssertExpectations(t)
+			rows := generateRows(userID, tc.rowCount)
+			switch tc.query {
+			case "ListUserTransactionsNewestFirst":
+				mockTxQ.On(tc.query, ctx, mock.AnythingOfType("database.ListUserTransactionsNewestFirstParams")).Return(rows, tc.queryErr).Once()
+			case "ListUserTransactionsOldestFirst":
+				oldestRows := make([]database.ListUserTransactionsOldestFirstRow, 0, len(rows))
+				for _, row := range rows {
+					oldestRows = append(oldestRows, database.ListUserTransactionsOldestFirstRow(row))
 				}
+				mockTxQ.On(tc.query, ctx, mock.AnythingOfType("database.ListUserTransactionsOldestFirstParams")).Return(oldestRows, tc.queryErr).Once()
+			}
 
-			} else {
-				nextRows := generatePaginatedRows(tc.userID, tc.txSliceLength)
-				if len(nextRows) > int(fetchSize) {
-					nextRows = nextRows[:fetchSize]
-				}
-				mockTxQ.On("GetUserTransactionsPaginated", ctx, mock.AnythingOfType("database.GetUserTransactionsPaginatedParams")).Return(nextRows, tc.getTxPaginatedErr).Maybe()
-				transactions, nextCursorDate, nextCursorID, hasMoreData, err := svc.ListUserTransactions(ctx, tc.userID, tc.cursorDate, tc.cursorID, tc.pageSize)
-				if tc.expectedPageSizeErrSubStr != "" {
-					require.Error(t, err)
-					require.Contains(t, err.Error(), tc.expectedPageSizeErrSubStr)
-				} else if tc.getTxPaginatedErr != nil {
-					require.Error(t, err)
-					require.Contains(t, err.Error(), tc.expectedTxPaginatedErrSubStr)
-					mockTxQ.AssertExpectations(t)
-				} else {
-					require.NoError(t, err)
-					if len(nextRows) > int(tc.pageSize) {
-						nextRows = nextRows[:tc.pageSize]
-					}
-					for _, row := range nextRows {
-						expectedTxs = append(expectedTxs, transaction.ConvertPaginatedRow(row))
-					}
-					if hasMoreData == true {
-						require.NotEmpty(t, nextCursorID)
-					}
-					require.Equal(t, tc.expectedCursorDate, nextCursorDate)
-					require.Equal(t, tc.expectedHasMoreData, hasMoreData)
+			page, err := svc.ListUserTransactions(ctx, userID, tc.filter, cursor, tc.pageSize)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.expectedErrStr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrStr)
+				return
+			}
+			require.NoError(t, err)
 
-					if diff := cmp.Diff(expectedTxs, transactions); diff != "" {
-						t.Errorf("transaction slice mismatch (-want +got)\n%s", diff)
-					}
-					mockTxQ.AssertExpectations(t)
-				}
+			if len(rows) > int(tc.pageSize) {
+				rows = rows[:tc.pageSize]
+			}
+			expectedTxs := make([]models.Tx, 0, len(rows))
+			for _, row := range rows {
+				expectedTxs = append(expectedTxs, transaction.ConvertNewestFirstRow(row))
 			}
+			if tc.expectedReversed {
+				slices.Reverse(expectedTxs)
+			}
+			if diff := cmp.Diff(expectedTxs, page.Transactions); diff != "" {
+				t.Errorf("transaction slice mismatch (-want +got)\n%s", diff)
+			}
+			require.Equal(t, tc.expectedHasMore, page.HasMoreData)
+			require.Equal(t, tc.expectedNext, page.NextCursor != "")
+			require.Equal(t, tc.expectedPrev, page.PrevCursor != "")
 
+			if tc.expectedNext {
+				var next models.TxCursor
+				require.NoError(t, svc.Cursors.Decode(page.NextCursor, &next))
+				last := expectedTxs[len(expectedTxs)-1]
+				require.Equal(t, last.Date, next.Date)
+				require.Equal(t, last.ID, next.ID)
+				require.False(t, next.Backward)
+			}
+			if tc.expectedPrev {
+				var prev models.TxCursor
+				require.NoError(t, svc.Cursors.Decode(page.PrevCursor, &prev))
+				require.Equal(t, expectedTxs[0].ID, prev.ID)
+				require.True(t, prev.Backward)
+			}
 		})
 	}
 }
 
-// Helpers
-func generateFirstPageRows(userID uuid.UUID, txSliceLength int) []database.GetUserTransactionsFirstPageRow {
-	var rows []database.GetUserTransactionsFirstPageRow
-	for i := 0; i < txSliceLength; i++ {
-		date := fmt.Sprintf("2025-02-%02d", i+1)
-		rows = append(rows, database.GetUserTransactionsFirstPageRow{
-			ID:                 uuid.NewString(),
-			UserID:             userID.String(),
-			TransactionDate:    date,
-			Merchant:           "costco",
-			AmountCents:        15744,
-			DetailedCategoryID: 40,
+func TestListUserTransactionsParams(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	cursorID := uuid.NewString()
+	tests := []struct {
+		name     string
+		filter   models.TxFilter
+		cursor   *models.TxCursor
+		expected database.ListUserTransactionsNewestFirstParams
+	}{
+		{
+			name: "no filters",
+			expected: database.ListUserTransactionsNewestFirstParams{
+				UserID:    userID.String(),
+				PageLimit: 3,
+			},
+		},
+		{
+			name: "all filters and cursor",
+			filter: models.TxFilter{
+				StartDate:          "2025-01-01",
+				EndDate:            "2025-01-31",
+				Merchant:           "100%_off\\",
+				PrimaryCategoryID:  7,
+				DetailedCategoryID: 40,
+				MinAmountCents:     100,
+				MaxAmountCents:     5000,
+				Flow:               models.FlowOutflow,
+			},
+			cursor: &models.TxCursor{
+				Date: "2025-01-15",
+				ID:   cursorID,
+				Filter: models.TxFilter{
+					StartDate:          "2025-01-01",
+					EndDate:            "2025-01-31",
+					Merchant:           "100%_off\\",
+					PrimaryCategoryID:  7,
+					DetailedCategoryID: 40,
+					MinAmountCents:     100,
+					MaxAmountCents:     5000,
+					Flow:               models.FlowOutflow,
+					Sort:               models.SortNewestFirst,
+				},
+			},
+			expected: database.ListUserTransactionsNewestFirstParams{
+				UserID:             userID.String(),
+				StartDate:          sql.NullString{String: "2025-01-01", Valid: true},
+				EndDate:            sql.NullString{String: "2025-01-31", Valid: true},
+				Merchant:           sql.NullString{String: `100\%\_off\\`, Valid: true},
+				PrimaryCategoryID:  sql.NullInt64{Int64: 7, Valid: true},
+				DetailedCategoryID: sql.NullInt64{Int64: 40, Valid: true},
+				MinAmountCents:     sql.NullInt64{Int64: 100, Valid: true},
+				MaxAmountCents:     sql.NullInt64{Int64: 5000, Valid: true},
+				Flow:               sql.NullString{String: models.FlowOutflow, Valid: true},
+				CursorDate:         sql.NullString{String: "2025-01-15", Valid: true},
+				CursorID:           sql.NullString{String: cursorID, Valid: true},
+				PageLimit:          3,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+			svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+			mockTxQ.On("ListUserTransactionsNewestFirst", ctx, tc.expected).Return(nil, nil).Once()
+
+			var cursor string
+			if tc.cursor != nil {
+				var err error
+				cursor, err = svc.Cursors.Encode(tc.cursor)
+				require.NoError(t, err)
+			}
+			_, err := svc.ListUserTransactions(ctx, userID, tc.filter, cursor, 2)
+			require.NoError(t, err)
 		})
 	}
-	return rows
 }
 
-func generatePaginatedRows(userID uuid.UUID, txSliceLength int) []database.GetUserTransactionsPaginatedRow {
-	var rows []database.GetUserTransactionsPaginatedRow
+// Helpers
+func generateRows(userID uuid.UUID, txSliceLength int) []database.ListUserTransactionsNewestFirstRow {
+	var rows []database.ListUserTransactionsNewestFirstRow
 	for i := 0; i < txSliceLength; i++ {
 		date := fmt.Sprintf("2025-02-%02d", i+1)
-		rows = append(rows, database.GetUserTransactionsPaginatedRow{
+		rows = append(rows, database.ListUserTransactionsNewestFirstRow{
 			ID:                 uuid.NewString(),
 			UserID:             userID.String(),
 			TransactionDate:    date,
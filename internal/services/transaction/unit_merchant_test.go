@@ -0,0 +1,120 @@
+package transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeResolver resolves every merchant string to one merchant.
+type fakeResolver struct {
+	merchant models.Merchant
+	err      error
+	resolved []string
+}
+
+func (f *fakeResolver) Resolve(_ context.Context, _, raw string) (models.Merchant, error) {
+	f.resolved = append(f.resolved, raw)
+	return f.merchant, f.err
+}
+
+func TestCreateTransactionResolvesMerchant(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	merchantID := uuid.NewString()
+	raw := "SQ *BLUE BOTTLE 0423"
+
+	tests := []struct {
+		name             string
+		resolver         *fakeResolver
+		category         int64
+		expectedParams   func(p database.CreateTransactionParams) bool
+		expectedMerchant string
+		expectedCategory int64
+		errContains      string
+	}{
+		{
+			name:     "no resolver",
+			category: 41,
+			expectedParams: func(p database.CreateTransactionParams) bool {
+				return p.Merchant == raw && !p.MerchantID.Valid && p.RawMerchant == raw
+			},
+			expectedMerchant: raw,
+			expectedCategory: 41,
+		},
+		{
+			name:     "canonical name",
+			resolver: &fakeResolver{merchant: models.Merchant{ID: merchantID, Name: "Blue Bottle"}},
+			category: 41,
+			expectedParams: func(p database.CreateTransactionParams) bool {
+				return p.Merchant == "Blue Bottle" && p.MerchantID.String == merchantID && p.RawMerchant == raw && p.DetailedCategoryID == 41
+			},
+			expectedMerchant: "Blue Bottle",
+			expectedCategory: 41,
+		},
+		{
+			name: "default category",
+			resolver: &fakeResolver{merchant: models.Merchant{
+				ID: merchantID, Name: "Blue Bottle", DefaultCategoryID: sql.NullInt64{Int64: 41, Valid: true},
+			}},
+			expectedParams: func(p database.CreateTransactionParams) bool {
+				return p.DetailedCategoryID == 41
+			},
+			expectedMerchant: "Blue Bottle",
+			expectedCategory: 41,
+		},
+		{
+			name: "sent category wins",
+			resolver: &fakeResolver{merchant: models.Merchant{
+				ID: merchantID, Name: "Blue Bottle", DefaultCategoryID: sql.NullInt64{Int64: 41, Valid: true},
+			}},
+			category: 40,
+			expectedParams: func(p database.CreateTransactionParams) bool {
+				return p.DetailedCategoryID == 40
+			},
+			expectedMerchant: "Blue Bottle",
+			expectedCategory: 40,
+		},
+		{
+			name:        "resolver fails",
+			resolver:    &fakeResolver{err: errors.New("db down")},
+			category:    41,
+			errContains: "unable to resolve merchant",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+			svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+			if tc.resolver != nil {
+				svc.Merchants = tc.resolver
+			}
+			if tc.expectedParams != nil {
+				mockTxQ.On("CreateTransaction", ctx, mock.MatchedBy(tc.expectedParams)).Return(nil).Once()
+			}
+
+			txn, err := svc.CreateTransaction(ctx, userID, models.NewTxRequest{Date: "2025-02-24", Merchant: raw, Amount: 5.25, DetailedCategory: tc.category})
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMerchant, txn.Merchant)
+			require.Equal(t, raw, txn.RawMerchant)
+			require.Equal(t, tc.expectedCategory, txn.DetailedCategory)
+			if tc.resolver != nil {
+				require.Equal(t, []string{raw}, tc.resolver.resolved)
+			}
+		})
+	}
+}
@@ -2,12 +2,14 @@ package transaction_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
 	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
 	"github.com/seanhuebl/unity-wealth/internal/models"
 	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
@@ -90,3 +92,89 @@ func TestCreateTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTransactionExternalID(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	req := models.NewTxRequest{
+		Date:             "2025-02-24",
+		Merchant:         "Costco",
+		Amount:           145.56,
+		DetailedCategory: 40,
+		ExternalID:       "ofx:1234:20250224001",
+	}
+	lookup := database.GetTransactionIDByExternalIDParams{
+		UserID:     userID,
+		ExternalID: sql.NullString{String: req.ExternalID, Valid: true},
+	}
+
+	t.Run("new", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("GetTransactionIDByExternalID", ctx, lookup).Return("", sql.ErrNoRows).Once()
+		mockTxQ.On("CreateTransaction", ctx, mock.MatchedBy(func(p database.CreateTransactionParams) bool {
+			return p.ExternalID == lookup.ExternalID
+		})).Return(nil).Once()
+
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		_, err := svc.CreateTransaction(ctx, userID, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("GetTransactionIDByExternalID", ctx, lookup).Return(uuid.NewString(), nil).Once()
+
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		_, err := svc.CreateTransaction(ctx, userID, req)
+		require.ErrorIs(t, err, transaction.ErrDuplicateExternalID)
+	})
+}
+
+type ruleLoaderFunc func(ctx context.Context, userID string) (func(*models.NewTxRequest), error)
+
+func (f ruleLoaderFunc) UserRules(ctx context.Context, userID string) (func(*models.NewTxRequest), error) {
+	return f(ctx, userID)
+}
+
+func TestCreateTransactionRunsRules(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	req := models.NewTxRequest{
+		Date:             "2025-02-24",
+		Merchant:         "SQ *BLUE BOTTLE",
+		Amount:           5.25,
+		DetailedCategory: 40,
+	}
+
+	t.Run("rules applied", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("CreateTransaction", ctx, mock.MatchedBy(func(p database.CreateTransactionParams) bool {
+			return p.Merchant == "Blue Bottle" && p.DetailedCategoryID == 41 && p.Tags == "coffee"
+		})).Return(nil).Once()
+
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Rules = ruleLoaderFunc(func(_ context.Context, id string) (func(*models.NewTxRequest), error) {
+			require.Equal(t, userID, id)
+			return func(r *models.NewTxRequest) {
+				r.Merchant, r.DetailedCategory, r.Tags = "Blue Bottle", 41, []string{"coffee"}
+			}, nil
+		})
+		tx, err := svc.CreateTransaction(ctx, userID, req)
+		require.NoError(t, err)
+		require.Equal(t, "Blue Bottle", tx.Merchant)
+		require.Equal(t, int64(41), tx.DetailedCategory)
+		require.Equal(t, []string{"coffee"}, tx.Tags)
+	})
+
+	t.Run("rules fail to load", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Rules = ruleLoaderFunc(func(context.Context, string) (func(*models.NewTxRequest), error) {
+			return nil, errors.New("db down")
+		})
+		tx, err := svc.CreateTransaction(ctx, userID, req)
+		require.ErrorContains(t, err, "unable to load rules")
+		require.Nil(t, tx)
+	})
+}
@@ -0,0 +1,215 @@
+package transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPatchTransaction(t *testing.T) {
+	ctx := context.Background()
+	txID := uuid.NewString()
+	userID := uuid.NewString()
+	updatedAt := time.Date(2025, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	date := "2025-03-06"
+	badDate := "3/6/2025"
+	amount := -12.5
+	notes := ""
+	tags := []string{" Food ", "food", "Work"}
+
+	row := database.PatchTransactionByIDRow{
+		ID:                 txID,
+		UserID:             userID,
+		TransactionDate:    date,
+		Merchant:           "costco",
+		AmountCents:        -1250,
+		DetailedCategoryID: 40,
+		Tags:               "food,work",
+		UpdatedAt:          sql.NullTime{Time: updatedAt, Valid: true},
+	}
+
+	tests := []struct {
+		name           string
+		patch          models.TxPatch
+		expectedParams func(p database.PatchTransactionByIDParams) bool
+		row            database.PatchTransactionByIDRow
+		queryErr       error
+		splitRows      []models.TransactionSplit
+		splitsErr      error
+		expectedSplits []models.TxSplit
+		expectedErr    string
+	}{
+		{
+			name:  "only present fields are set",
+			patch: models.TxPatch{Date: &date, Amount: &amount, Notes: &notes, Tags: &tags},
+			expectedParams: func(p database.PatchTransactionByIDParams) bool {
+				return p.ID == txID && p.UserID == userID &&
+					p.TransactionDate == sql.NullString{String: date, Valid: true} &&
+					!p.Merchant.Valid &&
+					p.AmountCents == sql.NullInt64{Int64: -1250, Valid: true} &&
+					!p.DetailedCategoryID.Valid &&
+					p.Notes == sql.NullString{String: "", Valid: true} &&
+					p.Tags == sql.NullString{String: "food,work", Valid: true} &&
+					p.UpdatedAt.Valid
+			},
+			row: row,
+		},
+		{
+			name:  "splits are returned",
+			patch: models.TxPatch{Notes: &notes},
+			expectedParams: func(p database.PatchTransactionByIDParams) bool {
+				return p.Notes == sql.NullString{String: "", Valid: true}
+			},
+			row: row,
+			splitRows: []models.TransactionSplit{
+				{TransactionID: txID, Line: 1, AmountCents: -1000, DetailedCategoryID: 40, Memo: "food"},
+				{TransactionID: txID, Line: 2, AmountCents: -250, DetailedCategoryID: 50},
+			},
+			expectedSplits: []models.TxSplit{
+				{Amount: -10, DetailedCategory: 40, Memo: "food"},
+				{Amount: -2.5, DetailedCategory: 50},
+			},
+		},
+		{
+			name:  "splits error",
+			patch: models.TxPatch{Notes: &notes},
+			expectedParams: func(p database.PatchTransactionByIDParams) bool {
+				return true
+			},
+			row:         row,
+			splitsErr:   errors.New("db error"),
+			expectedErr: "error loading splits",
+		},
+		{
+			name:        "invalid date",
+			patch:       models.TxPatch{Date: &badDate},
+			expectedErr: "invalid date format",
+		},
+		{
+			name:  "not found",
+			patch: models.TxPatch{Amount: &amount},
+			expectedParams: func(p database.PatchTransactionByIDParams) bool {
+				return p.ID == txID && p.UserID == userID
+			},
+			queryErr:    sql.ErrNoRows,
+			expectedErr: "transaction not found",
+		},
+		{
+			name:  "database error",
+			patch: models.TxPatch{Amount: &amount},
+			expectedParams: func(p database.PatchTransactionByIDParams) bool {
+				return true
+			},
+			queryErr:    errors.New("db error"),
+			expectedErr: "error patching transaction",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+			if tc.expectedParams != nil {
+				mockTxQ.On("PatchTransactionByID", ctx, mock.MatchedBy(tc.expectedParams)).Return(tc.row, tc.queryErr)
+			}
+			if tc.expectedParams != nil && tc.queryErr == nil {
+				mockTxQ.On("ListTransactionSplits", ctx, txID).Return(tc.splitRows, tc.splitsErr)
+			}
+			svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+
+			txn, err := svc.PatchTransaction(ctx, txID, userID, tc.patch, 0)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr)
+				require.Nil(t, txn)
+				return
+			}
+			require.NoError(t, err)
+			expected := &models.Tx{
+				ID:               txID,
+				UserID:           userID,
+				Date:             date,
+				Merchant:         "costco",
+				Amount:           -12.5,
+				DetailedCategory: 40,
+				Tags:             []string{"food", "work"},
+				UpdatedAt:        &updatedAt,
+				Splits:           tc.expectedSplits,
+			}
+			if diff := cmp.Diff(expected, txn); diff != "" {
+				t.Errorf("transaction mismatch (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchTransactions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	category := int64(41)
+	ops := []models.TxPatchOp{
+		{ID: "t1", Version: 2, Patch: models.TxPatch{DetailedCategory: &category}},
+		{ID: "t2", Version: 5, Patch: models.TxPatch{DetailedCategory: &category}},
+	}
+	versioned := func(id string, version int64) func(p database.PatchTransactionByIDParams) bool {
+		return func(p database.PatchTransactionByIDParams) bool {
+			return p.ID == id && p.UserID == userID &&
+				p.ExpectedVersion == sql.NullInt64{Int64: version, Valid: true} &&
+				p.DetailedCategoryID == sql.NullInt64{Int64: category, Valid: true}
+		}
+	}
+
+	t.Run("every op is written at its version", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		for _, op := range ops {
+			mockTxQ.On("PatchTransactionByID", ctx, mock.MatchedBy(versioned(op.ID, op.Version))).
+				Return(database.PatchTransactionByIDRow{ID: op.ID, UserID: userID, DetailedCategoryID: category}, nil).Once()
+			mockTxQ.On("ListTransactionSplits", ctx, op.ID).Return([]models.TransactionSplit{}, nil).Once()
+		}
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+
+		errs, err := svc.PatchTransactions(ctx, userID, ops)
+		require.NoError(t, err)
+		require.Equal(t, []error{nil, nil}, errs)
+	})
+
+	t.Run("a stale op is left out", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("PatchTransactionByID", ctx, mock.MatchedBy(versioned("t1", 2))).
+			Return(database.PatchTransactionByIDRow{ID: "t1", UserID: userID, DetailedCategoryID: category}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, "t1").Return([]models.TransactionSplit{}, nil).Once()
+		mockTxQ.On("PatchTransactionByID", ctx, mock.MatchedBy(versioned("t2", 5))).
+			Return(database.PatchTransactionByIDRow{}, sql.ErrNoRows).Once()
+		mockTxQ.On("GetUserTransactionByID", ctx, database.GetUserTransactionByIDParams{UserID: userID, ID: "t2"}).
+			Return(database.GetUserTransactionByIDRow{ID: "t2", UserID: userID, Version: 6}, nil).Once()
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+
+		errs, err := svc.PatchTransactions(ctx, userID, ops)
+		require.NoError(t, err)
+		require.Len(t, errs, 2)
+		require.NoError(t, errs[0])
+		require.ErrorIs(t, errs[1], transaction.ErrVersionMismatch)
+	})
+
+	t.Run("a database error fails the run", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("PatchTransactionByID", ctx, mock.MatchedBy(versioned("t1", 2))).
+			Return(database.PatchTransactionByIDRow{}, errors.New("disk I/O error")).Once()
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+
+		_, err := svc.PatchTransactions(ctx, userID, ops)
+		require.ErrorContains(t, err, "failed to update transaction t1")
+	})
+}
@@ -0,0 +1,150 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSearchTransactions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	txID := uuid.New()
+
+	row := database.SearchUserTransactionsRow{
+		ID:                 txID.String(),
+		UserID:             userID.String(),
+		TransactionDate:    "2025-04-23",
+		Merchant:           "SQ *BLUE BOTTLE 0423 SF",
+		AmountCents:        650,
+		DetailedCategoryID: 40,
+		Notes:              "team offsite",
+		Tags:               "coffee,work",
+		Snippet:            "SQ *\x02BLUE\x03 \x02BOTTLE\x03 0423 SF",
+	}
+	markup := row
+	markup.Notes = `<img src=x onerror="alert(1)"> blue`
+	markup.Snippet = "<img src=x onerror=\"alert(1)\"> \x02blue\x03"
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedMatch   string
+		rows            []database.SearchUserTransactionsRow
+		searchErr       error
+		expected        []models.TxSearchResult
+		expectedErr     error
+		expectedErrText string
+	}{
+		{
+			name:          "words become prefix terms",
+			query:         "blue bottle",
+			expectedMatch: `"blue"* "bottle"*`,
+			rows:          []database.SearchUserTransactionsRow{row},
+			expected: []models.TxSearchResult{
+				{
+					Tx: models.Tx{
+						ID:               txID.String(),
+						UserID:           userID.String(),
+						Date:             "2025-04-23",
+						Merchant:         "SQ *BLUE BOTTLE 0423 SF",
+						Amount:           6.5,
+						DetailedCategory: 40,
+						Notes:            "team offsite",
+						Tags:             []string{"coffee", "work"},
+					},
+					Snippet: "SQ *<mark>BLUE</mark> <mark>BOTTLE</mark> 0423 SF",
+				},
+			},
+		},
+		{
+			name:          "snippet text is escaped",
+			query:         "blue",
+			expectedMatch: `"blue"*`,
+			rows:          []database.SearchUserTransactionsRow{markup},
+			expected: []models.TxSearchResult{
+				{
+					Tx: models.Tx{
+						ID:               txID.String(),
+						UserID:           userID.String(),
+						Date:             "2025-04-23",
+						Merchant:         "SQ *BLUE BOTTLE 0423 SF",
+						Amount:           6.5,
+						DetailedCategory: 40,
+						Notes:            markup.Notes,
+						Tags:             []string{"coffee", "work"},
+					},
+					Snippet: "&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>blue</mark>",
+				},
+			},
+		},
+		{
+			name:          "FTS5 syntax is stripped",
+			query:         `blue* OR "bottle" NEAR(x)`,
+			expectedMatch: `"blue"* "OR"* "bottle"* "NEAR"* "x"*`,
+			expected:      []models.TxSearchResult{},
+		},
+		{
+			name:          "non-ASCII letters are kept",
+			query:         "café-crème",
+			expectedMatch: `"café"* "crème"*`,
+			expected:      []models.TxSearchResult{},
+		},
+		{
+			name:        "no words",
+			query:       `*"()`,
+			expectedErr: transaction.ErrEmptySearchQuery,
+		},
+		{
+			name:        "query too long",
+			query:       strings.Repeat("a", transaction.MaxSearchQueryLength+1),
+			expectedErr: transaction.ErrSearchQueryTooLong,
+		},
+		{
+			name:            "database error",
+			query:           "blue",
+			expectedMatch:   `"blue"*`,
+			searchErr:       errors.New("db error"),
+			expectedErrText: "error searching transactions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+			if tc.expectedMatch != "" {
+				mockTxQ.On("SearchUserTransactions", ctx, database.SearchUserTransactionsParams{
+					Query:     tc.expectedMatch,
+					UserID:    userID.String(),
+					PageLimit: 20,
+				}).Return(tc.rows, tc.searchErr)
+			}
+			svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+
+			results, err := svc.SearchTransactions(ctx, userID, tc.query, 20)
+			switch {
+			case tc.expectedErr != nil:
+				require.ErrorIs(t, err, tc.expectedErr)
+			case tc.expectedErrText != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErrText)
+			default:
+				require.NoError(t, err)
+				if diff := cmp.Diff(tc.expected, results); diff != "" {
+					t.Errorf("results mismatch (-want +got)\n%s", diff)
+				}
+			}
+			mockTxQ.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/database"
+	dbmocks "github.com/seanhuebl/unity-wealth/internal/mocks/database"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeCategorizer suggests a fixed list and records what it learns and forgets.
+type fakeCategorizer struct {
+	suggestions []models.CategorySuggestion
+	err         error
+	asked       []models.Tx
+	learned     []models.Tx
+	forgotten   []string
+}
+
+func (f *fakeCategorizer) Suggest(_ context.Context, _ string, txn models.Tx) ([]models.CategorySuggestion, error) {
+	f.asked = append(f.asked, txn)
+	return f.suggestions, f.err
+}
+
+func (f *fakeCategorizer) Learn(_ context.Context, _ string, txn models.Tx) error {
+	f.learned = append(f.learned, txn)
+	return f.err
+}
+
+func (f *fakeCategorizer) Forget(_ context.Context, _, txnID string) error {
+	f.forgotten = append(f.forgotten, txnID)
+	return f.err
+}
+
+func TestCreateTransactionWithoutCategory(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	req := models.NewTxRequest{Date: "2025-02-24", Merchant: "Shell", Amount: 40}
+	suggestions := []models.CategorySuggestion{
+		{DetailedCategory: 42, Confidence: 0.8},
+		{DetailedCategory: 40, Confidence: 0.15},
+		{DetailedCategory: 41, Confidence: 0.05},
+	}
+
+	tests := []struct {
+		name        string
+		categorizer *fakeCategorizer
+		expectedErr error
+		errContains string
+	}{
+		{
+			name:        "top suggestion used",
+			categorizer: &fakeCategorizer{suggestions: suggestions},
+		},
+		{
+			name:        "no categorizer",
+			expectedErr: transaction.ErrCategoryRequired,
+		},
+		{
+			name:        "nothing to suggest",
+			categorizer: &fakeCategorizer{suggestions: []models.CategorySuggestion{}},
+			expectedErr: transaction.ErrCategoryRequired,
+		},
+		{
+			name:        "categorizer fails",
+			categorizer: &fakeCategorizer{err: errors.New("db down")},
+			errContains: "unable to suggest a category",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxQ := dbmocks.NewTransactionQuerier(t)
+			svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+			if tc.categorizer != nil {
+				svc.Categorizer = tc.categorizer
+			}
+			if tc.expectedErr == nil && tc.errContains == "" {
+				mockTxQ.On("CreateTransaction", ctx, mock.MatchedBy(func(p database.CreateTransactionParams) bool {
+					return p.DetailedCategoryID == 42
+				})).Return(nil).Once()
+			}
+
+			txn, err := svc.CreateTransaction(ctx, userID, req)
+			switch {
+			case tc.expectedErr != nil:
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			case tc.errContains != "":
+				require.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(42), txn.DetailedCategory)
+			require.Equal(t, suggestions, txn.Suggestions)
+			require.Equal(t, []models.Tx{{Merchant: "Shell", Amount: 40}}, tc.categorizer.asked)
+			require.Len(t, tc.categorizer.learned, 1)
+			require.Equal(t, txn.ID, tc.categorizer.learned[0].ID)
+		})
+	}
+}
+
+func TestCategorizerLearnsFromWrites(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	txnID := uuid.NewString()
+
+	t.Run("create with a category", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("CreateTransaction", ctx, mock.AnythingOfType("database.CreateTransactionParams")).Return(nil).Once()
+		categorizer := &fakeCategorizer{err: errors.New("training failed")}
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Categorizer = categorizer
+
+		// A failure to train does not fail the write.
+		txn, err := svc.CreateTransaction(ctx, userID, models.NewTxRequest{Date: "2025-02-24", Merchant: "Costco", Amount: 12, DetailedCategory: 40})
+		require.NoError(t, err)
+		require.Empty(t, categorizer.asked)
+		require.Equal(t, []models.Tx{*txn}, categorizer.learned)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("DeleteTransactionSplits", ctx, database.DeleteTransactionSplitsParams{TransactionID: txnID, UserID: userID}).Return(nil).Once()
+		mockTxQ.On("UpdateTransactionByID", ctx, mock.AnythingOfType("database.UpdateTransactionByIDParams")).
+			Return(database.UpdateTransactionByIDRow{ID: txnID, TransactionDate: "2025-02-24", Merchant: "Costco", AmountCents: 1200, DetailedCategoryID: 41, Version: 2}, nil).Once()
+		categorizer := &fakeCategorizer{}
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Categorizer = categorizer
+
+		_, err := svc.UpdateTransaction(ctx, txnID, userID, models.NewTxRequest{Date: "2025-02-24", Merchant: "Costco", Amount: 12, DetailedCategory: 41}, 0)
+		require.NoError(t, err)
+		require.Len(t, categorizer.learned, 1)
+		require.Equal(t, int64(41), categorizer.learned[0].DetailedCategory)
+	})
+
+	t.Run("update without a category", func(t *testing.T) {
+		svc := transaction.NewTransactionService(dbmocks.NewTransactionQuerier(t), zap.NewNop())
+		_, err := svc.UpdateTransaction(ctx, txnID, userID, models.NewTxRequest{Date: "2025-02-24", Merchant: "Costco", Amount: 12}, 0)
+		require.ErrorIs(t, err, transaction.ErrCategoryRequired)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("DeleteTransactionByID", ctx, mock.AnythingOfType("database.DeleteTransactionByIDParams")).Return(txnID, nil).Once()
+		categorizer := &fakeCategorizer{}
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Categorizer = categorizer
+
+		require.NoError(t, svc.DeleteTransaction(ctx, txnID, userID, 0))
+		require.Equal(t, []string{txnID}, categorizer.forgotten)
+	})
+
+	t.Run("failed delete", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("DeleteTransactionByID", ctx, mock.AnythingOfType("database.DeleteTransactionByIDParams")).Return("", sql.ErrNoRows).Once()
+		categorizer := &fakeCategorizer{}
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Categorizer = categorizer
+
+		require.Error(t, svc.DeleteTransaction(ctx, txnID, userID, 0))
+		require.Empty(t, categorizer.forgotten)
+	})
+}
+
+func TestSuggestCategories(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	txnID := uuid.NewString()
+	params := database.GetUserTransactionByIDParams{UserID: userID, ID: txnID}
+	suggestions := []models.CategorySuggestion{{DetailedCategory: 41, Confidence: 0.9}}
+
+	t.Run("success", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("GetUserTransactionByID", ctx, params).
+			Return(database.GetUserTransactionByIDRow{ID: txnID, UserID: userID, TransactionDate: "2025-02-24", Merchant: "Blue Bottle", AmountCents: 525, DetailedCategoryID: 40}, nil).Once()
+		mockTxQ.On("ListTransactionSplits", ctx, txnID).Return(nil, nil).Once()
+		categorizer := &fakeCategorizer{suggestions: suggestions}
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Categorizer = categorizer
+
+		got, err := svc.SuggestCategories(ctx, userID, txnID)
+		require.NoError(t, err)
+		require.Equal(t, suggestions, got)
+		require.Equal(t, txnID, categorizer.asked[0].ID)
+		require.Equal(t, 5.25, categorizer.asked[0].Amount)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockTxQ := dbmocks.NewTransactionQuerier(t)
+		mockTxQ.On("GetUserTransactionByID", ctx, params).Return(database.GetUserTransactionByIDRow{}, sql.ErrNoRows).Once()
+		svc := transaction.NewTransactionService(mockTxQ, zap.NewNop())
+		svc.Categorizer = &fakeCategorizer{}
+
+		_, err := svc.SuggestCategories(ctx, userID, txnID)
+		require.ErrorContains(t, err, "transaction not found")
+	})
+
+	t.Run("no categorizer", func(t *testing.T) {
+		svc := transaction.NewTransactionService(dbmocks.NewTransactionQuerier(t), zap.NewNop())
+		_, err := svc.SuggestCategories(ctx, userID, txnID)
+		require.ErrorIs(t, err, transaction.ErrCategorizerUnavailable)
+	})
+}
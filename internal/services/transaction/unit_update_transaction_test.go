@@ -86,11 +86,12 @@ func TestUpdateTransaction(t *testing.T) {
 				if tc.txErr != nil {
 					returnRow = database.UpdateTransactionByIDRow{}
 				}
+				mockTxQ.On("DeleteTransactionSplits", ctx, mock.AnythingOfType("database.DeleteTransactionSplitsParams")).Return(nil)
 				mockTxQ.On("UpdateTransactionByID", ctx, mock.AnythingOfType("database.UpdateTransactionByIDParams")).Return(returnRow, tc.txErr)
 			}
 			nopLogger := zap.NewNop()
 			svc := transaction.NewTransactionService(mockTxQ, nopLogger)
-			tx, err := svc.UpdateTransaction(ctx, txID.String(), userID.String(), tc.req)
+			tx, err := svc.UpdateTransaction(ctx, txID.String(), userID.String(), tc.req, 0)
 			if tc.expectedDateErrSubStr != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), tc.expectedDateErrSubStr)
@@ -5,74 +5,74 @@ import (
 	"github.com/seanhuebl/unity-wealth/internal/database"
 )
 
-type FirstPageRowWrapper struct {
-	database.GetUserTransactionsFirstPageRow
+type NewestFirstRowWrapper struct {
+	database.ListUserTransactionsNewestFirstRow
 }
 
-func (w FirstPageRowWrapper) GetTxID() uuid.UUID {
-	return uuid.MustParse(w.GetUserTransactionsFirstPageRow.ID)
+func (w NewestFirstRowWrapper) GetTxID() uuid.UUID {
+	return uuid.MustParse(w.ListUserTransactionsNewestFirstRow.ID)
 }
 
-func (w FirstPageRowWrapper) GetUserID() uuid.UUID {
-	return uuid.MustParse(w.GetUserTransactionsFirstPageRow.UserID)
+func (w NewestFirstRowWrapper) GetUserID() uuid.UUID {
+	return uuid.MustParse(w.ListUserTransactionsNewestFirstRow.UserID)
 }
 
-func (w FirstPageRowWrapper) GetTxDate() string {
-	return w.GetUserTransactionsFirstPageRow.TransactionDate
+func (w NewestFirstRowWrapper) GetTxDate() string {
+	return w.ListUserTransactionsNewestFirstRow.TransactionDate
 }
 
-func (w FirstPageRowWrapper) GetMerchant() string {
-	return w.GetUserTransactionsFirstPageRow.Merchant
+func (w NewestFirstRowWrapper) GetMerchant() string {
+	return w.ListUserTransactionsNewestFirstRow.Merchant
 }
 
-func (w FirstPageRowWrapper) GetAmountCents() int64 {
-	return w.GetUserTransactionsFirstPageRow.AmountCents
+func (w NewestFirstRowWrapper) GetAmountCents() int64 {
+	return w.ListUserTransactionsNewestFirstRow.AmountCents
 }
 
-func (w FirstPageRowWrapper) GetDetailedCatID() int64 {
-	return w.GetUserTransactionsFirstPageRow.DetailedCategoryID
+func (w NewestFirstRowWrapper) GetDetailedCatID() int64 {
+	return w.ListUserTransactionsNewestFirstRow.DetailedCategoryID
 }
 
-type PaginatedRowWrapper struct {
-	database.GetUserTransactionsPaginatedRow
+type OldestFirstRowWrapper struct {
+	database.ListUserTransactionsOldestFirstRow
 }
 
-func (w PaginatedRowWrapper) GetTxID() uuid.UUID {
-	return uuid.MustParse(w.GetUserTransactionsPaginatedRow.ID)
+func (w OldestFirstRowWrapper) GetTxID() uuid.UUID {
+	return uuid.MustParse(w.ListUserTransactionsOldestFirstRow.ID)
 }
 
-func (w PaginatedRowWrapper) GetUserID() uuid.UUID {
-	return uuid.MustParse(w.GetUserTransactionsPaginatedRow.UserID)
+func (w OldestFirstRowWrapper) GetUserID() uuid.UUID {
+	return uuid.MustParse(w.ListUserTransactionsOldestFirstRow.UserID)
 }
 
-func (w PaginatedRowWrapper) GetTxDate() string {
-	return w.GetUserTransactionsPaginatedRow.TransactionDate
+func (w OldestFirstRowWrapper) GetTxDate() string {
+	return w.ListUserTransactionsOldestFirstRow.TransactionDate
 }
 
-func (w PaginatedRowWrapper) GetMerchant() string {
-	return w.GetUserTransactionsPaginatedRow.Merchant
+func (w OldestFirstRowWrapper) GetMerchant() string {
+	return w.ListUserTransactionsOldestFirstRow.Merchant
 }
 
-func (w PaginatedRowWrapper) GetAmountCents() int64 {
-	return w.GetUserTransactionsPaginatedRow.AmountCents
+func (w OldestFirstRowWrapper) GetAmountCents() int64 {
+	return w.ListUserTransactionsOldestFirstRow.AmountCents
 }
 
-func (w PaginatedRowWrapper) GetDetailedCatID() int64 {
-	return w.GetUserTransactionsPaginatedRow.DetailedCategoryID
+func (w OldestFirstRowWrapper) GetDetailedCatID() int64 {
+	return w.ListUserTransactionsOldestFirstRow.DetailedCategoryID
 }
 
-func WrapFirstPageRows(rows []database.GetUserTransactionsFirstPageRow) []FirstPageRowWrapper {
-	wrapped := make([]FirstPageRowWrapper, len(rows))
+func WrapNewestFirstRows(rows []database.ListUserTransactionsNewestFirstRow) []NewestFirstRowWrapper {
+	wrapped := make([]NewestFirstRowWrapper, len(rows))
 	for i, r := range rows {
-		wrapped[i] = FirstPageRowWrapper{r}
+		wrapped[i] = NewestFirstRowWrapper{r}
 	}
 	return wrapped
 }
 
-func WrapPaginatedRows(rows []database.GetUserTransactionsPaginatedRow) []PaginatedRowWrapper {
-	wrapped := make([]PaginatedRowWrapper, len(rows))
+func WrapOldestFirstRows(rows []database.ListUserTransactionsOldestFirstRow) []OldestFirstRowWrapper {
+	wrapped := make([]OldestFirstRowWrapper, len(rows))
 	for i, r := range rows {
-		wrapped[i] = PaginatedRowWrapper{r}
+		wrapped[i] = OldestFirstRowWrapper{r}
 	}
 	return wrapped
 }
@@ -47,7 +47,7 @@ func TestIntSignup(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			env := testhelpers.SetupTestEnv(t)
-			userSvc := user.NewUserService(env.UserQ, auth.NewRealPwdHasher(), env.Logger)
+			userSvc := user.NewUserService(env.UserQ, auth.NewRealPwdHasher(), env.Services.AuthService, env.Logger)
 			err := userSvc.SignUp(ctx, tc.input)
 			if tc.wantErrSubstr != "" {
 				if tc.wantErrSubstr == auth.ErrInvalidEmail.Error() {
@@ -63,13 +63,19 @@ func TestIntSignup(t *testing.T) {
 				require.NoError(t, err)
 				require.NotEmpty(t, userRecord.ID)
 				require.NoError(t, auth.NewRealPwdHasher().CheckPasswordHash(tc.input.Password, userRecord.HashedPassword))
+				require.False(t, userRecord.EmailVerifiedAt.Valid)
+
+				messages := env.Mailer.Messages()
+				require.Len(t, messages, 1)
+				require.Equal(t, tc.input.Email, messages[0].To)
+				require.Contains(t, messages[0].Body, "/verify-email?token=")
 			}
 		})
 
 	}
 	t.Run("create user failure", func(t *testing.T) {
 		env := testhelpers.SetupTestEnv(t)
-		svc := user.NewUserService(env.UserQ, auth.NewRealPwdHasher(), env.Logger)
+		svc := user.NewUserService(env.UserQ, auth.NewRealPwdHasher(), env.Services.AuthService, env.Logger)
 		input := user.SignUpInput{"duplicate@example.com", "Validpass1!"}
 
 		require.NoError(t, svc.SignUp(ctx, input))
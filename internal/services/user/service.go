@@ -14,13 +14,15 @@ import (
 type UserService struct {
 	userQueries database.UserQuerier
 	pwdHasher   auth.PasswordHasher
+	verifier    auth.EmailVerifier
 	logger      *zap.Logger
 }
 
-func NewUserService(userQueries database.UserQuerier, pwdHasher auth.PasswordHasher, logger *zap.Logger) *UserService {
+func NewUserService(userQueries database.UserQuerier, pwdHasher auth.PasswordHasher, verifier auth.EmailVerifier, logger *zap.Logger) *UserService {
 	return &UserService{
 		userQueries: userQueries,
 		pwdHasher:   pwdHasher,
+		verifier:    verifier,
 		logger:      logger,
 	}
 }
@@ -46,5 +48,10 @@ func (u *UserService) SignUp(ctx context.Context, input SignUpInput) error {
 	}); err != nil {
 		return fmt.Errorf("unable to create user: %w", err)
 	}
+
+	// The account exists at this point; a failed email can be retried through the resend endpoint.
+	if err := u.verifier.SendVerificationEmail(ctx, newID.String(), input.Email); err != nil {
+		u.logger.Warn("unable to send verification email", zap.String("user_id", newID.String()), zap.Error(err))
+	}
 	return nil
 }
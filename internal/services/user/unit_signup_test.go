@@ -26,6 +26,7 @@ func TestSignup(t *testing.T) {
 		hashPasswordOutput    string
 		hashPasswordError     error
 		createUserError       error
+		sendVerificationError error
 		expectedError         string
 	}{
 		{
@@ -79,6 +80,16 @@ func TestSignup(t *testing.T) {
 			createUserError:       errors.New("db error"),
 			expectedError:         "unable to create user",
 		},
+		{
+			name: "verification email failure does not fail signup",
+			input: user.SignUpInput{
+				Email:    "valid@example.com",
+				Password: "Validpass1!",
+			},
+			hashPasswordOutput:    "hashedpassword",
+			sendVerificationError: errors.New("smtp down"),
+			expectedError:         "",
+		},
 	}
 
 	for _, tc := range tests {
@@ -86,8 +97,9 @@ func TestSignup(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockUserQ := dbmocks.NewUserQuerier(t)
 			mockPwdHasher := authmocks.NewPasswordHasher(t)
+			mockVerifier := authmocks.NewEmailVerifier(t)
 			nopLogger := zap.NewNop()
-			userSvc := user.NewUserService(mockUserQ, mockPwdHasher, nopLogger)
+			userSvc := user.NewUserService(mockUserQ, mockPwdHasher, mockVerifier, nopLogger)
 			if models.IsValidEmail(tc.input.Email) {
 				err := models.ValidatePassword(tc.input.Password)
 
@@ -106,6 +118,9 @@ func TestSignup(t *testing.T) {
 							}
 							return params.ID != ""
 						})).Return(tc.createUserError)
+						if tc.createUserError == nil {
+							mockVerifier.On("SendVerificationEmail", mock.Anything, mock.AnythingOfType("string"), tc.input.Email).Return(tc.sendVerificationError).Once()
+						}
 					}
 				}
 			}
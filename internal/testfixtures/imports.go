@@ -0,0 +1,9 @@
+package testfixtures
+
+import "embed"
+
+// ImportFiles holds sample bank downloads for the import tests: an OFX 1.x checking
+// statement, an OFX 2.x credit card statement saved as QFX, and a QIF file.
+//
+//go:embed imports
+var ImportFiles embed.FS
@@ -3,20 +3,31 @@ package testhelpers
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 	httpauth "github.com/seanhuebl/unity-wealth/handlers/auth"
+	categoryhandler "github.com/seanhuebl/unity-wealth/handlers/category"
+	importhandler "github.com/seanhuebl/unity-wealth/handlers/importer"
+	merchanthandler "github.com/seanhuebl/unity-wealth/handlers/merchant"
+	rulehandler "github.com/seanhuebl/unity-wealth/handlers/rules"
 	txhandler "github.com/seanhuebl/unity-wealth/handlers/transaction"
 	httpuser "github.com/seanhuebl/unity-wealth/handlers/user"
 	"github.com/seanhuebl/unity-wealth/internal/constants"
 	"github.com/seanhuebl/unity-wealth/internal/database"
 	"github.com/seanhuebl/unity-wealth/internal/helpers"
 	"github.com/seanhuebl/unity-wealth/internal/interfaces"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
 	"github.com/seanhuebl/unity-wealth/internal/models"
 	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/services/categorizer"
+	"github.com/seanhuebl/unity-wealth/internal/services/category"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/seanhuebl/unity-wealth/internal/services/merchant"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
 	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
 	"github.com/seanhuebl/unity-wealth/internal/services/user"
 	"github.com/seanhuebl/unity-wealth/internal/testmodels"
@@ -37,6 +48,37 @@ func CreateTestingSchema(t *testing.T, db *sql.DB) {
 	require.NoError(t, err)
 	_, err = db.Exec(constants.CreateRefrTokenTable)
 	require.NoError(t, err)
+	_, err = db.Exec(constants.CreatePwdResetTokenTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateEmailVerifyTokenTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateMFARecoveryCodeTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateMFAChallengeTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateMFATrustedDeviceTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateAPIKeyTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateImportMappingTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateRuleTable)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateCategoryModelTables)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateMerchantTables)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateUserCategoryTables)
+	require.NoError(t, err)
+	_, err = db.Exec(constants.CreateTxSplitTables)
+	require.NoError(t, err)
+}
+
+// CreateSearchSchema adds the full-text search table and its triggers. Tests that call
+// it need the sqlite_fts5 build tag.
+func CreateSearchSchema(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(constants.CreateTxSearchTable)
+	require.NoError(t, err)
 }
 
 func SeedTestUser(t *testing.T, userQ database.UserQuerier, userID uuid.UUID, requiresHash bool) {
@@ -79,6 +121,8 @@ func SeedTestTransaction(t *testing.T, txQ database.TransactionQuerier, userID,
 		Merchant:           req.Merchant,
 		AmountCents:        helpers.ConvertToCents(req.Amount),
 		DetailedCategoryID: req.DetailedCategory,
+		Notes:              req.Notes,
+		Tags:               strings.Join(req.Tags, ","),
 	})
 	require.NoError(t, err)
 }
@@ -122,13 +166,27 @@ func SetupTestEnv(t *testing.T) *testmodels.TestEnv {
 	pwdHasher := auth.NewRealPwdHasher()
 	tokenGen := auth.NewRealTokenGenerator("your-secret-key", "your-issuer")
 	tokenExtractor := auth.NewRealTokenExtractor()
+	denylist := auth.NewMemoryTokenDenylist(auth.AccessTokenTTL)
+	testMailer := mailer.NewMemoryMailer()
 
 	testLogger := zap.NewNop()
 
-	authSvc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, tokenExtractor, pwdHasher, testLogger)
+	authSvc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, tokenExtractor, pwdHasher, denylist, testMailer, testLogger)
 	txSvc := transaction.NewTransactionService(txQ, testLogger)
-	userSvc := user.NewUserService(userQ, pwdHasher, testLogger)
+	txSvc.SqlTxQuerier = sqlTxQ
+	userSvc := user.NewUserService(userQ, pwdHasher, authSvc, testLogger)
+	importSvc := importer.NewImportService(database.NewRealImportQuerier(transactionalQ), txQ, txSvc, testLogger)
+	ruleSvc := rules.NewRuleService(database.NewRealRuleQuerier(transactionalQ), txQ, txSvc, testLogger)
+	txSvc.Rules = ruleSvc
+	txSvc.Categorizer = categorizer.NewCategorizerService(database.NewRealCategoryModelQuerier(transactionalQ), sqlTxQ, txQ, testLogger)
+	merchantSvc := merchant.NewMerchantService(database.NewRealMerchantQuerier(transactionalQ), sqlTxQ, txQ, testLogger)
+	txSvc.Merchants = merchantSvc
+	categorySvc := category.NewCategoryService(database.NewRealUserCategoryQuerier(transactionalQ), sqlTxQ, txQ, testLogger)
 
+	categoryH := categoryhandler.NewHandler(categorySvc)
+	importH := importhandler.NewHandler(importSvc)
+	merchantH := merchanthandler.NewHandler(merchantSvc)
+	ruleH := rulehandler.NewHandler(ruleSvc)
 	txH := txhandler.NewHandler(txSvc)
 	authH := httpauth.NewHandler(authSvc)
 	userH := httpuser.NewHandler(userSvc)
@@ -141,16 +199,25 @@ func SetupTestEnv(t *testing.T) *testmodels.TestEnv {
 		TxQ:     txQ,
 		TokenQ:  tokenQ,
 		DeviceQ: deviceQ,
+		Mailer:  testMailer,
 		Logger:  testLogger,
 		Services: &testmodels.Services{
-			AuthService: authSvc,
-			TxService:   txSvc,
-			UserService: userSvc,
+			AuthService:     authSvc,
+			CategoryService: categorySvc,
+			ImportService:   importSvc,
+			MerchantService: merchantSvc,
+			RuleService:     ruleSvc,
+			TxService:       txSvc,
+			UserService:     userSvc,
 		},
 		Handlers: &testmodels.Handlers{
-			AuthHandler: authH,
-			TxHandler:   txH,
-			UserHandler: userH,
+			AuthHandler:     authH,
+			CategoryHandler: categoryH,
+			ImportHandler:   importH,
+			MerchantHandler: merchantH,
+			RuleHandler:     ruleH,
+			TxHandler:       txH,
+			UserHandler:     userH,
 		},
 	}
 }
@@ -185,3 +252,12 @@ func SeedTestDeviceInfo(t *testing.T, deviceQ database.DeviceQuerier, userID uui
 	})
 	require.NoError(t, err)
 }
+
+// MerchantID returns the ID of the user's merchant with the given canonical name.
+func MerchantID(t *testing.T, db *sql.DB, userID uuid.UUID, name string) string {
+	t.Helper()
+	var id string
+	err := db.QueryRow("SELECT id FROM merchants WHERE user_id = ? AND name = ?", userID.String(), name).Scan(&id)
+	require.NoError(t, err)
+	return id
+}
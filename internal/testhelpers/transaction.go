@@ -11,11 +11,13 @@ func ConvertResponseFloatToInt(actualResponse map[string]interface{}) map[string
 		actualResponse["data"] = data
 		return actualResponse
 	}
-	if transactions, ok := data["transactions"].([]interface{}); ok {
-		for _, item := range transactions {
-			if tx, ok := item.(map[string]interface{}); ok {
-				if dc, ok := tx["detailed_category"].(float64); ok {
-					tx["detailed_category"] = int(dc)
+	for _, key := range []string{"transactions", "results"} {
+		if transactions, ok := data[key].([]interface{}); ok {
+			for _, item := range transactions {
+				if tx, ok := item.(map[string]interface{}); ok {
+					if dc, ok := tx["detailed_category"].(float64); ok {
+						tx["detailed_category"] = int(dc)
+					}
 				}
 			}
 		}
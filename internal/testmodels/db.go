@@ -5,10 +5,19 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/seanhuebl/unity-wealth/handlers/auth"
+	"github.com/seanhuebl/unity-wealth/handlers/category"
+	"github.com/seanhuebl/unity-wealth/handlers/importer"
+	"github.com/seanhuebl/unity-wealth/handlers/merchant"
+	"github.com/seanhuebl/unity-wealth/handlers/rules"
 	"github.com/seanhuebl/unity-wealth/handlers/transaction"
 	"github.com/seanhuebl/unity-wealth/handlers/user"
 	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
 	authSvc "github.com/seanhuebl/unity-wealth/internal/services/auth"
+	categorySvc "github.com/seanhuebl/unity-wealth/internal/services/category"
+	importSvc "github.com/seanhuebl/unity-wealth/internal/services/importer"
+	merchantSvc "github.com/seanhuebl/unity-wealth/internal/services/merchant"
+	ruleSvc "github.com/seanhuebl/unity-wealth/internal/services/rules"
 	txSvc "github.com/seanhuebl/unity-wealth/internal/services/transaction"
 	userSvc "github.com/seanhuebl/unity-wealth/internal/services/user"
 	"go.uber.org/zap"
@@ -21,19 +30,28 @@ type TestEnv struct {
 	TxQ      database.TransactionQuerier
 	TokenQ   database.TokenQuerier
 	DeviceQ  database.DeviceQuerier
+	Mailer   *mailer.MemoryMailer
 	Logger   *zap.Logger
 	Services *Services
 	Handlers *Handlers
 }
 
 type Services struct {
-	AuthService *authSvc.AuthService
-	TxService   *txSvc.TransactionService
-	UserService *userSvc.UserService
+	AuthService     *authSvc.AuthService
+	CategoryService *categorySvc.CategoryService
+	ImportService   *importSvc.ImportService
+	MerchantService *merchantSvc.MerchantService
+	RuleService     *ruleSvc.RuleService
+	TxService       *txSvc.TransactionService
+	UserService     *userSvc.UserService
 }
 
 type Handlers struct {
-	AuthHandler *auth.Handler
-	TxHandler   *transaction.Handler
-	UserHandler *user.Handler
+	AuthHandler     *auth.Handler
+	CategoryHandler *category.Handler
+	ImportHandler   *importer.Handler
+	MerchantHandler *merchant.Handler
+	RuleHandler     *rules.Handler
+	TxHandler       *transaction.Handler
+	UserHandler     *user.Handler
 }
@@ -1,6 +1,9 @@
 package testmodels
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/seanhuebl/unity-wealth/internal/models"
+)
 
 type BaseHTTPTestCase struct {
 	Name               string
@@ -51,9 +54,13 @@ func (d DeleteTxTestCase) BaseAccess() BaseHTTPTestCase {
 
 type GetAllTxByUserIDTestCase struct {
 	BaseHTTPTestCase
-	CursorDate        string
-	CursorID          string
+	Cursor            string
+	CursorPos         *models.TxCursor
+	ExpectedNext      *models.TxCursor
+	ExpectedPrev      *models.TxCursor
 	PageSize          int
+	Query             string
+	Filter            models.TxFilter
 	GetFirstPageErr   error
 	GetTxPaginatedErr error
 	FirstPageTest     bool
@@ -63,3 +70,16 @@ type GetAllTxByUserIDTestCase struct {
 func (allTx GetAllTxByUserIDTestCase) BaseAccess() BaseHTTPTestCase {
 	return allTx.BaseHTTPTestCase
 }
+
+type SearchTxTestCase struct {
+	BaseHTTPTestCase
+	Query     string
+	PageSize  int
+	Results   []models.TxSearchResult
+	SearchErr error
+	CallsSvc  bool
+}
+
+func (s SearchTxTestCase) BaseAccess() BaseHTTPTestCase {
+	return s.BaseHTTPTestCase
+}
@@ -1,23 +1,37 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/seanhuebl/unity-wealth/cache"
 	authHandler "github.com/seanhuebl/unity-wealth/handlers/auth"
 	"github.com/seanhuebl/unity-wealth/handlers/category"
 	"github.com/seanhuebl/unity-wealth/handlers/common"
+	importHandler "github.com/seanhuebl/unity-wealth/handlers/importer"
+	merchantHandler "github.com/seanhuebl/unity-wealth/handlers/merchant"
+	ruleHandler "github.com/seanhuebl/unity-wealth/handlers/rules"
 	txHandler "github.com/seanhuebl/unity-wealth/handlers/transaction"
 	userHandler "github.com/seanhuebl/unity-wealth/handlers/user"
 	"github.com/seanhuebl/unity-wealth/internal/config"
 	"github.com/seanhuebl/unity-wealth/internal/database"
+	"github.com/seanhuebl/unity-wealth/internal/idempotency"
+	"github.com/seanhuebl/unity-wealth/internal/mailer"
 	"github.com/seanhuebl/unity-wealth/internal/middleware"
 	"github.com/seanhuebl/unity-wealth/internal/models"
+	"github.com/seanhuebl/unity-wealth/internal/pagination"
 	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/services/categorizer"
+	categoryService "github.com/seanhuebl/unity-wealth/internal/services/category"
+	"github.com/seanhuebl/unity-wealth/internal/services/importer"
+	"github.com/seanhuebl/unity-wealth/internal/services/merchant"
+	"github.com/seanhuebl/unity-wealth/internal/services/rules"
 	"github.com/seanhuebl/unity-wealth/internal/services/transaction"
 	userService "github.com/seanhuebl/unity-wealth/internal/services/user"
 	"github.com/seanhuebl/unity-wealth/logger"
@@ -51,14 +65,50 @@ func main() {
 		Queries:  database.New(db),
 		Database: db,
 	}
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+		}
+	}
 
 	if err := cache.WarmCategoriesCache(cfg); err != nil {
 		appLogger.Warn("unable to warm cache", zap.Error(err))
 	}
 
-	pwdHasher := auth.NewRealPwdHasher()
+	pwdHasher := auth.NewArgon2idHasher(auth.DefaultArgon2idParams)
 	tokenGen := auth.NewRealTokenGenerator(os.Getenv("TOKEN_SECRET"), models.TokenType(os.Getenv("TOKEN_TYPE")))
+	if keySource := signingKeySource(); keySource.Dir != "" || keySource.ActiveFile != "" {
+		keyRing, err := auth.LoadKeyRing(keySource)
+		if err != nil {
+			appLogger.Fatal("unable to load signing keys", zap.Error(err))
+		}
+		keyRing.Watch(context.Background(), time.Minute, appLogger)
+		tokenGen = auth.NewKeyRingTokenGenerator(keyRing, models.TokenType(os.Getenv("TOKEN_TYPE")))
+		appLogger.Info("signing tokens with key ring", zap.String("kid", keyRing.Active().ID))
+		// JWT_LEGACY_SECRET_UNTIL (RFC 3339) keeps TOKEN_SECRET tokens valid while
+		// clients move over. Without it they are refused.
+		if until := os.Getenv("JWT_LEGACY_SECRET_UNTIL"); until != "" {
+			cutover, err := time.Parse(time.RFC3339, until)
+			if err != nil {
+				appLogger.Fatal("invalid JWT_LEGACY_SECRET_UNTIL", zap.Error(err))
+			}
+			if os.Getenv("TOKEN_SECRET") == "" {
+				appLogger.Fatal("JWT_LEGACY_SECRET_UNTIL is set but TOKEN_SECRET is empty")
+			}
+			tokenGen.AcceptLegacySecret(os.Getenv("TOKEN_SECRET"), cutover, appLogger)
+			appLogger.Warn("accepting tokens signed with TOKEN_SECRET", zap.Time("until", cutover))
+		}
+	}
 	tokenExtract := auth.NewRealTokenExtractor()
+	denylist := auth.NewRedisTokenDenylist(cache.RedisClient, auth.AccessTokenTTL)
+
+	var appMailer auth.Mailer
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		appMailer = mailer.NewSMTPMailer(smtpHost, os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("MAIL_FROM"))
+	} else {
+		appLogger.Warn("SMTP_HOST not set, writing outgoing mail to ./mail")
+		appMailer = mailer.NewFileMailer("mail", os.Getenv("MAIL_FROM"))
+	}
 
 	transactionalQ := database.NewRealTransactionalQuerier(cfg.Queries)
 
@@ -66,13 +116,41 @@ func main() {
 	txQ := database.NewRealTransactionQuerier(transactionalQ)
 	userQ := database.NewRealUserQuerier(transactionalQ)
 
-	authSvc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, tokenExtract, pwdHasher, appLogger)
+	authSvc := auth.NewAuthService(sqlTxQ, userQ, tokenGen, tokenExtract, pwdHasher, denylist, appMailer, appLogger)
+	if refreshKey := os.Getenv("REFRESH_TOKEN_KEY"); refreshKey != "" {
+		authSvc.RefreshHasher = auth.NewHMACTokenHasher([]byte(refreshKey))
+	} else {
+		appLogger.Warn("REFRESH_TOKEN_KEY not set, refresh tokens are hashed without a key")
+	}
+	authSvc.UnverifiedEmailPolicy, err = auth.ParseUnverifiedEmailPolicy(os.Getenv("UNVERIFIED_EMAIL_POLICY"))
+	if err != nil {
+		appLogger.Fatal("invalid UNVERIFIED_EMAIL_POLICY", zap.Error(err))
+	}
+	attemptStore := auth.NewFallbackAttemptStore(auth.NewRedisAttemptStore(cache.RedisClient), auth.NewMemoryAttemptStore(), appLogger)
+	authSvc.Throttle = auth.NewLoginThrottle(attemptStore, appLogger)
+	authSvc.PasswordResetLimiter = auth.NewRateLimiter(attemptStore, "password_reset:email", 3, time.Hour)
 	txnSvc := transaction.NewTransactionService(txQ, appLogger)
-	userSvc := userService.NewUserService(cfg.Queries, pwdHasher, appLogger)
+	txnSvc.SqlTxQuerier = sqlTxQ
+	if cursorKey := os.Getenv("CURSOR_SECRET"); cursorKey != "" {
+		txnSvc.Cursors = pagination.NewCursorCodec([]byte(cursorKey))
+	} else {
+		appLogger.Warn("CURSOR_SECRET not set, page cursors will not survive a restart")
+	}
+	userSvc := userService.NewUserService(cfg.Queries, pwdHasher, authSvc, appLogger)
+	importSvc := importer.NewImportService(database.NewRealImportQuerier(transactionalQ), txQ, txnSvc, appLogger)
+	ruleSvc := rules.NewRuleService(database.NewRealRuleQuerier(transactionalQ), txQ, txnSvc, appLogger)
+	txnSvc.Rules = ruleSvc
+	txnSvc.Categorizer = categorizer.NewCategorizerService(database.NewRealCategoryModelQuerier(transactionalQ), sqlTxQ, txQ, appLogger)
+	merchantSvc := merchant.NewMerchantService(database.NewRealMerchantQuerier(transactionalQ), sqlTxQ, txQ, appLogger)
+	txnSvc.Merchants = merchantSvc
+	categorySvc := categoryService.NewCategoryService(database.NewRealUserCategoryQuerier(transactionalQ), sqlTxQ, txQ, appLogger)
 
 	authHandler := authHandler.NewHandler(authSvc)
-	catHandler := category.NewHandler()
+	catHandler := category.NewHandler(categorySvc)
 	commonHandler := common.NewHandler()
+	importHandler := importHandler.NewHandler(importSvc)
+	merchantHandler := merchantHandler.NewHandler(merchantSvc)
+	ruleHandler := ruleHandler.NewHandler(ruleSvc)
 	txHandler := txHandler.NewHandler(txnSvc)
 	userHandler := userHandler.NewHandler(userSvc)
 
@@ -80,12 +158,22 @@ func main() {
 		authHandler,
 		catHandler,
 		commonHandler,
+		importHandler,
+		merchantHandler,
+		ruleHandler,
 		txHandler,
 		userHandler,
 	)
-	m := middleware.NewMiddleware(tokenGen, tokenExtract)
+	m := middleware.NewMiddleware(tokenGen, tokenExtract, denylist)
+	m.SignupLimiter = auth.NewRateLimiter(attemptStore, "signup", 5, time.Hour)
+	m.ForgotPasswordLimiter = auth.NewRateLimiter(attemptStore, "password_reset:ip", 10, time.Hour)
+	m.APIKeys = authSvc
+	m.Idempotency = idempotency.NewRedisStore(cache.RedisClient)
 
-	router := server.NewRouter(cfg, h, m, appLogger)
+	router, err := server.NewRouter(cfg, h, m, appLogger)
+	if err != nil {
+		appLogger.Fatal("invalid TRUSTED_PROXIES", zap.Error(err))
+	}
 
 	appLogger.Info("starting server", zap.String("port", cfg.Port))
 	err = router.Run(cfg.Port)
@@ -94,3 +182,20 @@ func main() {
 	}
 
 }
+
+// signingKeySource reads the JWT key locations. JWT_KEYS_DIR holds <kid>.pem files;
+// JWT_SIGNING_KEY_FILE and the comma separated JWT_PREVIOUS_KEY_FILES name keys
+// individually. With neither set, tokens are signed with TOKEN_SECRET.
+func signingKeySource() auth.KeyRingSource {
+	source := auth.KeyRingSource{
+		Dir:        os.Getenv("JWT_KEYS_DIR"),
+		ActiveFile: os.Getenv("JWT_SIGNING_KEY_FILE"),
+		ActiveKID:  os.Getenv("JWT_ACTIVE_KID"),
+	}
+	for _, path := range strings.Split(os.Getenv("JWT_PREVIOUS_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			source.PreviousFiles = append(source.PreviousFiles, path)
+		}
+	}
+	return source
+}
@@ -4,24 +4,33 @@ import (
 	"github.com/seanhuebl/unity-wealth/handlers/auth"
 	"github.com/seanhuebl/unity-wealth/handlers/category"
 	"github.com/seanhuebl/unity-wealth/handlers/common"
+	"github.com/seanhuebl/unity-wealth/handlers/importer"
+	"github.com/seanhuebl/unity-wealth/handlers/merchant"
+	"github.com/seanhuebl/unity-wealth/handlers/rules"
 	"github.com/seanhuebl/unity-wealth/handlers/transaction"
 	"github.com/seanhuebl/unity-wealth/handlers/user"
 )
 
 type HandlersGroup struct {
-	Auth *auth.Handler
-	Cat  *category.Handler
-	Cmn  *common.Handler
-	Tx   *transaction.Handler
-	User *user.Handler
+	Auth     *auth.Handler
+	Cat      *category.Handler
+	Cmn      *common.Handler
+	Import   *importer.Handler
+	Merchant *merchant.Handler
+	Rules    *rules.Handler
+	Tx       *transaction.Handler
+	User     *user.Handler
 }
 
-func NewHandlers(authHandler *auth.Handler, catHandler *category.Handler, commonHandler *common.Handler, txHandler *transaction.Handler, userHandler *user.Handler) *HandlersGroup {
+func NewHandlers(authHandler *auth.Handler, catHandler *category.Handler, commonHandler *common.Handler, importHandler *importer.Handler, merchantHandler *merchant.Handler, ruleHandler *rules.Handler, txHandler *transaction.Handler, userHandler *user.Handler) *HandlersGroup {
 	return &HandlersGroup{
-		Auth: authHandler,
-		Cat:  catHandler,
-		Cmn:  commonHandler,
-		Tx:   txHandler,
-		User: userHandler,
+		Auth:     authHandler,
+		Cat:      catHandler,
+		Cmn:      commonHandler,
+		Import:   importHandler,
+		Merchant: merchantHandler,
+		Rules:    ruleHandler,
+		Tx:       txHandler,
+		User:     userHandler,
 	}
 }
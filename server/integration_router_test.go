@@ -0,0 +1,71 @@
+package server_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seanhuebl/unity-wealth/internal/config"
+	"github.com/seanhuebl/unity-wealth/internal/middleware"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
+	"github.com/seanhuebl/unity-wealth/internal/testhelpers"
+	"github.com/seanhuebl/unity-wealth/server"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIntegrationRouterClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		expectedStatus int
+	}{
+		{
+			name:           "spoofed X-Forwarded-For does not reset the IP lockout",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "a trusted proxy forwards the client IP",
+			trustedProxies: []string{"192.0.2.1"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := testhelpers.SetupTestEnv(t)
+			defer env.Db.Close()
+			throttle := auth.NewLoginThrottle(auth.NewMemoryAttemptStore(), zap.NewNop())
+			throttle.BaseDelay = 0
+			throttle.MaxIPFailures = 3
+			env.Services.AuthService.Throttle = throttle
+
+			h := server.NewHandlers(env.Handlers.AuthHandler, env.Handlers.CategoryHandler, nil, env.Handlers.ImportHandler,
+				env.Handlers.MerchantHandler, env.Handlers.RuleHandler, env.Handlers.TxHandler, env.Handlers.UserHandler)
+			router, err := server.NewRouter(&config.ApiConfig{TrustedProxies: tc.trustedProxies}, h, middleware.NewMiddleware(nil, nil, nil), zap.NewNop())
+			require.NoError(t, err)
+
+			// Every attempt uses a new email and claims a new address, so only the
+			// counter for the caller's IP can stop it.
+			var w *httptest.ResponseRecorder
+			for i := int64(0); i <= throttle.MaxIPFailures; i++ {
+				body := fmt.Sprintf(`{"email": "user%d@example.com", "password": "Wrongpass1!"}`, i)
+				req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(body))
+				req.RemoteAddr = "192.0.2.1:4711"
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i+1))
+				req.Header.Set("X-Device-Info", "os=Android; os_version=11; device_type=Mobile; browser=Chrome; browser_version=100.0")
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+			}
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		_, err := server.NewRouter(&config.ApiConfig{TrustedProxies: []string{"not-an-ip"}}, &server.HandlersGroup{}, middleware.NewMiddleware(nil, nil, nil), zap.NewNop())
+		require.Error(t, err)
+	})
+}
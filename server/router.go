@@ -3,24 +3,37 @@ package server
 import (
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seanhuebl/unity-wealth/internal/config"
 	"github.com/seanhuebl/unity-wealth/internal/middleware"
+	"github.com/seanhuebl/unity-wealth/internal/services/auth"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// NewRouter builds the HTTP routes. Only cfg.TrustedProxies may set the client IP
+// with X-Forwarded-For; throttles and rate limits key on that IP, so any other caller
+// is known by its peer address.
 func NewRouter(
 	cfg *config.ApiConfig,
 	h *HandlersGroup,
 	m *middleware.Middleware,
 	logger *zap.Logger,
-) *gin.Engine {
+) (*gin.Engine, error) {
 
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
 
 	r.Use(func(c *gin.Context) {
 		start := time.Now()
@@ -46,32 +59,111 @@ func NewRouter(
 		},
 	))
 
-	registerPublicRoutes(r, h)
+	registerPublicRoutes(r, h, m)
 	registerAppRoutes(r, h, m)
 	registerLookupRoutes(r, h)
 
-	return r
+	return r, nil
 }
 
 // helpers
 
-func registerPublicRoutes(r *gin.Engine, h *HandlersGroup) {
+func registerPublicRoutes(r *gin.Engine, h *HandlersGroup, m *middleware.Middleware) {
 	public := r.Group("/")
-	public.POST("signup", h.User.SignUp)
+	public.Use(m.RequestContextMiddleware())
+	public.POST("signup", m.SignupRateLimitMiddleware(), h.User.SignUp)
 	public.POST("login", h.Auth.Login)
+	public.POST("login/mfa", h.Auth.LoginMFA)
+	public.POST("refresh", h.Auth.RefreshToken)
+	public.POST("password/forgot", m.ForgotPasswordRateLimitMiddleware(), h.Auth.ForgotPassword)
+	public.POST("password/reset", h.Auth.ResetPassword)
+	public.GET("verify-email", h.Auth.VerifyEmail)
+	public.POST("verify-email/resend", h.Auth.ResendVerificationEmail)
 	public.GET("health", h.Cmn.Health)
+	public.GET(".well-known/jwks.json", h.Auth.JWKS)
 }
 
 func registerAppRoutes(r *gin.Engine, h *HandlersGroup, m *middleware.Middleware) {
 	app := r.Group("/app")
 	app.Use(m.UserAuthMiddleware(), m.ClaimsAuthMiddleware())
 
-	app.POST("transactions", h.Tx.NewTransaction)
-	app.GET("transactions", h.Tx.GetTransactionsByUserID)
-	app.GET("transactions/:id", h.Tx.GetTransactionByID)
-	app.POST("transactions/:id", h.Tx.UpdateTransaction) // I want full transaction update to be re-written not partiel
-	app.DELETE("transactions/:id", h.Tx.DeleteTransaction)
+	app.POST("logout", h.Auth.Logout)
+	app.POST("logout/all", h.Auth.LogoutAll)
+	app.GET("sessions", h.Auth.ListSessions)
+	app.DELETE("sessions/:id", h.Auth.RevokeSession)
+	app.POST("mfa/totp/enroll", h.Auth.EnrollTOTP)
+	app.POST("mfa/totp/confirm", h.Auth.ConfirmTOTP)
+	app.POST("mfa/totp/disable", h.Auth.DisableTOTP)
+	app.POST("api-keys", h.Auth.CreateAPIKey)
+	app.GET("api-keys", h.Auth.ListAPIKeys)
+	app.DELETE("api-keys/:id", h.Auth.RevokeAPIKey)
+
+	// Transaction routes also accept personal API keys, limited by scope.
+	data := r.Group("/app")
+	// Idempotency-Key only applies here; the auth routes above return secrets that
+	// should not be kept for replay.
+	data.Use(m.ApiKeyAuthMiddleware(), m.UserAuthMiddleware(), m.ClaimsAuthMiddleware(), m.IdempotencyMiddleware())
+
+	data.POST("transactions", m.RequireScope(auth.ScopeTransactionsWrite), h.Tx.NewTransaction)
+	data.POST("transactions:method", m.RequireScope(auth.ScopeTransactionsWrite), customMethods(map[string]gin.HandlerFunc{
+		"batch": h.Tx.BatchTransactions,
+	}))
+	data.GET("transactions", m.RequireScope(auth.ScopeTransactionsRead), m.PaginationMiddleware(defaultPageSize, maxPageSize), h.Tx.GetTransactionsByUserID)
+	data.GET("transactions/search", m.RequireScope(auth.ScopeTransactionsRead), m.PaginationMiddleware(defaultPageSize, maxPageSize), h.Tx.SearchTransactions)
+	data.GET("transactions/export", m.RequireScope(auth.ScopeTransactionsRead), h.Tx.ExportTransactions)
+	data.GET("transactions/:id", m.RequireScope(auth.ScopeTransactionsRead), h.Tx.GetTransactionByID)
+	data.GET("transactions/:id/suggestions", m.RequireScope(auth.ScopeTransactionsRead), h.Tx.GetSuggestions)
+	data.PUT("transactions/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Tx.UpdateTransaction)
+	data.PATCH("transactions/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Tx.PatchTransaction)
+	// POST is the original full update route, kept for existing clients.
+	data.POST("transactions/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Tx.UpdateTransaction)
+	data.DELETE("transactions/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Tx.DeleteTransaction)
+
+	data.POST("imports/csv", m.RequireScope(auth.ScopeTransactionsWrite), h.Import.ImportCSV)
+	data.POST("imports/csv/preview", m.RequireScope(auth.ScopeTransactionsWrite), h.Import.PreviewCSV)
+	data.POST("imports/ofx", m.RequireScope(auth.ScopeTransactionsWrite), h.Import.ImportOFX)
+	data.POST("imports/qif", m.RequireScope(auth.ScopeTransactionsWrite), h.Import.ImportQIF)
+	data.GET("imports/mappings", m.RequireScope(auth.ScopeTransactionsRead), h.Import.ListMappings)
+	data.PUT("imports/mappings/:bank", m.RequireScope(auth.ScopeTransactionsWrite), h.Import.SaveMapping)
+	data.DELETE("imports/mappings/:bank", m.RequireScope(auth.ScopeTransactionsWrite), h.Import.DeleteMapping)
 
+	data.GET("categories", m.RequireScope(auth.ScopeTransactionsRead), h.Cat.GetCategories)
+	data.POST("categories", m.RequireScope(auth.ScopeTransactionsWrite), h.Cat.CreateCategory)
+	data.POST("categories:method", m.RequireScope(auth.ScopeTransactionsWrite), customMethods(map[string]gin.HandlerFunc{
+		"merge": h.Cat.MergeCategories,
+	}))
+	data.PATCH("categories/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Cat.UpdateCategory)
+
+	data.GET("merchants", m.RequireScope(auth.ScopeTransactionsRead), h.Merchant.ListMerchants)
+	data.POST("merchants:method", m.RequireScope(auth.ScopeTransactionsWrite), customMethods(map[string]gin.HandlerFunc{
+		"merge": h.Merchant.MergeMerchants,
+	}))
+	data.PATCH("merchants/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Merchant.UpdateMerchant)
+
+	data.GET("rules", m.RequireScope(auth.ScopeTransactionsRead), h.Rules.ListRules)
+	data.POST("rules", m.RequireScope(auth.ScopeTransactionsWrite), h.Rules.CreateRule)
+	data.POST("rules:method", m.RequireScope(auth.ScopeTransactionsWrite), customMethods(map[string]gin.HandlerFunc{
+		"dryRun": h.Rules.DryRun,
+		"apply":  h.Rules.ApplyRules,
+	}))
+	data.PUT("rules/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Rules.UpdateRule)
+	data.DELETE("rules/:id", m.RequireScope(auth.ScopeTransactionsWrite), h.Rules.DeleteRule)
+
+}
+
+// customMethods dispatches "collection:method" routes such as transactions:batch.
+// gin cannot route a literal colon, so the route is registered with a :method
+// parameter, which captures the colon along with the name.
+func customMethods(methods map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name, ok := strings.CutPrefix(ctx.Param("method"), ":")
+		handler, found := methods[name]
+		if !ok || !found {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		handler(ctx)
+	}
 }
 
 func registerLookupRoutes(r *gin.Engine, h *HandlersGroup) {
@@ -0,0 +1,23 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6);
+-- name: GetAPIKeyByPrefix :one
+SELECT *
+FROM api_keys
+WHERE prefix = ?1;
+-- name: ListUserAPIKeys :many
+SELECT *
+FROM api_keys
+WHERE user_id = ?1
+    AND revoked_at IS NULL
+ORDER BY created_at DESC;
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = ?1
+WHERE id = ?2
+    AND user_id = ?3
+    AND revoked_at IS NULL;
+-- name: UpdateAPIKeyLastUsed :exec
+UPDATE api_keys
+SET last_used_at = ?1
+WHERE id = ?2;
\ No newline at end of file
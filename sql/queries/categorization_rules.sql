@@ -0,0 +1,39 @@
+-- name: CountUserRules :one
+SELECT COUNT(*)
+FROM categorization_rules
+WHERE user_id = ?1;
+-- name: CreateRule :exec
+INSERT INTO categorization_rules (
+        id,
+        user_id,
+        name,
+        priority,
+        conditions,
+        actions
+    )
+VALUES (?1, ?2, ?3, ?4, ?5, ?6);
+-- name: DeleteRule :execrows
+DELETE FROM categorization_rules
+WHERE id = ?1
+    AND user_id = ?2;
+-- name: GetRule :one
+SELECT *
+FROM categorization_rules
+WHERE id = ?1
+    AND user_id = ?2;
+-- name: ListUserRules :many
+SELECT *
+FROM categorization_rules
+WHERE user_id = ?1
+ORDER BY priority,
+    created_at,
+    id;
+-- name: UpdateRule :execrows
+UPDATE categorization_rules
+SET name = ?3,
+    priority = ?4,
+    conditions = ?5,
+    actions = ?6,
+    updated_at = ?7
+WHERE id = ?1
+    AND user_id = ?2;
\ No newline at end of file
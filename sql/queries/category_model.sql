@@ -0,0 +1,44 @@
+-- name: AddCategoryFeature :exec
+INSERT INTO category_model_features (user_id, feature, detailed_category_id, count)
+VALUES (?1, ?2, ?3, ?4) ON CONFLICT (user_id, feature, detailed_category_id) DO
+UPDATE
+SET count = count + excluded.count;
+-- name: DeleteCategoryExample :exec
+DELETE FROM category_model_examples
+WHERE transaction_id = ?1
+    AND user_id = ?2;
+-- name: DeleteEmptyCategoryFeatures :exec
+DELETE FROM category_model_features
+WHERE user_id = ?1
+    AND count <= 0;
+-- name: GetCategoryExample :one
+SELECT *
+FROM category_model_examples
+WHERE transaction_id = ?1
+    AND user_id = ?2;
+-- name: ListCategoryFeatures :many
+SELECT *
+FROM category_model_features
+WHERE user_id = ?1;
+-- name: ListUnlearnedTransactions :many
+SELECT t.id,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id
+FROM transactions t
+    LEFT JOIN category_model_examples e ON e.transaction_id = t.id
+WHERE t.user_id = ?1
+    AND e.transaction_id IS NULL
+ORDER BY t.id
+LIMIT ?2;
+-- name: SaveCategoryExample :exec
+INSERT INTO category_model_examples (
+        transaction_id,
+        user_id,
+        detailed_category_id,
+        features
+    )
+VALUES (?1, ?2, ?3, ?4) ON CONFLICT (transaction_id) DO
+UPDATE
+SET detailed_category_id = excluded.detailed_category_id,
+    features = excluded.features;
\ No newline at end of file
@@ -16,7 +16,8 @@ INSERT INTO device_info_logs (
         browser,
         browser_version,
         os,
-        os_version
+        os_version,
+        app_info
     )
 VALUES (
         ?1,
@@ -25,6 +26,42 @@ VALUES (
         ?4,
         ?5,
         ?6,
-        ?7
+        ?7,
+        ?8
     )
-RETURNING id;
\ No newline at end of file
+RETURNING id;
+-- name: GetDeviceInfoByID :one
+SELECT id,
+    user_id,
+    device_type,
+    browser,
+    browser_version,
+    os,
+    os_version,
+    app_info,
+    created_at,
+    last_used_at
+FROM device_info_logs
+WHERE id = ?1
+    AND user_id = ?2;
+-- name: UpdateDeviceLastUsed :exec
+UPDATE device_info_logs
+SET last_used_at = ?1,
+    app_info = COALESCE(?2, app_info)
+WHERE id = ?3;
+-- name: ListUserSessions :many
+SELECT d.id,
+    d.device_type,
+    d.browser,
+    d.browser_version,
+    d.os,
+    d.os_version,
+    d.app_info,
+    d.created_at,
+    d.last_used_at,
+    r.expires_at
+FROM device_info_logs d
+    LEFT JOIN refresh_tokens r ON r.device_info_id = d.id
+    AND r.revoked_at IS NULL
+WHERE d.user_id = ?1
+ORDER BY d.last_used_at DESC;
\ No newline at end of file
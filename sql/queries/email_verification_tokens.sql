@@ -0,0 +1,23 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (id, token_hash, user_id, expires_at)
+VALUES (?1, ?2, ?3, ?4);
+-- name: GetEmailVerificationTokenByHash :one
+SELECT *
+FROM email_verification_tokens
+WHERE token_hash = ?1;
+-- name: GetLatestEmailVerificationToken :one
+SELECT *
+FROM email_verification_tokens
+WHERE user_id = ?1
+ORDER BY created_at DESC
+LIMIT 1;
+-- name: MarkEmailVerificationTokenUsed :execrows
+UPDATE email_verification_tokens
+SET used_at = ?1
+WHERE id = ?2
+    AND used_at IS NULL;
+-- name: InvalidateUserEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = ?1
+WHERE user_id = ?2
+    AND used_at IS NULL;
\ No newline at end of file
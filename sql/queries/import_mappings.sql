@@ -0,0 +1,20 @@
+-- name: DeleteImportMapping :execrows
+DELETE FROM import_mappings
+WHERE user_id = ?1
+    AND bank = ?2;
+-- name: GetImportMapping :one
+SELECT *
+FROM import_mappings
+WHERE user_id = ?1
+    AND bank = ?2;
+-- name: ListImportMappings :many
+SELECT *
+FROM import_mappings
+WHERE user_id = ?1
+ORDER BY bank;
+-- name: UpsertImportMapping :exec
+INSERT INTO import_mappings (user_id, bank, mapping, updated_at)
+VALUES (?1, ?2, ?3, ?4) ON CONFLICT (user_id, bank) DO
+UPDATE
+SET mapping = excluded.mapping,
+    updated_at = excluded.updated_at;
\ No newline at end of file
@@ -0,0 +1,80 @@
+-- name: CreateMerchant :exec
+INSERT INTO merchants (id, user_id, name)
+VALUES (?1, ?2, ?3);
+-- name: CreateMerchantAlias :execrows
+INSERT INTO merchant_aliases (user_id, alias, merchant_id)
+VALUES (?1, ?2, ?3) ON CONFLICT (user_id, alias) DO NOTHING;
+-- name: DeleteMerchant :execrows
+DELETE FROM merchants
+WHERE id = ?1
+    AND user_id = ?2;
+-- name: GetMerchant :one
+SELECT *
+FROM merchants
+WHERE id = ?1
+    AND user_id = ?2;
+-- name: GetMerchantByAlias :one
+SELECT m.*
+FROM merchant_aliases a
+    JOIN merchants m ON m.id = a.merchant_id
+WHERE a.user_id = ?1
+    AND a.alias = ?2;
+-- name: LinkTransactionMerchant :exec
+UPDATE transactions
+SET merchant_id = sqlc.arg(merchant_id),
+    merchant = sqlc.arg(merchant),
+    updated_at = sqlc.arg(updated_at),
+    version = version + 1
+WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    AND merchant_id IS NULL;
+-- name: ListMerchantAliases :many
+SELECT *
+FROM merchant_aliases
+WHERE user_id = ?1
+ORDER BY merchant_id,
+    alias;
+-- name: ListUnlinkedTransactions :many
+SELECT id,
+    raw_merchant
+FROM transactions
+WHERE user_id = ?1
+    AND merchant_id IS NULL
+ORDER BY id
+LIMIT ?2;
+-- name: ListUserMerchants :many
+SELECT m.id,
+    m.name,
+    m.default_category_id,
+    COUNT(t.id) AS transaction_count
+FROM merchants m
+    LEFT JOIN transactions t ON t.merchant_id = m.id
+    AND t.user_id = m.user_id
+WHERE m.user_id = ?1
+GROUP BY m.id
+ORDER BY m.name COLLATE NOCASE,
+    m.id;
+-- name: MoveMerchantAliases :exec
+UPDATE merchant_aliases
+SET merchant_id = sqlc.arg(to_merchant_id)
+WHERE user_id = sqlc.arg(user_id)
+    AND merchant_id = sqlc.arg(from_merchant_id);
+-- name: UpdateMerchant :execrows
+UPDATE merchants
+SET name = sqlc.arg(name),
+    default_category_id = sqlc.narg(default_category_id),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id);
+-- name: UpdateMerchantTransactions :execrows
+UPDATE transactions
+SET merchant_id = sqlc.arg(merchant_id),
+    merchant = sqlc.arg(merchant),
+    updated_at = sqlc.arg(updated_at),
+    version = version + 1
+WHERE user_id = sqlc.arg(user_id)
+    AND merchant_id = sqlc.arg(from_merchant_id)
+    AND (
+        merchant_id <> sqlc.arg(merchant_id)
+        OR merchant <> sqlc.arg(merchant)
+    );
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (id, user_id, code_hash)
+VALUES (?1, ?2, ?3);
+-- name: UseMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = ?1
+WHERE user_id = ?2
+    AND code_hash = ?3
+    AND used_at IS NULL;
+-- name: DeleteUserMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = ?1;
+-- name: CreateMFAChallenge :exec
+INSERT INTO mfa_challenges (id, token_hash, user_id, expires_at)
+VALUES (?1, ?2, ?3, ?4);
+-- name: GetMFAChallengeByHash :one
+SELECT *
+FROM mfa_challenges
+WHERE token_hash = ?1;
+-- name: IncrementMFAChallengeAttempts :exec
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = ?1;
+-- name: MarkMFAChallengeUsed :execrows
+UPDATE mfa_challenges
+SET used_at = ?1
+WHERE id = ?2
+    AND used_at IS NULL;;
+-- name: CreateMFATrustedDevice :exec
+INSERT INTO mfa_trusted_devices (id, token_hash, user_id, expires_at)
+VALUES (?1, ?2, ?3, ?4);
+-- name: GetMFATrustedDeviceByHash :one
+SELECT *
+FROM mfa_trusted_devices
+WHERE token_hash = ?1;
+-- name: DeleteUserMFATrustedDevices :exec
+DELETE FROM mfa_trusted_devices
+WHERE user_id = ?1;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (id, token_hash, user_id, expires_at)
+VALUES (?1, ?2, ?3, ?4);
+-- name: GetPasswordResetTokenByHash :one
+SELECT *
+FROM password_reset_tokens
+WHERE token_hash = ?1;
+-- name: MarkPasswordResetTokenUsed :execrows
+UPDATE password_reset_tokens
+SET used_at = ?1
+WHERE id = ?2
+    AND used_at IS NULL;
+-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens
+SET used_at = ?1
+WHERE user_id = ?2
+    AND used_at IS NULL;
\ No newline at end of file
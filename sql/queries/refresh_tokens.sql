@@ -5,7 +5,8 @@ INSERT INTO refresh_tokens (
         expires_at,
         revoked_at,
         user_id,
-        device_info_id
+        device_info_id,
+        family_id
     )
 VALUES (
         ?1,
@@ -13,7 +14,8 @@ VALUES (
         ?3,
         NULL,
         ?4,
-        ?5
+        ?5,
+        ?6
     );
 -- name: RevokeToken :exec
 UPDATE refresh_tokens
@@ -25,4 +27,26 @@ WHERE user_id = ?2
 SELECT *
 FROM refresh_tokens
 WHERE user_id = ?1
-    AND device_info_id = ?2;
\ No newline at end of file
+    AND device_info_id = ?2
+    AND revoked_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+-- name: GetRefreshTokenByID :one
+SELECT *
+FROM refresh_tokens
+WHERE id = ?1;
+-- name: RevokeTokenByID :execrows
+UPDATE refresh_tokens
+SET revoked_at = ?1
+WHERE id = ?2
+    AND revoked_at IS NULL;
+-- name: RevokeTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = ?1
+WHERE family_id = ?2
+    AND revoked_at IS NULL;
+-- name: RevokeAllUserTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = ?1
+WHERE user_id = ?2
+    AND revoked_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- name: CreateTransactionSplit :exec
+INSERT INTO transaction_splits (
+        transaction_id,
+        line,
+        amount_cents,
+        detailed_category_id,
+        memo
+    )
+VALUES (?1, ?2, ?3, ?4, ?5);
+-- name: DeleteTransactionSplits :exec
+DELETE FROM transaction_splits
+WHERE transaction_id IN (
+        SELECT id
+        FROM transactions
+        WHERE id = sqlc.arg(transaction_id)
+            AND user_id = sqlc.arg(user_id)
+    );
+-- name: ListTransactionSplits :many
+SELECT *
+FROM transaction_splits
+WHERE transaction_id = ?1
+ORDER BY line;
\ No newline at end of file
@@ -5,76 +5,301 @@ INSERT INTO transactions (
         transaction_date,
         merchant,
         amount_cents,
-        detailed_category_id
+        detailed_category_id,
+        notes,
+        tags,
+        external_id,
+        merchant_id,
+        raw_merchant
     )
-VALUES (?1, ?2, ?3, ?4, ?5, ?6);
--- name: GetDetailedCategoryID :one
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11);
+-- name: GetTransactionIDByExternalID :one
 SELECT id
-FROM detailed_categories
-WHERE name = ?1;
+FROM transactions
+WHERE user_id = ?1
+    AND external_id = ?2;
+-- name: GetDetailedCategoryID :one
+SELECT d.id
+FROM detailed_categories d
+    JOIN primary_categories p ON p.id = d.primary_category_id
+    LEFT JOIN user_categories u ON u.detailed_category_id = d.id
+WHERE upper(p.name || '_' || d.name) = upper(sqlc.arg(name))
+    AND (
+        u.user_id IS NULL
+        OR (
+            u.user_id = sqlc.arg(user_id)
+            AND u.archived_at IS NULL
+        )
+    )
+ORDER BY d.id
+LIMIT 1;
 -- name: UpdateTransactionByID :one
 UPDATE transactions
-SET transaction_date = ?1,
-    merchant = ?2,
-    amount_cents = ?3,
-    detailed_category_id = ?4,
-    updated_at = ?5
-WHERE id = ?6
+SET transaction_date = sqlc.arg(transaction_date),
+    merchant = sqlc.arg(merchant),
+    merchant_id = sqlc.narg(merchant_id),
+    raw_merchant = CASE
+        WHEN sqlc.arg(raw_merchant) = merchant THEN raw_merchant
+        ELSE sqlc.arg(raw_merchant)
+    END,
+    amount_cents = sqlc.arg(amount_cents),
+    detailed_category_id = sqlc.arg(detailed_category_id),
+    notes = sqlc.arg(notes),
+    tags = sqlc.arg(tags),
+    updated_at = sqlc.arg(updated_at),
+    version = version + 1
+WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    AND version = COALESCE(sqlc.narg(expected_version), version)
+RETURNING id,
+    transaction_date,
+    merchant,
+    amount_cents,
+    detailed_category_id,
+    notes,
+    tags,
+    updated_at,
+    version,
+    merchant_id,
+    raw_merchant;
+-- name: PatchTransactionByID :one
+UPDATE transactions
+SET transaction_date = COALESCE(sqlc.narg(transaction_date), transaction_date),
+    merchant = COALESCE(sqlc.narg(merchant), merchant),
+    merchant_id = CASE
+        WHEN sqlc.narg(merchant) IS NULL THEN merchant_id
+        ELSE sqlc.narg(merchant_id)
+    END,
+    raw_merchant = CASE
+        WHEN sqlc.narg(raw_merchant) IS NULL
+        OR sqlc.narg(raw_merchant) = merchant THEN raw_merchant
+        ELSE sqlc.narg(raw_merchant)
+    END,
+    amount_cents = COALESCE(sqlc.narg(amount_cents), amount_cents),
+    detailed_category_id = COALESCE(
+        sqlc.narg(detailed_category_id),
+        detailed_category_id
+    ),
+    notes = COALESCE(sqlc.narg(notes), notes),
+    tags = COALESCE(sqlc.narg(tags), tags),
+    updated_at = sqlc.arg(updated_at),
+    version = version + 1
+WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    AND version = COALESCE(sqlc.narg(expected_version), version)
 RETURNING id,
+    user_id,
     transaction_date,
     merchant,
     amount_cents,
-    detailed_category_id;
+    detailed_category_id,
+    notes,
+    tags,
+    updated_at,
+    version,
+    merchant_id,
+    raw_merchant;
 -- name: GetPrimaryCategories :many
 SELECT *
 FROM primary_categories;
 -- name: GetDetailedCategories :many
-SELECT *
-FROM detailed_categories;
+SELECT d.*
+FROM detailed_categories d
+WHERE NOT EXISTS (
+        SELECT 1
+        FROM user_categories u
+        WHERE u.detailed_category_id = d.id
+    );
 -- name: DeleteTransactionByID :one
 DELETE FROM transactions
-WHERE id = ?1
-    AND user_id = ?2
+WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    AND version = COALESCE(sqlc.narg(expected_version), version)
 RETURNING id;
--- name: GetUserTransactionsFirstPage :many
-SELECT id,
-    user_id,
-    transaction_date,
-    merchant,
-    amount_cents,
-    detailed_category_id
-FROM transactions
-WHERE user_id = ?1
-ORDER BY transaction_date ASC,
-    id ASC
-LIMIT ?2;
--- name: GetUserTransactionsPaginated :many
-SELECT id,
-    user_id,
-    transaction_date,
-    merchant,
-    amount_cents,
-    detailed_category_id
-FROM transactions
-WHERE user_id = ?1
+-- name: ListUserTransactionsNewestFirst :many
+SELECT t.id,
+    t.user_id,
+    t.transaction_date,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id,
+    t.notes,
+    t.tags,
+    t.merchant_id,
+    t.raw_merchant,
+    t.version
+FROM transactions t
+WHERE t.user_id = sqlc.arg(user_id)
+    AND (
+        sqlc.narg(start_date) IS NULL
+        OR t.transaction_date >= sqlc.narg(start_date)
+    )
+    AND (
+        sqlc.narg(end_date) IS NULL
+        OR t.transaction_date <= sqlc.narg(end_date)
+    )
+    AND (
+        sqlc.narg(merchant) IS NULL
+        OR t.merchant LIKE '%' || sqlc.narg(merchant) || '%' ESCAPE '\'
+    )
+    AND (
+        sqlc.narg(primary_category_id) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+                JOIN detailed_categories ldc ON ldc.id = l.detailed_category_id
+            WHERE l.transaction_id = t.id
+                AND ldc.primary_category_id = sqlc.narg(primary_category_id)
+        )
+    )
+    AND (
+        sqlc.narg(detailed_category_id) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+            WHERE l.transaction_id = t.id
+                AND l.detailed_category_id = sqlc.narg(detailed_category_id)
+        )
+    )
+    AND (
+        sqlc.narg(min_amount_cents) IS NULL
+        OR ABS(t.amount_cents) >= sqlc.narg(min_amount_cents)
+    )
+    AND (
+        sqlc.narg(max_amount_cents) IS NULL
+        OR ABS(t.amount_cents) <= sqlc.narg(max_amount_cents)
+    )
+    AND (
+        sqlc.narg(flow) IS NULL
+        OR (
+            sqlc.narg(flow) = 'inflow'
+            AND t.amount_cents < 0
+        )
+        OR (
+            sqlc.narg(flow) = 'outflow'
+            AND t.amount_cents > 0
+        )
+    )
+    AND (
+        sqlc.narg(cursor_date) IS NULL
+        OR t.transaction_date < sqlc.narg(cursor_date)
+        OR (
+            t.transaction_date = sqlc.narg(cursor_date)
+            AND t.id < sqlc.narg(cursor_id)
+        )
+    )
+ORDER BY t.transaction_date DESC,
+    t.id DESC
+LIMIT sqlc.arg(page_limit);
+-- name: ListUserTransactionsOldestFirst :many
+SELECT t.id,
+    t.user_id,
+    t.transaction_date,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id,
+    t.notes,
+    t.tags,
+    t.merchant_id,
+    t.raw_merchant,
+    t.version
+FROM transactions t
+WHERE t.user_id = sqlc.arg(user_id)
+    AND (
+        sqlc.narg(start_date) IS NULL
+        OR t.transaction_date >= sqlc.narg(start_date)
+    )
+    AND (
+        sqlc.narg(end_date) IS NULL
+        OR t.transaction_date <= sqlc.narg(end_date)
+    )
+    AND (
+        sqlc.narg(merchant) IS NULL
+        OR t.merchant LIKE '%' || sqlc.narg(merchant) || '%' ESCAPE '\'
+    )
+    AND (
+        sqlc.narg(primary_category_id) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+                JOIN detailed_categories ldc ON ldc.id = l.detailed_category_id
+            WHERE l.transaction_id = t.id
+                AND ldc.primary_category_id = sqlc.narg(primary_category_id)
+        )
+    )
+    AND (
+        sqlc.narg(detailed_category_id) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM transaction_lines l
+            WHERE l.transaction_id = t.id
+                AND l.detailed_category_id = sqlc.narg(detailed_category_id)
+        )
+    )
+    AND (
+        sqlc.narg(min_amount_cents) IS NULL
+        OR ABS(t.amount_cents) >= sqlc.narg(min_amount_cents)
+    )
+    AND (
+        sqlc.narg(max_amount_cents) IS NULL
+        OR ABS(t.amount_cents) <= sqlc.narg(max_amount_cents)
+    )
+    AND (
+        sqlc.narg(flow) IS NULL
+        OR (
+            sqlc.narg(flow) = 'inflow'
+            AND t.amount_cents < 0
+        )
+        OR (
+            sqlc.narg(flow) = 'outflow'
+            AND t.amount_cents > 0
+        )
+    )
     AND (
-        transaction_date > ?2
+        sqlc.narg(cursor_date) IS NULL
+        OR t.transaction_date > sqlc.narg(cursor_date)
         OR (
-            transaction_date = ?2
-            AND id < ?3
+            t.transaction_date = sqlc.narg(cursor_date)
+            AND t.id > sqlc.narg(cursor_id)
         )
     )
-ORDER BY transaction_date ASC,
-    id ASC
-LIMIT ?4;
+ORDER BY t.transaction_date ASC,
+    t.id ASC
+LIMIT sqlc.arg(page_limit);
 -- name: GetUserTransactionByID :one
 SELECT id,
     user_id,
     transaction_date,
     merchant,
     amount_cents,
-    detailed_category_id
+    detailed_category_id,
+    notes,
+    tags,
+    version,
+    merchant_id,
+    raw_merchant
 FROM transactions
 WHERE user_id = ?1
     AND id = ?2
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+-- name: SearchUserTransactions :many
+SELECT t.id,
+    t.user_id,
+    t.transaction_date,
+    t.merchant,
+    t.amount_cents,
+    t.detailed_category_id,
+    t.notes,
+    t.tags,
+    CAST(
+        snippet(transactions_fts, -1, char(2), char(3), '…', 12) AS TEXT
+    ) AS snippet
+FROM transactions_fts
+    JOIN transactions_fts_keys k ON k.id = transactions_fts.rowid
+    JOIN transactions t ON t.id = k.transaction_id
+WHERE transactions_fts MATCH sqlc.arg(query)
+    AND t.user_id = sqlc.arg(user_id)
+ORDER BY bm25(transactions_fts, 10.0, 2.0, 5.0),
+    t.transaction_date DESC,
+    t.id DESC
+LIMIT sqlc.arg(page_limit);
\ No newline at end of file
@@ -0,0 +1,108 @@
+-- name: CreateDetailedCategory :one
+INSERT INTO detailed_categories (name, description, primary_category_id)
+VALUES (?1, ?2, ?3)
+RETURNING id;
+-- name: CreateUserCategory :exec
+INSERT INTO user_categories (detailed_category_id, user_id)
+VALUES (?1, ?2);
+-- name: DeleteCategoryModelFeatures :exec
+DELETE FROM category_model_features
+WHERE user_id = ?1
+    AND detailed_category_id = ?2;
+-- name: ListUserCategories :many
+SELECT d.id,
+    d.name,
+    d.description,
+    d.primary_category_id,
+    p.name AS primary_category_name,
+    u.user_id,
+    o.name AS override_name,
+    COALESCE(o.hidden, FALSE) AS hidden,
+    u.archived_at,
+    u.merged_into_id
+FROM detailed_categories d
+    JOIN primary_categories p ON p.id = d.primary_category_id
+    LEFT JOIN user_categories u ON u.detailed_category_id = d.id
+    LEFT JOIN category_overrides o ON o.detailed_category_id = d.id
+    AND o.user_id = ?1
+WHERE u.user_id IS NULL
+    OR u.user_id = ?1
+ORDER BY d.primary_category_id,
+    d.id;
+-- name: MoveCategoryModelExamples :exec
+UPDATE category_model_examples
+SET detailed_category_id = sqlc.arg(to_category_id)
+WHERE user_id = sqlc.arg(user_id)
+    AND detailed_category_id = sqlc.arg(from_category_id);
+-- name: MoveCategoryModelFeatures :exec
+INSERT INTO category_model_features (user_id, feature, detailed_category_id, count)
+SELECT user_id,
+    feature,
+    sqlc.arg(to_category_id),
+    count
+FROM category_model_features
+WHERE user_id = sqlc.arg(user_id)
+    AND detailed_category_id = sqlc.arg(from_category_id) ON CONFLICT (user_id, feature, detailed_category_id) DO
+UPDATE
+SET count = count + excluded.count;
+-- name: ReassignMerchantCategory :exec
+UPDATE merchants
+SET default_category_id = sqlc.arg(to_category_id),
+    updated_at = sqlc.arg(updated_at)
+WHERE user_id = sqlc.arg(user_id)
+    AND default_category_id = sqlc.arg(from_category_id);
+-- name: ReassignRuleCategory :exec
+UPDATE categorization_rules
+SET actions = json_set(
+        actions,
+        '$.detailed_category',
+        sqlc.arg(to_category_id)
+    ),
+    updated_at = sqlc.arg(updated_at)
+WHERE user_id = sqlc.arg(user_id)
+    AND json_extract(actions, '$.detailed_category') = sqlc.arg(from_category_id);
+-- name: ReassignSplitCategory :exec
+UPDATE transaction_splits
+SET detailed_category_id = sqlc.arg(to_category_id)
+WHERE detailed_category_id = sqlc.arg(from_category_id)
+    AND transaction_id IN (
+        SELECT id
+        FROM transactions
+        WHERE user_id = sqlc.arg(user_id)
+    );
+-- name: ReassignTransactionCategory :execrows
+UPDATE transactions
+SET detailed_category_id = sqlc.arg(to_category_id),
+    updated_at = sqlc.arg(updated_at),
+    version = version + 1
+WHERE user_id = sqlc.arg(user_id)
+    AND detailed_category_id = sqlc.arg(from_category_id);
+-- name: RenameUserCategory :execrows
+UPDATE detailed_categories
+SET name = sqlc.arg(name)
+WHERE id = sqlc.arg(id)
+    AND id IN (
+        SELECT detailed_category_id
+        FROM user_categories
+        WHERE user_id = sqlc.arg(user_id)
+    );
+-- name: SetUserCategoryArchived :execrows
+UPDATE user_categories
+SET archived_at = sqlc.narg(archived_at),
+    merged_into_id = sqlc.narg(merged_into_id),
+    updated_at = sqlc.arg(updated_at)
+WHERE detailed_category_id = sqlc.arg(detailed_category_id)
+    AND user_id = sqlc.arg(user_id);
+-- name: UpsertCategoryOverride :exec
+INSERT INTO category_overrides (
+        user_id,
+        detailed_category_id,
+        name,
+        hidden,
+        updated_at
+    )
+VALUES (?1, ?2, ?3, ?4, ?5) ON CONFLICT (user_id, detailed_category_id) DO
+UPDATE
+SET name = excluded.name,
+    hidden = excluded.hidden,
+    updated_at = excluded.updated_at;
\ No newline at end of file
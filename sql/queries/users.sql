@@ -3,6 +3,63 @@ INSERT INTO users (id, email, hashed_password)
 VALUES (?1, ?2, ?3);
 -- name: GetUserByEmail :one
 SELECT id,
-    hashed_password
+    hashed_password,
+    email_verified_at,
+    totp_enabled_at
 FROM users
-WHERE email = ?1;
\ No newline at end of file
+WHERE email = ?1;
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET hashed_password = ?1,
+    updated_at = ?2
+WHERE id = ?3;
+-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = ?1,
+    updated_at = ?1
+WHERE id = ?2
+    AND email_verified_at IS NULL;
+-- name: GetUserMFAByID :one
+SELECT email,
+    totp_secret,
+    totp_enabled_at,
+    totp_last_step,
+    email_verified_at
+FROM users
+WHERE id = ?1;
+-- name: SetUserTOTPSecret :exec
+UPDATE users
+SET totp_secret = ?1,
+    totp_last_step = NULL,
+    updated_at = ?2
+WHERE id = ?3
+    AND totp_enabled_at IS NULL;
+-- name: EnableUserTOTP :execrows
+UPDATE users
+SET totp_enabled_at = ?1,
+    totp_last_step = ?2,
+    updated_at = ?1
+WHERE id = ?3
+    AND totp_secret IS NOT NULL
+    AND totp_enabled_at IS NULL;
+-- name: DisableUserTOTP :exec
+UPDATE users
+SET totp_secret = NULL,
+    totp_enabled_at = NULL,
+    totp_last_step = NULL,
+    updated_at = ?1
+WHERE id = ?2;
+-- name: UpdateUserTOTPLastStep :execrows
+UPDATE users
+SET totp_last_step = ?1
+WHERE id = ?2
+    AND (
+        totp_last_step IS NULL
+        OR totp_last_step < ?1
+    );
+-- name: UpgradeUserPasswordHash :execrows
+UPDATE users
+SET hashed_password = ?1,
+    updated_at = ?2
+WHERE id = ?3
+    AND hashed_password = ?4;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE refresh_tokens
+ADD COLUMN family_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+-- +goose Down
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+ALTER TABLE refresh_tokens DROP COLUMN family_id;
\ No newline at end of file
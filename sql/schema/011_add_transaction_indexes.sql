@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_transactions_user_date ON transactions (user_id, transaction_date, id);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_category ON transactions (user_id, detailed_category_id);
+CREATE INDEX IF NOT EXISTS idx_detailed_categories_primary ON detailed_categories (primary_category_id);
+-- +goose Down
+DROP INDEX IF EXISTS idx_detailed_categories_primary;
+DROP INDEX IF EXISTS idx_transactions_user_category;
+DROP INDEX IF EXISTS idx_transactions_user_date;
\ No newline at end of file
@@ -0,0 +1,43 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+CREATE VIRTUAL TABLE IF NOT EXISTS transactions_fts USING fts5(
+    merchant,
+    notes,
+    tags,
+    content = 'transactions',
+    content_rowid = 'rowid',
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '2 3'
+);
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_insert
+AFTER INSERT ON transactions BEGIN
+    INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+    VALUES (new.rowid, new.merchant, new.notes, new.tags);
+END;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_delete
+AFTER DELETE ON transactions BEGIN
+    INSERT INTO transactions_fts (transactions_fts, rowid, merchant, notes, tags)
+    VALUES ('delete', old.rowid, old.merchant, old.notes, old.tags);
+END;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_update
+AFTER UPDATE OF merchant, notes, tags ON transactions BEGIN
+    INSERT INTO transactions_fts (transactions_fts, rowid, merchant, notes, tags)
+    VALUES ('delete', old.rowid, old.merchant, old.notes, old.tags);
+    INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+    VALUES (new.rowid, new.merchant, new.notes, new.tags);
+END;
+-- +goose StatementEnd
+INSERT INTO transactions_fts (transactions_fts) VALUES ('rebuild');
+-- +goose Down
+DROP TRIGGER IF EXISTS transactions_fts_update;
+DROP TRIGGER IF EXISTS transactions_fts_delete;
+DROP TRIGGER IF EXISTS transactions_fts_insert;
+DROP TABLE IF EXISTS transactions_fts;
+ALTER TABLE transactions DROP COLUMN tags;
+ALTER TABLE transactions DROP COLUMN notes;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN version;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS import_mappings (
+    user_id TEXT NOT NULL,
+    bank TEXT NOT NULL,
+    mapping TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, bank),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +goose Down
+DROP TABLE IF EXISTS import_mappings;
\ No newline at end of file
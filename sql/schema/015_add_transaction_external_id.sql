@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN external_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_user_external_id ON transactions (user_id, external_id);
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_user_external_id;
+ALTER TABLE transactions DROP COLUMN external_id;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    conditions TEXT NOT NULL,
+    actions TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_user_priority ON categorization_rules (user_id, priority);
+-- +goose Down
+DROP INDEX IF EXISTS idx_categorization_rules_user_priority;
+DROP TABLE IF EXISTS categorization_rules;
\ No newline at end of file
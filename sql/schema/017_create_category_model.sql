@@ -0,0 +1,27 @@
+-- +goose Up
+-- Counts of each user's category model: how often each feature was seen on
+-- transactions of each detailed category. The feature '*' counts the transactions.
+CREATE TABLE IF NOT EXISTS category_model_features (
+    user_id TEXT NOT NULL,
+    feature TEXT NOT NULL,
+    detailed_category_id INTEGER NOT NULL,
+    count INTEGER NOT NULL,
+    PRIMARY KEY (user_id, feature, detailed_category_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id)
+);
+-- What each transaction added to the counts, so that a correction can take it back.
+-- There is no foreign key to transactions: a transaction's counts are taken back
+-- before it is deleted.
+CREATE TABLE IF NOT EXISTS category_model_examples (
+    transaction_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    detailed_category_id INTEGER NOT NULL,
+    features TEXT NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_category_model_examples_user ON category_model_examples (user_id);
+-- +goose Down
+DROP INDEX IF EXISTS idx_category_model_examples_user;
+DROP TABLE IF EXISTS category_model_examples;
+DROP TABLE IF EXISTS category_model_features;
\ No newline at end of file
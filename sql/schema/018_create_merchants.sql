@@ -0,0 +1,43 @@
+-- +goose Up
+-- Each user's directory of canonical merchants. default_category_id is used for new
+-- transactions of the merchant sent without a category.
+CREATE TABLE IF NOT EXISTS merchants (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    default_category_id INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (default_category_id) REFERENCES detailed_categories (id)
+);
+CREATE INDEX IF NOT EXISTS idx_merchants_user ON merchants (user_id);
+-- The normalized merchant strings that resolve to each merchant. Merging moves the
+-- aliases of the merged merchants to the one they are merged into.
+CREATE TABLE IF NOT EXISTS merchant_aliases (
+    user_id TEXT NOT NULL,
+    alias TEXT NOT NULL,
+    merchant_id TEXT NOT NULL,
+    PRIMARY KEY (user_id, alias),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (merchant_id) REFERENCES merchants (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_merchant_aliases_merchant ON merchant_aliases (merchant_id);
+-- merchant now holds the canonical name and raw_merchant the string the client sent.
+-- Transactions written before this migration are linked on first use of the
+-- directory.
+ALTER TABLE transactions ADD COLUMN merchant_id TEXT;
+ALTER TABLE transactions ADD COLUMN raw_merchant TEXT NOT NULL DEFAULT '';
+UPDATE transactions
+SET raw_merchant = merchant;
+CREATE INDEX IF NOT EXISTS idx_transactions_user_merchant ON transactions (user_id, merchant_id);
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_user_merchant;
+UPDATE transactions
+SET merchant = raw_merchant;
+ALTER TABLE transactions DROP COLUMN raw_merchant;
+ALTER TABLE transactions DROP COLUMN merchant_id;
+DROP INDEX IF EXISTS idx_merchant_aliases_merchant;
+DROP TABLE IF EXISTS merchant_aliases;
+DROP INDEX IF EXISTS idx_merchants_user;
+DROP TABLE IF EXISTS merchants;
\ No newline at end of file
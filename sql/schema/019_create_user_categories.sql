@@ -0,0 +1,63 @@
+-- +goose Up
+-- A user's own categories are detailed categories, so transactions reference them
+-- like any other; this table marks who owns them. Rows of detailed_categories without
+-- an owner are the system taxonomy. A merged category is archived and records the
+-- category its transactions went to.
+CREATE TABLE IF NOT EXISTS user_categories (
+    detailed_category_id INTEGER PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    archived_at DATETIME,
+    merged_into_id INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (merged_into_id) REFERENCES detailed_categories (id)
+);
+CREATE INDEX IF NOT EXISTS idx_user_categories_user ON user_categories (user_id);
+-- How a user sees a system category: under another name, or hidden.
+CREATE TABLE IF NOT EXISTS category_overrides (
+    user_id TEXT NOT NULL,
+    detailed_category_id INTEGER NOT NULL,
+    name TEXT,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, detailed_category_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id) ON DELETE CASCADE
+);
+-- A transaction can only be put in a system category or an active category of its
+-- own user. Transactions already in a category that is archived later keep it.
+CREATE TRIGGER IF NOT EXISTS transactions_user_category_insert
+BEFORE INSERT ON transactions
+WHEN EXISTS (
+    SELECT 1
+    FROM user_categories u
+    WHERE u.detailed_category_id = new.detailed_category_id
+        AND (
+            u.user_id <> new.user_id
+            OR u.archived_at IS NOT NULL
+        )
+) BEGIN
+SELECT RAISE(ABORT, 'detailed_category is not available');
+END;
+CREATE TRIGGER IF NOT EXISTS transactions_user_category_update
+BEFORE UPDATE OF detailed_category_id ON transactions
+WHEN new.detailed_category_id <> old.detailed_category_id
+AND EXISTS (
+    SELECT 1
+    FROM user_categories u
+    WHERE u.detailed_category_id = new.detailed_category_id
+        AND (
+            u.user_id <> new.user_id
+            OR u.archived_at IS NOT NULL
+        )
+) BEGIN
+SELECT RAISE(ABORT, 'detailed_category is not available');
+END;
+-- +goose Down
+DROP TRIGGER IF EXISTS transactions_user_category_update;
+DROP TRIGGER IF EXISTS transactions_user_category_insert;
+DROP TABLE IF EXISTS category_overrides;
+DROP INDEX IF EXISTS idx_user_categories_user;
+DROP TABLE IF EXISTS user_categories;
\ No newline at end of file
@@ -0,0 +1,90 @@
+-- +goose Up
+-- A split transaction divides its amount into lines, each under its own category.
+-- Lines are numbered from 1 and sum to the transaction's amount_cents; the
+-- transaction keeps the category of its first line.
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    transaction_id TEXT NOT NULL,
+    line INTEGER NOT NULL CHECK(line > 0),
+    amount_cents INTEGER NOT NULL CHECK(amount_cents <> 0),
+    detailed_category_id INTEGER NOT NULL,
+    memo TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (transaction_id, line),
+    FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+    FOREIGN KEY (detailed_category_id) REFERENCES detailed_categories (id)
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_category ON transaction_splits (detailed_category_id);
+-- Reports and budgets read transaction_lines rather than transactions: one row per
+-- line of a split transaction, and the transaction itself as line 0 when it is not
+-- split.
+CREATE VIEW IF NOT EXISTS transaction_lines AS
+SELECT t.id AS transaction_id,
+    t.user_id,
+    t.transaction_date,
+    0 AS line,
+    t.amount_cents,
+    t.detailed_category_id,
+    '' AS memo
+FROM transactions t
+WHERE NOT EXISTS (
+        SELECT 1
+        FROM transaction_splits s
+        WHERE s.transaction_id = t.id
+    )
+UNION ALL
+SELECT s.transaction_id,
+    t.user_id,
+    t.transaction_date,
+    s.line,
+    s.amount_cents,
+    s.detailed_category_id,
+    s.memo
+FROM transaction_splits s
+    JOIN transactions t ON t.id = s.transaction_id;
+-- Lines take categories under the same rule as transactions.
+CREATE TRIGGER IF NOT EXISTS transaction_splits_user_category_insert
+BEFORE INSERT ON transaction_splits
+WHEN EXISTS (
+    SELECT 1
+    FROM user_categories u
+        JOIN transactions t ON t.id = new.transaction_id
+    WHERE u.detailed_category_id = new.detailed_category_id
+        AND (
+            u.user_id <> t.user_id
+            OR u.archived_at IS NOT NULL
+        )
+) BEGIN
+SELECT RAISE(ABORT, 'detailed_category is not available');
+END;
+-- The lines are replaced as a whole, so the amount and category of a split
+-- transaction cannot change on their own.
+CREATE TRIGGER IF NOT EXISTS transactions_split_amount_update
+BEFORE UPDATE OF amount_cents ON transactions
+WHEN EXISTS (
+    SELECT 1
+    FROM transaction_splits s
+    WHERE s.transaction_id = new.id
+)
+AND new.amount_cents <> (
+    SELECT SUM(s.amount_cents)
+    FROM transaction_splits s
+    WHERE s.transaction_id = new.id
+) BEGIN
+SELECT RAISE(ABORT, 'splits must sum to amount_cents');
+END;
+CREATE TRIGGER IF NOT EXISTS transactions_split_category_update
+BEFORE UPDATE OF detailed_category_id ON transactions
+WHEN new.detailed_category_id <> (
+    SELECT s.detailed_category_id
+    FROM transaction_splits s
+    WHERE s.transaction_id = new.id
+        AND s.line = 1
+) BEGIN
+SELECT RAISE(ABORT, 'a split transaction has the category of its first line');
+END;
+-- +goose Down
+DROP TRIGGER IF EXISTS transactions_split_category_update;
+DROP TRIGGER IF EXISTS transactions_split_amount_update;
+DROP TRIGGER IF EXISTS transaction_splits_user_category_insert;
+DROP VIEW IF EXISTS transaction_lines;
+DROP INDEX IF EXISTS idx_transaction_splits_category;
+DROP TABLE IF EXISTS transaction_splits;
\ No newline at end of file
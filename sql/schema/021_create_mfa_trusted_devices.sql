@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS mfa_trusted_devices (
+    id TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    user_id TEXT NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_mfa_trusted_devices_user_id ON mfa_trusted_devices (user_id);
+-- Trust used to follow the device's user agent fields, which anyone can send.
+ALTER TABLE device_info_logs DROP COLUMN mfa_trusted_until;
+-- +goose Down
+ALTER TABLE device_info_logs ADD COLUMN mfa_trusted_until DATETIME;
+DROP INDEX IF EXISTS idx_mfa_trusted_devices_user_id;
+DROP TABLE IF EXISTS mfa_trusted_devices;
\ No newline at end of file
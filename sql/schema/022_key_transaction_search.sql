@@ -0,0 +1,97 @@
+-- +goose Up
+-- transactions has a TEXT primary key, so its rowid changes on VACUUM and the index
+-- from 012 drifts away from the rows it describes. Each transaction now gets a stable
+-- integer key, and the index keeps its own copy of the text under that key.
+DROP TRIGGER IF EXISTS transactions_fts_update;
+DROP TRIGGER IF EXISTS transactions_fts_delete;
+DROP TRIGGER IF EXISTS transactions_fts_insert;
+DROP TABLE IF EXISTS transactions_fts;
+CREATE TABLE IF NOT EXISTS transactions_fts_keys (
+    id INTEGER PRIMARY KEY,
+    transaction_id TEXT NOT NULL UNIQUE
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS transactions_fts USING fts5(
+    merchant,
+    notes,
+    tags,
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '2 3'
+);
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_insert
+AFTER INSERT ON transactions BEGIN
+    INSERT INTO transactions_fts_keys (transaction_id)
+    VALUES (new.id);
+    INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+    VALUES (
+        (SELECT id FROM transactions_fts_keys WHERE transaction_id = new.id),
+        new.merchant,
+        new.notes,
+        new.tags
+    );
+END;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_delete
+AFTER DELETE ON transactions BEGIN
+    DELETE FROM transactions_fts
+    WHERE rowid = (SELECT id FROM transactions_fts_keys WHERE transaction_id = old.id);
+    DELETE FROM transactions_fts_keys
+    WHERE transaction_id = old.id;
+END;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_update
+AFTER UPDATE OF merchant, notes, tags ON transactions BEGIN
+    UPDATE transactions_fts
+    SET merchant = new.merchant,
+        notes = new.notes,
+        tags = new.tags
+    WHERE rowid = (SELECT id FROM transactions_fts_keys WHERE transaction_id = new.id);
+END;
+-- +goose StatementEnd
+INSERT INTO transactions_fts_keys (transaction_id)
+SELECT id FROM transactions;
+INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+SELECT k.id, t.merchant, t.notes, t.tags
+FROM transactions t
+    JOIN transactions_fts_keys k ON k.transaction_id = t.id;
+-- +goose Down
+DROP TRIGGER IF EXISTS transactions_fts_update;
+DROP TRIGGER IF EXISTS transactions_fts_delete;
+DROP TRIGGER IF EXISTS transactions_fts_insert;
+DROP TABLE IF EXISTS transactions_fts;
+DROP TABLE IF EXISTS transactions_fts_keys;
+CREATE VIRTUAL TABLE IF NOT EXISTS transactions_fts USING fts5(
+    merchant,
+    notes,
+    tags,
+    content = 'transactions',
+    content_rowid = 'rowid',
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '2 3'
+);
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_insert
+AFTER INSERT ON transactions BEGIN
+    INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+    VALUES (new.rowid, new.merchant, new.notes, new.tags);
+END;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_delete
+AFTER DELETE ON transactions BEGIN
+    INSERT INTO transactions_fts (transactions_fts, rowid, merchant, notes, tags)
+    VALUES ('delete', old.rowid, old.merchant, old.notes, old.tags);
+END;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS transactions_fts_update
+AFTER UPDATE OF merchant, notes, tags ON transactions BEGIN
+    INSERT INTO transactions_fts (transactions_fts, rowid, merchant, notes, tags)
+    VALUES ('delete', old.rowid, old.merchant, old.notes, old.tags);
+    INSERT INTO transactions_fts (rowid, merchant, notes, tags)
+    VALUES (new.rowid, new.merchant, new.notes, new.tags);
+END;
+-- +goose StatementEnd
+INSERT INTO transactions_fts (transactions_fts) VALUES ('rebuild');
\ No newline at end of file